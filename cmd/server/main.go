@@ -4,17 +4,27 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/spf13/viper"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 	"todo-list/internal/app"
+	"todo-list/internal/app/valid"
+	"todo-list/internal/blobstore"
+	"todo-list/internal/events"
+	"todo-list/internal/jobs"
+	"todo-list/internal/model"
+	"todo-list/internal/notify"
+	"todo-list/internal/ports/grpcserver"
 	"todo-list/internal/ports/httpserver"
 	"todo-list/internal/repo"
+	"todo-list/migrations"
 )
 
 // InitConfig initializes configuration file
@@ -23,16 +33,100 @@ func InitConfig() error {
 	return viper.ReadInConfig()
 }
 
-// TaskRepoConfig initializes connection to database
-func TaskRepoConfig(ctx context.Context, dbURL string) *pgx.Conn {
+// BlobStoreConfig initializes storage of the content of attached files
+func BlobStoreConfig() (app.BlobStore, error) {
+	switch viper.GetString("blob_store.type") {
+	case "local":
+		return blobstore.NewLocal(viper.GetString("blob_store.local.dir"))
+	case "s3":
+		return blobstore.NewS3(blobstore.S3Config{
+			Endpoint:  viper.GetString("blob_store.s3.endpoint"),
+			Region:    viper.GetString("blob_store.s3.region"),
+			Bucket:    viper.GetString("blob_store.s3.bucket"),
+			AccessKey: viper.GetString("blob_store.s3.access_key"),
+			SecretKey: viper.GetString("blob_store.s3.secret_key"),
+		})
+	default:
+		return nil, fmt.Errorf("unknown type of blob store %q", viper.GetString("blob_store.type"))
+	}
+}
+
+// WorkflowConfig reads states of the tasks and transitions between them,
+// default workflow is used if states are not configured
+func WorkflowConfig() (model.Workflow, error) {
+	var w model.Workflow
+	if err := viper.UnmarshalKey("workflow", &w); err != nil {
+		return model.Workflow{}, err
+	} else if len(w.States) == 0 {
+		return app.DefaultWorkflow(), nil
+	}
+	return w, valid.Workflow(w)
+}
+
+// NotifiersConfig initializes notifiers of the reminders by their channels,
+// email is sent only if mail server is configured
+func NotifiersConfig() (map[string]app.Notifier, error) {
+	notifiers := map[string]app.Notifier{
+		model.ChannelWebhook: notify.NewWebhook(viper.GetDuration("reminders.webhook.timeout")),
+	}
+	if viper.GetString("reminders.smtp.addr") != "" {
+		n, err := notify.NewSMTP(notify.SMTPConfig{
+			Addr:     viper.GetString("reminders.smtp.addr"),
+			From:     viper.GetString("reminders.smtp.from"),
+			Username: viper.GetString("reminders.smtp.username"),
+			Password: viper.GetString("reminders.smtp.password"),
+			Timeout:  viper.GetDuration("reminders.smtp.timeout"),
+		})
+		if err != nil {
+			return nil, err
+		}
+		notifiers[model.ChannelEmail] = n
+	}
+	return notifiers, nil
+}
+
+// SinksConfig initializes sinks the events of the outbox are published to
+func SinksConfig(wr app.WebhookRepo) ([]app.EventSink, error) {
+	names := viper.GetStringSlice("outbox.sinks")
+	sinks := make([]app.EventSink, 0, len(names))
+	for _, name := range names {
+		switch name {
+		case "webhook":
+			sinks = append(sinks, events.NewWebhook(wr))
+		case "log":
+			sinks = append(sinks, events.NewLog(log.Default()))
+		case "nats":
+			s, err := events.NewNATS(events.NATSConfig{
+				Addr:    viper.GetString("outbox.nats.addr"),
+				Subject: viper.GetString("outbox.nats.subject"),
+				Timeout: viper.GetDuration("outbox.nats.timeout"),
+			})
+			if err != nil {
+				return nil, err
+			}
+			sinks = append(sinks, s)
+		default:
+			return nil, fmt.Errorf("unknown event sink %q", name)
+		}
+	}
+	return sinks, nil
+}
+
+// TaskRepoConfig initializes pool of connections to database, so concurrent
+// requests run their queries and transactions on separate connections
+func TaskRepoConfig(ctx context.Context, dbURL string) (*pgxpool.Pool, error) {
+	pool, err := pgxpool.New(ctx, dbURL)
+	if err != nil {
+		return nil, err
+	}
+
 	// connecting to a database in the loop with delay 1 sec for correct starting in docker container
 	for {
-		conn, err := pgx.Connect(ctx, dbURL)
-		if err != nil { // database haven't initialized in docker container yet
+		if err = pool.Ping(ctx); err != nil { // database haven't initialized in docker container yet
 			log.Printf("taskRepo connection error: %s\n", err.Error())
 			time.Sleep(time.Second)
 		} else { // database already initialized
-			return conn
+			return pool, nil
 		}
 	}
 }
@@ -56,17 +150,167 @@ func main() {
 		viper.GetInt("task_repo.port"),
 		viper.GetString("task_repo.dbname"),
 		viper.GetString("task_repo.sslmode"))
-	taskRepoConn := TaskRepoConfig(ctx, taskRepoURL)
-	defer func(ctx context.Context, conn *pgx.Conn) {
-		if err := conn.Close(ctx); err != nil {
-			log.Fatalf("taskRepo disconnect error: %s", err.Error())
+	taskRepoPool, err := TaskRepoConfig(ctx, taskRepoURL)
+	if err != nil {
+		log.Fatalf("taskRepo config error: %s", err.Error())
+	}
+	defer taskRepoPool.Close()
+
+	if err = migrations.Apply(ctx, taskRepoPool); err != nil {
+		log.Fatalf("taskRepo migrations error: %s", err.Error())
+	}
+
+	blobStore, err := BlobStoreConfig()
+	if err != nil {
+		log.Fatalf("blob store error: %s", err.Error())
+	}
+
+	workflow, err := WorkflowConfig()
+	if err != nil {
+		log.Fatalf("workflow error: %s", err.Error())
+	}
+
+	rolloverPolicy := viper.GetString("rollover.policy")
+	if rolloverPolicy != app.RolloverMove && rolloverPolicy != app.RolloverFlag && rolloverPolicy != app.RolloverOff {
+		log.Fatalf("rollover error: unknown policy %q", rolloverPolicy)
+	} else if rolloverPolicy != app.RolloverOff && viper.GetDuration("rollover.interval") <= 0 {
+		log.Fatalf("rollover error: interval must be positive")
+	}
+
+	notifiers, err := NotifiersConfig()
+	if err != nil {
+		log.Fatalf("notifiers error: %s", err.Error())
+	} else if viper.GetDuration("reminders.interval") <= 0 {
+		log.Fatalf("reminders error: interval must be positive")
+	} else if viper.GetDuration("webhooks.interval") <= 0 {
+		log.Fatalf("webhooks error: interval must be positive")
+	}
+
+	webhookRepo := repo.NewWebhookRepo(taskRepoPool)
+	sinks, err := SinksConfig(webhookRepo)
+	if err != nil {
+		log.Fatalf("outbox error: %s", err.Error())
+	} else if viper.GetDuration("outbox.interval") <= 0 {
+		log.Fatalf("outbox error: interval must be positive")
+	} else if viper.GetInt("stream.buffer_size") <= 0 {
+		log.Fatalf("stream error: buffer size must be positive")
+	} else if viper.GetDuration("sync.purge_interval") <= 0 {
+		log.Fatalf("sync error: purge interval must be positive")
+	}
+
+	// published events are always passed to the subscribers of the stream
+	broker := events.NewBroker(viper.GetInt("stream.buffer_size"))
+	sinks = append(sinks, broker)
+
+	a := app.New(
+		app.Deps{
+			Tasks:         repo.New(taskRepoPool),
+			Dependencies:  repo.NewDependencyRepo(taskRepoPool),
+			Board:         repo.NewBoardRepo(taskRepoPool),
+			Plans:         repo.NewPlanRepo(taskRepoPool),
+			Schedule:      repo.NewScheduleRepo(taskRepoPool),
+			Comments:      repo.NewCommentRepo(taskRepoPool),
+			Attachments:   repo.NewAttachmentRepo(taskRepoPool),
+			Blobs:         blobStore,
+			Reminders:     repo.NewReminderRepo(taskRepoPool),
+			Notifiers:     notifiers,
+			Webhooks:      webhookRepo,
+			WebhookSender: notify.NewWebhookSender(viper.GetDuration("webhooks.timeout")),
+			Outbox:        repo.NewOutboxRepo(taskRepoPool),
+			Sinks:         sinks,
+			Broker:        broker,
+			Sync:          repo.NewSyncRepo(taskRepoPool),
+			Credentials:   repo.NewCredentialRepo(taskRepoPool),
+			Calendars:     repo.NewCalendarRepo(taskRepoPool),
+		},
+		app.Config{
+			Members:            viper.GetStringSlice("workspace.members"),
+			MaxAttachmentSize:  viper.GetInt64("attachments.max_size"),
+			Workflow:           workflow,
+			RolloverPolicy:     rolloverPolicy,
+			ReminderAttempts:   viper.GetInt("reminders.attempts"),
+			WebhookAttempts:    viper.GetInt("webhooks.attempts"),
+			OutboxRetention:    viper.GetDuration("outbox.retention"),
+			TombstoneRetention: viper.GetDuration("sync.tombstone_retention"),
+			CalendarEnabled:    viper.GetBool("calendar.enabled"),
+		})
+
+	// commands of the binary work with the tasks and exit without the servers
+	if len(os.Args) > 1 {
+		if err := RunCommand(ctx, a, os.Args[1:], os.Stdin, os.Stdout); err != nil {
+			log.Fatalf("%s error: %s", os.Args[1], err.Error())
 		}
-	}(ctx, taskRepoConn)
+		return
+	}
+
+	// starting background jobs which are stopped before the shutdown
+	jobsCtx, stopJobs := context.WithCancel(ctx)
+	var jobsWg sync.WaitGroup
+	if rolloverPolicy != app.RolloverOff {
+		jobsWg.Add(1)
+		go func() {
+			defer jobsWg.Done()
+			jobs.Run(jobsCtx, "rollover", viper.GetDuration("rollover.interval"), func(ctx context.Context) error {
+				n, err := a.RolloverOverdue(ctx)
+				if n > 0 {
+					log.Printf("rollover: %d overdue tasks\n", n)
+				}
+				return err
+			})
+		}()
+	}
+
+	// reminders are kept in the database, so the ones which were due while
+	// the server was stopped are delivered on the first run
+	jobsWg.Add(1)
+	go func() {
+		defer jobsWg.Done()
+		jobs.Run(jobsCtx, "reminders", viper.GetDuration("reminders.interval"), func(ctx context.Context) error {
+			n, err := a.DeliverReminders(ctx)
+			if n > 0 {
+				log.Printf("reminders: %d sent\n", n)
+			}
+			return err
+		})
+	}()
+
+	// events are written to the outbox with the changes of the tasks, so the
+	// ones which were not published before the stop are published on the first run
+	jobsWg.Add(1)
+	go func() {
+		defer jobsWg.Done()
+		jobs.Run(jobsCtx, "outbox", viper.GetDuration("outbox.interval"), func(ctx context.Context) error {
+			_, err := a.RelayEvents(ctx)
+			return err
+		})
+	}()
+
+	jobsWg.Add(1)
+	go func() {
+		defer jobsWg.Done()
+		jobs.Run(jobsCtx, "webhooks", viper.GetDuration("webhooks.interval"), func(ctx context.Context) error {
+			_, err := a.DeliverWebhooks(ctx)
+			return err
+		})
+	}()
 
-	a := app.New(repo.New(taskRepoConn))
+	jobsWg.Add(1)
+	go func() {
+		defer jobsWg.Done()
+		jobs.Run(jobsCtx, "tombstones", viper.GetDuration("sync.purge_interval"), func(ctx context.Context) error {
+			_, err := a.PurgeTombstones(ctx)
+			return err
+		})
+	}()
 
 	srv := httpserver.New(fmt.Sprintf("%s:%d", viper.GetString("http_server.host"), viper.GetInt("http_server.port")), a)
 
+	grpcLis, err := net.Listen("tcp", fmt.Sprintf("%s:%d", viper.GetString("grpc_server.host"), viper.GetInt("grpc_server.port")))
+	if err != nil {
+		log.Fatalf("grpc server error: %s", err.Error())
+	}
+	grpcSrv := grpcserver.New(a)
+
 	// preparing graceful shutdown
 	osSignals := make(chan os.Signal, 1)
 	signal.Notify(osSignals, os.Interrupt, syscall.SIGTERM)
@@ -79,14 +323,34 @@ func main() {
 		}
 	}()
 
+	go func() {
+		log.Println("Starting grpc server")
+		if err := grpcSrv.Serve(grpcLis); err != nil {
+			log.Fatal("can't serve grpc server:", err.Error())
+		}
+	}()
+
 	// waiting for Ctrl+C
 	<-osSignals
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second) // 30s timeout to finish all active connections
 	defer cancel()
 
+	stopJobs()
+	jobsWg.Wait()
+
+	// both servers finish their active calls within the same timeout
+	var shutdownWg sync.WaitGroup
+	shutdownWg.Add(1)
+	go func() {
+		defer shutdownWg.Done()
+		if err := grpcSrv.Shutdown(ctx); err != nil {
+			log.Println("grpc server graceful shutdown failed:", err.Error())
+		}
+	}()
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Fatal("Server graceful shutdown failed:", err.Error())
 	}
+	shutdownWg.Wait()
 	log.Println("Server was gracefully stopped")
 }
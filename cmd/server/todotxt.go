@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+	"todo-list/internal/todotxt"
+)
+
+// errUsage is returned for invalid arguments of the command
+var errUsage = errors.New(`usage:
+  server import-todotxt [-dry-run] [-mode best_effort|transaction] [file]  add tasks from todo.txt file or stdin
+  server export-todotxt [file]                                             write tasks to todo.txt file or stdout`)
+
+// RunCommand runs the command of the binary instead of the servers
+func RunCommand(ctx context.Context, a app.App, args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	dryRun := fs.Bool("dry-run", false, "report what would be imported without adding the tasks")
+	mode := fs.String("mode", model.ImportBestEffort, "add valid tasks or all tasks in a single transaction")
+	if err := fs.Parse(args[1:]); err != nil || fs.NArg() > 1 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "import-todotxt":
+		r := stdin
+		if fs.NArg() == 1 {
+			f, err := os.Open(fs.Arg(0))
+			if err != nil {
+				return err
+			}
+			defer func() {
+				_ = f.Close()
+			}()
+			r = f
+		}
+		return ImportTodoTxt(ctx, a, r, *mode, *dryRun, stdout)
+	case "export-todotxt":
+		if *dryRun || *mode != model.ImportBestEffort {
+			return errUsage
+		} else if fs.NArg() == 0 {
+			return ExportTodoTxt(ctx, a, stdout)
+		}
+		f, err := os.Create(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		if err = ExportTodoTxt(ctx, a, f); err != nil {
+			_ = f.Close()
+			return err
+		}
+		return f.Close()
+	default:
+		return errUsage
+	}
+}
+
+// ImportTodoTxt adds tasks from todo.txt and prints outcome and warnings of every line
+func ImportTodoTxt(ctx context.Context, a app.App, r io.Reader, mode string, dryRun bool, w io.Writer) error {
+	rows, err := todotxt.Read(r)
+	if err != nil {
+		return err
+	}
+	report, err := a.ImportTasks(ctx, rows, mode, dryRun)
+	if err != nil {
+		return err
+	}
+
+	for _, res := range report.Results {
+		if res.Status == model.ImportCreated {
+			_, _ = fmt.Fprintf(w, "line %d: %s %q\n", res.Line, res.Status, res.Task.Title)
+		} else {
+			_, _ = fmt.Fprintf(w, "line %d: %s: %s\n", res.Line, res.Status, strings.ReplaceAll(res.Err.Error(), "\n", ": "))
+		}
+		for _, warning := range res.Warnings {
+			_, _ = fmt.Fprintf(w, "line %d: warning: %s\n", res.Line, warning)
+		}
+	}
+	if dryRun {
+		_, err = fmt.Fprintf(w, "dry run: %d would be created, %d skipped\n", report.Created, report.Skipped)
+	} else {
+		_, err = fmt.Fprintf(w, "%d created, %d skipped\n", report.Created, report.Skipped)
+	}
+	return err
+}
+
+// ExportTodoTxt writes all tasks to todo.txt
+func ExportTodoTxt(ctx context.Context, a app.App, w io.Writer) error {
+	tasks, err := a.ExportTasks(ctx)
+	if err != nil {
+		return err
+	}
+	return todotxt.Write(w, tasks, a.Workflow())
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+	"todo-list/pkg/client"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// requestTimeout limits every request to the API, so the UI doesn't hang
+// while the server is unavailable
+const requestTimeout = 10 * time.Second
+
+// tasksMsg is sent when tasks of the date are loaded
+type tasksMsg struct {
+	date  client.Date
+	tasks []client.Task
+}
+
+// changedMsg is sent when the task is changed, tasks of the day are reloaded after it
+type changedMsg struct {
+	status string
+}
+
+// errMsg is sent when the request failed
+type errMsg struct {
+	err error
+}
+
+// api makes requests to the server in commands of the UI
+type api struct {
+	client *client.Client
+}
+
+// loadDay loads undone and then done tasks of the date in order of the day
+func (a api) loadDay(date client.Date) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+
+		undone, err := a.client.GetTasksByDateAndStatus(ctx, date, false)
+		if err != nil {
+			return errMsg{err}
+		}
+		done, err := a.client.GetTasksByDateAndStatus(ctx, date, true)
+		if err != nil {
+			return errMsg{err}
+		}
+		return tasksMsg{date: date, tasks: append(undone, done...)}
+	}
+}
+
+// change runs the request changing the task and reports status on success
+func (a api) change(status string, f func(ctx context.Context, c *client.Client) error) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+
+		if err := f(ctx, a.client); err != nil {
+			return errMsg{err}
+		}
+		return changedMsg{status: status}
+	}
+}
+
+// toggle marks the undone task as done and the done task as undone, the
+// state of the task is chosen by the server
+func (a api) toggle(t client.Task) tea.Cmd {
+	status := "done: " + t.Title
+	if t.Status {
+		status = "undone: " + t.Title
+	}
+	return a.change(status, func(ctx context.Context, c *client.Client) error {
+		t.Status, t.State = !t.Status, ""
+		_, err := c.UpdateTask(ctx, t.Id, t)
+		return err
+	})
+}
+
+// add adds the task with the title planned on the date
+func (a api) add(title string, date client.Date) tea.Cmd {
+	return a.change("added: "+title, func(ctx context.Context, c *client.Client) error {
+		_, err := c.AddTask(ctx, client.Task{Title: title, PlanningDate: date})
+		return err
+	})
+}
+
+// rename changes title of the task keeping its other fields
+func (a api) rename(t client.Task, title string) tea.Cmd {
+	return a.change("edited: "+title, func(ctx context.Context, c *client.Client) error {
+		t.Title = title
+		_, err := c.UpdateTask(ctx, t.Id, t)
+		return err
+	})
+}
+
+// reschedule moves the task to the date
+func (a api) reschedule(t client.Task, date client.Date) tea.Cmd {
+	return a.change("moved to "+formatDate(date)+": "+t.Title, func(ctx context.Context, c *client.Client) error {
+		_, err := c.RescheduleTask(ctx, t.Id, date)
+		return err
+	})
+}
+
+// snooze moves the task to the next day
+func (a api) snooze(t client.Task) tea.Cmd {
+	return a.change("snoozed: "+t.Title, func(ctx context.Context, c *client.Client) error {
+		_, err := c.SnoozeTask(ctx, t.Id, 1)
+		return err
+	})
+}
+
+// describe returns text of the error shown in the status line, errors of
+// the API are shown as is and other errors mean that server is unavailable
+func describe(err error) string {
+	var apiErr *client.Error
+	switch {
+	case errors.As(err, &apiErr):
+		return apiErr.Error()
+	case errors.Is(err, context.DeadlineExceeded):
+		return "offline: server doesn't respond, press g to retry"
+	default:
+		return "offline: " + err.Error() + ", press g to retry"
+	}
+}
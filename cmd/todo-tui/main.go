@@ -0,0 +1,43 @@
+// Command todo-tui is a keyboard-driven terminal UI for planning the day. It
+// shows tasks of the day from the HTTP API of todo-list and lets to mark them
+// as done, add, edit, reschedule them and navigate between days
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"todo-list/pkg/client"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// defaultURL is the address of the API of the server started locally
+const defaultURL = "http://localhost:8080/todo-list/api"
+
+func main() {
+	url := flag.String("url", envOr("TODO_URL", defaultURL), "address of the API, TODO_URL by default")
+	user := flag.String("user", os.Getenv("TODO_USER"), "name of the current user, TODO_USER by default")
+	flag.Parse()
+
+	c := client.New(client.Config{BaseURL: strings.TrimSuffix(*url, "/"), User: *user})
+	today := func() client.Date {
+		t := time.Now()
+		return client.Date{Year: t.Year(), Month: t.Month(), Day: t.Day()}
+	}
+
+	if _, err := tea.NewProgram(newPlanner(api{client: c}, today), tea.WithAltScreen()).Run(); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "todo-tui: %s\n", err.Error())
+		os.Exit(1)
+	}
+}
+
+// envOr returns the environment variable or def if it is not set
+func envOr(key string, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
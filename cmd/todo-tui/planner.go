@@ -0,0 +1,267 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"todo-list/pkg/client"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// mode is what the keys do: in the list mode they navigate and change the
+// tasks, other modes edit the text of the input
+type mode int
+
+const (
+	modeList mode = iota
+	modeAdd
+	modeEdit
+	modeReschedule
+)
+
+var prompts = map[mode]string{
+	modeAdd:        "New task: ",
+	modeEdit:       "Title: ",
+	modeReschedule: "Move to (YYYY-MM-DD or +days): ",
+}
+
+const help = "j/k move  h/l day  t today  space done  a add  e edit  r reschedule  s snooze  g reload  q quit"
+
+// planner is the state of the UI. Tasks of the day are kept when reloading
+// fails, so the shown day stays usable while the server is unavailable.
+// Switching the day drops them, so the actions never apply to tasks of
+// another day
+type planner struct {
+	api    api
+	today  func() client.Date
+	date   client.Date
+	tasks  []client.Task
+	loaded bool
+	cursor int
+	mode   mode
+	input  textinput.Model
+	status string
+	err    string
+}
+
+func newPlanner(a api, today func() client.Date) planner {
+	input := textinput.New()
+	input.CharLimit = 200
+	return planner{api: a, today: today, date: today(), input: input}
+}
+
+func (m planner) Init() tea.Cmd {
+	return m.api.loadDay(m.date)
+}
+
+// formatDate formats the date as YYYY-MM-DD
+func formatDate(d client.Date) string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// addDays returns the date days after d
+func addDays(d client.Date, days int) client.Date {
+	t := time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC).AddDate(0, 0, days)
+	return client.Date{Year: t.Year(), Month: t.Month(), Day: t.Day()}
+}
+
+// parseTarget parses date of rescheduling, it is either YYYY-MM-DD or
+// number of days after the shown date prefixed with +
+func parseTarget(s string, from client.Date) (client.Date, error) {
+	s = strings.TrimSpace(s)
+	if days, ok := strings.CutPrefix(s, "+"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return client.Date{}, fmt.Errorf("invalid number of days %q", days)
+		}
+		return addDays(from, n), nil
+	}
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return client.Date{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", s)
+	}
+	return client.Date{Year: t.Year(), Month: t.Month(), Day: t.Day()}, nil
+}
+
+// selected returns the task under the cursor
+func (m planner) selected() (client.Task, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.tasks) {
+		return client.Task{}, false
+	}
+	return m.tasks[m.cursor], true
+}
+
+// showDay switches to the date and loads its tasks
+func (m planner) showDay(date client.Date) (tea.Model, tea.Cmd) {
+	m.date, m.cursor, m.status = date, 0, ""
+	m.tasks, m.loaded = nil, false
+	return m, m.api.loadDay(date)
+}
+
+// startInput switches to the mode editing the text
+func (m planner) startInput(md mode, value string) (tea.Model, tea.Cmd) {
+	m.mode = md
+	m.input.Prompt = prompts[md]
+	m.input.SetValue(value)
+	m.input.CursorEnd()
+	return m, m.input.Focus()
+}
+
+func (m planner) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tasksMsg:
+		if msg.date != m.date { // answer for the day which is not shown anymore
+			return m, nil
+		}
+		m.tasks, m.loaded, m.err = msg.tasks, true, ""
+		m.cursor = min(m.cursor, max(len(m.tasks)-1, 0))
+		return m, nil
+	case changedMsg:
+		m.status, m.err = msg.status, ""
+		return m, m.api.loadDay(m.date)
+	case errMsg:
+		m.err = describe(msg.err)
+		return m, nil
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return m, tea.Quit
+		}
+		if m.mode != modeList {
+			return m.updateInput(msg)
+		}
+		return m.updateList(msg)
+	}
+	return m, nil
+}
+
+func (m planner) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "up", "k":
+		m.cursor = max(m.cursor-1, 0)
+	case "down", "j":
+		m.cursor = min(m.cursor+1, max(len(m.tasks)-1, 0))
+	case "left", "h":
+		return m.showDay(addDays(m.date, -1))
+	case "right", "l":
+		return m.showDay(addDays(m.date, 1))
+	case "t":
+		return m.showDay(m.today())
+	case "g":
+		m.status = ""
+		return m, m.api.loadDay(m.date)
+	case "a":
+		return m.startInput(modeAdd, "")
+	case " ", "x":
+		if t, ok := m.selected(); ok {
+			return m, m.api.toggle(t)
+		}
+	case "e":
+		if t, ok := m.selected(); ok {
+			return m.startInput(modeEdit, t.Title)
+		}
+	case "r":
+		if _, ok := m.selected(); ok {
+			return m.startInput(modeReschedule, "+1")
+		}
+	case "s":
+		if t, ok := m.selected(); ok {
+			return m, m.api.snooze(t)
+		}
+	}
+	return m, nil
+}
+
+func (m planner) updateInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.mode = modeList
+		m.input.Blur()
+		return m, nil
+	case tea.KeyEnter:
+		value := strings.TrimSpace(m.input.Value())
+		md := m.mode
+		m.mode = modeList
+		m.input.Blur()
+		if value == "" {
+			return m, nil
+		}
+
+		if md == modeAdd {
+			return m, m.api.add(value, m.date)
+		}
+		t, ok := m.selected()
+		if !ok { // the task is gone after reloading
+			return m, nil
+		}
+		switch md {
+		case modeEdit:
+			return m, m.api.rename(t, value)
+		case modeReschedule:
+			date, err := parseTarget(value, m.date)
+			if err != nil {
+				m.err = err.Error()
+				return m, nil
+			}
+			return m, m.api.reschedule(t, date)
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m planner) View() string {
+	var b strings.Builder
+
+	day := time.Date(m.date.Year, m.date.Month, m.date.Day, 0, 0, 0, 0, time.UTC)
+	b.WriteString(day.Format("Monday, 2006-01-02"))
+	if m.date == m.today() {
+		b.WriteString(" (today)")
+	}
+	b.WriteString("\n\n")
+
+	switch {
+	case !m.loaded && m.err == "":
+		b.WriteString("  loading...\n")
+	case len(m.tasks) == 0 && m.loaded:
+		b.WriteString("  no tasks, press a to add one\n")
+	}
+	for i, t := range m.tasks {
+		cursor, mark := "  ", " "
+		if i == m.cursor {
+			cursor = "> "
+		}
+		if t.Status {
+			mark = "x"
+		}
+		line := fmt.Sprintf("%s[%s] %s", cursor, mark, t.Title)
+		if t.Blocked && !t.Status {
+			line += "  (blocked)"
+		}
+		if t.Postponed > 0 {
+			line += fmt.Sprintf("  (postponed %d)", t.Postponed)
+		}
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\n")
+
+	switch {
+	case m.mode != modeList:
+		b.WriteString(m.input.View() + "\n")
+	case m.err != "":
+		b.WriteString("! " + m.err + "\n")
+	case m.status != "":
+		b.WriteString(m.status + "\n")
+	default:
+		b.WriteString("\n")
+	}
+	b.WriteString(help + "\n")
+	return b.String()
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+	"todo-list/internal/app"
+	"todo-list/internal/app/mocks"
+	"todo-list/internal/model"
+	"todo-list/internal/ports/httpserver"
+	"todo-list/pkg/client"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// send passes the message to the model and runs the returned command with
+// its results until the model has nothing to do
+func send(t *testing.T, m tea.Model, msg tea.Msg) tea.Model {
+	m, cmd := m.Update(msg)
+	for cmd != nil {
+		msg = cmd()
+		if msg == nil {
+			return m
+		}
+		m, cmd = m.Update(msg)
+	}
+	return m
+}
+
+// key passes the key to the model without running the commands of the input
+func key(m tea.Model, k string) tea.Model {
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+	switch k {
+	case "enter":
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		msg = tea.KeyMsg{Type: tea.KeyEsc}
+	}
+	m, _ = m.Update(msg)
+	return m
+}
+
+func TestPlanner(t *testing.T) {
+	tr, br := new(mocks.TaskRepo), new(mocks.BoardRepo)
+	a := app.New(app.Deps{Tasks: tr, Board: br}, app.Config{})
+	srv := httptest.NewServer(httpserver.New("", a).Handler)
+	defer srv.Close()
+
+	today := model.Date{Year: 2027, Month: time.June, Day: 1}
+	tomorrow := model.Date{Year: 2027, Month: time.June, Day: 2}
+	undone := model.TodoTask{Id: 1121, Title: "Plan", PlanningDate: today, State: "todo"}
+	done := model.TodoTask{Id: 1122, Title: "Review", PlanningDate: today, Status: true, State: "done"}
+
+	var m tea.Model = newPlanner(api{client.New(client.Config{BaseURL: srv.URL + "/todo-list/api", RetryDelay: time.Millisecond})}, func() client.Date {
+		return today
+	})
+
+	t.Run("tasks of today", func(t *testing.T) {
+		tr.On("GetTasksByDateAndStatus", mock.Anything, today, false).Return([]model.TodoTask{undone}, nil).Once()
+		tr.On("GetTasksByDateAndStatus", mock.Anything, today, true).Return([]model.TodoTask{done}, nil).Once()
+
+		m = send(t, m, m.Init()())
+		view := m.View()
+		assert.Contains(t, view, "Tuesday, 2027-06-01 (today)")
+		assert.Contains(t, view, "> [ ] Plan\n  [x] Review\n")
+	})
+
+	t.Run("check off", func(t *testing.T) {
+		tr.On("GetTaskById", mock.Anything, 1121).Return(undone, nil).Once()
+		br.On("GetLastRank", mock.Anything, "done").Return("", nil).Once()
+		tr.On("UpdateTask", mock.Anything, 1121, mock.MatchedBy(func(t model.TodoTask) bool {
+			return t.Status && t.State == "done" && t.Title == "Plan"
+		})).Return(model.TodoTask{Id: 1121, Title: "Plan", PlanningDate: today, Status: true, State: "done"}, nil).Once()
+		tr.On("GetTasksByDateAndStatus", mock.Anything, today, false).Return([]model.TodoTask{}, nil).Once()
+		tr.On("GetTasksByDateAndStatus", mock.Anything, today, true).Return([]model.TodoTask{
+			{Id: 1121, Title: "Plan", PlanningDate: today, Status: true, State: "done"}, done,
+		}, nil).Once()
+
+		m = send(t, m, tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+		view := m.View()
+		assert.Contains(t, view, "> [x] Plan\n  [x] Review\n")
+		assert.Contains(t, view, "done: Plan")
+	})
+
+	t.Run("next day and add", func(t *testing.T) {
+		tr.On("GetTasksByDateAndStatus", mock.Anything, tomorrow, false).Return([]model.TodoTask{}, nil).Once()
+		tr.On("GetTasksByDateAndStatus", mock.Anything, tomorrow, true).Return([]model.TodoTask{}, nil).Once()
+		m = send(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+		assert.Contains(t, m.View(), "Wednesday, 2027-06-02\n\n  no tasks")
+
+		br.On("GetLastRank", mock.Anything, "todo").Return("", nil).Once()
+		tr.On("AddTask", mock.Anything, mock.MatchedBy(func(t model.TodoTask) bool {
+			return t.Title == "Deploy" && t.PlanningDate == tomorrow
+		})).Return(model.TodoTask{Id: 1123, Title: "Deploy", PlanningDate: tomorrow, State: "todo"}, nil).Once()
+		tr.On("GetTasksByDateAndStatus", mock.Anything, tomorrow, false).Return([]model.TodoTask{
+			{Id: 1123, Title: "Deploy", PlanningDate: tomorrow, State: "todo"},
+		}, nil).Once()
+		tr.On("GetTasksByDateAndStatus", mock.Anything, tomorrow, true).Return([]model.TodoTask{}, nil).Once()
+
+		m = key(m, "a")
+		assert.Contains(t, m.View(), "New task: ")
+		m = key(m, "Deploy")
+		m = send(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Contains(t, m.View(), "> [ ] Deploy\n")
+	})
+
+	t.Run("invalid date of rescheduling", func(t *testing.T) {
+		m = key(m, "r")
+		m = key(m, "esc")
+		m = key(m, "r")
+		m = key(m, "x")
+		m = send(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Contains(t, m.View(), `! invalid number of days "1x"`)
+	})
+
+	t.Run("server is unavailable", func(t *testing.T) {
+		srv.Close()
+		m = send(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+		view := m.View()
+		assert.Contains(t, view, "> [ ] Deploy\n")
+		assert.Contains(t, view, "! offline: ")
+		assert.Contains(t, view, "press g to retry")
+	})
+
+	t.Run("other day is unavailable", func(t *testing.T) {
+		m = send(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")})
+		view := m.View()
+		assert.Contains(t, view, "Tuesday, 2027-06-01 (today)\n\n\n")
+		assert.NotContains(t, view, "Deploy")
+		assert.Contains(t, view, "! offline: ")
+
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+		assert.Nil(t, cmd)
+		m = key(m, "e")
+		assert.NotContains(t, m.View(), "Title: ")
+	})
+
+	tr.AssertExpectations(t)
+	br.AssertExpectations(t)
+}
+
+func TestParseTarget(t *testing.T) {
+	from := client.Date{Year: 2027, Month: time.December, Day: 31}
+
+	date, err := parseTarget("+2", from)
+	require.NoError(t, err)
+	assert.Equal(t, client.Date{Year: 2028, Month: time.January, Day: 2}, date)
+
+	date, err = parseTarget(" 2028-02-29 ", from)
+	require.NoError(t, err)
+	assert.Equal(t, client.Date{Year: 2028, Month: time.February, Day: 29}, date)
+
+	_, err = parseTarget("tomorrow", from)
+	assert.Error(t, err)
+	_, err = parseTarget("+0", from)
+	assert.Error(t, err)
+}
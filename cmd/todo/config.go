@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	// defaultURL is the address of the API of the server started locally
+	defaultURL = "http://localhost:8080/todo-list/api"
+
+	// defaultProfile is a profile used if it is not chosen by flag or config
+	defaultProfile = "default"
+
+	// configEnv is an environment variable with path of the config file
+	configEnv = "TODO_CONFIG"
+)
+
+// profile is a server the CLI works with, the config file contains profiles
+// by their names
+type profile struct {
+	URL    string `mapstructure:"url"`
+	User   string `mapstructure:"user"`
+	Output string `mapstructure:"output"`
+}
+
+// configPath returns path of the config file from the environment or
+// todo/config.yml in the user config directory
+func configPath() string {
+	if path := os.Getenv(configEnv); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "todo", "config.yml")
+}
+
+// loadProfile reads the profile with given name from the config file, empty
+// name selects the profile from the profile key of the config. Missing
+// config file is not an error, then the default profile is returned
+func loadProfile(path string, name string) (profile, error) {
+	p := profile{URL: defaultURL, Output: formatTable}
+	if path == "" {
+		return p, nil
+	}
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		if errors.Is(err, fs.ErrNotExist) && name == "" {
+			return p, nil
+		}
+		return profile{}, fmt.Errorf("reading config %s: %w", path, err)
+	}
+
+	if name == "" {
+		name = v.GetString("profile")
+	}
+	if name == "" {
+		name = defaultProfile
+	}
+	profiles := map[string]profile{}
+	if err := v.UnmarshalKey("profiles", &profiles); err != nil {
+		return profile{}, fmt.Errorf("reading profiles from %s: %w", path, err)
+	}
+	found, ok := profiles[name]
+	if !ok {
+		if name == defaultProfile {
+			return p, nil
+		}
+		return profile{}, fmt.Errorf("profile %q not found in %s", name, path)
+	}
+
+	if found.URL != "" {
+		p.URL = found.URL
+	}
+	if found.Output != "" {
+		p.Output = found.Output
+	}
+	p.User = found.User
+	return p, nil
+}
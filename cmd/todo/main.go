@@ -0,0 +1,287 @@
+// Command todo manages tasks of the todo-list server from the terminal:
+//
+//	todo add "Deploy" --date 2026-10-20
+//	todo today
+//	todo done 42
+//	todo search deploy
+//
+// Servers are described by profiles in the config file, tasks are printed as
+// a table, JSON or plain lines
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"todo-list/pkg/client"
+)
+
+// errUsage is returned for invalid arguments of the command, usage is
+// printed instead of it
+var errUsage = errors.New("invalid usage")
+
+// now returns current time, it is replaced in tests
+var now = time.Now
+
+// options are the flags of every command
+type options struct {
+	config  string
+	profile string
+	url     string
+	user    string
+	output  string
+}
+
+func (o *options) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.config, "config", configPath(), "path of the config file")
+	fs.StringVar(&o.profile, "profile", "", "profile of the config file")
+	fs.StringVar(&o.url, "url", "", "address of the API, overrides the profile")
+	fs.StringVar(&o.user, "user", "", "name of the current user, overrides the profile")
+	fs.StringVar(&o.output, "output", "", "output format: table, json or plain")
+	fs.StringVar(&o.output, "o", "", "shorthand for -output")
+}
+
+// env is the environment of the command
+type env struct {
+	client *client.Client
+	format string
+	stdout io.Writer
+}
+
+// command is a subcommand of the CLI, flags adds its own flags to the set
+// and returns the function which runs it with positional arguments
+type command struct {
+	usage       string
+	description string
+	flags       func(fs *flag.FlagSet) func(ctx context.Context, e env, args []string) error
+}
+
+var commands = map[string]command{
+	"add": {
+		usage:       "add <title> [-date YYYY-MM-DD] [-description text] [-project name]",
+		description: "add the task, it is planned on today by default",
+		flags: func(fs *flag.FlagSet) func(ctx context.Context, e env, args []string) error {
+			date := fs.String("date", "", "planning date of the task in format YYYY-MM-DD")
+			description := fs.String("description", "", "description of the task")
+			project := fs.String("project", "", "project of the task")
+			return func(ctx context.Context, e env, args []string) error {
+				if len(args) == 0 {
+					return errUsage
+				}
+				d, err := parseDate(*date)
+				if err != nil {
+					return err
+				}
+				t, err := e.client.AddTask(ctx, client.Task{
+					Title:        strings.Join(args, " "),
+					Description:  *description,
+					PlanningDate: d,
+					Project:      *project,
+				})
+				if err != nil {
+					return err
+				}
+				return printTask(e.stdout, e.format, t)
+			}
+		},
+	},
+	"today": {
+		usage:       "today",
+		description: "list undone and done tasks planned on today in order of the day",
+		flags: func(fs *flag.FlagSet) func(ctx context.Context, e env, args []string) error {
+			return func(ctx context.Context, e env, args []string) error {
+				if len(args) != 0 {
+					return errUsage
+				}
+				today, _ := parseDate("")
+				undone, err := e.client.GetTasksByDateAndStatus(ctx, today, false)
+				if err != nil {
+					return err
+				}
+				done, err := e.client.GetTasksByDateAndStatus(ctx, today, true)
+				if err != nil {
+					return err
+				}
+				return printTasks(e.stdout, e.format, append(undone, done...))
+			}
+		},
+	},
+	"done": {
+		usage:       "done <id>",
+		description: "mark the task as done",
+		flags: func(fs *flag.FlagSet) func(ctx context.Context, e env, args []string) error {
+			force := fs.Bool("force", false, "mark the task as done even if its blockers are not done")
+			return func(ctx context.Context, e env, args []string) error {
+				if len(args) != 1 {
+					return errUsage
+				}
+				id, err := strconv.Atoi(args[0])
+				if err != nil {
+					return fmt.Errorf("invalid id of the task %q", args[0])
+				}
+				t, err := e.client.GetTaskById(ctx, id)
+				if err != nil {
+					return err
+				}
+
+				// state of the done task is chosen by the server
+				t.Status, t.State = true, ""
+				update := e.client.UpdateTask
+				if *force {
+					update = e.client.ForceUpdateTask
+				}
+				if t, err = update(ctx, id, t); err != nil {
+					return err
+				}
+				return printTask(e.stdout, e.format, t)
+			}
+		},
+	},
+	"search": {
+		usage:       "search <text>",
+		description: "list tasks with the text in title or description",
+		flags: func(fs *flag.FlagSet) func(ctx context.Context, e env, args []string) error {
+			return func(ctx context.Context, e env, args []string) error {
+				if len(args) == 0 {
+					return errUsage
+				}
+				tasks, err := e.client.GetTaskByText(ctx, strings.Join(args, " "))
+				if err != nil {
+					return err
+				}
+				return printTasks(e.stdout, e.format, tasks)
+			}
+		},
+	},
+}
+
+// parseDate parses date in format YYYY-MM-DD, empty string is today
+func parseDate(s string) (client.Date, error) {
+	if s == "" {
+		t := now()
+		return client.Date{Year: t.Year(), Month: t.Month(), Day: t.Day()}, nil
+	}
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return client.Date{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", s)
+	}
+	return client.Date{Year: t.Year(), Month: t.Month(), Day: t.Day()}, nil
+}
+
+// parseInterspersed parses flags placed before, between and after the
+// positional arguments and returns the positional arguments
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		if args[0] == "--" {
+			return append(positional, args[1:]...), nil
+		}
+		positional, args = append(positional, args[0]), args[1:]
+	}
+}
+
+func usage(w io.Writer) {
+	_, _ = fmt.Fprintln(w, "Usage: todo <command> [flags] [arguments]")
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, "Commands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		_, _ = fmt.Fprintf(w, "  %-50s %s\n", commands[name].usage, commands[name].description)
+	}
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, "Run todo <command> -h to see flags of the command")
+}
+
+// run runs the command from args and returns exit code
+func run(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		usage(stderr)
+		if len(args) == 0 {
+			return 2
+		}
+		return 0
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		_, _ = fmt.Fprintf(stderr, "todo: unknown command %q\n\n", args[0])
+		usage(stderr)
+		return 2
+	}
+
+	fs := flag.NewFlagSet("todo "+args[0], flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(stderr, "Usage: todo %s\n\nFlags:\n", cmd.usage)
+		fs.PrintDefaults()
+	}
+	var opts options
+	opts.register(fs)
+	exec := cmd.flags(fs)
+
+	positional, err := parseInterspersed(fs, args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	} else if err != nil {
+		return 2
+	}
+
+	p, err := loadProfile(opts.config, opts.profile)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "todo: %s\n", err.Error())
+		return 1
+	}
+	if opts.url != "" {
+		p.URL = opts.url
+	}
+	if opts.user != "" {
+		p.User = opts.user
+	}
+	if opts.output != "" {
+		p.Output = opts.output
+	}
+	if !validFormat(p.Output) {
+		_, _ = fmt.Fprintf(stderr, "todo: unknown output format %q\n", p.Output)
+		return 2
+	}
+
+	err = exec(ctx, env{
+		client: client.New(client.Config{BaseURL: strings.TrimSuffix(p.URL, "/"), User: p.User}),
+		format: p.Output,
+		stdout: stdout,
+	}, positional)
+	switch {
+	case errors.Is(err, errUsage):
+		fs.Usage()
+		return 2
+	case err != nil:
+		_, _ = fmt.Fprintf(stderr, "todo: %s\n", err.Error())
+		return 1
+	}
+	return 0
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"todo-list/internal/app"
+	"todo-list/internal/app/mocks"
+	"todo-list/internal/model"
+	"todo-list/internal/ports/httpserver"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// runTodo runs the CLI and returns exit code, stdout and stderr
+func runTodo(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestCommands(t *testing.T) {
+	tr, br := new(mocks.TaskRepo), new(mocks.BoardRepo)
+	a := app.New(app.Deps{Tasks: tr, Board: br}, app.Config{})
+	srv := httptest.NewServer(httpserver.New("", a).Handler)
+	defer srv.Close()
+
+	url := srv.URL + "/todo-list/api"
+	config := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(config, []byte("profile: test\nprofiles:\n  test:\n    url: "+url+"\n    output: plain\n"), 0o600))
+
+	today := model.Date{Year: 2027, Month: time.June, Day: 1}
+	now = func() time.Time { return time.Date(2027, time.June, 1, 9, 0, 0, 0, time.Local) }
+	defer func() { now = time.Now }()
+
+	t.Run("add with flags after the title", func(t *testing.T) {
+		date := model.Date{Year: 2027, Month: time.June, Day: 3}
+		br.On("GetLastRank", mock.Anything, "todo").Return("", nil).Once()
+		tr.On("AddTask", mock.Anything, mock.MatchedBy(func(task model.TodoTask) bool {
+			return task.Title == "Deploy" && task.PlanningDate == date && task.Project == "backend"
+		})).Return(model.TodoTask{Id: 1111, Title: "Deploy", PlanningDate: date, State: "todo", Project: "backend"}, nil).Once()
+
+		code, stdout, stderr := runTodo("add", "-config", config, "Deploy", "-date", "2027-06-03", "-project", "backend", "-o", "json")
+		require.Equal(t, 0, code, stderr)
+		var view taskView
+		require.NoError(t, json.Unmarshal([]byte(stdout), &view))
+		assert.Equal(t, taskView{Id: 1111, Title: "Deploy", PlanningDate: "2027-06-03", State: "todo", Assignees: []string{}, Project: "backend"}, view)
+	})
+
+	t.Run("today", func(t *testing.T) {
+		tr.On("GetTasksByDateAndStatus", mock.Anything, today, false).Return([]model.TodoTask{{Id: 1112, Title: "Undone"}}, nil).Once()
+		tr.On("GetTasksByDateAndStatus", mock.Anything, today, true).Return([]model.TodoTask{{Id: 1113, Title: "Done", Status: true}}, nil).Once()
+
+		code, stdout, stderr := runTodo("today", "-config", config)
+		require.Equal(t, 0, code, stderr)
+		assert.Equal(t, "[ ] 1112 Undone\n[x] 1113 Done\n", stdout)
+	})
+
+	t.Run("done", func(t *testing.T) {
+		task := model.TodoTask{Id: 1114, Title: "Finish", PlanningDate: today, State: "todo"}
+		tr.On("GetTaskById", mock.Anything, 1114).Return(task, nil).Twice()
+		br.On("GetLastRank", mock.Anything, "done").Return("", nil).Once()
+		tr.On("UpdateTask", mock.Anything, 1114, mock.MatchedBy(func(t model.TodoTask) bool {
+			return t.Status && t.State == "done"
+		})).Return(model.TodoTask{Id: 1114, Title: "Finish", PlanningDate: today, Status: true, State: "done"}, nil).Once()
+
+		code, stdout, stderr := runTodo("done", "1114", "-config", config, "-output", "table")
+		require.Equal(t, 0, code, stderr)
+		assert.Equal(t, "ID    DONE  TITLE   DATE        STATE\n1114  x     Finish  2027-06-01  done\n", stdout)
+	})
+
+	t.Run("error of the API", func(t *testing.T) {
+		tr.On("GetTaskById", mock.Anything, 1115).Return(model.TodoTask{}, model.ErrTaskNotFound).Once()
+
+		code, _, stderr := runTodo("done", "1115", "-config", config)
+		assert.Equal(t, 1, code)
+		assert.Equal(t, "todo: "+model.ErrTaskNotFound.Error()+"\n", stderr)
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		code, _, stderr := runTodo("search", "deploy", "-config", config, "-profile", "prod")
+		assert.Equal(t, 1, code)
+		assert.Contains(t, stderr, `profile "prod" not found`)
+	})
+
+	t.Run("invalid usage", func(t *testing.T) {
+		code, _, _ := runTodo("unknown")
+		assert.Equal(t, 2, code)
+		code, _, _ = runTodo("done", "-config", config)
+		assert.Equal(t, 2, code)
+		code, _, _ = runTodo("today", "-config", config, "-o", "xml")
+		assert.Equal(t, 2, code)
+	})
+
+	tr.AssertExpectations(t)
+	br.AssertExpectations(t)
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"todo-list/pkg/client"
+)
+
+// formats of the output of the tasks
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatPlain = "plain"
+)
+
+// taskView is the task printed in JSON format
+type taskView struct {
+	Id           int      `json:"id"`
+	Title        string   `json:"title"`
+	Description  string   `json:"description"`
+	PlanningDate string   `json:"planning_date"`
+	Status       bool     `json:"status"`
+	State        string   `json:"state"`
+	Overdue      bool     `json:"overdue"`
+	Assignees    []string `json:"assignees"`
+	Project      string   `json:"project"`
+	Blocked      bool     `json:"blocked"`
+}
+
+func formatDate(d client.Date) string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+func viewOf(t client.Task) taskView {
+	assignees := t.Assignees
+	if assignees == nil {
+		assignees = []string{}
+	}
+	return taskView{
+		Id:           t.Id,
+		Title:        t.Title,
+		Description:  t.Description,
+		PlanningDate: formatDate(t.PlanningDate),
+		Status:       t.Status,
+		State:        t.State,
+		Overdue:      t.Overdue,
+		Assignees:    assignees,
+		Project:      t.Project,
+		Blocked:      t.Blocked,
+	}
+}
+
+// validFormat returns true if the tasks can be printed in the format
+func validFormat(format string) bool {
+	return format == formatTable || format == formatJSON || format == formatPlain
+}
+
+// printTasks prints the tasks in the format
+func printTasks(w io.Writer, format string, tasks []client.Task) error {
+	switch format {
+	case formatJSON:
+		views := make([]taskView, 0, len(tasks))
+		for _, t := range tasks {
+			views = append(views, viewOf(t))
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(views)
+	case formatPlain:
+		for _, t := range tasks {
+			mark := " "
+			if t.Status {
+				mark = "x"
+			}
+			if _, err := fmt.Fprintf(w, "[%s] %d %s\n", mark, t.Id, t.Title); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "ID\tDONE\tTITLE\tDATE\tSTATE")
+		for _, t := range tasks {
+			done := ""
+			if t.Status {
+				done = "x"
+			}
+			_, _ = fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", t.Id, done, t.Title, formatDate(t.PlanningDate), t.State)
+		}
+		return tw.Flush()
+	}
+}
+
+// printTask prints the task in the format, it is an object in JSON format
+func printTask(w io.Writer, format string, t client.Task) error {
+	if format == formatJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(viewOf(t))
+	}
+	return printTasks(w, format, []client.Task{t})
+}
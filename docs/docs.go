@@ -16,29 +16,29 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/task": {
+        "/board": {
             "get": {
-                "description": "Возвращает задачу с вхождением данной строки в заголовке или описании",
+                "description": "Возвращает колонки для всех состояний задач, в каждой из которых не больше limit задач в порядке их ранга",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Поиск задачи по тексту заголовка или описания",
+                "summary": "Получение доски задач",
                 "parameters": [
                     {
-                        "description": "Текст в JSON",
+                        "description": "Количество задач в колонке",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/httpserver.getTaskByTextRequest"
+                            "$ref": "#/definitions/httpserver.getBoardRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Успешное получение задач",
+                        "description": "Успешное получение доски",
                         "schema": {
-                            "$ref": "#/definitions/httpserver.tasksResponse"
+                            "$ref": "#/definitions/httpserver.boardResponse"
                         }
                     },
                     "400": {
@@ -54,33 +54,45 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/calendar/caldav": {
             "post": {
-                "description": "Возвращает добавленную задачу с её id в postgres",
+                "description": "Возвращает новый случайный пароль текущего пользователя для входа CalDAV клиентов по HTTP Basic и путь к его календарю на сервере, предыдущий пароль пользователя перестаёт работать. Пароль выпускается отдельно от токена ICS ленты, поэтому ссылка на ленту не даёт изменять задачи. Сервер хранит только хеш пароля, поэтому он показывается один раз",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Добавление новой задачи",
+                "summary": "Выпуск пароля CalDAV пользователя",
                 "parameters": [
                     {
-                        "description": "Новая задача в JSON",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/httpserver.addTaskRequest"
-                        }
+                        "type": "string",
+                        "description": "Имя текущего пользователя",
+                        "name": "X-User",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Успешное добавление",
+                        "description": "Успешный выпуск",
                         "schema": {
-                            "$ref": "#/definitions/httpserver.taskResponse"
+                            "$ref": "#/definitions/httpserver.caldavPasswordResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат входных данных",
+                        "description": "Неверное имя пользователя",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не указан текущий пользователь",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Пользователь не является участником рабочего пространства",
                         "schema": {
                             "$ref": "#/definitions/httpserver.taskResponse"
                         }
@@ -90,37 +102,51 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/httpserver.taskResponse"
                         }
+                    },
+                    "501": {
+                        "description": "Календари не включены",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/task/by_date": {
-            "get": {
-                "description": "Возвращает список задач",
+            },
+            "delete": {
+                "description": "CalDAV клиенты текущего пользователя больше не могут войти с его паролем",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Получение списка задач с фильтром по дате и статусу",
+                "summary": "Отзыв пароля CalDAV пользователя",
                 "parameters": [
                     {
-                        "description": "Дата и статус",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/httpserver.getTasksByDateAndStatusRequest"
-                        }
+                        "type": "string",
+                        "description": "Имя текущего пользователя",
+                        "name": "X-User",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Успешное получение задач",
+                        "description": "Успешный отзыв",
                         "schema": {
-                            "$ref": "#/definitions/httpserver.tasksResponse"
+                            "$ref": "#/definitions/httpserver.taskResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат входных данных",
+                        "description": "Неверное имя пользователя",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не указан текущий пользователь",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "У пользователя нет пароля CalDAV",
                         "schema": {
                             "$ref": "#/definitions/httpserver.taskResponse"
                         }
@@ -134,29 +160,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/task/by_status": {
+        "/calendar/export": {
             "get": {
-                "description": "Возвращает список задач",
+                "description": "Возвращает файл iCalendar с невыполненными и выполненными задачами каждого дня периода включительно, период не может быть длиннее 366 дней",
                 "produces": [
-                    "application/json"
+                    "text/calendar"
                 ],
-                "summary": "Получение списка задач с фильтром по статусу и пагинацией",
+                "summary": "Экспорт задач за период в ICS файл",
                 "parameters": [
                     {
-                        "description": "Статус и пагинация",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/httpserver.getTasksByStatusRequest"
-                        }
+                        "type": "string",
+                        "description": "Первый день периода в формате YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Последний день периода в формате YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Компоненты через запятую: vevent, vtodo (по умолчанию оба)",
+                        "name": "components",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Успешное получение задач",
+                        "description": "Файл календаря",
                         "schema": {
-                            "$ref": "#/definitions/httpserver.tasksResponse"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -174,37 +211,43 @@ const docTemplate = `{
                 }
             }
         },
-        "/task/{id}": {
-            "get": {
-                "description": "Возвращает задачу с заданным id",
+        "/calendar/token": {
+            "post": {
+                "description": "Возвращает новый случайный токен календаря текущего пользователя и путь к его ICS ленте относительно префикса API, предыдущая ссылка пользователя перестаёт работать. Сервер хранит только хеш токена, поэтому ссылка показывается один раз. Лента доступна без заголовка X-User любому, кто знает ссылку, поэтому X-User должен выставлять прокси, который аутентифицирует пользователей",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Поиск задачи по её id в postgres",
+                "summary": "Выпуск ссылки на календарь задач пользователя",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "id задачи",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "Имя текущего пользователя",
+                        "name": "X-User",
+                        "in": "header",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Успешное получение",
+                        "description": "Успешный выпуск",
                         "schema": {
-                            "$ref": "#/definitions/httpserver.taskResponse"
+                            "$ref": "#/definitions/httpserver.calendarFeedResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат входных данных",
+                        "description": "Неверное имя пользователя",
                         "schema": {
                             "$ref": "#/definitions/httpserver.taskResponse"
                         }
                     },
-                    "404": {
-                        "description": "Задача с заданным id не найдена",
+                    "401": {
+                        "description": "Не указан текущий пользователь",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Пользователь не является участником рабочего пространства",
                         "schema": {
                             "$ref": "#/definitions/httpserver.taskResponse"
                         }
@@ -214,48 +257,51 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/httpserver.taskResponse"
                         }
+                    },
+                    "501": {
+                        "description": "Календари не включены",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
                     }
                 }
             },
-            "put": {
-                "description": "Возвращает задачу с заданным id и изменёнными полями",
+            "delete": {
+                "description": "Ссылка на ICS ленту текущего пользователя перестаёт работать",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Обновление полей задачи по её id в postgres",
+                "summary": "Отзыв ссылки на календарь задач пользователя",
                 "parameters": [
                     {
-                        "description": "Новые поля задачи",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/httpserver.updateTaskRequest"
-                        }
-                    },
-                    {
-                        "type": "integer",
-                        "description": "id изменяемой задачи",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "Имя текущего пользователя",
+                        "name": "X-User",
+                        "in": "header",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Успешное обновление",
+                        "description": "Успешный отзыв",
                         "schema": {
                             "$ref": "#/definitions/httpserver.taskResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат входных данных",
+                        "description": "Неверное имя пользователя",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не указан текущий пользователь",
                         "schema": {
                             "$ref": "#/definitions/httpserver.taskResponse"
                         }
                     },
                     "404": {
-                        "description": "Задача с заданным id не найдена",
+                        "description": "У пользователя нет ссылки на календарь",
                         "schema": {
                             "$ref": "#/definitions/httpserver.taskResponse"
                         }
@@ -267,27 +313,42 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Удаляет задачу с заданным id",
+            }
+        },
+        "/calendar/{user}/{token}": {
+            "get": {
+                "description": "Возвращает задачи, назначенные пользователю, в формате iCalendar для подписки в приложениях календаря. Каждая задача передаётся событием на весь день (VEVENT) и задачей (VTODO) со статусом COMPLETED для выполненных и NEEDS-ACTION для невыполненных задач",
                 "produces": [
-                    "application/json"
+                    "text/calendar"
                 ],
-                "summary": "Удаление задачи по её id в postgres",
+                "summary": "ICS лента задач пользователя",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "id удаляемой задачи",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Имя пользователя",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Токен календаря пользователя с расширением .ics",
+                        "name": "token",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Компоненты через запятую: vevent, vtodo (по умолчанию оба)",
+                        "name": "components",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Успешное удаление",
+                        "description": "Календарь",
                         "schema": {
-                            "$ref": "#/definitions/httpserver.taskResponse"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -297,7 +358,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Задача с заданным id не найдена",
+                        "description": "Календарь с таким токеном не найден",
                         "schema": {
                             "$ref": "#/definitions/httpserver.taskResponse"
                         }
@@ -310,90 +371,3667 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "httpserver.addTaskRequest": {
-            "type": "object",
-            "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "planning_date": {
-                    "type": "object",
-                    "properties": {
-                        "day": {
-                            "type": "integer"
-                        },
-                        "month": {
-                            "type": "integer"
-                        },
-                        "year": {
-                            "type": "integer"
+        },
+        "/events": {
+            "get": {
+                "description": "Передаёт события task.created, task.updated и task.deleted задач, которые были в области подписки до или после изменения. После переподключения поток продолжается с события из заголовка Last-Event-ID, а если оно уже вытеснено из буфера, приходит событие reset. Каждые 15 секунд отправляется комментарий heartbeat",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "summary": "Поток событий изменения задач (Server-Sent Events)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Имя текущего пользователя",
+                        "name": "X-User",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "id последнего полученного события",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя текущего пользователя для клиентов, которые не могут передать заголовок X-User",
+                        "name": "user",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "id последнего полученного события для клиентов, которые не могут передать заголовок Last-Event-ID",
+                        "name": "last_event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только задачи, назначенные текущему пользователю",
+                        "name": "assigned_to_me",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Только задачи, запланированные на дату в формате YYYY-MM-DD",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Только задачи проекта",
+                        "name": "project",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Поток событий",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не указан текущий пользователь",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Пользователь не является участником рабочего пространства",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
                         }
                     }
-                },
-                "status": {
-                    "type": "boolean"
-                },
-                "title": {
-                    "type": "string"
                 }
             }
         },
-        "httpserver.getTaskByTextRequest": {
-            "type": "object",
-            "properties": {
-                "text": {
-                    "type": "string"
+        "/graphql": {
+            "post": {
+                "description": "Выполняет GraphQL запрос или мутацию и возвращает только запрошенные поля задач. Запросы: task(id), searchTasks(text, offset, limit), tasksByStatus(status, offset, limit), tasksByState(state, offset, limit), tasksByDate(date, status, offset, limit) и overdueTasks(offset, limit), по умолчанию limit = 20, мутации: addTask(task), updateTask(id, task, force) и deleteTask(id). Ответ имеет формат GraphQL с полями data и errors. Запросы глубже 5 уровней или со сложностью больше 1000 полей не выполняются, поля списков задач учитываются для каждой задачи списка (limit или 20 задач)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "GraphQL запросы задач",
+                "parameters": [
+                    {
+                        "description": "GraphQL запрос, имя операции и переменные",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.graphqlRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Результат запроса, ошибки отдельных полей возвращаются в errors",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.graphqlResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат запроса, запрос не прошёл проверку или превысил ограничения",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.graphqlResponse"
+                        }
+                    }
                 }
             }
         },
-        "httpserver.getTasksByDateAndStatusRequest": {
-            "type": "object",
-            "properties": {
-                "planning_date": {
-                    "type": "object",
-                    "properties": {
-                        "day": {
-                            "type": "integer"
-                        },
-                        "month": {
-                            "type": "integer"
-                        },
-                        "year": {
-                            "type": "integer"
+        "/live": {
+            "get": {
+                "description": "Открывает WebSocket соединение. Клиент отправляет JSON сообщения с полями id и type. Сообщение subscribe с необязательной датой date в формате YYYY-MM-DD и last_event_id переводит клиента в комнату задач этой даты или всей доски, unsubscribe выводит из неё. Сообщения add_task и update_task (поле task как в теле POST /task, task_id и force), delete_task, move_task (поле move как в теле POST /task/{id}/move), assign_task и unassign_task (поле assignee) изменяют задачи так же, как соответствующие запросы. На каждое сообщение сервер отвечает сообщением result с тем же id и полями data или error. Изменения задач комнаты приходят сообщениями event с полями event_id, event и payload как у вебхуков, сообщение reset означает, что клиенту нужно перезагрузить задачи. Сообщение presence со списком users приходит всем клиентам комнаты, когда в неё входят или из неё выходят",
+                "summary": "Совместная работа с задачами через WebSocket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Имя текущего пользователя",
+                        "name": "X-User",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя текущего пользователя для клиентов, которые не могут передать заголовок X-User",
+                        "name": "user",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Соединение переключено на протокол WebSocket",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Запрос не является запросом на открытие WebSocket соединения",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Не указан текущий пользователь",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/project": {
+            "get": {
+                "description": "Возвращает все проекты, упорядоченные по названию",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получение списка проектов",
+                "responses": {
+                    "200": {
+                        "description": "Успешное получение",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.projectsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Возвращает добавленный проект с его участниками",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Добавление проекта",
+                "parameters": [
+                    {
+                        "description": "Название и участники проекта",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.addProjectRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное добавление",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.projectResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных или участник не состоит в рабочем пространстве",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Проект с таким названием уже существует",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/project/{name}": {
+            "get": {
+                "description": "Возвращает проект с заданным названием и его участников",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получение проекта",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Название проекта",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное получение",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.projectResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Проект не найден",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/project/{name}/members": {
+            "post": {
+                "description": "Возвращает проект с обновлённым списком участников",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Добавление участника проекта",
+                "parameters": [
+                    {
+                        "description": "Имя пользователя",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.addProjectMemberRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Название проекта",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное добавление",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.projectResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных или пользователь не состоит в рабочем пространстве",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Проект не найден",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/project/{name}/members/{member}": {
+            "delete": {
+                "description": "Возвращает проект с обновлённым списком участников, участника нельзя удалить, пока он назначен на задачи проекта",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Удаление участника проекта",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Название проекта",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя",
+                        "name": "member",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное удаление",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.projectResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Проект не найден",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Участник назначен на задачи проекта",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sync": {
+            "get": {
+                "description": "Возвращает задачи, изменённые после выдачи токена синхронизации, и удалённые задачи (tombstones). Без токена или с устаревшим токеном возвращаются все задачи и reset = true, тогда клиент заменяет ими свою копию. Полученный token передаётся в следующем запросе",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получение изменений задач для офлайн клиента",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Токен синхронизации из предыдущего ответа",
+                        "name": "token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное получение",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.syncResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный токен синхронизации",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Возвращает изменения задач после токена синхронизации так же, как GET /sync, и затем применяет изменения клиента (не более 100) по порядку. Операция create добавляет задачу, повторная операция create с тем же ref от того же клиента client возвращает уже добавленную задачу, поэтому изменения можно отправить повторно, если ответ потерян. Операции update и delete применяются, только если версия задачи на сервере равна версии version, известной клиенту, иначе изменение не применяется и возвращается конфликт с текущей задачей (deleted = true, если задача удалена). Для каждого изменения возвращается результат applied, conflict или rejected с ref изменения. Идентификатор клиента и ref не длиннее 100 символов. Изменения клиента придут повторно при следующей синхронизации",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Синхронизация офлайн клиента",
+                "parameters": [
+                    {
+                        "description": "Токен синхронизации и изменения клиента",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.syncRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешная синхронизация",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.syncResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных или токен синхронизации",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task": {
+            "get": {
+                "description": "Возвращает задачу с вхождением данной строки в заголовке или описании",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Поиск задачи по тексту заголовка или описания",
+                "parameters": [
+                    {
+                        "description": "Текст в JSON",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.getTaskByTextRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное получение задач",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.tasksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Возвращает добавленную задачу с её id в postgres",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Добавление новой задачи",
+                "parameters": [
+                    {
+                        "description": "Новая задача в JSON",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.addTaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное добавление",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Проект задачи не найден",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/assigned_to_me": {
+            "get": {
+                "description": "Возвращает список задач пользователя из заголовка X-User с пагинацией",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получение списка задач, назначенных текущему пользователю",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Имя текущего пользователя",
+                        "name": "X-User",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Пагинация",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.getTasksAssignedToMeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное получение задач",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.tasksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не указан текущий пользователь",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/by_assignee": {
+            "get": {
+                "description": "Возвращает список задач с пагинацией",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получение списка задач, назначенных пользователю",
+                "parameters": [
+                    {
+                        "description": "Имя пользователя и пагинация",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.getTasksByAssigneeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное получение задач",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.tasksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/by_date": {
+            "get": {
+                "description": "Возвращает список задач",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получение списка задач с фильтром по дате и статусу",
+                "parameters": [
+                    {
+                        "description": "Дата и статус",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.getTasksByDateAndStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное получение задач",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.tasksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/by_date/order": {
+            "put": {
+                "description": "Задаёт порядок всех задач, запланированных на дату, и возвращает их в новом порядке. Список id должен содержать все задачи этой даты без повторов, порядок сохраняется атомарно",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Изменение порядка задач на день",
+                "parameters": [
+                    {
+                        "description": "Дата и упорядоченный список id задач",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.reorderDayRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное изменение порядка",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.tasksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Список id не совпадает с задачами на эту дату",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/by_date/reschedule": {
+            "post": {
+                "description": "Переносит все невыполненные задачи даты from в конец даты to с сохранением их порядка и возвращает все задачи даты to",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Перенос всех невыполненных задач дня",
+                "parameters": [
+                    {
+                        "description": "Исходная и новая даты",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.rescheduleDayRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешный перенос",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.tasksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/by_state": {
+            "get": {
+                "description": "Возвращает список задач в заданном состоянии",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получение списка задач в заданном состоянии с пагинацией",
+                "parameters": [
+                    {
+                        "description": "Состояние и пагинация",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.getTasksByStateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное получение задач",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.tasksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных или неизвестное состояние",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/by_status": {
+            "get": {
+                "description": "Возвращает список задач в выполненных (status = true) или невыполненных состояниях",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получение списка задач с фильтром по статусу и пагинацией",
+                "parameters": [
+                    {
+                        "description": "Статус и пагинация",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.getTasksByStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное получение задач",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.tasksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/export/todotxt": {
+            "get": {
+                "description": "Возвращает файл todo.txt с невыполненными и затем выполненными задачами, не более 10000. Выполненные задачи отмечены x, дата планирования записывается тегом due:YYYY-MM-DD, проект — тегом +name, тег pri:A невыполненной задачи — приоритетом (A), а состояние, отличное от состояния по умолчанию, — тегом state:name. Описания задач не выгружаются",
+                "produces": [
+                    "text/plain"
+                ],
+                "summary": "Экспорт задач в todo.txt",
+                "responses": {
+                    "200": {
+                        "description": "Файл todo.txt",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/import": {
+            "post": {
+                "description": "Добавляет задачи из файла и возвращает отчёт по каждой строке с ошибками валидации. Формат файла задаётся заголовком Content-Type: text/csv — CSV с заголовком из колонок title, description, planning_date (YYYY-MM-DD), status, state и project, из которых обязательна только title; application/x-ndjson или application/jsonl — по одному JSON объекту в формате запроса добавления задачи на строку. Задачи без даты планируются на сегодня. Пропускаются строки с ошибками, невалидные задачи и задачи с тем же заголовком, что уже запланирована на ту же дату. В режиме best_effort добавляются все валидные строки, в режиме transaction строки добавляются в одной транзакции только если ни одна из них не пропущена. В режиме dry_run задачи не добавляются",
+                "consumes": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Массовый импорт задач из CSV или JSON lines",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Режим импорта: best_effort (по умолчанию) или transaction",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только проверить файл без добавления задач",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Содержимое файла",
+                        "name": "file",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Отчёт об импорте",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.importReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных или более 1000 строк",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Слишком большой файл",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Неподдерживаемый формат файла",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/import/todotxt": {
+            "post": {
+                "description": "Добавляет задачи из строк файла todo.txt и возвращает отчёт по каждой строке. Отметка x задаёт статус задачи, тег due:YYYY-MM-DD — дату планирования (без него задача планируется на сегодня), тег state:name — состояние, первый проект +name — проект задачи, контексты @name — ответственных из участников рабочего пространства и проекта. У задач нет приоритета, дат создания и выполнения и других проектов, поэтому приоритет (A) и даты сохраняются в заголовке тегами pri:A, created:YYYY-MM-DD и completed:YYYY-MM-DD, остальные проекты остаются в заголовке, и для каждого такого случая в отчёт по строке добавляется предупреждение. Выполненные задачи сохраняют прошедшие даты. Пропускаются строки с ошибками, невалидные задачи и задачи с тем же заголовком, что уже запланирована на ту же дату. Режимы mode и dry_run такие же, как у массового импорта: в режиме dry_run задачи не добавляются, а отчёт показывает, что было бы добавлено и пропущено",
+                "consumes": [
+                    "text/plain"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Импорт задач из todo.txt",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Режим импорта: best_effort (по умолчанию) или transaction",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только проверить файл без добавления задач",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Содержимое файла todo.txt",
+                        "name": "file",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Отчёт об импорте",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.importReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных или более 1000 строк",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Слишком большой файл",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/overdue": {
+            "get": {
+                "description": "Возвращает невыполненные задачи, которые не были выполнены в запланированный день и были перенесены или отмечены просроченными, в порядке запланированной даты",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получение списка просроченных задач с пагинацией",
+                "parameters": [
+                    {
+                        "description": "Пагинация",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.getOverdueTasksRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное получение задач",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.tasksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}": {
+            "get": {
+                "description": "Возвращает задачу с заданным id",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Поиск задачи по её id в postgres",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное получение",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Задача с заданным id не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Возвращает задачу с заданным id и изменёнными полями. Состояние задачи меняется только по разрешённым переходам, если состояние не указано, оно выводится из статуса. Задачу нельзя отметить выполненной, пока не выполнены блокирующие её задачи, если не указан параметр force",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Обновление полей задачи по её id в postgres",
+                "parameters": [
+                    {
+                        "description": "Новые поля задачи",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.updateTaskRequest"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "id изменяемой задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Отметить задачу выполненной несмотря на блокирующие задачи",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное обновление",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Задача с заданным id не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Задача заблокирована невыполненными задачами или переход в состояние запрещён",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Удаляет задачу с заданным id",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Удаление задачи по её id в postgres",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id удаляемой задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное удаление",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Задача с заданным id не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}/assignees": {
+            "post": {
+                "description": "Возвращает задачу с обновлённым списком ответственных",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Назначение пользователя ответственным за задачу",
+                "parameters": [
+                    {
+                        "description": "Имя пользователя",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.assignTaskRequest"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "id задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное назначение",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных или пользователь не состоит в рабочем пространстве",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Задача с заданным id не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}/assignees/{assignee}": {
+            "delete": {
+                "description": "Возвращает задачу с обновлённым списком ответственных",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Снятие пользователя с задачи",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя",
+                        "name": "assignee",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное снятие",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Задача с заданным id не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}/attachments": {
+            "get": {
+                "description": "Возвращает информацию о прикреплённых файлах без их содержимого",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получение списка файлов, прикреплённых к задаче",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное получение",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.attachmentsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Задача с заданным id не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Загружает файл из поля file формы multipart/form-data и возвращает информацию о нём",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Прикрепление файла к задаче",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Прикрепляемый файл",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное прикрепление",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.attachmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Задача с заданным id не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Слишком большой файл",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}/attachments/{attachment_id}": {
+            "get": {
+                "description": "Возвращает содержимое файла с его типом и именем",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "summary": "Скачивание прикреплённого файла",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "id прикреплённого файла",
+                        "name": "attachment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Содержимое файла",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Файл с заданным id не найден",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Удаляет файл вместе с его содержимым",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Удаление прикреплённого файла",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "id прикреплённого файла",
+                        "name": "attachment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное удаление",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Файл с заданным id не найден",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}/blockers": {
+            "post": {
+                "description": "Возвращает заблокированную задачу. Связь, образующая цикл зависимостей, не добавляется",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Добавление задачи, блокирующей данную",
+                "parameters": [
+                    {
+                        "description": "id блокирующей задачи",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.blockTaskRequest"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "id блокируемой задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное добавление",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Задача с заданным id не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Связь образует цикл зависимостей",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}/blockers/{blocker_id}": {
+            "delete": {
+                "description": "Возвращает задачу, которая больше не блокируется заданной",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Удаление задачи из блокирующих данную",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id блокируемой задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "id блокирующей задачи",
+                        "name": "blocker_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное удаление",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Задача с заданным id не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}/comments": {
+            "get": {
+                "description": "Возвращает список комментариев от старых к новым",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получение комментариев к задаче с пагинацией",
+                "parameters": [
+                    {
+                        "description": "Пагинация",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.getCommentsByTaskRequest"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "id задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное получение комментариев",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.commentsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Задача с заданным id не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Возвращает добавленный комментарий текущего пользователя",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Добавление комментария к задаче",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Имя текущего пользователя",
+                        "name": "X-User",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Текст комментария в формате Markdown",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.addCommentRequest"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "id задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное добавление",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.commentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не указан текущий пользователь",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Задача с заданным id не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}/comments/{comment_id}": {
+            "put": {
+                "description": "Возвращает изменённый комментарий, изменить комментарий может только его автор",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Изменение текста комментария",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Имя текущего пользователя",
+                        "name": "X-User",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Новый текст комментария в формате Markdown",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.updateCommentRequest"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "id задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "id комментария",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное изменение",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.commentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не указан текущий пользователь",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Текущий пользователь не автор комментария",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Комментарий с заданным id не найден",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Удаляет комментарий, удалить комментарий может только его автор",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Удаление комментария",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Имя текущего пользователя",
+                        "name": "X-User",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "id задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "id комментария",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное удаление",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не указан текущий пользователь",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Текущий пользователь не автор комментария",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Комментарий с заданным id не найден",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}/dependencies": {
+            "get": {
+                "description": "Возвращает задачу вместе со всеми задачами, которые прямо или косвенно блокируют её или блокируются ей, и связи между ними",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получение графа зависимостей задачи",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное получение",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.dependencyGraphResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Задача с заданным id не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}/history": {
+            "get": {
+                "description": "Возвращает список переносов задачи с одной даты на другую от старых к новым",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получение истории переносов задачи с пагинацией",
+                "parameters": [
+                    {
+                        "description": "Пагинация",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.getHistoryByTaskRequest"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "id задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное получение истории",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.historyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Задача с заданным id не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}/move": {
+            "post": {
+                "description": "Перемещает задачу в колонку заданного состояния перед задачей before_id или после задачи after_id, если они не указаны, задача перемещается в конец колонки. Если состояние не указано, задача перемещается внутри своей колонки",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Перемещение задачи на доске",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id перемещаемой задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новое место задачи",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.moveTaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное перемещение",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных или неизвестное состояние",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Задача не найдена или задача-ориентир не найдена в колонке",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Задача заблокирована невыполненными задачами или переход в состояние запрещён",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}/next_weekday": {
+            "post": {
+                "description": "Переносит задачу на ближайший заданный день недели (sunday, monday, ..., saturday) после запланированной даты, просроченная задача переносится относительно текущей даты",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Перенос задачи на ближайший день недели",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "День недели",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.rescheduleToWeekdayRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешный перенос",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Задача с заданным id не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}/project": {
+            "put": {
+                "description": "Возвращает задачу с обновлённым проектом, пустой проект оставляет задачу только в рабочем пространстве",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Перенос задачи в проект",
+                "parameters": [
+                    {
+                        "description": "Название проекта",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.setTaskProjectRequest"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "id задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешный перенос",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных или ответственный задачи не состоит в проекте",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Задача с заданным id или проект не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}/reminders": {
+            "get": {
+                "description": "Возвращает напоминания задачи с их статусом и временем следующей попытки отправки",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получение напоминаний задачи",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное получение",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.remindersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Задача с заданным id не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Напоминание срабатывает в момент at или, если он не указан, со смещением offset_minutes от начала запланированной даты задачи в UTC",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Добавление напоминания к задаче",
+                "parameters": [
+                    {
+                        "description": "Время, канал (email или webhook) и получатель напоминания",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.addReminderRequest"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "id задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное добавление",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.reminderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Задача с заданным id не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}/reminders/{reminder_id}": {
+            "delete": {
+                "description": "Удаляет напоминание задачи вместе с журналом его отправки",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Удаление напоминания",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "id напоминания",
+                        "name": "reminder_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное удаление",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Напоминание с заданным id не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}/reminders/{reminder_id}/deliveries": {
+            "get": {
+                "description": "Возвращает попытки отправки напоминания от старых к новым, у успешных попыток ошибка пустая",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получение журнала отправки напоминания с пагинацией",
+                "parameters": [
+                    {
+                        "description": "Пагинация",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.getDeliveriesByReminderRequest"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "id задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "id напоминания",
+                        "name": "reminder_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное получение",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.deliveriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Напоминание с заданным id не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}/reschedule": {
+            "post": {
+                "description": "Переносит задачу в конец заданного дня, дата не может быть раньше текущей",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Перенос задачи на заданную дату",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новая дата",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.rescheduleTaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешный перенос",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Задача с заданным id не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}/snooze": {
+            "post": {
+                "description": "Переносит задачу на заданное количество дней (от 1 до 366) после запланированной даты, просроченная задача откладывается от текущей даты",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Откладывание задачи на несколько дней",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Количество дней",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.snoozeTaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешный перенос",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Задача с заданным id не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "description": "Возвращает все подписки без их секретов",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получение подписок webhook",
+                "responses": {
+                    "200": {
+                        "description": "Успешное получение",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.subscriptionsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Возвращает подписку вместе с секретом, которым подписываются события, секрет генерируется, если не указан",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Подписка webhook на события задач",
+                "parameters": [
+                    {
+                        "description": "URL, типы событий (task.created, task.updated, task.completed, task.deleted) и секрет",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.addSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешная подписка",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.subscriptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "delete": {
+                "description": "Удаляет подписку вместе с историей доставки событий",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Удаление подписки webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id подписки",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное удаление",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Подписка с заданным id не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "description": "Возвращает доставки событий от новых к старым с результатом последней попытки",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получение истории доставки событий webhook с пагинацией",
+                "parameters": [
+                    {
+                        "description": "Пагинация",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.getDeliveriesBySubscriptionRequest"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "id подписки",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное получение",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.webhookDeliveriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат входных данных",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Подписка с заданным id не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Проблемы на стороне сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.taskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workflow": {
+            "get": {
+                "description": "Возвращает состояния задач и разрешённые переходы между ними, если переходы не указаны, разрешены любые",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получение состояний задач и переходов между ними",
+                "responses": {
+                    "200": {
+                        "description": "Успешное получение",
+                        "schema": {
+                            "$ref": "#/definitions/httpserver.workflowResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "httpserver.addCommentRequest": {
+            "type": "object",
+            "properties": {
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.addProjectMemberRequest": {
+            "type": "object",
+            "properties": {
+                "member": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.addProjectRequest": {
+            "type": "object",
+            "properties": {
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.addReminderRequest": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string"
+                },
+                "channel": {
+                    "type": "string"
+                },
+                "offset_minutes": {
+                    "type": "integer"
+                },
+                "recipient": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.addSubscriptionRequest": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.addTaskRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "planning_date": {
+                    "type": "object",
+                    "properties": {
+                        "day": {
+                            "type": "integer"
+                        },
+                        "month": {
+                            "type": "integer"
+                        },
+                        "year": {
+                            "type": "integer"
+                        }
+                    }
+                },
+                "project": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "boolean"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.assignTaskRequest": {
+            "type": "object",
+            "properties": {
+                "assignee": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.attachmentData": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "task_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpserver.attachmentResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/httpserver.attachmentData"
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.attachmentsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpserver.attachmentData"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.blockTaskRequest": {
+            "type": "object",
+            "properties": {
+                "blocker_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpserver.boardData": {
+            "type": "object",
+            "properties": {
+                "columns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpserver.columnData"
+                    }
+                }
+            }
+        },
+        "httpserver.boardResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/httpserver.boardData"
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.caldavPasswordData": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.caldavPasswordResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/httpserver.caldavPasswordData"
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.calendarFeedData": {
+            "type": "object",
+            "properties": {
+                "path": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.calendarFeedResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/httpserver.calendarFeedData"
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.columnData": {
+            "type": "object",
+            "properties": {
+                "done": {
+                    "type": "boolean"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpserver.taskData"
+                    }
+                }
+            }
+        },
+        "httpserver.commentData": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "task_id": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.commentResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/httpserver.commentData"
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.commentsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpserver.commentData"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.dateData": {
+            "type": "object",
+            "properties": {
+                "day": {
+                    "type": "integer"
+                },
+                "month": {
+                    "type": "integer"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpserver.deliveriesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpserver.deliveryData"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.deliveryData": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reminder_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpserver.dependencyData": {
+            "type": "object",
+            "properties": {
+                "blocked_id": {
+                    "type": "integer"
+                },
+                "blocker_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpserver.dependencyGraphData": {
+            "type": "object",
+            "properties": {
+                "dependencies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpserver.dependencyData"
+                    }
+                },
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpserver.taskData"
+                    }
+                }
+            }
+        },
+        "httpserver.dependencyGraphResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/httpserver.dependencyGraphData"
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.getBoardRequest": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpserver.getCommentsByTaskRequest": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpserver.getDeliveriesByReminderRequest": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpserver.getDeliveriesBySubscriptionRequest": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpserver.getHistoryByTaskRequest": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpserver.getOverdueTasksRequest": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpserver.getTaskByTextRequest": {
+            "type": "object",
+            "properties": {
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.getTasksAssignedToMeRequest": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpserver.getTasksByAssigneeRequest": {
+            "type": "object",
+            "properties": {
+                "assignee": {
+                    "type": "string"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpserver.getTasksByDateAndStatusRequest": {
+            "type": "object",
+            "properties": {
+                "planning_date": {
+                    "type": "object",
+                    "properties": {
+                        "day": {
+                            "type": "integer"
+                        },
+                        "month": {
+                            "type": "integer"
+                        },
+                        "year": {
+                            "type": "integer"
+                        }
+                    }
+                },
+                "status": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "httpserver.getTasksByStateRequest": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.getTasksByStatusRequest": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "httpserver.graphqlRequest": {
+            "type": "object",
+            "properties": {
+                "operationName": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": {}
+                }
+            }
+        },
+        "httpserver.graphqlResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "object"
+                    }
+                }
+            }
+        },
+        "httpserver.historyEntryData": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "from_date": {
+                    "$ref": "#/definitions/httpserver.dateData"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "task_id": {
+                    "type": "integer"
+                },
+                "to_date": {
+                    "$ref": "#/definitions/httpserver.dateData"
+                }
+            }
+        },
+        "httpserver.historyResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpserver.historyEntryData"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.importReportData": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpserver.importResultData"
+                    }
+                },
+                "skipped": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpserver.importReportResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/httpserver.importReportData"
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.importResultData": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "line": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "task": {
+                    "$ref": "#/definitions/httpserver.taskData"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "httpserver.moveTaskRequest": {
+            "type": "object",
+            "properties": {
+                "after_id": {
+                    "type": "integer"
+                },
+                "before_id": {
+                    "type": "integer"
+                },
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.projectData": {
+            "type": "object",
+            "properties": {
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.projectResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/httpserver.projectData"
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.projectsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpserver.projectData"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.reminderData": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string"
+                },
+                "attempts": {
+                    "type": "integer"
+                },
+                "channel": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "fire_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "offset_minutes": {
+                    "type": "integer"
+                },
+                "recipient": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "task_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpserver.reminderResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/httpserver.reminderData"
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.remindersResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpserver.reminderData"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.reorderDayRequest": {
+            "type": "object",
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "planning_date": {
+                    "type": "object",
+                    "properties": {
+                        "day": {
+                            "type": "integer"
+                        },
+                        "month": {
+                            "type": "integer"
+                        },
+                        "year": {
+                            "type": "integer"
+                        }
+                    }
+                }
+            }
+        },
+        "httpserver.rescheduleDayRequest": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "object",
+                    "properties": {
+                        "day": {
+                            "type": "integer"
+                        },
+                        "month": {
+                            "type": "integer"
+                        },
+                        "year": {
+                            "type": "integer"
+                        }
+                    }
+                },
+                "to": {
+                    "type": "object",
+                    "properties": {
+                        "day": {
+                            "type": "integer"
+                        },
+                        "month": {
+                            "type": "integer"
+                        },
+                        "year": {
+                            "type": "integer"
                         }
                     }
-                },
-                "status": {
+                }
+            }
+        },
+        "httpserver.rescheduleTaskRequest": {
+            "type": "object",
+            "properties": {
+                "planning_date": {
+                    "type": "object",
+                    "properties": {
+                        "day": {
+                            "type": "integer"
+                        },
+                        "month": {
+                            "type": "integer"
+                        },
+                        "year": {
+                            "type": "integer"
+                        }
+                    }
+                }
+            }
+        },
+        "httpserver.rescheduleToWeekdayRequest": {
+            "type": "object",
+            "properties": {
+                "weekday": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.setTaskProjectRequest": {
+            "type": "object",
+            "properties": {
+                "project": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.snoozeTaskRequest": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpserver.stateData": {
+            "type": "object",
+            "properties": {
+                "done": {
                     "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
                 }
             }
         },
-        "httpserver.getTasksByStatusRequest": {
+        "httpserver.subscriptionData": {
             "type": "object",
             "properties": {
-                "limit": {
+                "created_at": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
                     "type": "integer"
                 },
-                "offset": {
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.subscriptionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/httpserver.subscriptionData"
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.subscriptionsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpserver.subscriptionData"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.syncChangeRequest": {
+            "type": "object",
+            "properties": {
+                "op": {
+                    "type": "string"
+                },
+                "ref": {
+                    "type": "string"
+                },
+                "task": {
+                    "$ref": "#/definitions/httpserver.updateTaskRequest"
+                },
+                "task_id": {
                     "type": "integer"
                 },
-                "status": {
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpserver.syncData": {
+            "type": "object",
+            "properties": {
+                "reset": {
+                    "type": "boolean"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpserver.syncResultData"
+                    }
+                },
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpserver.taskData"
+                    }
+                },
+                "token": {
+                    "type": "string"
+                },
+                "tombstones": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpserver.tombstoneData"
+                    }
+                }
+            }
+        },
+        "httpserver.syncRequest": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpserver.syncChangeRequest"
+                    }
+                },
+                "client": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.syncResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/httpserver.syncData"
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.syncResultData": {
+            "type": "object",
+            "properties": {
+                "deleted": {
                     "type": "boolean"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "ref": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "task": {
+                    "$ref": "#/definitions/httpserver.taskData"
+                },
+                "task_id": {
+                    "type": "integer"
                 }
             }
         },
         "httpserver.taskData": {
             "type": "object",
             "properties": {
+                "assignees": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "blocked": {
+                    "type": "boolean"
+                },
                 "description": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
+                "overdue": {
+                    "type": "boolean"
+                },
                 "planning_date": {
                     "type": "object",
                     "properties": {
@@ -408,11 +4046,29 @@ const docTemplate = `{
                         }
                     }
                 },
+                "position": {
+                    "type": "integer"
+                },
+                "postponed": {
+                    "type": "integer"
+                },
+                "project": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
                 "status": {
                     "type": "boolean"
                 },
                 "title": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
@@ -436,7 +4092,31 @@ const docTemplate = `{
                         "$ref": "#/definitions/httpserver.taskData"
                     }
                 },
-                "error": {}
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.tombstoneData": {
+            "type": "object",
+            "properties": {
+                "deleted_at": {
+                    "type": "string"
+                },
+                "task_id": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpserver.updateCommentRequest": {
+            "type": "object",
+            "properties": {
+                "text": {
+                    "type": "string"
+                }
             }
         },
         "httpserver.updateTaskRequest": {
@@ -459,6 +4139,9 @@ const docTemplate = `{
                         }
                     }
                 },
+                "state": {
+                    "type": "string"
+                },
                 "status": {
                     "type": "boolean"
                 },
@@ -466,6 +4149,86 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "httpserver.webhookDeliveriesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpserver.webhookDeliveryData"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpserver.webhookDeliveryData": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "response_code": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "subscription_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpserver.workflowData": {
+            "type": "object",
+            "properties": {
+                "states": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpserver.stateData"
+                    }
+                },
+                "transitions": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "httpserver.workflowResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/httpserver.workflowData"
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
         }
     }
 }`
@@ -3,19 +3,126 @@ package app
 import (
 	"context"
 	"errors"
+	"log"
+	"slices"
+	"strings"
+	"time"
 	"todo-list/internal/app/valid"
 	"todo-list/internal/model"
 )
 
+// Config contains settings of the app
+type Config struct {
+	// Members are users of the workspace who can be assigned to the tasks
+	// and comment them, empty list allows everyone
+	Members []string
+
+	// MaxAttachmentSize is a limit of size of the file attached to the task in bytes
+	MaxAttachmentSize int64
+
+	// Workflow contains states of the tasks and transitions between them,
+	// DefaultWorkflow is used if it has no states
+	Workflow model.Workflow
+
+	// RolloverPolicy is a policy of the rollover of overdue tasks, one of
+	// RolloverMove, RolloverFlag or RolloverOff
+	RolloverPolicy string
+
+	// ReminderAttempts is a number of attempts to deliver the reminder
+	// before it is marked as failed, defaultReminderAttempts is used if it
+	// is not positive
+	ReminderAttempts int
+
+	// WebhookAttempts is a number of attempts to deliver the event to the
+	// webhook before the delivery is marked as failed,
+	// defaultWebhookAttempts is used if it is not positive
+	WebhookAttempts int
+
+	// OutboxRetention is a time for which published events are kept in the
+	// outbox, defaultOutboxRetention is used if it is not positive
+	OutboxRetention time.Duration
+
+	// TombstoneRetention is a time for which deleted tasks are kept for the
+	// sync clients, older sync tokens get all tasks again,
+	// defaultTombstoneRetention is used if it is not positive
+	TombstoneRetention time.Duration
+
+	// CalendarEnabled allows users to issue tokens of their calendar feeds,
+	// issued tokens stop working while it is false
+	CalendarEnabled bool
+}
+
 type app struct {
 	TaskRepo
+	dependencies       DependencyRepo
+	board              BoardRepo
+	plans              PlanRepo
+	schedule           ScheduleRepo
+	comments           CommentRepo
+	attachments        AttachmentRepo
+	blobs              BlobStore
+	reminders          ReminderRepo
+	notifiers          map[string]Notifier
+	webhooks           WebhookRepo
+	sender             WebhookSender
+	outbox             OutboxRepo
+	sinks              []EventSink
+	broker             EventBroker
+	sync               SyncRepo
+	credentials        CredentialRepo
+	calendars          CalendarRepo
+	members            map[string]struct{}
+	maxAttachmentSize  int64
+	workflow           model.Workflow
+	rolloverPolicy     string
+	reminderAttempts   int
+	webhookAttempts    int
+	outboxRetention    time.Duration
+	tombstoneRetention time.Duration
+	calendarEnabled    bool
+}
+
+// isMember returns true if user belongs to the workspace. Empty list of
+// members means that workspace is open and every user is its member
+func (a *app) isMember(user string) bool {
+	if len(a.members) == 0 {
+		return true
+	}
+	_, ok := a.members[user]
+	return ok
 }
 
 func (a *app) AddTask(ctx context.Context, t model.TodoTask) (model.TodoTask, error) {
+	t, err := a.newTask(ctx, t)
+	if err != nil {
+		return model.TodoTask{}, err
+	}
+	return a.TaskRepo.AddTask(ctx, t)
+}
+
+// newTask checks the added task and returns it with the state and the rank at
+// the end of the column of the state
+func (a *app) newTask(ctx context.Context, t model.TodoTask) (model.TodoTask, error) {
 	if err := valid.TodoTask(t); err != nil {
 		return model.TodoTask{}, errors.Join(model.ErrInvalidTask, err)
 	}
-	return a.TaskRepo.AddTask(ctx, t)
+
+	// clients which don't know about workflow set only status of the task
+	if t.State == "" {
+		t.State = defaultState(a.workflow, t.Status)
+	}
+	state, ok := findState(a.workflow, t.State)
+	if !ok {
+		return model.TodoTask{}, model.ErrUnknownState
+	}
+	t.Status = state.Done
+
+	rank, err := a.lastRank(ctx, t.State)
+	if err != nil {
+		return model.TodoTask{}, err
+	}
+	t.Rank = rank
+	return t, nil
 }
 
 func (a *app) GetTaskById(ctx context.Context, id int) (model.TodoTask, error) {
@@ -30,14 +137,94 @@ func (a *app) GetTaskByText(ctx context.Context, text string) ([]model.TodoTask,
 }
 
 func (a *app) UpdateTask(ctx context.Context, id int, t model.TodoTask) (model.TodoTask, error) {
-	if err := valid.TodoTask(t); err != nil {
+	return a.updateTask(ctx, id, t, false)
+}
+
+func (a *app) ForceUpdateTask(ctx context.Context, id int, t model.TodoTask) (model.TodoTask, error) {
+	return a.updateTask(ctx, id, t, true)
+}
+
+// transit returns state of the workflow the task can be moved to, blockers of
+// the task are checked by the repo after the task is locked
+func (a *app) transit(current model.TodoTask, to string) (model.State, error) {
+	state, ok := findState(a.workflow, to)
+	if !ok {
+		return model.State{}, model.ErrUnknownState
+	} else if !canTransit(a.workflow, current.State, state.Name) {
+		return model.State{}, model.ErrTransition
+	}
+	return state, nil
+}
+
+// updateTask moves task to the new state if the transition is allowed by the
+// workflow, task is placed to the end of the column of the new state. Task
+// can't be moved to the done state until all its blockers are done unless it
+// is forced
+func (a *app) updateTask(ctx context.Context, id int, t model.TodoTask, force bool) (model.TodoTask, error) {
+	if err := valid.UpdatedTodoTask(t); err != nil {
 		return model.TodoTask{}, errors.Join(model.ErrInvalidTask, err)
 	}
+
+	current, err := a.TaskRepo.GetTaskById(ctx, id)
+	if err != nil {
+		return model.TodoTask{}, err
+	}
+
+	// overdue task left on its date by the rollover can be edited and
+	// completed, only the task moved to another date must not be late
+	if t.PlanningDate != current.PlanningDate {
+		if err = valid.PlanningDate(t.PlanningDate); err != nil {
+			return model.TodoTask{}, errors.Join(model.ErrInvalidTask, err)
+		}
+	}
+
+	// clients which don't know about workflow set only status of the task, so
+	// the state is kept while the status is not changed
+	if t.State == "" {
+		if t.Status == current.Status {
+			t.State = current.State
+		} else {
+			t.State = defaultState(a.workflow, t.Status)
+		}
+	}
+
+	state, err := a.transit(current, t.State)
+	if err != nil {
+		return model.TodoTask{}, err
+	}
+	t.Status = state.Done
+
+	t.Rank = current.Rank
+	if t.State != current.State {
+		if t.Rank, err = a.lastRank(ctx, t.State); err != nil {
+			return model.TodoTask{}, err
+		}
+	}
+
+	if force {
+		return a.TaskRepo.ForceUpdateTask(ctx, id, t)
+	}
 	return a.TaskRepo.UpdateTask(ctx, id, t)
 }
 
 func (a *app) DeleteTask(ctx context.Context, id int) error {
-	return a.TaskRepo.DeleteTask(ctx, id)
+	attachments, err := a.attachments.GetAttachmentsByTask(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err = a.TaskRepo.DeleteTask(ctx, id); err != nil {
+		return err
+	}
+
+	// attachments of the task are deleted from database by cascade, so
+	// failed removing of the content leaves only unreachable blobs
+	for _, at := range attachments {
+		if err = a.blobs.Delete(ctx, at.Key); err != nil {
+			log.Printf("content %s of attachment %d was not deleted: %s\n", at.Key, at.Id, err.Error())
+		}
+	}
+	return nil
 }
 
 func (a *app) GetTasksByStatus(ctx context.Context, status bool, limit int, offset int) ([]model.TodoTask, error) {
@@ -54,8 +241,245 @@ func (a *app) GetTasksByDateAndStatus(ctx context.Context, date model.Date, stat
 	return a.TaskRepo.GetTasksByDateAndStatus(ctx, date, status)
 }
 
-func New(tr TaskRepo) App {
+func (a *app) AssignTask(ctx context.Context, id int, assignee string) (model.TodoTask, error) {
+	if err := valid.User(assignee); err != nil {
+		return model.TodoTask{}, errors.Join(model.ErrInvalidInput, err)
+	} else if !a.isMember(assignee) {
+		return model.TodoTask{}, model.ErrNotMember
+	}
+	return a.TaskRepo.AssignTask(ctx, id, assignee)
+}
+
+func (a *app) UnassignTask(ctx context.Context, id int, assignee string) (model.TodoTask, error) {
+	if err := valid.User(assignee); err != nil {
+		return model.TodoTask{}, errors.Join(model.ErrInvalidInput, err)
+	}
+	return a.TaskRepo.UnassignTask(ctx, id, assignee)
+}
+
+func (a *app) GetTasksByAssignee(ctx context.Context, assignee string, offset int, limit int) ([]model.TodoTask, error) {
+	if err := valid.User(assignee); err != nil {
+		return nil, errors.Join(model.ErrInvalidInput, err)
+	} else if limit < 0 || offset < 0 {
+		return nil, model.ErrInvalidInput
+	}
+	return a.TaskRepo.GetTasksByAssignee(ctx, assignee, offset, limit)
+}
+
+func (a *app) SetTaskProject(ctx context.Context, id int, project string) (model.TodoTask, error) {
+	if project != "" {
+		if err := valid.ProjectName(project); err != nil {
+			return model.TodoTask{}, errors.Join(model.ErrInvalidInput, err)
+		}
+	}
+	return a.TaskRepo.SetTaskProject(ctx, id, project)
+}
+
+func (a *app) AddProject(ctx context.Context, p model.Project) (model.Project, error) {
+	if err := valid.Project(p); err != nil {
+		return model.Project{}, errors.Join(model.ErrInvalidInput, err)
+	}
+
+	members := make([]string, 0, len(p.Members))
+	for _, member := range p.Members {
+		if !a.isMember(member) {
+			return model.Project{}, model.ErrNotMember
+		} else if !slices.Contains(members, member) {
+			members = append(members, member)
+		}
+	}
+	p.Members = members
+	return a.TaskRepo.AddProject(ctx, p)
+}
+
+func (a *app) GetProject(ctx context.Context, name string) (model.Project, error) {
+	if err := valid.ProjectName(name); err != nil {
+		return model.Project{}, errors.Join(model.ErrInvalidInput, err)
+	}
+	return a.TaskRepo.GetProject(ctx, name)
+}
+
+func (a *app) AddProjectMember(ctx context.Context, name string, member string) (model.Project, error) {
+	if err := valid.ProjectName(name); err != nil {
+		return model.Project{}, errors.Join(model.ErrInvalidInput, err)
+	} else if err = valid.User(member); err != nil {
+		return model.Project{}, errors.Join(model.ErrInvalidInput, err)
+	} else if !a.isMember(member) {
+		return model.Project{}, model.ErrNotMember
+	}
+	return a.TaskRepo.AddProjectMember(ctx, name, member)
+}
+
+func (a *app) RemoveProjectMember(ctx context.Context, name string, member string) (model.Project, error) {
+	if err := valid.ProjectName(name); err != nil {
+		return model.Project{}, errors.Join(model.ErrInvalidInput, err)
+	} else if err = valid.User(member); err != nil {
+		return model.Project{}, errors.Join(model.ErrInvalidInput, err)
+	}
+	return a.TaskRepo.RemoveProjectMember(ctx, name, member)
+}
+
+// normalizeComment makes line endings of the Markdown text of the comment uniform
+func normalizeComment(text string) string {
+	return strings.ReplaceAll(text, "\r\n", "\n")
+}
+
+func (a *app) AddComment(ctx context.Context, taskId int, author string, text string) (model.Comment, error) {
+	if err := valid.User(author); err != nil {
+		return model.Comment{}, errors.Join(model.ErrInvalidInput, err)
+	} else if !a.isMember(author) {
+		return model.Comment{}, model.ErrNotMember
+	}
+
+	text = normalizeComment(text)
+	if err := valid.Comment(text); err != nil {
+		return model.Comment{}, errors.Join(model.ErrInvalidComment, err)
+	}
+
+	if _, err := a.TaskRepo.GetTaskById(ctx, taskId); err != nil {
+		return model.Comment{}, err
+	}
+	return a.comments.AddComment(ctx, model.Comment{
+		TaskId: taskId,
+		Author: author,
+		Text:   text,
+	})
+}
+
+// authoredComment returns comment of the task if it was written by the author
+func (a *app) authoredComment(ctx context.Context, taskId int, id int, author string) (model.Comment, error) {
+	c, err := a.comments.GetCommentById(ctx, id)
+	if err != nil {
+		return model.Comment{}, err
+	} else if c.TaskId != taskId {
+		return model.Comment{}, model.ErrCommentNotFound
+	} else if c.Author != author {
+		return model.Comment{}, model.ErrForbidden
+	}
+	return c, nil
+}
+
+func (a *app) UpdateComment(ctx context.Context, taskId int, id int, author string, text string) (model.Comment, error) {
+	text = normalizeComment(text)
+	if err := valid.Comment(text); err != nil {
+		return model.Comment{}, errors.Join(model.ErrInvalidComment, err)
+	}
+
+	if _, err := a.authoredComment(ctx, taskId, id, author); err != nil {
+		return model.Comment{}, err
+	}
+	return a.comments.UpdateComment(ctx, id, text)
+}
+
+func (a *app) DeleteComment(ctx context.Context, taskId int, id int, author string) error {
+	if _, err := a.authoredComment(ctx, taskId, id, author); err != nil {
+		return err
+	}
+	return a.comments.DeleteComment(ctx, id)
+}
+
+func (a *app) GetCommentsByTask(ctx context.Context, taskId int, offset int, limit int) ([]model.Comment, error) {
+	if limit < 0 || offset < 0 {
+		return nil, model.ErrInvalidInput
+	}
+
+	if _, err := a.TaskRepo.GetTaskById(ctx, taskId); err != nil {
+		return nil, err
+	}
+	return a.comments.GetCommentsByTask(ctx, taskId, offset, limit)
+}
+
+// Deps are repositories and services the app works with. Repositories not
+// used by the caller may be nil
+type Deps struct {
+	Tasks        TaskRepo
+	Dependencies DependencyRepo
+	Board        BoardRepo
+	Plans        PlanRepo
+	Schedule     ScheduleRepo
+	Comments     CommentRepo
+	Attachments  AttachmentRepo
+
+	// Blobs keeps content of the attached files
+	Blobs BlobStore
+
+	Reminders ReminderRepo
+
+	// Notifiers deliver the reminders by their channels
+	Notifiers map[string]Notifier
+
+	Webhooks      WebhookRepo
+	WebhookSender WebhookSender
+	Outbox        OutboxRepo
+
+	// Sinks are sinks the events of the outbox are published to, Broker of
+	// the stream of the events has to be one of them
+	Sinks  []EventSink
+	Broker EventBroker
+
+	Sync        SyncRepo
+	Credentials CredentialRepo
+	Calendars   CalendarRepo
+}
+
+// New creates app which works with given dependencies. Only given members of
+// the workspace can be assigned to the tasks, added to the projects and
+// comment the tasks, empty members allow everyone
+func New(deps Deps, cfg Config) App {
+	m := make(map[string]struct{}, len(cfg.Members))
+	for _, member := range cfg.Members {
+		m[member] = struct{}{}
+	}
+
+	workflow := cfg.Workflow
+	if len(workflow.States) == 0 {
+		workflow = DefaultWorkflow()
+	}
+
+	reminderAttempts := cfg.ReminderAttempts
+	if reminderAttempts <= 0 {
+		reminderAttempts = defaultReminderAttempts
+	}
+	webhookAttempts := cfg.WebhookAttempts
+	if webhookAttempts <= 0 {
+		webhookAttempts = defaultWebhookAttempts
+	}
+	outboxRetention := cfg.OutboxRetention
+	if outboxRetention <= 0 {
+		outboxRetention = defaultOutboxRetention
+	}
+	tombstoneRetention := cfg.TombstoneRetention
+	if tombstoneRetention <= 0 {
+		tombstoneRetention = defaultTombstoneRetention
+	}
+
 	return &app{
-		TaskRepo: tr,
+		TaskRepo:           deps.Tasks,
+		dependencies:       deps.Dependencies,
+		board:              deps.Board,
+		plans:              deps.Plans,
+		schedule:           deps.Schedule,
+		comments:           deps.Comments,
+		attachments:        deps.Attachments,
+		blobs:              deps.Blobs,
+		reminders:          deps.Reminders,
+		notifiers:          deps.Notifiers,
+		webhooks:           deps.Webhooks,
+		sender:             deps.WebhookSender,
+		outbox:             deps.Outbox,
+		sinks:              deps.Sinks,
+		broker:             deps.Broker,
+		sync:               deps.Sync,
+		credentials:        deps.Credentials,
+		calendars:          deps.Calendars,
+		members:            m,
+		maxAttachmentSize:  cfg.MaxAttachmentSize,
+		workflow:           workflow,
+		rolloverPolicy:     cfg.RolloverPolicy,
+		reminderAttempts:   reminderAttempts,
+		webhookAttempts:    webhookAttempts,
+		outboxRetention:    outboxRetention,
+		tombstoneRetention: tombstoneRetention,
+		calendarEnabled:    cfg.CalendarEnabled,
 	}
 }
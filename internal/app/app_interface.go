@@ -2,11 +2,205 @@ package app
 
 import (
 	"context"
+	"io"
+	"time"
 	"todo-list/internal/model"
 )
 
 type App interface {
 	TaskRepo
+
+	// Workflow returns states of the tasks and allowed transitions between them
+	Workflow() model.Workflow
+
+	// MoveTask moves task to the column of the board and place in it described by m
+	MoveTask(ctx context.Context, id int, m model.Move) (model.TodoTask, error)
+
+	// GetBoard returns columns for all states of the workflow with at most
+	// limit tasks ordered by rank in each of them
+	GetBoard(ctx context.Context, limit int) (model.Board, error)
+
+	// ReorderDay sets order of the tasks planned on the date, ids must contain
+	// all these tasks, tasks of the date are returned in the new order
+	ReorderDay(ctx context.Context, date model.Date, ids []int) ([]model.TodoTask, error)
+
+	// RolloverOverdue moves undone tasks which planning date has passed to the
+	// current day or flags them as overdue depending on the rollover policy and
+	// returns number of affected tasks
+	RolloverOverdue(ctx context.Context) (int, error)
+
+	// SnoozeTask moves the task to the date days later than its planning date
+	// or than the current date if the task is overdue
+	SnoozeTask(ctx context.Context, id int, days int) (model.TodoTask, error)
+
+	// RescheduleToWeekday moves the task to the nearest given day of the week
+	// after its planning date or after the current date if the task is overdue
+	RescheduleToWeekday(ctx context.Context, id int, weekday time.Weekday) (model.TodoTask, error)
+
+	// RescheduleTask moves the task to the date
+	RescheduleTask(ctx context.Context, id int, date model.Date) (model.TodoTask, error)
+
+	// RescheduleDay moves all undone tasks planned on the date from to the end
+	// of the date to and returns all tasks of the date to in order of the day
+	RescheduleDay(ctx context.Context, from model.Date, to model.Date) ([]model.TodoTask, error)
+
+	// GetHistoryByTask returns slice of moves of the task between days from oldest to newest with pagination
+	GetHistoryByTask(ctx context.Context, taskId int, offset int, limit int) ([]model.HistoryEntry, error)
+
+	// AddReminder adds reminder to the task with given id
+	AddReminder(ctx context.Context, taskId int, r model.Reminder) (model.Reminder, error)
+
+	// GetRemindersByTask returns slice of reminders of the task
+	GetRemindersByTask(ctx context.Context, taskId int) ([]model.Reminder, error)
+
+	// DeleteReminder deletes reminder of the task
+	DeleteReminder(ctx context.Context, taskId int, id int) error
+
+	// GetDeliveriesByReminder returns slice of attempts to deliver the
+	// reminder of the task from oldest to newest with pagination
+	GetDeliveriesByReminder(ctx context.Context, taskId int, id int, offset int, limit int) ([]model.Delivery, error)
+
+	// DeliverReminders sends due reminders of undone tasks through their
+	// notifiers, schedules retries of failed attempts and returns number of
+	// sent reminders
+	DeliverReminders(ctx context.Context) (int, error)
+
+	// AddSubscription subscribes the webhook to events of given types, secret
+	// is generated if it is empty
+	AddSubscription(ctx context.Context, sub model.Subscription) (model.Subscription, error)
+
+	// GetSubscriptions returns slice of all webhook subscriptions without secrets
+	GetSubscriptions(ctx context.Context) ([]model.Subscription, error)
+
+	// DeleteSubscription deletes webhook subscription with its deliveries
+	DeleteSubscription(ctx context.Context, id int) error
+
+	// GetDeliveriesBySubscription returns slice of deliveries of the events
+	// to the webhook from newest to oldest with pagination
+	GetDeliveriesBySubscription(ctx context.Context, id int, offset int, limit int) ([]model.WebhookDelivery, error)
+
+	// DeliverWebhooks sends due deliveries of the events to the webhooks,
+	// schedules retries of failed attempts and returns number of delivered events
+	DeliverWebhooks(ctx context.Context) (int, error)
+
+	// RelayEvents publishes events of the outbox to all sinks, schedules
+	// retries of failed events, removes published events older than the
+	// retention period and returns number of published events
+	RelayEvents(ctx context.Context) (int, error)
+
+	// SubscribeEvents subscribes to creation, update and deletion of the
+	// tasks of the scope until ctx is done, events published after the event
+	// with afterId are returned as missed if they are still buffered
+	SubscribeEvents(ctx context.Context, scope model.EventScope, afterId int) (model.EventStream, error)
+
+	// GetChanges returns tasks changed and deleted since the sync token, all
+	// tasks are returned for empty or expired token
+	GetChanges(ctx context.Context, token string) (model.SyncChanges, error)
+
+	// PushChanges applies changes made by the offline client in their order
+	// and returns their outcomes, change of the task which version differs
+	// from the version known by the client is not applied and reported as
+	// a conflict. Creation with the ref already applied for the client
+	// returns the task created before, empty client disables this check
+	PushChanges(ctx context.Context, client string, changes []model.SyncChange) ([]model.SyncResult, error)
+
+	// PurgeTombstones removes deleted tasks older than the retention period
+	// and returns their number
+	PurgeTombstones(ctx context.Context) (int, error)
+
+	// IssueCalendarToken returns new random token of the calendar feed of the
+	// user, the previous token of the user stops working
+	IssueCalendarToken(ctx context.Context, user string) (string, error)
+
+	// RevokeCalendarToken revokes token of the calendar feed of the user
+	RevokeCalendarToken(ctx context.Context, user string) error
+
+	// GetCalendarFeed returns tasks assigned to the user if the token is the
+	// current token of the calendar feed of the user
+	GetCalendarFeed(ctx context.Context, user string, token string) ([]model.TodoTask, error)
+
+	// GetTasksByDateRange returns undone and then done tasks of every day
+	// from the date from to the date to inclusive
+	GetTasksByDateRange(ctx context.Context, from model.Date, to model.Date) ([]model.TodoTask, error)
+
+	// IssueCalDAVPassword returns new random password of the user in CalDAV
+	// clients, it is issued separately from the token of the calendar feed
+	// and the previous password of the user stops working
+	IssueCalDAVPassword(ctx context.Context, user string) (string, error)
+
+	// RevokeCalDAVPassword revokes CalDAV password of the user
+	RevokeCalDAVPassword(ctx context.Context, user string) error
+
+	// CheckCalDAVPassword returns ErrWrongPassword if the password is not the
+	// current CalDAV password of the user
+	CheckCalDAVPassword(ctx context.Context, user string, password string) error
+
+	// GetCalendarObjects returns tasks assigned to the user as objects of
+	// their CalDAV calendar
+	GetCalendarObjects(ctx context.Context, user string) ([]model.CalendarObject, error)
+
+	// GetCalendarObject returns object of the CalDAV calendar of the user
+	// with given name
+	GetCalendarObject(ctx context.Context, user string, name string) (model.CalendarObject, error)
+
+	// PutCalendarObject updates the task of the object of the calendar of
+	// the user with the same name or creates the task assigned to the user if
+	// there is no such object, returns the object and true if it was created.
+	// Non-zero version of the task must be equal to the version of the task
+	// of the existing object
+	PutCalendarObject(ctx context.Context, o model.CalendarObject) (model.CalendarObject, bool, error)
+
+	// DeleteCalendarObject removes the object from the calendar of the user:
+	// the user is unassigned from its task, the task is deleted if nobody
+	// else is assigned to it. Non-zero version must be equal to the version
+	// of the task
+	DeleteCalendarObject(ctx context.Context, user string, name string, version int) error
+
+	// ImportTasks adds tasks of the rows of the imported file and reports
+	// outcome of every row. Rows which can't be read, invalid tasks and tasks
+	// with the same title as another task planned on the same date are
+	// skipped, tasks without planning date are planned on the current day.
+	// In the transaction mode all rows are skipped with ErrImportAborted if
+	// any of them is skipped. Nothing is added in the dry run
+	ImportTasks(ctx context.Context, rows []model.ImportRow, mode string, dryRun bool) (model.ImportReport, error)
+
+	// ExportTasks returns undone and then done tasks, at most maxExportTasks
+	ExportTasks(ctx context.Context) ([]model.TodoTask, error)
+
+	// BlockTask makes task with blockerId a blocker of task with blockedId
+	// and returns updated blocked task
+	BlockTask(ctx context.Context, blockerId int, blockedId int) (model.TodoTask, error)
+
+	// UnblockTask removes task with blockerId from blockers of task with
+	// blockedId and returns updated blocked task
+	UnblockTask(ctx context.Context, blockerId int, blockedId int) (model.TodoTask, error)
+
+	// AddComment adds comment of the author to the task with given id
+	AddComment(ctx context.Context, taskId int, author string, text string) (model.Comment, error)
+
+	// UpdateComment changes text of the comment, only author can do it
+	UpdateComment(ctx context.Context, taskId int, id int, author string, text string) (model.Comment, error)
+
+	// DeleteComment deletes comment, only author can do it
+	DeleteComment(ctx context.Context, taskId int, id int, author string) error
+
+	// GetCommentsByTask returns slice of comments of the task from oldest to newest with pagination
+	GetCommentsByTask(ctx context.Context, taskId int, offset int, limit int) ([]model.Comment, error)
+
+	// AddAttachment stores file with given name and size read from r and attaches it to the task
+	AddAttachment(ctx context.Context, taskId int, name string, size int64, r io.Reader) (model.Attachment, error)
+
+	// GetAttachmentsByTask returns slice of attachments of the task
+	GetAttachmentsByTask(ctx context.Context, taskId int) ([]model.Attachment, error)
+
+	// GetAttachment returns attachment of the task with its content, content must be closed by caller
+	GetAttachment(ctx context.Context, taskId int, id int) (model.Attachment, io.ReadCloser, error)
+
+	// DeleteAttachment deletes attachment of the task with its content
+	DeleteAttachment(ctx context.Context, taskId int, id int) error
+
+	// MaxAttachmentSize returns the limit of size of the attached file in bytes
+	MaxAttachmentSize() int64
 }
 
 type TaskRepo interface {
@@ -19,15 +213,324 @@ type TaskRepo interface {
 	// GetTaskByText returns slice of tasks with given text in title or description
 	GetTaskByText(ctx context.Context, text string) ([]model.TodoTask, error)
 
-	// UpdateTask updates fields of task with given id
+	// UpdateTask updates fields of task with given id, returns ErrTaskBlocked
+	// if the task is marked as done while its blockers are not done, non-zero
+	// version of t must be equal to the version of the task
 	UpdateTask(ctx context.Context, id int, t model.TodoTask) (model.TodoTask, error)
 
+	// ForceUpdateTask updates fields of task with given id even if it is
+	// marked as done while its blockers are not done
+	ForceUpdateTask(ctx context.Context, id int, t model.TodoTask) (model.TodoTask, error)
+
 	// DeleteTask deletes task with given id from database
 	DeleteTask(ctx context.Context, id int) error
 
-	// GetTasksByStatus returns slice of tasks filtered by status with pagination
+	// GetTasksByStatus returns slice of tasks filtered by status (done or not done state) with pagination
 	GetTasksByStatus(ctx context.Context, status bool, offset int, limit int) ([]model.TodoTask, error)
 
-	// GetTasksByDateAndStatus returns slice of tasks filtered by planning date and status
+	// GetTasksByState returns slice of tasks in given state of the workflow ordered by rank with pagination
+	GetTasksByState(ctx context.Context, state string, offset int, limit int) ([]model.TodoTask, error)
+
+	// GetTasksByDateAndStatus returns slice of tasks filtered by planning date and status in order of the day
 	GetTasksByDateAndStatus(ctx context.Context, date model.Date, status bool) ([]model.TodoTask, error)
+
+	// AssignTask adds user to the assignees of task with given id
+	AssignTask(ctx context.Context, id int, assignee string) (model.TodoTask, error)
+
+	// UnassignTask removes user from the assignees of task with given id
+	UnassignTask(ctx context.Context, id int, assignee string) (model.TodoTask, error)
+
+	// GetTasksByAssignee returns slice of tasks assigned to given user with pagination
+	GetTasksByAssignee(ctx context.Context, assignee string, offset int, limit int) ([]model.TodoTask, error)
+
+	// SetTaskProject moves task with given id to the project, empty project
+	// leaves the task only in the workspace
+	SetTaskProject(ctx context.Context, id int, project string) (model.TodoTask, error)
+
+	// AddProject adds project with its members to database
+	AddProject(ctx context.Context, p model.Project) (model.Project, error)
+
+	// GetProject searches project in database with given name
+	GetProject(ctx context.Context, name string) (model.Project, error)
+
+	// GetProjects returns slice of all projects ordered by name
+	GetProjects(ctx context.Context) ([]model.Project, error)
+
+	// AddProjectMember adds user to the members of the project
+	AddProjectMember(ctx context.Context, name string, member string) (model.Project, error)
+
+	// RemoveProjectMember removes user from the members of the project if
+	// the user is not assigned to its tasks
+	RemoveProjectMember(ctx context.Context, name string, member string) (model.Project, error)
+
+	// GetOverdueTasks returns slice of undone tasks flagged as overdue ordered by planning date with pagination
+	GetOverdueTasks(ctx context.Context, offset int, limit int) ([]model.TodoTask, error)
+
+	// GetDependencyGraph returns task with given id with all tasks which
+	// transitively block it or are blocked by it
+	GetDependencyGraph(ctx context.Context, id int) (model.DependencyGraph, error)
+
+	// AddTasks adds tasks in a single transaction and returns them with their
+	// ids, no task is added if any of them fails
+	AddTasks(ctx context.Context, tasks []model.TodoTask) ([]model.TodoTask, error)
+}
+
+type BoardRepo interface {
+	// GetLastRank returns the greatest rank of the tasks in given state or
+	// empty string if there are no such tasks
+	GetLastRank(ctx context.Context, state string) (string, error)
+
+	// GetRankNeighbours returns ranks of the previous task, the task with given
+	// id and the next task in the column of given state, task with exceptId is
+	// skipped and empty rank is returned if there is no neighbour
+	GetRankNeighbours(ctx context.Context, id int, exceptId int, state string) (string, string, string, error)
+
+	// MoveTask sets state, status and rank of the task with given id, returns
+	// ErrTaskBlocked if the task is marked as done while its blockers are not done
+	MoveTask(ctx context.Context, id int, state string, status bool, rank string) (model.TodoTask, error)
+
+	// SetRanks sets ranks of the tasks by their ids in a single transaction
+	SetRanks(ctx context.Context, ranks map[int]string) error
+}
+
+type PlanRepo interface {
+	// GetTasksByDate returns slice of all tasks planned on the date in order of the day
+	GetTasksByDate(ctx context.Context, date model.Date) ([]model.TodoTask, error)
+
+	// ReorderDay atomically sets positions of the tasks planned on the date
+	// by order of ids and writes their updates to the outbox or returns
+	// ErrDayOrder if ids don't match these tasks
+	ReorderDay(ctx context.Context, date model.Date, ids []int) error
+}
+
+type ScheduleRepo interface {
+	// MoveOverdue moves undone tasks planned before today to the end of
+	// today, flags them as overdue and returns written history entries
+	MoveOverdue(ctx context.Context, today model.Date) ([]model.HistoryEntry, error)
+
+	// RescheduleTask moves the task to the end of the date, writes the move
+	// with given action to the history and returns the entry, ErrTaskNotFound
+	// is returned if the task doesn't exist or is already planned on the date
+	RescheduleTask(ctx context.Context, id int, date model.Date, action string) (model.HistoryEntry, error)
+
+	// RescheduleDay moves undone tasks of the date from to the end of the date
+	// to keeping their order and returns written history entries
+	RescheduleDay(ctx context.Context, from model.Date, to model.Date, action string) ([]model.HistoryEntry, error)
+
+	// FlagOverdue flags undone tasks planned before today as overdue, writes
+	// their updates to the outbox and returns number of newly flagged tasks
+	FlagOverdue(ctx context.Context, today model.Date) (int, error)
+
+	// GetHistoryByTask returns slice of moves of the task between days with pagination
+	GetHistoryByTask(ctx context.Context, taskId int, offset int, limit int) ([]model.HistoryEntry, error)
+}
+
+type ReminderRepo interface {
+	// AddReminder adds reminder to database
+	AddReminder(ctx context.Context, r model.Reminder) (model.Reminder, error)
+
+	// GetReminderById searches reminder in database with given id
+	GetReminderById(ctx context.Context, id int) (model.Reminder, error)
+
+	// GetRemindersByTask returns slice of reminders of the task
+	GetRemindersByTask(ctx context.Context, taskId int) ([]model.Reminder, error)
+
+	// DeleteReminder deletes reminder with given id from database
+	DeleteReminder(ctx context.Context, id int) error
+
+	// ClaimDueReminders returns at most limit pending reminders of undone
+	// tasks which fire time is not later than now, counts new attempt of
+	// each of them and postpones them until given time, so they are not
+	// claimed again while being delivered
+	ClaimDueReminders(ctx context.Context, now time.Time, until time.Time, limit int) ([]model.Reminder, error)
+
+	// RecordDelivery adds the attempt to the delivery log and sets status of
+	// the reminder and time of its next attempt in a single transaction
+	RecordDelivery(ctx context.Context, d model.Delivery, status string, retryAt time.Time) error
+
+	// GetDeliveriesByReminder returns slice of attempts to deliver the reminder with pagination
+	GetDeliveriesByReminder(ctx context.Context, reminderId int, offset int, limit int) ([]model.Delivery, error)
+}
+
+type Notifier interface {
+	// Notify delivers the reminder about the task to its recipient
+	Notify(ctx context.Context, r model.Reminder, t model.TodoTask) error
+}
+
+type WebhookRepo interface {
+	// AddSubscription adds webhook subscription to database
+	AddSubscription(ctx context.Context, sub model.Subscription) (model.Subscription, error)
+
+	// GetSubscriptionById searches webhook subscription in database with given id
+	GetSubscriptionById(ctx context.Context, id int) (model.Subscription, error)
+
+	// GetSubscriptions returns slice of all webhook subscriptions
+	GetSubscriptions(ctx context.Context) ([]model.Subscription, error)
+
+	// DeleteSubscription deletes webhook subscription with given id from database
+	DeleteSubscription(ctx context.Context, id int) error
+
+	// AddDeliveries adds pending delivery of the event to every webhook
+	// subscribed to its type, the event is added to each webhook only once
+	// even if it is published again
+	AddDeliveries(ctx context.Context, e model.Event) error
+
+	// ClaimDueDeliveries returns at most limit pending deliveries which time
+	// of the next attempt is not later than now, counts new attempt of each
+	// of them and postpones them until given time, so they are not claimed
+	// again while being sent
+	ClaimDueDeliveries(ctx context.Context, now time.Time, until time.Time, limit int) ([]model.WebhookDelivery, error)
+
+	// RecordAttempt sets status, result of the last attempt and time of the
+	// next attempt of the delivery with given id
+	RecordAttempt(ctx context.Context, id int, status string, code int, errText string, nextAttemptAt time.Time) error
+
+	// GetDeliveriesBySubscription returns slice of deliveries to the webhook with pagination
+	GetDeliveriesBySubscription(ctx context.Context, subscriptionId int, offset int, limit int) ([]model.WebhookDelivery, error)
+}
+
+type CredentialRepo interface {
+	// SetCredential stores hash of the secret of given kind of the user
+	// replacing the previous one
+	SetCredential(ctx context.Context, user string, kind string, hash []byte) error
+
+	// GetCredential returns hash of the secret of given kind of the user
+	GetCredential(ctx context.Context, user string, kind string) ([]byte, error)
+
+	// DeleteCredential deletes secret of given kind of the user from database
+	DeleteCredential(ctx context.Context, user string, kind string) error
+}
+
+type WebhookSender interface {
+	// Send posts payload of the delivery to the URL signed with the secret
+	// and returns status code of the response, response except 2xx is an error
+	Send(ctx context.Context, url string, secret string, d model.WebhookDelivery) (int, error)
+}
+
+type OutboxRepo interface {
+	// ClaimEvents returns at most limit not published events which time of
+	// the next attempt is not later than now in order of the outbox, counts
+	// new attempt of each of them and postpones them until given time, so
+	// they are not claimed again while being published
+	ClaimEvents(ctx context.Context, now time.Time, until time.Time, limit int) ([]model.Event, error)
+
+	// MarkPublished marks event with given id as published at given time
+	MarkPublished(ctx context.Context, id int, at time.Time) error
+
+	// RetryEvent saves error of the last attempt to publish event with given
+	// id and sets time of its next attempt
+	RetryEvent(ctx context.Context, id int, errText string, nextAttemptAt time.Time) error
+
+	// PurgeEvents deletes events published before given time and returns their number
+	PurgeEvents(ctx context.Context, before time.Time) (int, error)
+}
+
+type EventSink interface {
+	// Publish delivers the event to the consumers of the sink. The event is
+	// published again if the relay fails before it is marked as published,
+	// so the sink passes its key to the consumers to skip duplicates
+	Publish(ctx context.Context, e model.Event) error
+}
+
+type EventBroker interface {
+	EventSink
+
+	// Subscribe returns stream of the events matched by match until ctx is
+	// done, match gets the task and the task before the change, which is nil
+	// for created and deleted tasks. The buffered events published after the
+	// event with afterId are returned as missed, zero afterId means that the
+	// subscriber has seen no events yet
+	Subscribe(ctx context.Context, afterId int, match func(event string, t model.TodoTask, prev *model.TodoTask) bool) model.EventStream
+}
+
+type SyncRepo interface {
+	// GetChanges returns tasks changed and deleted by the transactions with
+	// ids not less than since, all tasks without tombstones if since is zero,
+	// and the id of the oldest transaction in progress while they were read
+	GetChanges(ctx context.Context, since uint64) (model.SyncChanges, uint64, error)
+
+	// GetCreatedTask returns id of the task created by the change of the
+	// client with given ref or zero if there is no such change
+	GetCreatedTask(ctx context.Context, client string, ref string) (int, error)
+
+	// AddCreatedTask adds task created by the change of the client with given
+	// ref in one transaction with the ref and returns its id. If the change
+	// with this ref is already applied, id of its task is returned instead
+	AddCreatedTask(ctx context.Context, client string, ref string, t model.TodoTask) (int, error)
+
+	// PurgeTombstones deletes tombstones of the tasks deleted before given
+	// time and refs of the changes applied before it and returns number of
+	// the tombstones
+	PurgeTombstones(ctx context.Context, before time.Time) (int, error)
+}
+
+type CalendarRepo interface {
+	// AddObject links the task to the object of the calendar of the user
+	// with given name and UID, ErrObjectExists is returned if the user has
+	// an object with this name
+	AddObject(ctx context.Context, o model.CalendarObject) error
+
+	// GetObjectByName searches object of the calendar of the user with given
+	// name, only id of its task is set
+	GetObjectByName(ctx context.Context, user string, name string) (model.CalendarObject, error)
+
+	// GetObjectsByUser returns slice of all objects of the calendar of the
+	// user, only ids of their tasks are set
+	GetObjectsByUser(ctx context.Context, user string) ([]model.CalendarObject, error)
+
+	// DeleteObject deletes object of the calendar of the user with given
+	// name, missing object is not an error
+	DeleteObject(ctx context.Context, user string, name string) error
+}
+
+type DependencyRepo interface {
+	// AddDependency adds link between tasks to database, existing link is not an error.
+	// Returns ErrDependencyCycle if the blocked task already transitively blocks the
+	// blocker, the check and the insert are atomic for concurrent links
+	AddDependency(ctx context.Context, blockerId int, blockedId int) error
+
+	// DeleteDependency deletes link between tasks from database
+	DeleteDependency(ctx context.Context, blockerId int, blockedId int) error
+}
+
+type CommentRepo interface {
+	// AddComment adds comment to database
+	AddComment(ctx context.Context, c model.Comment) (model.Comment, error)
+
+	// GetCommentById searches comment in database with given id
+	GetCommentById(ctx context.Context, id int) (model.Comment, error)
+
+	// UpdateComment updates text of comment with given id
+	UpdateComment(ctx context.Context, id int, text string) (model.Comment, error)
+
+	// DeleteComment deletes comment with given id from database
+	DeleteComment(ctx context.Context, id int) error
+
+	// GetCommentsByTask returns slice of comments of the task with pagination
+	GetCommentsByTask(ctx context.Context, taskId int, offset int, limit int) ([]model.Comment, error)
+}
+
+type AttachmentRepo interface {
+	// AddAttachment adds attachment to database
+	AddAttachment(ctx context.Context, at model.Attachment) (model.Attachment, error)
+
+	// GetAttachmentById searches attachment in database with given id
+	GetAttachmentById(ctx context.Context, id int) (model.Attachment, error)
+
+	// GetAttachmentsByTask returns slice of attachments of the task
+	GetAttachmentsByTask(ctx context.Context, taskId int) ([]model.Attachment, error)
+
+	// DeleteAttachment deletes attachment with given id from database
+	DeleteAttachment(ctx context.Context, id int) error
+}
+
+type BlobStore interface {
+	// Put stores size bytes read from r under given key
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+
+	// Get returns content stored under given key, content must be closed by caller
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes content stored under given key, missing key is not an error
+	Delete(ctx context.Context, key string) error
 }
@@ -5,6 +5,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"io"
+	"strings"
 	"testing"
 	"time"
 	"todo-list/internal/app/mocks"
@@ -13,13 +15,82 @@ import (
 
 type appTestSuite struct {
 	suite.Suite
-	taskRepo *mocks.TaskRepo
-	a        App
+	taskRepo       *mocks.TaskRepo
+	dependencyRepo *mocks.DependencyRepo
+	boardRepo      *mocks.BoardRepo
+	planRepo       *mocks.PlanRepo
+	scheduleRepo   *mocks.ScheduleRepo
+	commentRepo    *mocks.CommentRepo
+	attachmentRepo *mocks.AttachmentRepo
+	blobStore      *mocks.BlobStore
+	reminderRepo   *mocks.ReminderRepo
+	notifier       *mocks.Notifier
+	webhookRepo    *mocks.WebhookRepo
+	webhookSender  *mocks.WebhookSender
+	outboxRepo     *mocks.OutboxRepo
+	sink           *mocks.EventSink
+	broker         *mocks.EventBroker
+	syncRepo       *mocks.SyncRepo
+	credentialRepo *mocks.CredentialRepo
+	calendarRepo   *mocks.CalendarRepo
+	a              App
 }
 
 func (s *appTestSuite) SetupSuite() {
 	s.taskRepo = new(mocks.TaskRepo)
-	s.a = New(s.taskRepo)
+	s.dependencyRepo = new(mocks.DependencyRepo)
+	s.boardRepo = new(mocks.BoardRepo)
+	s.planRepo = new(mocks.PlanRepo)
+	s.scheduleRepo = new(mocks.ScheduleRepo)
+	s.commentRepo = new(mocks.CommentRepo)
+	s.attachmentRepo = new(mocks.AttachmentRepo)
+	s.blobStore = new(mocks.BlobStore)
+	s.reminderRepo = new(mocks.ReminderRepo)
+	s.notifier = new(mocks.Notifier)
+	s.webhookRepo = new(mocks.WebhookRepo)
+	s.webhookSender = new(mocks.WebhookSender)
+	s.outboxRepo = new(mocks.OutboxRepo)
+	s.sink = new(mocks.EventSink)
+	s.broker = new(mocks.EventBroker)
+	s.syncRepo = new(mocks.SyncRepo)
+	s.credentialRepo = new(mocks.CredentialRepo)
+	s.calendarRepo = new(mocks.CalendarRepo)
+	deps := s.deps()
+	// only email channel is configured
+	deps.Notifiers = map[string]Notifier{model.ChannelEmail: s.notifier}
+	deps.Sinks = []EventSink{s.sink}
+	s.a = New(deps, Config{
+		Members:           []string{"alice", "bob"},
+		MaxAttachmentSize: 1024,
+		RolloverPolicy:    RolloverMove,
+		ReminderAttempts:  3,
+		CalendarEnabled:   true,
+	})
+
+	// columns of the board are empty unless test sets ranks explicitly
+	s.boardRepo.On("GetLastRank", mock.Anything, mock.AnythingOfType("string")).Return("", nil)
+}
+
+// deps returns mocks of all dependencies of the app without notifiers and sinks
+func (s *appTestSuite) deps() Deps {
+	return Deps{
+		Tasks:         s.taskRepo,
+		Dependencies:  s.dependencyRepo,
+		Board:         s.boardRepo,
+		Plans:         s.planRepo,
+		Schedule:      s.scheduleRepo,
+		Comments:      s.commentRepo,
+		Attachments:   s.attachmentRepo,
+		Blobs:         s.blobStore,
+		Reminders:     s.reminderRepo,
+		Webhooks:      s.webhookRepo,
+		WebhookSender: s.webhookSender,
+		Outbox:        s.outboxRepo,
+		Broker:        s.broker,
+		Sync:          s.syncRepo,
+		Credentials:   s.credentialRepo,
+		Calendars:     s.calendarRepo,
+	}
 }
 
 type addTaskMock struct {
@@ -47,6 +118,8 @@ func (s *appTestSuite) TestAddTask() {
 					Day:   1,
 				},
 				Status: false,
+				State:  "todo",
+				Rank:   "i",
 			},
 			returnTask: model.TodoTask{
 				Id:          1,
@@ -58,6 +131,8 @@ func (s *appTestSuite) TestAddTask() {
 					Day:   1,
 				},
 				Status: false,
+				State:  "todo",
+				Rank:   "i",
 			},
 			returnErr: nil,
 		},
@@ -71,6 +146,8 @@ func (s *appTestSuite) TestAddTask() {
 					Day:   1,
 				},
 				Status: false,
+				State:  "todo",
+				Rank:   "i",
 			},
 			returnTask: model.TodoTask{},
 			returnErr:  model.ErrTaskRepo,
@@ -100,6 +177,8 @@ func (s *appTestSuite) TestAddTask() {
 					Day:   1,
 				},
 				Status: false,
+				State:  "todo",
+				Rank:   "i",
 			},
 			expectedErr: nil,
 		},
@@ -408,6 +487,7 @@ func (s *appTestSuite) TestUpdateTask() {
 					Day:   1,
 				},
 				Status: false,
+				State:  "todo",
 			},
 			returnTask: model.TodoTask{
 				Id:          1,
@@ -419,6 +499,7 @@ func (s *appTestSuite) TestUpdateTask() {
 					Day:   1,
 				},
 				Status: false,
+				State:  "todo",
 			},
 			returnErr: nil,
 		},
@@ -433,6 +514,7 @@ func (s *appTestSuite) TestUpdateTask() {
 					Day:   1,
 				},
 				Status: false,
+				State:  "todo",
 			},
 			returnTask: model.TodoTask{},
 			returnErr:  model.ErrTaskNotFound,
@@ -463,6 +545,7 @@ func (s *appTestSuite) TestUpdateTask() {
 					Day:   1,
 				},
 				Status: false,
+				State:  "todo",
 			},
 			expectedErr: nil,
 		},
@@ -501,6 +584,7 @@ func (s *appTestSuite) TestUpdateTask() {
 	}
 
 	for _, m := range updateTaskMocks {
+		s.taskRepo.On("GetTaskById", mock.Anything, m.givenId).Return(model.TodoTask{Id: m.givenId, State: "todo"}, nil).Once()
 		s.taskRepo.On("UpdateTask", mock.Anything, m.givenId, m.givenTask).Return(m.returnTask, m.returnErr).Once()
 	}
 
@@ -561,6 +645,7 @@ func (s *appTestSuite) TestDeleteTask() {
 	}
 
 	for _, m := range deleteTaskMocks {
+		s.attachmentRepo.On("GetAttachmentsByTask", mock.Anything, m.givenId).Return([]model.Attachment{}, nil).Once()
 		s.taskRepo.On("DeleteTask", mock.Anything, m.givenId).Return(m.returnErr).Once()
 	}
 
@@ -932,6 +1017,2791 @@ func (s *appTestSuite) TestGetTasksByDateAndStatus() {
 	}
 }
 
+type assignTaskMock struct {
+	givenId       int
+	givenAssignee string
+	returnTask    model.TodoTask
+	returnErr     error
+}
+
+type assignTaskTest struct {
+	description   string
+	givenId       int
+	givenAssignee string
+	expectedTask  model.TodoTask
+	expectedErr   error
+}
+
+func (s *appTestSuite) TestAssignTask() {
+	assignTaskMocks := []assignTaskMock{
+		{
+			givenId:       1,
+			givenAssignee: "alice",
+			returnTask: model.TodoTask{
+				Id:          1,
+				Title:       "title",
+				Description: "description",
+				PlanningDate: model.Date{
+					Year:  2024,
+					Month: time.January,
+					Day:   1,
+				},
+				Status:    false,
+				Assignees: []string{"alice"},
+			},
+			returnErr: nil,
+		},
+		{
+			givenId:       46447,
+			givenAssignee: "bob",
+			returnTask:    model.TodoTask{},
+			returnErr:     model.ErrTaskNotFound,
+		},
+	}
+
+	tests := []assignTaskTest{
+		{
+			description:   "test of successful assigning of the task",
+			givenId:       1,
+			givenAssignee: "alice",
+			expectedTask: model.TodoTask{
+				Id:          1,
+				Title:       "title",
+				Description: "description",
+				PlanningDate: model.Date{
+					Year:  2024,
+					Month: time.January,
+					Day:   1,
+				},
+				Status:    false,
+				Assignees: []string{"alice"},
+			},
+			expectedErr: nil,
+		},
+		{
+			description:   "test of assigning of non existing task",
+			givenId:       46447,
+			givenAssignee: "bob",
+			expectedTask:  model.TodoTask{},
+			expectedErr:   model.ErrTaskNotFound,
+		},
+		{
+			description:   "test of assigning of user who is not a member of the workspace",
+			givenId:       1,
+			givenAssignee: "mallory",
+			expectedTask:  model.TodoTask{},
+			expectedErr:   model.ErrNotMember,
+		},
+		{
+			description:   "test of assigning of user with invalid name",
+			givenId:       1,
+			givenAssignee: "",
+			expectedTask:  model.TodoTask{},
+			expectedErr:   model.ErrInvalidInput,
+		},
+	}
+
+	for _, m := range assignTaskMocks {
+		s.taskRepo.On("AssignTask", mock.Anything, m.givenId, m.givenAssignee).Return(m.returnTask, m.returnErr).Once()
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		s.T().Run(test.description, func(t *testing.T) {
+			task, err := s.a.AssignTask(ctx, test.givenId, test.givenAssignee)
+			assert.Equal(t, test.expectedTask, task)
+			assert.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+type unassignTaskMock struct {
+	givenId       int
+	givenAssignee string
+	returnTask    model.TodoTask
+	returnErr     error
+}
+
+type unassignTaskTest struct {
+	description   string
+	givenId       int
+	givenAssignee string
+	expectedTask  model.TodoTask
+	expectedErr   error
+}
+
+func (s *appTestSuite) TestUnassignTask() {
+	unassignTaskMocks := []unassignTaskMock{
+		{
+			givenId:       2,
+			givenAssignee: "alice",
+			returnTask: model.TodoTask{
+				Id:          2,
+				Title:       "title",
+				Description: "description",
+				PlanningDate: model.Date{
+					Year:  2024,
+					Month: time.January,
+					Day:   1,
+				},
+				Status:    false,
+				Assignees: []string{},
+			},
+			returnErr: nil,
+		},
+		{
+			givenId:       3,
+			givenAssignee: "alice",
+			returnTask:    model.TodoTask{},
+			returnErr:     model.ErrTaskRepo,
+		},
+	}
+
+	tests := []unassignTaskTest{
+		{
+			description:   "test of successful unassigning of the task",
+			givenId:       2,
+			givenAssignee: "alice",
+			expectedTask: model.TodoTask{
+				Id:          2,
+				Title:       "title",
+				Description: "description",
+				PlanningDate: model.Date{
+					Year:  2024,
+					Month: time.January,
+					Day:   1,
+				},
+				Status:    false,
+				Assignees: []string{},
+			},
+			expectedErr: nil,
+		},
+		{
+			description:   "test of occurring error in the database",
+			givenId:       3,
+			givenAssignee: "alice",
+			expectedTask:  model.TodoTask{},
+			expectedErr:   model.ErrTaskRepo,
+		},
+		{
+			description:   "test of unassigning of user with invalid name",
+			givenId:       2,
+			givenAssignee: "bad name",
+			expectedTask:  model.TodoTask{},
+			expectedErr:   model.ErrInvalidInput,
+		},
+	}
+
+	for _, m := range unassignTaskMocks {
+		s.taskRepo.On("UnassignTask", mock.Anything, m.givenId, m.givenAssignee).Return(m.returnTask, m.returnErr).Once()
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		s.T().Run(test.description, func(t *testing.T) {
+			task, err := s.a.UnassignTask(ctx, test.givenId, test.givenAssignee)
+			assert.Equal(t, test.expectedTask, task)
+			assert.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+type getTasksByAssigneeMock struct {
+	givenAssignee string
+	givenOffset   int
+	givenLimit    int
+	returnTasks   []model.TodoTask
+	returnErr     error
+}
+
+type getTasksByAssigneeTest struct {
+	description   string
+	givenAssignee string
+	givenOffset   int
+	givenLimit    int
+	expectedTasks []model.TodoTask
+	expectedErr   error
+}
+
+func (s *appTestSuite) TestGetTasksByAssignee() {
+	getTasksByAssigneeMocks := []getTasksByAssigneeMock{
+		{
+			givenAssignee: "bob",
+			givenOffset:   0,
+			givenLimit:    10,
+			returnTasks: []model.TodoTask{
+				{
+					Id:          4,
+					Title:       "title",
+					Description: "description",
+					PlanningDate: model.Date{
+						Year:  2024,
+						Month: time.January,
+						Day:   1,
+					},
+					Status:    false,
+					Assignees: []string{"alice", "bob"},
+				},
+			},
+			returnErr: nil,
+		},
+		{
+			givenAssignee: "alice",
+			givenOffset:   0,
+			givenLimit:    10,
+			returnTasks:   nil,
+			returnErr:     model.ErrTaskRepo,
+		},
+	}
+
+	tests := []getTasksByAssigneeTest{
+		{
+			description:   "test of successful getting of tasks by assignee",
+			givenAssignee: "bob",
+			givenOffset:   0,
+			givenLimit:    10,
+			expectedTasks: []model.TodoTask{
+				{
+					Id:          4,
+					Title:       "title",
+					Description: "description",
+					PlanningDate: model.Date{
+						Year:  2024,
+						Month: time.January,
+						Day:   1,
+					},
+					Status:    false,
+					Assignees: []string{"alice", "bob"},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			description:   "test of occurring error in the database",
+			givenAssignee: "alice",
+			givenOffset:   0,
+			givenLimit:    10,
+			expectedTasks: nil,
+			expectedErr:   model.ErrTaskRepo,
+		},
+		{
+			description:   "test of getting tasks with invalid pagination",
+			givenAssignee: "alice",
+			givenOffset:   -1,
+			givenLimit:    10,
+			expectedTasks: nil,
+			expectedErr:   model.ErrInvalidInput,
+		},
+	}
+
+	for _, m := range getTasksByAssigneeMocks {
+		s.taskRepo.On("GetTasksByAssignee", mock.Anything, m.givenAssignee, m.givenOffset, m.givenLimit).Return(m.returnTasks, m.returnErr).Once()
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		s.T().Run(test.description, func(t *testing.T) {
+			tasks, err := s.a.GetTasksByAssignee(ctx, test.givenAssignee, test.givenOffset, test.givenLimit)
+			assert.Equal(t, test.expectedTasks, tasks)
+			assert.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+type addCommentMock struct {
+	givenComment  model.Comment
+	returnComment model.Comment
+	returnErr     error
+}
+
+type addCommentTest struct {
+	description     string
+	givenTaskId     int
+	givenAuthor     string
+	givenText       string
+	expectedComment model.Comment
+	expectedErr     error
+}
+
+func (s *appTestSuite) TestAddComment() {
+	createdAt := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	s.taskRepo.On("GetTaskById", mock.Anything, 101).Return(model.TodoTask{Id: 101}, nil)
+	s.taskRepo.On("GetTaskById", mock.Anything, 46448).Return(model.TodoTask{}, model.ErrTaskNotFound)
+
+	addCommentMocks := []addCommentMock{
+		{
+			givenComment: model.Comment{
+				TaskId: 101,
+				Author: "alice",
+				Text:   "first line\nsecond line",
+			},
+			returnComment: model.Comment{
+				Id:        1,
+				TaskId:    101,
+				Author:    "alice",
+				Text:      "first line\nsecond line",
+				CreatedAt: createdAt,
+				UpdatedAt: createdAt,
+			},
+			returnErr: nil,
+		},
+		{
+			givenComment: model.Comment{
+				TaskId: 101,
+				Author: "bob",
+				Text:   "comment",
+			},
+			returnComment: model.Comment{},
+			returnErr:     model.ErrTaskRepo,
+		},
+	}
+
+	tests := []addCommentTest{
+		{
+			description: "test of successful adding of the comment with normalized line endings",
+			givenTaskId: 101,
+			givenAuthor: "alice",
+			givenText:   "first line\r\nsecond line",
+			expectedComment: model.Comment{
+				Id:        1,
+				TaskId:    101,
+				Author:    "alice",
+				Text:      "first line\nsecond line",
+				CreatedAt: createdAt,
+				UpdatedAt: createdAt,
+			},
+			expectedErr: nil,
+		},
+		{
+			description:     "test of occurring error in the database",
+			givenTaskId:     101,
+			givenAuthor:     "bob",
+			givenText:       "comment",
+			expectedComment: model.Comment{},
+			expectedErr:     model.ErrTaskRepo,
+		},
+		{
+			description:     "test of adding of the comment to non existing task",
+			givenTaskId:     46448,
+			givenAuthor:     "alice",
+			givenText:       "comment",
+			expectedComment: model.Comment{},
+			expectedErr:     model.ErrTaskNotFound,
+		},
+		{
+			description:     "test of adding of the empty comment",
+			givenTaskId:     101,
+			givenAuthor:     "alice",
+			givenText:       " ",
+			expectedComment: model.Comment{},
+			expectedErr:     model.ErrInvalidComment,
+		},
+		{
+			description:     "test of adding of the comment by user who is not a member of the workspace",
+			givenTaskId:     101,
+			givenAuthor:     "mallory",
+			givenText:       "comment",
+			expectedComment: model.Comment{},
+			expectedErr:     model.ErrNotMember,
+		},
+	}
+
+	for _, m := range addCommentMocks {
+		s.commentRepo.On("AddComment", mock.Anything, m.givenComment).Return(m.returnComment, m.returnErr).Once()
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		s.T().Run(test.description, func(t *testing.T) {
+			comment, err := s.a.AddComment(ctx, test.givenTaskId, test.givenAuthor, test.givenText)
+			assert.Equal(t, test.expectedComment, comment)
+			assert.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+type updateCommentTest struct {
+	description     string
+	givenTaskId     int
+	givenId         int
+	givenAuthor     string
+	givenText       string
+	expectedComment model.Comment
+	expectedErr     error
+}
+
+func (s *appTestSuite) TestUpdateComment() {
+	createdAt := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	updatedAt := time.Date(2024, time.January, 2, 12, 0, 0, 0, time.UTC)
+
+	s.commentRepo.On("GetCommentById", mock.Anything, 11).Return(model.Comment{
+		Id:        11,
+		TaskId:    102,
+		Author:    "alice",
+		Text:      "old text",
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}, nil)
+	s.commentRepo.On("GetCommentById", mock.Anything, 46449).Return(model.Comment{}, model.ErrCommentNotFound)
+	s.commentRepo.On("UpdateComment", mock.Anything, 11, "new text").Return(model.Comment{
+		Id:        11,
+		TaskId:    102,
+		Author:    "alice",
+		Text:      "new text",
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+	}, nil).Once()
+
+	tests := []updateCommentTest{
+		{
+			description: "test of successful updating of the comment",
+			givenTaskId: 102,
+			givenId:     11,
+			givenAuthor: "alice",
+			givenText:   "new text",
+			expectedComment: model.Comment{
+				Id:        11,
+				TaskId:    102,
+				Author:    "alice",
+				Text:      "new text",
+				CreatedAt: createdAt,
+				UpdatedAt: updatedAt,
+			},
+			expectedErr: nil,
+		},
+		{
+			description:     "test of updating of the comment by other user",
+			givenTaskId:     102,
+			givenId:         11,
+			givenAuthor:     "bob",
+			givenText:       "new text",
+			expectedComment: model.Comment{},
+			expectedErr:     model.ErrForbidden,
+		},
+		{
+			description:     "test of updating of the comment of other task",
+			givenTaskId:     103,
+			givenId:         11,
+			givenAuthor:     "alice",
+			givenText:       "new text",
+			expectedComment: model.Comment{},
+			expectedErr:     model.ErrCommentNotFound,
+		},
+		{
+			description:     "test of updating of non existing comment",
+			givenTaskId:     102,
+			givenId:         46449,
+			givenAuthor:     "alice",
+			givenText:       "new text",
+			expectedComment: model.Comment{},
+			expectedErr:     model.ErrCommentNotFound,
+		},
+		{
+			description:     "test of updating of the comment with invalid text",
+			givenTaskId:     102,
+			givenId:         11,
+			givenAuthor:     "alice",
+			givenText:       "",
+			expectedComment: model.Comment{},
+			expectedErr:     model.ErrInvalidComment,
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		s.T().Run(test.description, func(t *testing.T) {
+			comment, err := s.a.UpdateComment(ctx, test.givenTaskId, test.givenId, test.givenAuthor, test.givenText)
+			assert.Equal(t, test.expectedComment, comment)
+			assert.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+type deleteCommentTest struct {
+	description string
+	givenTaskId int
+	givenId     int
+	givenAuthor string
+	expectedErr error
+}
+
+func (s *appTestSuite) TestDeleteComment() {
+	s.commentRepo.On("GetCommentById", mock.Anything, 12).Return(model.Comment{
+		Id:     12,
+		TaskId: 104,
+		Author: "bob",
+		Text:   "text",
+	}, nil)
+	s.commentRepo.On("DeleteComment", mock.Anything, 12).Return(nil).Once()
+
+	tests := []deleteCommentTest{
+		{
+			description: "test of deleting of the comment by other user",
+			givenTaskId: 104,
+			givenId:     12,
+			givenAuthor: "alice",
+			expectedErr: model.ErrForbidden,
+		},
+		{
+			description: "test of successful deleting of the comment",
+			givenTaskId: 104,
+			givenId:     12,
+			givenAuthor: "bob",
+			expectedErr: nil,
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		s.T().Run(test.description, func(t *testing.T) {
+			err := s.a.DeleteComment(ctx, test.givenTaskId, test.givenId, test.givenAuthor)
+			assert.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+type getCommentsByTaskTest struct {
+	description      string
+	givenTaskId      int
+	givenOffset      int
+	givenLimit       int
+	expectedComments []model.Comment
+	expectedErr      error
+}
+
+func (s *appTestSuite) TestGetCommentsByTask() {
+	s.taskRepo.On("GetTaskById", mock.Anything, 105).Return(model.TodoTask{Id: 105}, nil)
+	s.taskRepo.On("GetTaskById", mock.Anything, 46450).Return(model.TodoTask{}, model.ErrTaskNotFound)
+	s.commentRepo.On("GetCommentsByTask", mock.Anything, 105, 0, 10).Return([]model.Comment{
+		{
+			Id:     13,
+			TaskId: 105,
+			Author: "alice",
+			Text:   "text",
+		},
+	}, nil).Once()
+
+	tests := []getCommentsByTaskTest{
+		{
+			description: "test of successful getting of comments",
+			givenTaskId: 105,
+			givenOffset: 0,
+			givenLimit:  10,
+			expectedComments: []model.Comment{
+				{
+					Id:     13,
+					TaskId: 105,
+					Author: "alice",
+					Text:   "text",
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			description:      "test of getting comments of non existing task",
+			givenTaskId:      46450,
+			givenOffset:      0,
+			givenLimit:       10,
+			expectedComments: nil,
+			expectedErr:      model.ErrTaskNotFound,
+		},
+		{
+			description:      "test of getting comments with invalid pagination",
+			givenTaskId:      105,
+			givenOffset:      0,
+			givenLimit:       -1,
+			expectedComments: nil,
+			expectedErr:      model.ErrInvalidInput,
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		s.T().Run(test.description, func(t *testing.T) {
+			comments, err := s.a.GetCommentsByTask(ctx, test.givenTaskId, test.givenOffset, test.givenLimit)
+			assert.Equal(t, test.expectedComments, comments)
+			assert.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func (s *appTestSuite) TestDeleteTaskWithAttachments() {
+	s.attachmentRepo.On("GetAttachmentsByTask", mock.Anything, 201).Return([]model.Attachment{
+		{
+			Id:     21,
+			TaskId: 201,
+			Key:    "tasks/201/a",
+		},
+		{
+			Id:     22,
+			TaskId: 201,
+			Key:    "tasks/201/b",
+		},
+	}, nil).Once()
+	s.taskRepo.On("DeleteTask", mock.Anything, 201).Return(nil).Once()
+	s.blobStore.On("Delete", mock.Anything, "tasks/201/a").Return(model.ErrBlobStore).Once()
+	s.blobStore.On("Delete", mock.Anything, "tasks/201/b").Return(nil).Once()
+
+	err := s.a.DeleteTask(context.Background(), 201)
+	s.NoError(err)
+	s.blobStore.AssertCalled(s.T(), "Delete", mock.Anything, "tasks/201/a")
+	s.blobStore.AssertCalled(s.T(), "Delete", mock.Anything, "tasks/201/b")
+}
+
+type addAttachmentTest struct {
+	description         string
+	givenTaskId         int
+	givenName           string
+	givenContent        string
+	givenSize           int64
+	expectedContentType string
+	expectedErr         error
+}
+
+func (s *appTestSuite) TestAddAttachment() {
+	createdAt := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	s.taskRepo.On("GetTaskById", mock.Anything, 202).Return(model.TodoTask{Id: 202}, nil)
+	s.taskRepo.On("GetTaskById", mock.Anything, 46451).Return(model.TodoTask{}, model.ErrTaskNotFound)
+	s.blobStore.On("Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	s.attachmentRepo.On("AddAttachment", mock.Anything, mock.Anything).Return(func(_ context.Context, at model.Attachment) model.Attachment {
+		at.Id = 23
+		at.CreatedAt = createdAt
+		return at
+	}, nil)
+
+	tests := []addAttachmentTest{
+		{
+			description:         "test of attaching of the png image with content type detected by content",
+			givenTaskId:         202,
+			givenName:           "screenshot.bin",
+			givenContent:        "\x89PNG\r\n\x1a\n image",
+			givenSize:           int64(len("\x89PNG\r\n\x1a\n image")),
+			expectedContentType: "image/png",
+			expectedErr:         nil,
+		},
+		{
+			description:         "test of attaching of the file with content type detected by extension",
+			givenTaskId:         202,
+			givenName:           "archive.zip",
+			givenContent:        "\x00\x01\x02",
+			givenSize:           3,
+			expectedContentType: "application/zip",
+			expectedErr:         nil,
+		},
+		{
+			description:  "test of attaching of too large file",
+			givenTaskId:  202,
+			givenName:    "large.pdf",
+			givenContent: "",
+			givenSize:    1025,
+			expectedErr:  model.ErrAttachmentTooLarge,
+		},
+		{
+			description:  "test of attaching of the file with path in name",
+			givenTaskId:  202,
+			givenName:    "../report.pdf",
+			givenContent: "%PDF-",
+			givenSize:    5,
+			expectedErr:  model.ErrInvalidAttachment,
+		},
+		{
+			description:  "test of attaching of the file to non existing task",
+			givenTaskId:  46451,
+			givenName:    "report.pdf",
+			givenContent: "%PDF-",
+			givenSize:    5,
+			expectedErr:  model.ErrTaskNotFound,
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		s.T().Run(test.description, func(t *testing.T) {
+			at, err := s.a.AddAttachment(ctx, test.givenTaskId, test.givenName, test.givenSize, strings.NewReader(test.givenContent))
+			assert.ErrorIs(t, err, test.expectedErr)
+			if test.expectedErr == nil {
+				assert.Equal(t, 23, at.Id)
+				assert.Equal(t, test.givenTaskId, at.TaskId)
+				assert.Equal(t, test.givenName, at.Name)
+				assert.Equal(t, test.expectedContentType, at.ContentType)
+				assert.Equal(t, test.givenSize, at.Size)
+				assert.True(t, strings.HasPrefix(at.Key, "tasks/202/"))
+			}
+		})
+	}
+}
+
+func (s *appTestSuite) TestGetAttachment() {
+	s.attachmentRepo.On("GetAttachmentById", mock.Anything, 24).Return(model.Attachment{
+		Id:          24,
+		TaskId:      203,
+		Name:        "report.pdf",
+		ContentType: "application/pdf",
+		Size:        5,
+		Key:         "tasks/203/c",
+	}, nil)
+	s.blobStore.On("Get", mock.Anything, "tasks/203/c").Return(io.NopCloser(strings.NewReader("%PDF-")), nil).Once()
+
+	ctx := context.Background()
+
+	s.T().Run("test of successful getting of the attachment", func(t *testing.T) {
+		at, content, err := s.a.GetAttachment(ctx, 203, 24)
+		assert.NoError(t, err)
+		assert.Equal(t, "application/pdf", at.ContentType)
+		b, _ := io.ReadAll(content)
+		assert.Equal(t, "%PDF-", string(b))
+	})
+
+	s.T().Run("test of getting of the attachment of other task", func(t *testing.T) {
+		_, content, err := s.a.GetAttachment(ctx, 204, 24)
+		assert.ErrorIs(t, err, model.ErrAttachmentNotFound)
+		assert.Nil(t, content)
+	})
+}
+
+func (s *appTestSuite) TestDeleteAttachment() {
+	s.attachmentRepo.On("GetAttachmentById", mock.Anything, 25).Return(model.Attachment{
+		Id:     25,
+		TaskId: 205,
+		Key:    "tasks/205/d",
+	}, nil)
+	s.attachmentRepo.On("GetAttachmentById", mock.Anything, 46452).Return(model.Attachment{}, model.ErrAttachmentNotFound)
+	s.attachmentRepo.On("DeleteAttachment", mock.Anything, 25).Return(nil).Once()
+	s.blobStore.On("Delete", mock.Anything, "tasks/205/d").Return(nil).Once()
+
+	ctx := context.Background()
+
+	s.T().Run("test of successful deleting of the attachment", func(t *testing.T) {
+		assert.NoError(t, s.a.DeleteAttachment(ctx, 205, 25))
+		s.blobStore.AssertCalled(t, "Delete", mock.Anything, "tasks/205/d")
+	})
+
+	s.T().Run("test of deleting of non existing attachment", func(t *testing.T) {
+		assert.ErrorIs(t, s.a.DeleteAttachment(ctx, 205, 46452), model.ErrAttachmentNotFound)
+	})
+}
+
+type updateBlockedTaskTest struct {
+	description  string
+	givenId      int
+	givenForce   bool
+	expectedTask model.TodoTask
+	expectedErr  error
+}
+
+func (s *appTestSuite) TestUpdateBlockedTask() {
+	planningDate := model.Date{
+		Year:  time.Now().Year() + 1,
+		Month: time.January,
+		Day:   1,
+	}
+	doneTask := model.TodoTask{
+		Title:        "title",
+		Description:  "description",
+		PlanningDate: planningDate,
+		Status:       true,
+		State:        "done",
+		Rank:         "i",
+	}
+
+	s.taskRepo.On("GetTaskById", mock.Anything, 301).Return(model.TodoTask{Id: 301, State: "todo", Blocked: true}, nil)
+	s.taskRepo.On("GetTaskById", mock.Anything, 302).Return(model.TodoTask{Id: 302, Status: true, State: "done", Rank: "i", Blocked: true}, nil)
+	s.taskRepo.On("UpdateTask", mock.Anything, 301, doneTask).Return(model.TodoTask{}, model.ErrTaskBlocked).Once()
+	s.taskRepo.On("ForceUpdateTask", mock.Anything, 301, doneTask).Return(model.TodoTask{
+		Id:           301,
+		Title:        "title",
+		Description:  "description",
+		PlanningDate: planningDate,
+		Status:       true,
+		State:        "done",
+		Rank:         "i",
+		Blocked:      true,
+	}, nil).Once()
+	s.taskRepo.On("UpdateTask", mock.Anything, 302, doneTask).Return(model.TodoTask{
+		Id:           302,
+		Title:        "title",
+		Description:  "description",
+		PlanningDate: planningDate,
+		Status:       true,
+		State:        "done",
+		Rank:         "i",
+		Blocked:      true,
+	}, nil).Once()
+
+	tests := []updateBlockedTaskTest{
+		{
+			description:  "test of marking of the blocked task as done",
+			givenId:      301,
+			givenForce:   false,
+			expectedTask: model.TodoTask{},
+			expectedErr:  model.ErrTaskBlocked,
+		},
+		{
+			description: "test of forced marking of the blocked task as done",
+			givenId:     301,
+			givenForce:  true,
+			expectedTask: model.TodoTask{
+				Id:           301,
+				Title:        "title",
+				Description:  "description",
+				PlanningDate: planningDate,
+				Status:       true,
+				State:        "done",
+				Rank:         "i",
+				Blocked:      true,
+			},
+			expectedErr: nil,
+		},
+		{
+			description: "test of updating of the blocked task which is already done",
+			givenId:     302,
+			givenForce:  false,
+			expectedTask: model.TodoTask{
+				Id:           302,
+				Title:        "title",
+				Description:  "description",
+				PlanningDate: planningDate,
+				Status:       true,
+				State:        "done",
+				Rank:         "i",
+				Blocked:      true,
+			},
+			expectedErr: nil,
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		s.T().Run(test.description, func(t *testing.T) {
+			update := s.a.UpdateTask
+			if test.givenForce {
+				update = s.a.ForceUpdateTask
+			}
+			task, err := update(ctx, test.givenId, doneTask)
+			assert.Equal(t, test.expectedTask, task)
+			assert.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+type blockTaskTest struct {
+	description    string
+	givenBlockerId int
+	givenBlockedId int
+	expectedTask   model.TodoTask
+	expectedErr    error
+}
+
+func (s *appTestSuite) TestBlockTask() {
+	// existing dependencies: 311 -> 312 -> 313
+	s.taskRepo.On("GetTaskById", mock.Anything, 311).Return(model.TodoTask{Id: 311}, nil)
+	s.taskRepo.On("GetTaskById", mock.Anything, 313).Return(model.TodoTask{Id: 313}, nil)
+	s.taskRepo.On("GetTaskById", mock.Anything, 46453).Return(model.TodoTask{}, model.ErrTaskNotFound)
+	s.taskRepo.On("GetTaskById", mock.Anything, 314).Return(model.TodoTask{Id: 314, Blocked: true}, nil).Once()
+	s.taskRepo.On("GetDependencyGraph", mock.Anything, 311).Return(model.DependencyGraph{
+		Tasks: []model.TodoTask{{Id: 311}, {Id: 312}, {Id: 313}},
+		Dependencies: []model.Dependency{
+			{BlockerId: 311, BlockedId: 312},
+			{BlockerId: 312, BlockedId: 313},
+		},
+	}, nil)
+	s.taskRepo.On("GetDependencyGraph", mock.Anything, 314).Return(model.DependencyGraph{
+		Tasks:        []model.TodoTask{{Id: 314}},
+		Dependencies: []model.Dependency{},
+	}, nil)
+	s.taskRepo.On("GetDependencyGraph", mock.Anything, 46453).Return(model.DependencyGraph{
+		Tasks:        []model.TodoTask{},
+		Dependencies: []model.Dependency{},
+	}, nil)
+	s.dependencyRepo.On("AddDependency", mock.Anything, 313, 314).Return(nil).Once()
+	s.dependencyRepo.On("AddDependency", mock.Anything, 311, 314).Return(model.ErrDependencyCycle).Once()
+
+	tests := []blockTaskTest{
+		{
+			description:    "test of successful blocking of the task",
+			givenBlockerId: 313,
+			givenBlockedId: 314,
+			expectedTask:   model.TodoTask{Id: 314, Blocked: true},
+			expectedErr:    nil,
+		},
+		{
+			description:    "test of blocking which makes a cycle",
+			givenBlockerId: 313,
+			givenBlockedId: 311,
+			expectedTask:   model.TodoTask{},
+			expectedErr:    model.ErrDependencyCycle,
+		},
+		{
+			description:    "test of blocking which makes a cycle with concurrently added link",
+			givenBlockerId: 311,
+			givenBlockedId: 314,
+			expectedTask:   model.TodoTask{},
+			expectedErr:    model.ErrDependencyCycle,
+		},
+		{
+			description:    "test of blocking of the task by itself",
+			givenBlockerId: 311,
+			givenBlockedId: 311,
+			expectedTask:   model.TodoTask{},
+			expectedErr:    model.ErrDependencyCycle,
+		},
+		{
+			description:    "test of blocking by non existing task",
+			givenBlockerId: 46453,
+			givenBlockedId: 311,
+			expectedTask:   model.TodoTask{},
+			expectedErr:    model.ErrTaskNotFound,
+		},
+		{
+			description:    "test of blocking of non existing task",
+			givenBlockerId: 311,
+			givenBlockedId: 46453,
+			expectedTask:   model.TodoTask{},
+			expectedErr:    model.ErrTaskNotFound,
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		s.T().Run(test.description, func(t *testing.T) {
+			task, err := s.a.BlockTask(ctx, test.givenBlockerId, test.givenBlockedId)
+			assert.Equal(t, test.expectedTask, task)
+			assert.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func (s *appTestSuite) TestUnblockTask() {
+	s.taskRepo.On("GetTaskById", mock.Anything, 315).Return(model.TodoTask{Id: 315, Blocked: true}, nil).Once()
+	s.taskRepo.On("GetTaskById", mock.Anything, 315).Return(model.TodoTask{Id: 315, Blocked: false}, nil).Once()
+	s.dependencyRepo.On("DeleteDependency", mock.Anything, 316, 315).Return(nil).Once()
+
+	task, err := s.a.UnblockTask(context.Background(), 316, 315)
+	s.NoError(err)
+	s.Equal(model.TodoTask{Id: 315, Blocked: false}, task)
+}
+
+func (s *appTestSuite) TestGetDependencyGraph() {
+	s.taskRepo.On("GetDependencyGraph", mock.Anything, 46454).Return(model.DependencyGraph{
+		Tasks:        []model.TodoTask{},
+		Dependencies: []model.Dependency{},
+	}, nil)
+
+	_, err := s.a.GetDependencyGraph(context.Background(), 46454)
+	s.ErrorIs(err, model.ErrTaskNotFound)
+}
+
+type updateTaskStateTest struct {
+	description   string
+	givenId       int
+	givenStatus   bool
+	givenState    string
+	expectedState string
+	expectedErr   error
+}
+
+func (s *appTestSuite) TestUpdateTaskState() {
+	planningDate := model.Date{
+		Year:  time.Now().Year() + 1,
+		Month: time.January,
+		Day:   1,
+	}
+
+	s.taskRepo.On("GetTaskById", mock.Anything, 401).Return(model.TodoTask{Id: 401, State: "todo"}, nil)
+	s.taskRepo.On("GetTaskById", mock.Anything, 402).Return(model.TodoTask{Id: 402, State: "in_progress"}, nil)
+	s.taskRepo.On("GetTaskById", mock.Anything, 403).Return(model.TodoTask{Id: 403, State: "in_review"}, nil)
+	s.taskRepo.On("GetTaskById", mock.Anything, 404).Return(model.TodoTask{Id: 404, Status: true, State: "cancelled"}, nil)
+	s.taskRepo.On("UpdateTask", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("model.TodoTask")).Return(
+		func(_ context.Context, id int, t model.TodoTask) model.TodoTask {
+			t.Id = id
+			return t
+		},
+		nil).Times(4)
+
+	tests := []updateTaskStateTest{
+		{
+			description:   "test of allowed transition",
+			givenId:       402,
+			givenState:    "in_review",
+			expectedState: "in_review",
+			expectedErr:   nil,
+		},
+		{
+			description: "test of not allowed transition",
+			givenId:     401,
+			givenState:  "in_review",
+			expectedErr: model.ErrTransition,
+		},
+		{
+			description: "test of transition to unknown state",
+			givenId:     401,
+			givenState:  "unknown",
+			expectedErr: model.ErrUnknownState,
+		},
+		{
+			description:   "test of keeping of the state when status is not changed",
+			givenId:       402,
+			givenState:    "",
+			expectedState: "in_progress",
+			expectedErr:   nil,
+		},
+		{
+			description:   "test of moving to default done state by status",
+			givenId:       403,
+			givenStatus:   true,
+			givenState:    "",
+			expectedState: "done",
+			expectedErr:   nil,
+		},
+		{
+			description:   "test of moving to default not done state by status",
+			givenId:       404,
+			givenStatus:   false,
+			givenState:    "",
+			expectedState: "todo",
+			expectedErr:   nil,
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		s.T().Run(test.description, func(t *testing.T) {
+			task, err := s.a.UpdateTask(ctx, test.givenId, model.TodoTask{
+				Title:        "title",
+				PlanningDate: planningDate,
+				Status:       test.givenStatus,
+				State:        test.givenState,
+			})
+			assert.Equal(t, test.expectedState, task.State)
+			assert.Equal(t, test.expectedState == "done", task.Status)
+			assert.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+type addTaskStateTest struct {
+	description    string
+	givenStatus    bool
+	givenState     string
+	expectedStatus bool
+	expectedState  string
+	expectedErr    error
+}
+
+func (s *appTestSuite) TestAddTaskState() {
+	planningDate := model.Date{
+		Year:  time.Now().Year() + 1,
+		Month: time.January,
+		Day:   1,
+	}
+
+	for _, state := range []struct {
+		status bool
+		name   string
+	}{{false, "todo"}, {true, "done"}, {true, "cancelled"}} {
+		t := model.TodoTask{
+			Title:        "state " + state.name,
+			PlanningDate: planningDate,
+			Status:       state.status,
+			State:        state.name,
+			Rank:         "i",
+		}
+		s.taskRepo.On("AddTask", mock.Anything, t).Return(t, nil).Once()
+	}
+
+	tests := []addTaskStateTest{
+		{
+			description:    "test of adding of the task in the initial state",
+			givenStatus:    false,
+			givenState:     "",
+			expectedStatus: false,
+			expectedState:  "todo",
+			expectedErr:    nil,
+		},
+		{
+			description:    "test of adding of the done task without state",
+			givenStatus:    true,
+			givenState:     "",
+			expectedStatus: true,
+			expectedState:  "done",
+			expectedErr:    nil,
+		},
+		{
+			description:    "test of adding of the task in the done state",
+			givenStatus:    false,
+			givenState:     "cancelled",
+			expectedStatus: true,
+			expectedState:  "cancelled",
+			expectedErr:    nil,
+		},
+		{
+			description: "test of adding of the task in unknown state",
+			givenState:  "unknown",
+			expectedErr: model.ErrUnknownState,
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		s.T().Run(test.description, func(t *testing.T) {
+			title := "state " + test.expectedState
+			task, err := s.a.AddTask(ctx, model.TodoTask{
+				Title:        title,
+				PlanningDate: planningDate,
+				Status:       test.givenStatus,
+				State:        test.givenState,
+			})
+			assert.Equal(t, test.expectedStatus, task.Status)
+			assert.Equal(t, test.expectedState, task.State)
+			assert.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func (s *appTestSuite) TestGetTasksByUnknownState() {
+	tasks, err := s.a.GetTasksByState(context.Background(), "unknown", 0, 10)
+	assert.Nil(s.T(), tasks)
+	assert.ErrorIs(s.T(), err, model.ErrUnknownState)
+}
+
+type rankBetweenTest struct {
+	description string
+	givenPrev   string
+	givenNext   string
+}
+
+func (s *appTestSuite) TestRankBetween() {
+	tests := []rankBetweenTest{
+		{
+			description: "test of the rank in the empty column",
+			givenPrev:   "",
+			givenNext:   "",
+		},
+		{
+			description: "test of the rank at the end of the column",
+			givenPrev:   "zz",
+			givenNext:   "",
+		},
+		{
+			description: "test of the rank at the beginning of the column",
+			givenPrev:   "",
+			givenNext:   "01",
+		},
+		{
+			description: "test of the rank between adjacent digits",
+			givenPrev:   "a",
+			givenNext:   "b",
+		},
+		{
+			description: "test of the rank between rank and its prefix",
+			givenPrev:   "a",
+			givenNext:   "a01",
+		},
+		{
+			description: "test of the rank between ranks of different length",
+			givenPrev:   "az",
+			givenNext:   "b",
+		},
+	}
+
+	for _, test := range tests {
+		s.T().Run(test.description, func(t *testing.T) {
+			rank := rankBetween(test.givenPrev, test.givenNext)
+			assert.Less(t, test.givenPrev, rank)
+			if test.givenNext != "" {
+				assert.Less(t, rank, test.givenNext)
+			}
+			assert.False(t, strings.HasSuffix(rank, "0"))
+		})
+	}
+
+	s.T().Run("test of spreading of the ranks", func(t *testing.T) {
+		ranks := spreadRanks(100)
+		assert.Len(t, ranks, 100)
+		for i := 1; i < len(ranks); i++ {
+			assert.Less(t, ranks[i-1], ranks[i])
+			assert.False(t, strings.HasSuffix(ranks[i], "0"))
+		}
+	})
+}
+
+type moveTaskTest struct {
+	description  string
+	givenId      int
+	givenMove    model.Move
+	expectedRank string
+	expectedErr  error
+}
+
+func (s *appTestSuite) TestMoveTask() {
+	s.taskRepo.On("GetTaskById", mock.Anything, 501).Return(model.TodoTask{Id: 501, State: "todo", Rank: "5"}, nil)
+	s.taskRepo.On("GetTaskById", mock.Anything, 502).Return(model.TodoTask{Id: 502, State: "todo", Blocked: true}, nil)
+	s.boardRepo.On("MoveTask", mock.Anything, 502, "done", true, mock.AnythingOfType("string")).Return(model.TodoTask{}, model.ErrTaskBlocked)
+	s.boardRepo.On("GetRankNeighbours", mock.Anything, 511, 501, "in_progress").Return("a", "c", "e", nil)
+	s.boardRepo.On("GetRankNeighbours", mock.Anything, 512, 501, "in_progress").Return("", "", "", model.ErrTaskNotFound)
+	s.boardRepo.On("MoveTask", mock.Anything, 501, "in_progress", false, mock.AnythingOfType("string")).Return(
+		func(_ context.Context, id int, state string, status bool, rank string) model.TodoTask {
+			return model.TodoTask{Id: id, State: state, Status: status, Rank: rank}
+		},
+		nil)
+
+	tests := []moveTaskTest{
+		{
+			description:  "test of moving of the task before other task",
+			givenId:      501,
+			givenMove:    model.Move{State: "in_progress", Before: 511},
+			expectedRank: "b",
+			expectedErr:  nil,
+		},
+		{
+			description:  "test of moving of the task after other task",
+			givenId:      501,
+			givenMove:    model.Move{State: "in_progress", After: 511},
+			expectedRank: "d",
+			expectedErr:  nil,
+		},
+		{
+			description:  "test of moving of the task to the end of the column",
+			givenId:      501,
+			givenMove:    model.Move{State: "in_progress"},
+			expectedRank: "i",
+			expectedErr:  nil,
+		},
+		{
+			description: "test of moving of the task relative to the task from other column",
+			givenId:     501,
+			givenMove:   model.Move{State: "in_progress", After: 512},
+			expectedErr: model.ErrTaskNotFound,
+		},
+		{
+			description: "test of moving of the task before and after other tasks",
+			givenId:     501,
+			givenMove:   model.Move{State: "in_progress", Before: 511, After: 512},
+			expectedErr: model.ErrInvalidInput,
+		},
+		{
+			description: "test of moving of the task relative to itself",
+			givenId:     501,
+			givenMove:   model.Move{Before: 501},
+			expectedErr: model.ErrInvalidInput,
+		},
+		{
+			description: "test of not allowed transition",
+			givenId:     501,
+			givenMove:   model.Move{State: "in_review"},
+			expectedErr: model.ErrTransition,
+		},
+		{
+			description: "test of moving of the blocked task to the done column",
+			givenId:     502,
+			givenMove:   model.Move{State: "done"},
+			expectedErr: model.ErrTaskBlocked,
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		s.T().Run(test.description, func(t *testing.T) {
+			task, err := s.a.MoveTask(ctx, test.givenId, test.givenMove)
+			assert.Equal(t, test.expectedRank, task.Rank)
+			assert.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func (s *appTestSuite) TestMoveTaskWithEqualRanks() {
+	s.taskRepo.On("GetTaskById", mock.Anything, 521).Return(model.TodoTask{Id: 521, State: "in_review", Rank: "1"}, nil)
+	s.boardRepo.On("GetRankNeighbours", mock.Anything, 522, 521, "in_review").Return("c", "c", "", nil).Once()
+	s.taskRepo.On("GetTasksByState", mock.Anything, "in_review", 0, mock.AnythingOfType("int")).Return([]model.TodoTask{
+		{Id: 523, State: "in_review", Rank: "c"},
+		{Id: 522, State: "in_review", Rank: "c"},
+	}, nil).Once()
+	s.boardRepo.On("SetRanks", mock.Anything, map[int]string{523: "c", 522: "o"}).Return(nil).Once()
+	s.boardRepo.On("GetRankNeighbours", mock.Anything, 522, 521, "in_review").Return("c", "o", "", nil).Once()
+	s.boardRepo.On("MoveTask", mock.Anything, 521, "in_review", false, "i").Return(
+		model.TodoTask{Id: 521, State: "in_review", Rank: "i"}, nil).Once()
+
+	task, err := s.a.MoveTask(context.Background(), 521, model.Move{Before: 522})
+	assert.Equal(s.T(), "i", task.Rank)
+	assert.NoError(s.T(), err)
+}
+
+func (s *appTestSuite) TestGetBoard() {
+	for _, state := range DefaultWorkflow().States {
+		s.taskRepo.On("GetTasksByState", mock.Anything, state.Name, 0, 2).Return([]model.TodoTask{
+			{Id: 531, State: state.Name, Status: state.Done, Rank: "a"},
+			{Id: 532, State: state.Name, Status: state.Done, Rank: "b"},
+		}, nil).Once()
+	}
+
+	board, err := s.a.GetBoard(context.Background(), 2)
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), board.Columns, len(DefaultWorkflow().States))
+	for i, c := range board.Columns {
+		assert.Equal(s.T(), DefaultWorkflow().States[i], c.State)
+		assert.Len(s.T(), c.Tasks, 2)
+	}
+
+	_, err = s.a.GetBoard(context.Background(), -1)
+	assert.ErrorIs(s.T(), err, model.ErrInvalidInput)
+}
+
+type reorderDayTest struct {
+	description   string
+	givenDate     model.Date
+	givenIds      []int
+	expectedTasks []model.TodoTask
+	expectedErr   error
+}
+
+func (s *appTestSuite) TestReorderDay() {
+	date := model.Date{
+		Year:  2024,
+		Month: time.March,
+		Day:   1,
+	}
+	tasks := []model.TodoTask{
+		{Id: 603, PlanningDate: date, Position: 1},
+		{Id: 601, PlanningDate: date, Position: 2},
+		{Id: 602, PlanningDate: date, Position: 3},
+	}
+
+	s.planRepo.On("ReorderDay", mock.Anything, date, []int{603, 601, 602}).Return(nil).Once()
+	s.planRepo.On("ReorderDay", mock.Anything, date, []int{603, 601}).Return(model.ErrDayOrder).Once()
+	s.planRepo.On("GetTasksByDate", mock.Anything, date).Return(tasks, nil).Once()
+
+	tests := []reorderDayTest{
+		{
+			description:   "test of successful reordering of the day",
+			givenDate:     date,
+			givenIds:      []int{603, 601, 602},
+			expectedTasks: tasks,
+			expectedErr:   nil,
+		},
+		{
+			description:   "test of reordering of the day with missing tasks",
+			givenDate:     date,
+			givenIds:      []int{603, 601},
+			expectedTasks: nil,
+			expectedErr:   model.ErrDayOrder,
+		},
+		{
+			description:   "test of reordering of the day with duplicated tasks",
+			givenDate:     date,
+			givenIds:      []int{603, 601, 603},
+			expectedTasks: nil,
+			expectedErr:   model.ErrInvalidInput,
+		},
+		{
+			description: "test of reordering of the invalid date",
+			givenDate: model.Date{
+				Year:  2024,
+				Month: time.February,
+				Day:   30,
+			},
+			givenIds:      []int{603, 601, 602},
+			expectedTasks: nil,
+			expectedErr:   model.ErrInvalidInput,
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		s.T().Run(test.description, func(t *testing.T) {
+			tasks, err := s.a.ReorderDay(ctx, test.givenDate, test.givenIds)
+			assert.Equal(t, test.expectedTasks, tasks)
+			assert.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func (s *appTestSuite) TestRolloverOverdue() {
+	s.scheduleRepo.On("MoveOverdue", mock.Anything, today()).Return([]model.HistoryEntry{
+		{Id: 701, TaskId: 701, Action: model.ActionRollover, ToDate: today()},
+		{Id: 702, TaskId: 702, Action: model.ActionRollover, ToDate: today()},
+	}, nil).Once()
+	s.scheduleRepo.On("FlagOverdue", mock.Anything, today()).Return(3, nil).Once()
+
+	ctx := context.Background()
+
+	s.T().Run("test of moving of overdue tasks", func(t *testing.T) {
+		n, err := s.a.RolloverOverdue(ctx)
+		assert.Equal(t, 2, n)
+		assert.NoError(t, err)
+	})
+
+	s.T().Run("test of flagging of overdue tasks", func(t *testing.T) {
+		a := New(s.deps(), Config{
+			RolloverPolicy: RolloverFlag,
+		})
+		n, err := a.RolloverOverdue(ctx)
+		assert.Equal(t, 3, n)
+		assert.NoError(t, err)
+	})
+
+	s.T().Run("test of disabled rollover", func(t *testing.T) {
+		a := New(s.deps(), Config{
+			RolloverPolicy: RolloverOff,
+		})
+		n, err := a.RolloverOverdue(ctx)
+		assert.Equal(t, 0, n)
+		assert.NoError(t, err)
+	})
+}
+
+type getHistoryByTaskTest struct {
+	description     string
+	givenTaskId     int
+	givenOffset     int
+	givenLimit      int
+	expectedHistory []model.HistoryEntry
+	expectedErr     error
+}
+
+func (s *appTestSuite) TestGetHistoryByTask() {
+	history := []model.HistoryEntry{
+		{Id: 711, TaskId: 711, Action: model.ActionRollover},
+	}
+	s.taskRepo.On("GetTaskById", mock.Anything, 711).Return(model.TodoTask{Id: 711}, nil)
+	s.taskRepo.On("GetTaskById", mock.Anything, 712).Return(model.TodoTask{}, model.ErrTaskNotFound)
+	s.scheduleRepo.On("GetHistoryByTask", mock.Anything, 711, 0, 10).Return(history, nil).Once()
+
+	tests := []getHistoryByTaskTest{
+		{
+			description:     "test of getting of the history of the task",
+			givenTaskId:     711,
+			givenOffset:     0,
+			givenLimit:      10,
+			expectedHistory: history,
+			expectedErr:     nil,
+		},
+		{
+			description:     "test of getting of the history of non existing task",
+			givenTaskId:     712,
+			givenOffset:     0,
+			givenLimit:      10,
+			expectedHistory: nil,
+			expectedErr:     model.ErrTaskNotFound,
+		},
+		{
+			description:     "test of getting of the history with invalid pagination",
+			givenTaskId:     711,
+			givenOffset:     -1,
+			givenLimit:      10,
+			expectedHistory: nil,
+			expectedErr:     model.ErrInvalidInput,
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		s.T().Run(test.description, func(t *testing.T) {
+			history, err := s.a.GetHistoryByTask(ctx, test.givenTaskId, test.givenOffset, test.givenLimit)
+			assert.Equal(t, test.expectedHistory, history)
+			assert.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+type rescheduleTaskTest struct {
+	description  string
+	givenId      int
+	reschedule   func(ctx context.Context, id int) (model.TodoTask, error)
+	expectedDate model.Date
+	expectedErr  error
+}
+
+func (s *appTestSuite) TestRescheduleTask() {
+	// 1 January 2099 is Thursday
+	planned := model.Date{Year: 2099, Month: time.January, Day: 1}
+	past := model.Date{Year: 2020, Month: time.January, Day: 1}
+
+	s.taskRepo.On("GetTaskById", mock.Anything, 801).Return(model.TodoTask{Id: 801, PlanningDate: planned}, nil)
+	s.taskRepo.On("GetTaskById", mock.Anything, 802).Return(model.TodoTask{Id: 802, PlanningDate: past, Overdue: true}, nil)
+	s.taskRepo.On("GetTaskById", mock.Anything, 803).Return(model.TodoTask{}, model.ErrTaskNotFound)
+
+	// date of the last move of the task passed to the repository
+	var moved model.Date
+	s.scheduleRepo.On("RescheduleTask", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("model.Date"), mock.AnythingOfType("string")).Return(
+		func(_ context.Context, id int, date model.Date, action string) model.HistoryEntry {
+			moved = date
+			return model.HistoryEntry{TaskId: id, Action: action, ToDate: date}
+		},
+		nil)
+
+	tests := []rescheduleTaskTest{
+		{
+			description: "test of snoozing of the task",
+			givenId:     801,
+			reschedule: func(ctx context.Context, id int) (model.TodoTask, error) {
+				return s.a.SnoozeTask(ctx, id, 3)
+			},
+			expectedDate: model.Date{Year: 2099, Month: time.January, Day: 4},
+			expectedErr:  nil,
+		},
+		{
+			description: "test of snoozing of the overdue task",
+			givenId:     802,
+			reschedule: func(ctx context.Context, id int) (model.TodoTask, error) {
+				return s.a.SnoozeTask(ctx, id, 1)
+			},
+			expectedDate: dateOf(time.Now().UTC().AddDate(0, 0, 1)),
+			expectedErr:  nil,
+		},
+		{
+			description: "test of snoozing of the task for invalid number of days",
+			givenId:     801,
+			reschedule: func(ctx context.Context, id int) (model.TodoTask, error) {
+				return s.a.SnoozeTask(ctx, id, 0)
+			},
+			expectedErr: model.ErrInvalidInput,
+		},
+		{
+			description: "test of moving of the task to the next weekday",
+			givenId:     801,
+			reschedule: func(ctx context.Context, id int) (model.TodoTask, error) {
+				return s.a.RescheduleToWeekday(ctx, id, time.Monday)
+			},
+			expectedDate: model.Date{Year: 2099, Month: time.January, Day: 5},
+			expectedErr:  nil,
+		},
+		{
+			description: "test of moving of the task to the same weekday",
+			givenId:     801,
+			reschedule: func(ctx context.Context, id int) (model.TodoTask, error) {
+				return s.a.RescheduleToWeekday(ctx, id, time.Thursday)
+			},
+			expectedDate: model.Date{Year: 2099, Month: time.January, Day: 8},
+			expectedErr:  nil,
+		},
+		{
+			description: "test of moving of the task to the specific date",
+			givenId:     801,
+			reschedule: func(ctx context.Context, id int) (model.TodoTask, error) {
+				return s.a.RescheduleTask(ctx, id, model.Date{Year: 2099, Month: time.March, Day: 1})
+			},
+			expectedDate: model.Date{Year: 2099, Month: time.March, Day: 1},
+			expectedErr:  nil,
+		},
+		{
+			description: "test of moving of the task to the expired date",
+			givenId:     801,
+			reschedule: func(ctx context.Context, id int) (model.TodoTask, error) {
+				return s.a.RescheduleTask(ctx, id, past)
+			},
+			expectedErr: model.ErrInvalidInput,
+		},
+		{
+			description: "test of moving of non existing task",
+			givenId:     803,
+			reschedule: func(ctx context.Context, id int) (model.TodoTask, error) {
+				return s.a.SnoozeTask(ctx, id, 1)
+			},
+			expectedErr: model.ErrTaskNotFound,
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		s.T().Run(test.description, func(t *testing.T) {
+			_, err := test.reschedule(ctx, test.givenId)
+			assert.ErrorIs(t, err, test.expectedErr)
+			if test.expectedErr == nil {
+				assert.Equal(t, test.expectedDate, moved)
+			}
+		})
+	}
+}
+
+func (s *appTestSuite) TestRescheduleDay() {
+	from := model.Date{Year: 2020, Month: time.January, Day: 1}
+	to := model.Date{Year: 2099, Month: time.January, Day: 2}
+	tasks := []model.TodoTask{
+		{Id: 821, PlanningDate: to, Position: 1, Postponed: 1},
+		{Id: 822, PlanningDate: to, Position: 2, Postponed: 1},
+	}
+
+	s.scheduleRepo.On("RescheduleDay", mock.Anything, from, to, model.ActionRescheduleDay).Return([]model.HistoryEntry{
+		{TaskId: 821, Action: model.ActionRescheduleDay, FromDate: from, ToDate: to},
+		{TaskId: 822, Action: model.ActionRescheduleDay, FromDate: from, ToDate: to},
+	}, nil).Once()
+	s.planRepo.On("GetTasksByDate", mock.Anything, to).Return(tasks, nil).Once()
+
+	ctx := context.Background()
+
+	s.T().Run("test of rescheduling of the day", func(t *testing.T) {
+		result, err := s.a.RescheduleDay(ctx, from, to)
+		assert.Equal(t, tasks, result)
+		assert.NoError(t, err)
+	})
+
+	s.T().Run("test of rescheduling of the day to the same day", func(t *testing.T) {
+		result, err := s.a.RescheduleDay(ctx, to, to)
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+	})
+
+	s.T().Run("test of rescheduling of the day to the expired date", func(t *testing.T) {
+		result, err := s.a.RescheduleDay(ctx, to, from)
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+	})
+}
+
+type addReminderTest struct {
+	description string
+	givenTaskId int
+	givenRem    model.Reminder
+	expectedErr error
+}
+
+func (s *appTestSuite) TestAddReminder() {
+	s.taskRepo.On("GetTaskById", mock.Anything, 901).Return(model.TodoTask{Id: 901}, nil)
+	s.taskRepo.On("GetTaskById", mock.Anything, 902).Return(model.TodoTask{}, model.ErrTaskNotFound)
+	s.reminderRepo.On("AddReminder", mock.Anything, mock.MatchedBy(func(r model.Reminder) bool {
+		return r.TaskId == 901
+	})).Return(func(_ context.Context, r model.Reminder) model.Reminder {
+		r.Id, r.Status = 1, model.ReminderPending
+		return r
+	}, nil)
+
+	tests := []addReminderTest{
+		{
+			description: "test of adding of reminder before the planning date",
+			givenTaskId: 901,
+			givenRem:    model.Reminder{Offset: -time.Hour, Channel: model.ChannelEmail, Recipient: "alice@example.com"},
+			expectedErr: nil,
+		},
+		{
+			description: "test of adding of reminder through not configured channel",
+			givenTaskId: 901,
+			givenRem:    model.Reminder{Channel: model.ChannelWebhook, Recipient: "https://example.com/hook"},
+			expectedErr: model.ErrInvalidReminder,
+		},
+		{
+			description: "test of adding of reminder at the passed time",
+			givenTaskId: 901,
+			givenRem:    model.Reminder{At: time.Now().Add(-time.Hour), Channel: model.ChannelEmail, Recipient: "alice@example.com"},
+			expectedErr: model.ErrInvalidReminder,
+		},
+		{
+			description: "test of adding of invalid reminder",
+			givenTaskId: 901,
+			givenRem:    model.Reminder{Channel: model.ChannelEmail, Recipient: "alice"},
+			expectedErr: model.ErrInvalidReminder,
+		},
+		{
+			description: "test of adding of reminder to non-existing task",
+			givenTaskId: 902,
+			givenRem:    model.Reminder{Channel: model.ChannelEmail, Recipient: "alice@example.com"},
+			expectedErr: model.ErrTaskNotFound,
+		},
+	}
+
+	ctx := context.Background()
+	for _, test := range tests {
+		s.T().Run(test.description, func(t *testing.T) {
+			r, err := s.a.AddReminder(ctx, test.givenTaskId, test.givenRem)
+			assert.ErrorIs(t, err, test.expectedErr)
+			if test.expectedErr == nil {
+				assert.Equal(t, 1, r.Id)
+				assert.Equal(t, test.givenTaskId, r.TaskId)
+				assert.Equal(t, model.ReminderPending, r.Status)
+			}
+		})
+	}
+}
+
+func (s *appTestSuite) TestDeleteReminder() {
+	s.reminderRepo.On("GetReminderById", mock.Anything, 931).Return(model.Reminder{Id: 931, TaskId: 903}, nil)
+	s.reminderRepo.On("GetReminderById", mock.Anything, 932).Return(model.Reminder{}, model.ErrReminderNotFound)
+	s.reminderRepo.On("DeleteReminder", mock.Anything, 931).Return(nil)
+
+	ctx := context.Background()
+	s.T().Run("test of deleting of the reminder of the task", func(t *testing.T) {
+		assert.NoError(t, s.a.DeleteReminder(ctx, 903, 931))
+	})
+	s.T().Run("test of deleting of the reminder of another task", func(t *testing.T) {
+		assert.ErrorIs(t, s.a.DeleteReminder(ctx, 904, 931), model.ErrReminderNotFound)
+	})
+	s.T().Run("test of deleting of non-existing reminder", func(t *testing.T) {
+		assert.ErrorIs(t, s.a.DeleteReminder(ctx, 903, 932), model.ErrReminderNotFound)
+	})
+}
+
+func (s *appTestSuite) TestDeliverReminders() {
+	due := []model.Reminder{
+		{Id: 911, TaskId: 911, Channel: model.ChannelEmail, Attempts: 1},
+		{Id: 912, TaskId: 912, Channel: model.ChannelEmail, Attempts: 1},
+		{Id: 913, TaskId: 913, Channel: model.ChannelEmail, Attempts: 3},
+		{Id: 914, TaskId: 914, Channel: model.ChannelEmail, Attempts: 1},
+		{Id: 915, TaskId: 915, Channel: model.ChannelWebhook, Attempts: 1},
+	}
+	s.reminderRepo.On("ClaimDueReminders", mock.Anything, mock.Anything, mock.Anything, reminderBatch).Return(due, nil)
+	for _, id := range []int{911, 912, 913, 915} {
+		s.taskRepo.On("GetTaskById", mock.Anything, id).Return(model.TodoTask{Id: id}, nil)
+	}
+	s.taskRepo.On("GetTaskById", mock.Anything, 914).Return(model.TodoTask{}, model.ErrTaskNotFound)
+
+	reminder := func(id int) interface{} {
+		return mock.MatchedBy(func(r model.Reminder) bool { return r.Id == id })
+	}
+	s.notifier.On("Notify", mock.Anything, reminder(911), mock.Anything).Return(nil)
+	s.notifier.On("Notify", mock.Anything, reminder(912), mock.Anything).Return(model.ErrNotifier)
+	s.notifier.On("Notify", mock.Anything, reminder(913), mock.Anything).Return(model.ErrNotifier)
+	s.reminderRepo.On("RecordDelivery", mock.Anything, mock.AnythingOfType("model.Delivery"), mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(nil)
+
+	start := time.Now()
+	sent, err := s.a.DeliverReminders(context.Background())
+	s.NoError(err)
+	s.Equal(1, sent)
+
+	s.reminderRepo.AssertCalled(s.T(), "RecordDelivery", mock.Anything,
+		model.Delivery{ReminderId: 911, Attempt: 1}, model.ReminderSent, mock.Anything)
+	s.reminderRepo.AssertCalled(s.T(), "RecordDelivery", mock.Anything,
+		mock.MatchedBy(func(d model.Delivery) bool { return d.ReminderId == 912 && d.Error != "" }),
+		model.ReminderPending,
+		mock.MatchedBy(func(retryAt time.Time) bool { return !retryAt.Before(start.Add(reminderRetryDelay)) }))
+	s.reminderRepo.AssertCalled(s.T(), "RecordDelivery", mock.Anything,
+		mock.MatchedBy(func(d model.Delivery) bool { return d.ReminderId == 913 && d.Attempt == 3 }),
+		model.ReminderFailed, mock.Anything)
+	s.reminderRepo.AssertNotCalled(s.T(), "RecordDelivery", mock.Anything,
+		mock.MatchedBy(func(d model.Delivery) bool { return d.ReminderId == 914 }), mock.Anything, mock.Anything)
+	// channel of the reminder was disabled after it had been added
+	s.reminderRepo.AssertCalled(s.T(), "RecordDelivery", mock.Anything,
+		mock.MatchedBy(func(d model.Delivery) bool { return d.ReminderId == 915 && d.Error != "" }),
+		model.ReminderPending, mock.Anything)
+}
+
+func (s *appTestSuite) TestBackoff() {
+	s.Equal(time.Minute, backoff(1, time.Minute, time.Hour))
+	s.Equal(2*time.Minute, backoff(2, time.Minute, time.Hour))
+	s.Equal(4*time.Minute, backoff(3, time.Minute, time.Hour))
+	s.Equal(time.Hour, backoff(100, time.Minute, time.Hour))
+}
+
+func (s *appTestSuite) TestRelayEvents() {
+	ctx := context.Background()
+	published := model.Event{Id: 1041, Key: "key-1041", Type: model.EventTaskCreated, TaskId: 1041, Attempts: 1}
+	failed := model.Event{Id: 1042, Key: "key-1042", Type: model.EventTaskUpdated, TaskId: 1042, Attempts: 1}
+	retried := model.Event{Id: 1043, Key: "key-1043", Type: model.EventTaskDeleted, TaskId: 1043, Attempts: 4}
+
+	s.T().Run("test of publishing of due events", func(t *testing.T) {
+		start := time.Now()
+		s.outboxRepo.On("ClaimEvents", mock.Anything, mock.Anything, mock.Anything, outboxBatch).Return([]model.Event{published, failed}, nil).Once()
+		s.sink.On("Publish", mock.Anything, published).Return(nil).Once()
+		s.sink.On("Publish", mock.Anything, failed).Return(model.ErrEventSink).Once()
+		s.outboxRepo.On("MarkPublished", mock.Anything, 1041, mock.Anything).Return(nil).Once()
+		s.outboxRepo.On("RetryEvent", mock.Anything, 1042, model.ErrEventSink.Error(), mock.MatchedBy(func(next time.Time) bool {
+			return !next.Before(start.Add(outboxRetryDelay))
+		})).Return(nil).Once()
+		s.outboxRepo.On("PurgeEvents", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+			return !before.After(start.Add(-defaultOutboxRetention + time.Minute))
+		})).Return(3, nil).Once()
+
+		n, err := s.a.RelayEvents(ctx)
+		assert.Equal(t, 1, n)
+		assert.NoError(t, err)
+		s.outboxRepo.AssertExpectations(t)
+	})
+
+	s.T().Run("test of retry of the event failed by one of the sinks", func(t *testing.T) {
+		broken := new(mocks.EventSink)
+		deps := s.deps()
+		deps.Sinks = []EventSink{s.sink, broken}
+		a := New(deps, Config{})
+		s.outboxRepo.On("ClaimEvents", mock.Anything, mock.Anything, mock.Anything, outboxBatch).Return([]model.Event{retried}, nil).Once()
+		s.sink.On("Publish", mock.Anything, retried).Return(nil).Once()
+		broken.On("Publish", mock.Anything, retried).Return(model.ErrEventSink).Once()
+		s.outboxRepo.On("RetryEvent", mock.Anything, 1043, mock.AnythingOfType("string"), mock.Anything).Return(nil).Once()
+		s.outboxRepo.On("PurgeEvents", mock.Anything, mock.Anything).Return(0, nil).Once()
+
+		n, err := a.RelayEvents(ctx)
+		assert.Equal(t, 0, n)
+		assert.NoError(t, err)
+		s.sink.AssertCalled(t, "Publish", mock.Anything, retried)
+		s.outboxRepo.AssertNotCalled(t, "MarkPublished", mock.Anything, 1043, mock.Anything)
+	})
+
+	s.T().Run("test of claiming error", func(t *testing.T) {
+		s.outboxRepo.On("ClaimEvents", mock.Anything, mock.Anything, mock.Anything, outboxBatch).Return(nil, model.ErrTaskRepo).Once()
+
+		n, err := s.a.RelayEvents(ctx)
+		assert.Equal(t, 0, n)
+		assert.ErrorIs(t, err, model.ErrTaskRepo)
+	})
+}
+
+func (s *appTestSuite) TestSubscribeEvents() {
+	ctx := context.Background()
+	planned := model.Date{Year: 2099, Month: time.January, Day: 1}
+	var match func(string, model.TodoTask, *model.TodoTask) bool
+	s.broker.On("Subscribe", mock.Anything, 1051, mock.Anything).Return(
+		func(_ context.Context, _ int, m func(string, model.TodoTask, *model.TodoTask) bool) model.EventStream {
+			match = m
+			return model.EventStream{Lost: true}
+		}).Once()
+
+	s.T().Run("test of subscribing to the events of the scope", func(t *testing.T) {
+		stream, err := s.a.SubscribeEvents(ctx, model.EventScope{Assignee: "alice", Date: planned}, 1051)
+		assert.NoError(t, err)
+		assert.True(t, stream.Lost)
+
+		assigned := model.TodoTask{Id: 1052, PlanningDate: planned, Assignees: []string{"alice"}}
+		assert.True(t, match(model.EventTaskCreated, assigned, nil))
+		assert.True(t, match(model.EventTaskDeleted, assigned, nil))
+		assert.False(t, match(model.EventTaskCompleted, assigned, &assigned))
+		unassigned := model.TodoTask{Id: 1053, PlanningDate: planned, Assignees: []string{"bob"}}
+		assert.False(t, match(model.EventTaskUpdated, unassigned, nil))
+		moved := model.TodoTask{Id: 1054, PlanningDate: model.Date{Year: 2099, Month: time.January, Day: 2}, Assignees: []string{"alice"}}
+		assert.False(t, match(model.EventTaskUpdated, moved, nil))
+
+		// tasks leaving the scope are streamed by their previous values
+		previous := moved
+		previous.PlanningDate = planned
+		assert.True(t, match(model.EventTaskUpdated, moved, &previous))
+		previous = unassigned
+		previous.Assignees = []string{"bob", "alice"}
+		assert.True(t, match(model.EventTaskUpdated, unassigned, &previous))
+	})
+
+	s.T().Run("test of subscribing of not a member", func(t *testing.T) {
+		_, err := s.a.SubscribeEvents(ctx, model.EventScope{Assignee: "carol"}, 0)
+		assert.ErrorIs(t, err, model.ErrNotMember)
+	})
+
+	s.T().Run("test of subscribing with invalid scope", func(t *testing.T) {
+		_, err := s.a.SubscribeEvents(ctx, model.EventScope{Date: model.Date{Year: 2099, Month: time.February, Day: 30}}, 0)
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+		_, err = s.a.SubscribeEvents(ctx, model.EventScope{Project: "back end"}, 0)
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+		_, err = s.a.SubscribeEvents(ctx, model.EventScope{}, -1)
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+	})
+}
+
+func (s *appTestSuite) TestGetChanges() {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	s.syncRepo.On("GetChanges", mock.Anything, uint64(0)).Return(model.SyncChanges{
+		Tasks:      []model.TodoTask{{Id: 1071, Version: 1}},
+		Tombstones: []model.Tombstone{},
+	}, uint64(500), nil).Twice()
+	s.syncRepo.On("GetChanges", mock.Anything, uint64(400)).Return(model.SyncChanges{
+		Tasks:      []model.TodoTask{{Id: 1072, Version: 3}},
+		Tombstones: []model.Tombstone{{TaskId: 1073, Version: 2}},
+	}, uint64(510), nil).Once()
+
+	s.T().Run("test of getting of all tasks without token", func(t *testing.T) {
+		changes, err := s.a.GetChanges(ctx, "")
+		assert.NoError(t, err)
+		assert.True(t, changes.Reset)
+		assert.Equal(t, []model.TodoTask{{Id: 1071, Version: 1}}, changes.Tasks)
+
+		xmin, at, err := decodeToken(changes.Token)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(500), xmin)
+		assert.WithinDuration(t, now, at, time.Minute)
+	})
+
+	s.T().Run("test of getting of changes since the token", func(t *testing.T) {
+		changes, err := s.a.GetChanges(ctx, encodeToken(400, now.Add(-time.Hour)))
+		assert.NoError(t, err)
+		assert.False(t, changes.Reset)
+		assert.Equal(t, []model.TodoTask{{Id: 1072, Version: 3}}, changes.Tasks)
+		assert.Equal(t, []model.Tombstone{{TaskId: 1073, Version: 2}}, changes.Tombstones)
+	})
+
+	s.T().Run("test of getting of all tasks with expired token", func(t *testing.T) {
+		changes, err := s.a.GetChanges(ctx, encodeToken(400, now.Add(-defaultTombstoneRetention-time.Hour)))
+		assert.NoError(t, err)
+		assert.True(t, changes.Reset)
+		assert.Equal(t, []model.TodoTask{{Id: 1071, Version: 1}}, changes.Tasks)
+	})
+
+	s.T().Run("test of getting of changes with invalid token", func(t *testing.T) {
+		for _, token := range []string{"abc", "400", "0.100", "400.abc", encodeToken(400, now.Add(time.Hour))} {
+			_, err := s.a.GetChanges(ctx, token)
+			assert.ErrorIs(t, err, model.ErrInvalidInput, token)
+		}
+	})
+	s.syncRepo.AssertExpectations(s.T())
+}
+
+func (s *appTestSuite) TestPushChanges() {
+	ctx := context.Background()
+	planned := model.Date{Year: 2099, Month: time.January, Day: 1}
+	task := func(id int, version int) model.TodoTask {
+		return model.TodoTask{Id: id, Title: "Offline", PlanningDate: planned, State: "todo", Version: version}
+	}
+
+	s.taskRepo.On("AddTask", mock.Anything, mock.MatchedBy(func(t model.TodoTask) bool {
+		return t.Title == "Offline 1071"
+	})).Return(model.TodoTask{Id: 1071, Title: "Offline 1071", PlanningDate: planned, State: "todo", Version: 1}, nil).Once()
+
+	s.taskRepo.On("GetTaskById", mock.Anything, 1072).Return(task(1072, 2), nil).Twice()
+	s.taskRepo.On("UpdateTask", mock.Anything, 1072, mock.MatchedBy(func(t model.TodoTask) bool {
+		return t.Version == 2
+	})).Return(task(1072, 3), nil).Once()
+
+	s.taskRepo.On("GetTaskById", mock.Anything, 1073).Return(task(1073, 3), nil).Once()
+	s.taskRepo.On("GetTaskById", mock.Anything, 1074).Return(model.TodoTask{}, model.ErrTaskNotFound).Once()
+
+	s.taskRepo.On("GetTaskById", mock.Anything, 1075).Return(task(1075, 1), nil).Once()
+	s.attachmentRepo.On("GetAttachmentsByTask", mock.Anything, 1075).Return([]model.Attachment{}, nil).Once()
+	s.taskRepo.On("DeleteTask", mock.Anything, 1075).Return(nil).Once()
+
+	s.taskRepo.On("GetTaskById", mock.Anything, 1076).Return(task(1076, 1), nil).Once()
+
+	// task is changed between the check and the update
+	s.taskRepo.On("GetTaskById", mock.Anything, 1077).Return(task(1077, 1), nil).Twice()
+	s.taskRepo.On("UpdateTask", mock.Anything, 1077, mock.Anything).Return(model.TodoTask{}, model.ErrVersionConflict).Once()
+	s.taskRepo.On("GetTaskById", mock.Anything, 1077).Return(task(1077, 2), nil).Once()
+
+	results, err := s.a.PushChanges(ctx, "", []model.SyncChange{
+		{Ref: "a", Op: model.SyncCreate, Task: model.TodoTask{Title: "Offline 1071", PlanningDate: planned}},
+		{Ref: "b", Op: model.SyncUpdate, TaskId: 1072, Version: 2, Task: task(0, 0)},
+		{Ref: "c", Op: model.SyncUpdate, TaskId: 1073, Version: 1, Task: task(0, 0)},
+		{Ref: "d", Op: model.SyncUpdate, TaskId: 1074, Version: 1, Task: task(0, 0)},
+		{Ref: "e", Op: model.SyncDelete, TaskId: 1075, Version: 1},
+		{Ref: "f", Op: model.SyncUpdate, TaskId: 1076, Version: 1, Task: model.TodoTask{PlanningDate: planned}},
+		{Ref: "g", Op: model.SyncUpdate, TaskId: 1077, Version: 1, Task: task(0, 0)},
+		{Ref: "h", Op: "archive", TaskId: 1078},
+	})
+	s.NoError(err)
+	s.Require().Len(results, 8)
+
+	s.Equal(model.SyncResult{Ref: "a", Status: model.SyncApplied, Task: model.TodoTask{Id: 1071, Title: "Offline 1071", PlanningDate: planned, State: "todo", Version: 1}}, results[0])
+	s.Equal(model.SyncResult{Ref: "b", Status: model.SyncApplied, Task: task(1072, 3)}, results[1])
+	s.Equal(model.SyncResult{Ref: "c", Status: model.SyncConflict, Task: task(1073, 3)}, results[2])
+	s.Equal(model.SyncResult{Ref: "d", Status: model.SyncConflict, Task: model.TodoTask{Id: 1074}, Deleted: true}, results[3])
+	s.Equal(model.SyncResult{Ref: "e", Status: model.SyncApplied, Task: model.TodoTask{Id: 1075}}, results[4])
+	s.Equal(model.SyncRejected, results[5].Status)
+	s.ErrorIs(results[5].Err, model.ErrInvalidTask)
+	s.Equal(model.SyncResult{Ref: "g", Status: model.SyncConflict, Task: task(1077, 2)}, results[6])
+	s.Equal(model.SyncRejected, results[7].Status)
+	s.ErrorIs(results[7].Err, model.ErrInvalidInput)
+
+	_, err = s.a.PushChanges(ctx, "", make([]model.SyncChange, maxSyncChanges+1))
+	s.ErrorIs(err, model.ErrInvalidInput)
+	_, err = s.a.PushChanges(ctx, strings.Repeat("c", maxSyncRefLen+1), nil)
+	s.ErrorIs(err, model.ErrInvalidInput)
+}
+
+func (s *appTestSuite) TestPushChangesAgain() {
+	ctx := context.Background()
+	planned := model.Date{Year: 2099, Month: time.February, Day: 1}
+	created := model.TodoTask{Id: 1081, Title: "Offline 1081", PlanningDate: planned, State: "todo", Version: 1}
+	changes := []model.SyncChange{
+		{Ref: "a", Op: model.SyncCreate, Task: model.TodoTask{Title: "Offline 1081", PlanningDate: planned}},
+		{Ref: "b", Op: model.SyncCreate, Task: model.TodoTask{Title: "Offline 1082", PlanningDate: planned}},
+	}
+
+	// first push creates the tasks with the refs of the client
+	s.syncRepo.On("GetCreatedTask", mock.Anything, "phone", "a").Return(0, nil).Once()
+	s.syncRepo.On("AddCreatedTask", mock.Anything, "phone", "a", mock.MatchedBy(func(t model.TodoTask) bool {
+		return t.Title == "Offline 1081" && t.State == "todo" && t.Rank != ""
+	})).Return(1081, nil).Once()
+	s.taskRepo.On("GetTaskById", mock.Anything, 1081).Return(created, nil).Twice()
+	s.syncRepo.On("GetCreatedTask", mock.Anything, "phone", "b").Return(0, nil).Once()
+	s.syncRepo.On("AddCreatedTask", mock.Anything, "phone", "b", mock.Anything).Return(1082, nil).Once()
+	s.taskRepo.On("GetTaskById", mock.Anything, 1082).Return(model.TodoTask{Id: 1082, Title: "Offline 1082", Version: 1}, nil).Once()
+
+	results, err := s.a.PushChanges(ctx, "phone", changes)
+	s.Require().NoError(err)
+	s.Equal(model.SyncResult{Ref: "a", Status: model.SyncApplied, Task: created}, results[0])
+	s.Equal(1082, results[1].Task.Id)
+
+	// the same batch pushed again after a lost response creates nothing, the
+	// task deleted since is a conflict
+	s.syncRepo.On("GetCreatedTask", mock.Anything, "phone", "a").Return(1081, nil).Once()
+	s.syncRepo.On("GetCreatedTask", mock.Anything, "phone", "b").Return(1082, nil).Once()
+	s.taskRepo.On("GetTaskById", mock.Anything, 1082).Return(model.TodoTask{}, model.ErrTaskNotFound).Once()
+
+	results, err = s.a.PushChanges(ctx, "phone", changes)
+	s.Require().NoError(err)
+	s.Equal(model.SyncResult{Ref: "a", Status: model.SyncApplied, Task: created}, results[0])
+	s.Equal(model.SyncResult{Ref: "b", Status: model.SyncConflict, Task: model.TodoTask{Id: 1082}, Deleted: true}, results[1])
+	s.syncRepo.AssertExpectations(s.T())
+}
+
+func (s *appTestSuite) TestPurgeTombstones() {
+	s.syncRepo.On("PurgeTombstones", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+		return before.Before(time.Now().Add(-defaultTombstoneRetention))
+	})).Return(2, nil).Once()
+
+	n, err := s.a.PurgeTombstones(context.Background())
+	s.NoError(err)
+	s.Equal(2, n)
+}
+
+func (s *appTestSuite) TestAddSubscription() {
+	s.webhookRepo.On("AddSubscription", mock.Anything, mock.AnythingOfType("model.Subscription")).Return(
+		func(_ context.Context, sub model.Subscription) model.Subscription {
+			sub.Id = 1011
+			return sub
+		}, nil)
+
+	ctx := context.Background()
+
+	s.T().Run("test of subscription with generated secret", func(t *testing.T) {
+		sub, err := s.a.AddSubscription(ctx, model.Subscription{
+			URL:    "https://example.com/hook",
+			Events: []string{model.EventTaskCreated},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1011, sub.Id)
+		assert.Len(t, sub.Secret, 64)
+	})
+
+	s.T().Run("test of subscription with given secret", func(t *testing.T) {
+		sub, err := s.a.AddSubscription(ctx, model.Subscription{
+			URL:    "https://example.com/hook",
+			Events: []string{model.EventTaskCreated},
+			Secret: "secret",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "secret", sub.Secret)
+	})
+
+	s.T().Run("test of subscription to unknown event", func(t *testing.T) {
+		_, err := s.a.AddSubscription(ctx, model.Subscription{
+			URL:    "https://example.com/hook",
+			Events: []string{"task.archived"},
+		})
+		assert.ErrorIs(t, err, model.ErrInvalidSubscription)
+	})
+}
+
+func (s *appTestSuite) TestGetSubscriptions() {
+	s.webhookRepo.On("GetSubscriptions", mock.Anything).Return([]model.Subscription{
+		{Id: 1021, URL: "https://example.com/hook", Secret: "secret"},
+	}, nil).Once()
+
+	subs, err := s.a.GetSubscriptions(context.Background())
+	s.NoError(err)
+	s.Equal([]model.Subscription{{Id: 1021, URL: "https://example.com/hook"}}, subs)
+}
+
+func (s *appTestSuite) TestDeliverWebhooks() {
+	due := []model.WebhookDelivery{
+		{Id: 1031, SubscriptionId: 1031, Event: model.EventTaskCreated, Attempts: 1},
+		{Id: 1032, SubscriptionId: 1031, Event: model.EventTaskUpdated, Attempts: 2},
+		{Id: 1033, SubscriptionId: 1031, Event: model.EventTaskDeleted, Attempts: defaultWebhookAttempts},
+		{Id: 1034, SubscriptionId: 1032, Event: model.EventTaskCreated, Attempts: 1},
+	}
+	s.webhookRepo.On("ClaimDueDeliveries", mock.Anything, mock.Anything, mock.Anything, webhookBatch).Return(due, nil).Once()
+	s.webhookRepo.On("GetSubscriptionById", mock.Anything, 1031).Return(model.Subscription{Id: 1031, URL: "https://example.com/hook", Secret: "secret"}, nil).Once()
+	s.webhookRepo.On("GetSubscriptionById", mock.Anything, 1032).Return(model.Subscription{}, model.ErrSubscriptionNotFound).Once()
+
+	delivery := func(id int) interface{} {
+		return mock.MatchedBy(func(d model.WebhookDelivery) bool { return d.Id == id })
+	}
+	s.webhookSender.On("Send", mock.Anything, "https://example.com/hook", "secret", delivery(1031)).Return(200, nil).Once()
+	s.webhookSender.On("Send", mock.Anything, "https://example.com/hook", "secret", delivery(1032)).Return(500, model.ErrNotifier).Once()
+	s.webhookSender.On("Send", mock.Anything, "https://example.com/hook", "secret", delivery(1033)).Return(0, model.ErrNotifier).Once()
+	s.webhookRepo.On("RecordAttempt", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("string"),
+		mock.AnythingOfType("int"), mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(nil)
+
+	start := time.Now()
+	delivered, err := s.a.DeliverWebhooks(context.Background())
+	s.NoError(err)
+	s.Equal(1, delivered)
+
+	s.webhookRepo.AssertCalled(s.T(), "RecordAttempt", mock.Anything, 1031, model.DeliveryDelivered, 200, "", mock.Anything)
+	s.webhookRepo.AssertCalled(s.T(), "RecordAttempt", mock.Anything, 1032, model.DeliveryPending, 500, mock.Anything,
+		mock.MatchedBy(func(next time.Time) bool { return !next.Before(start.Add(2 * webhookRetryDelay)) }))
+	s.webhookRepo.AssertCalled(s.T(), "RecordAttempt", mock.Anything, 1033, model.DeliveryFailed, 0, mock.Anything, mock.Anything)
+	s.webhookRepo.AssertNotCalled(s.T(), "RecordAttempt", mock.Anything, 1034, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+type setTaskProjectMock struct {
+	givenId      int
+	givenProject string
+	returnTask   model.TodoTask
+	returnErr    error
+}
+
+type setTaskProjectTest struct {
+	description  string
+	givenId      int
+	givenProject string
+	expectedTask model.TodoTask
+	expectedErr  error
+}
+
+func (s *appTestSuite) TestSetTaskProject() {
+	setTaskProjectMocks := []setTaskProjectMock{
+		{
+			givenId:      5,
+			givenProject: "backend",
+			returnTask: model.TodoTask{
+				Id:          5,
+				Title:       "title",
+				Description: "description",
+				PlanningDate: model.Date{
+					Year:  2024,
+					Month: time.January,
+					Day:   1,
+				},
+				Status:    false,
+				Assignees: []string{"alice"},
+				Project:   "backend",
+			},
+			returnErr: nil,
+		},
+		{
+			givenId:      5,
+			givenProject: "frontend",
+			returnTask:   model.TodoTask{},
+			returnErr:    model.ErrNotMember,
+		},
+	}
+
+	tests := []setTaskProjectTest{
+		{
+			description:  "test of successful moving of the task to the project",
+			givenId:      5,
+			givenProject: "backend",
+			expectedTask: model.TodoTask{
+				Id:          5,
+				Title:       "title",
+				Description: "description",
+				PlanningDate: model.Date{
+					Year:  2024,
+					Month: time.January,
+					Day:   1,
+				},
+				Status:    false,
+				Assignees: []string{"alice"},
+				Project:   "backend",
+			},
+			expectedErr: nil,
+		},
+		{
+			description:  "test of moving of the task to the project without its assignees",
+			givenId:      5,
+			givenProject: "frontend",
+			expectedTask: model.TodoTask{},
+			expectedErr:  model.ErrNotMember,
+		},
+		{
+			description:  "test of moving of the task to the project with invalid name",
+			givenId:      5,
+			givenProject: "front end",
+			expectedTask: model.TodoTask{},
+			expectedErr:  model.ErrInvalidInput,
+		},
+	}
+
+	for _, m := range setTaskProjectMocks {
+		s.taskRepo.On("SetTaskProject", mock.Anything, m.givenId, m.givenProject).Return(m.returnTask, m.returnErr).Once()
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		s.T().Run(test.description, func(t *testing.T) {
+			task, err := s.a.SetTaskProject(ctx, test.givenId, test.givenProject)
+			assert.Equal(t, test.expectedTask, task)
+			assert.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+type addProjectMock struct {
+	givenProject  model.Project
+	returnProject model.Project
+	returnErr     error
+}
+
+type addProjectTest struct {
+	description     string
+	givenProject    model.Project
+	expectedProject model.Project
+	expectedErr     error
+}
+
+func (s *appTestSuite) TestAddProject() {
+	addProjectMocks := []addProjectMock{
+		{
+			givenProject: model.Project{
+				Name:    "backend",
+				Members: []string{"alice", "bob"},
+			},
+			returnProject: model.Project{
+				Name:    "backend",
+				Members: []string{"alice", "bob"},
+			},
+			returnErr: nil,
+		},
+		{
+			givenProject: model.Project{
+				Name:    "design",
+				Members: []string{},
+			},
+			returnProject: model.Project{},
+			returnErr:     model.ErrProjectExists,
+		},
+	}
+
+	tests := []addProjectTest{
+		{
+			description: "test of successful adding of the project with repeated member",
+			givenProject: model.Project{
+				Name:    "backend",
+				Members: []string{"alice", "bob", "alice"},
+			},
+			expectedProject: model.Project{
+				Name:    "backend",
+				Members: []string{"alice", "bob"},
+			},
+			expectedErr: nil,
+		},
+		{
+			description: "test of adding of the project with existing name",
+			givenProject: model.Project{
+				Name:    "design",
+				Members: nil,
+			},
+			expectedProject: model.Project{},
+			expectedErr:     model.ErrProjectExists,
+		},
+		{
+			description: "test of adding of the project with user who is not a member of the workspace",
+			givenProject: model.Project{
+				Name:    "backend",
+				Members: []string{"mallory"},
+			},
+			expectedProject: model.Project{},
+			expectedErr:     model.ErrNotMember,
+		},
+		{
+			description: "test of adding of the project without name",
+			givenProject: model.Project{
+				Name:    "",
+				Members: []string{"alice"},
+			},
+			expectedProject: model.Project{},
+			expectedErr:     model.ErrInvalidInput,
+		},
+	}
+
+	for _, m := range addProjectMocks {
+		s.taskRepo.On("AddProject", mock.Anything, m.givenProject).Return(m.returnProject, m.returnErr).Once()
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		s.T().Run(test.description, func(t *testing.T) {
+			p, err := s.a.AddProject(ctx, test.givenProject)
+			assert.Equal(t, test.expectedProject, p)
+			assert.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+type addProjectMemberMock struct {
+	givenName     string
+	givenMember   string
+	returnProject model.Project
+	returnErr     error
+}
+
+type addProjectMemberTest struct {
+	description     string
+	givenName       string
+	givenMember     string
+	expectedProject model.Project
+	expectedErr     error
+}
+
+func (s *appTestSuite) TestAddProjectMember() {
+	addProjectMemberMocks := []addProjectMemberMock{
+		{
+			givenName:   "frontend",
+			givenMember: "bob",
+			returnProject: model.Project{
+				Name:    "frontend",
+				Members: []string{"alice", "bob"},
+			},
+			returnErr: nil,
+		},
+		{
+			givenName:     "mobile",
+			givenMember:   "bob",
+			returnProject: model.Project{},
+			returnErr:     model.ErrProjectNotFound,
+		},
+	}
+
+	tests := []addProjectMemberTest{
+		{
+			description: "test of successful adding of the member to the project",
+			givenName:   "frontend",
+			givenMember: "bob",
+			expectedProject: model.Project{
+				Name:    "frontend",
+				Members: []string{"alice", "bob"},
+			},
+			expectedErr: nil,
+		},
+		{
+			description:     "test of adding of the member to non existing project",
+			givenName:       "mobile",
+			givenMember:     "bob",
+			expectedProject: model.Project{},
+			expectedErr:     model.ErrProjectNotFound,
+		},
+		{
+			description:     "test of adding of user who is not a member of the workspace",
+			givenName:       "frontend",
+			givenMember:     "mallory",
+			expectedProject: model.Project{},
+			expectedErr:     model.ErrNotMember,
+		},
+	}
+
+	for _, m := range addProjectMemberMocks {
+		s.taskRepo.On("AddProjectMember", mock.Anything, m.givenName, m.givenMember).Return(m.returnProject, m.returnErr).Once()
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		s.T().Run(test.description, func(t *testing.T) {
+			p, err := s.a.AddProjectMember(ctx, test.givenName, test.givenMember)
+			assert.Equal(t, test.expectedProject, p)
+			assert.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func (s *appTestSuite) TestUpdateOverdueTask() {
+	ctx := context.Background()
+	overdue := model.Date{Year: time.Now().Year() - 1, Month: time.March, Day: 10}
+	current := model.TodoTask{Id: 1461, Title: "Pay rent", PlanningDate: overdue, State: "todo", Rank: "i", Overdue: true}
+	s.taskRepo.On("GetTaskById", mock.Anything, 1461).Return(current, nil)
+
+	// task kept on its past date by the rollover is completed
+	done := model.TodoTask{Title: "Pay rent", PlanningDate: overdue, Status: true, State: "done", Rank: "i"}
+	s.taskRepo.On("UpdateTask", mock.Anything, 1461, done).Return(model.TodoTask{Id: 1461, Title: "Pay rent", PlanningDate: overdue, Status: true, State: "done", Rank: "i"}, nil).Once()
+	task, err := s.a.UpdateTask(ctx, 1461, model.TodoTask{Title: "Pay rent", PlanningDate: overdue, Status: true})
+	s.Require().NoError(err)
+	s.True(task.Status)
+
+	// but it can't be moved to another past date
+	_, err = s.a.UpdateTask(ctx, 1461, model.TodoTask{Title: "Pay rent", PlanningDate: model.Date{Year: overdue.Year, Month: time.March, Day: 11}})
+	s.ErrorIs(err, model.ErrInvalidTask)
+}
+
+func (s *appTestSuite) TestIssueCalendarToken() {
+	ctx := context.Background()
+	var hashes [][]byte
+	s.credentialRepo.On("SetCredential", mock.Anything, "alice", calendarCredential, mock.Anything).Run(func(args mock.Arguments) {
+		hashes = append(hashes, args.Get(3).([]byte))
+	}).Return(nil).Twice()
+
+	token, err := s.a.IssueCalendarToken(ctx, "alice")
+	s.NoError(err)
+	s.NotEmpty(token)
+	// only hash of the token is stored
+	s.Equal(hashToken(token), hashes[0])
+	s.NotEqual([]byte(token), hashes[0])
+
+	// new token replaces the previous one
+	other, err := s.a.IssueCalendarToken(ctx, "alice")
+	s.NoError(err)
+	s.NotEqual(token, other)
+	s.Equal(hashToken(other), hashes[1])
+
+	_, err = s.a.IssueCalendarToken(ctx, "mallory")
+	s.ErrorIs(err, model.ErrNotMember)
+	_, err = s.a.IssueCalendarToken(ctx, "")
+	s.ErrorIs(err, model.ErrInvalidInput)
+
+	disabled := New(Deps{Tasks: s.taskRepo, Board: s.boardRepo, Credentials: s.credentialRepo}, Config{})
+	_, err = disabled.IssueCalendarToken(ctx, "alice")
+	s.ErrorIs(err, model.ErrCalendarDisabled)
+}
+
+func (s *appTestSuite) TestRevokeCalendarToken() {
+	ctx := context.Background()
+	s.credentialRepo.On("DeleteCredential", mock.Anything, "alice", calendarCredential).Return(nil).Once()
+	s.credentialRepo.On("DeleteCredential", mock.Anything, "bob", calendarCredential).Return(model.ErrCredentialNotFound).Once()
+
+	s.NoError(s.a.RevokeCalendarToken(ctx, "alice"))
+	s.ErrorIs(s.a.RevokeCalendarToken(ctx, "bob"), model.ErrFeedNotFound)
+}
+
+func (s *appTestSuite) TestCalDAVPassword() {
+	ctx := context.Background()
+	var hash []byte
+	s.credentialRepo.On("SetCredential", mock.Anything, "alice", caldavCredential, mock.Anything).Run(func(args mock.Arguments) {
+		hash = args.Get(3).([]byte)
+	}).Return(nil).Once()
+
+	password, err := s.a.IssueCalDAVPassword(ctx, "alice")
+	s.NoError(err)
+	s.Equal(hashToken(password), hash)
+
+	s.credentialRepo.On("GetCredential", mock.Anything, "alice", caldavCredential).Return(hash, nil).Twice()
+	s.NoError(s.a.CheckCalDAVPassword(ctx, "alice", password))
+	s.ErrorIs(s.a.CheckCalDAVPassword(ctx, "alice", "feed-token"), model.ErrWrongPassword)
+	s.credentialRepo.On("GetCredential", mock.Anything, "bob", caldavCredential).Return(nil, model.ErrCredentialNotFound).Once()
+	s.ErrorIs(s.a.CheckCalDAVPassword(ctx, "bob", password), model.ErrWrongPassword)
+	s.ErrorIs(s.a.CheckCalDAVPassword(ctx, "mallory", password), model.ErrWrongPassword)
+
+	s.credentialRepo.On("DeleteCredential", mock.Anything, "alice", caldavCredential).Return(model.ErrCredentialNotFound).Once()
+	s.ErrorIs(s.a.RevokeCalDAVPassword(ctx, "alice"), model.ErrWrongPassword)
+
+	_, err = s.a.IssueCalDAVPassword(ctx, "mallory")
+	s.ErrorIs(err, model.ErrNotMember)
+}
+
+func (s *appTestSuite) TestGetCalendarFeed() {
+	ctx := context.Background()
+	page := make([]model.TodoTask, feedPageSize)
+	for i := range page {
+		page[i] = model.TodoTask{Id: 1141 + i}
+	}
+	s.credentialRepo.On("GetCredential", mock.Anything, "bob", calendarCredential).Return(hashToken("bob-token"), nil)
+	s.credentialRepo.On("GetCredential", mock.Anything, "alice", calendarCredential).Return(nil, model.ErrCredentialNotFound).Once()
+	s.taskRepo.On("GetTasksByAssignee", mock.Anything, "bob", 0, feedPageSize).Return(page, nil).Once()
+	s.taskRepo.On("GetTasksByAssignee", mock.Anything, "bob", feedPageSize, feedPageSize).Return([]model.TodoTask{{Id: 1241}}, nil).Once()
+
+	tasks, err := s.a.GetCalendarFeed(ctx, "bob", "bob-token")
+	s.NoError(err)
+	s.Len(tasks, feedPageSize+1)
+
+	// revoked or replaced token
+	_, err = s.a.GetCalendarFeed(ctx, "bob", "old-token")
+	s.ErrorIs(err, model.ErrFeedNotFound)
+	// user without the token
+	_, err = s.a.GetCalendarFeed(ctx, "alice", "bob-token")
+	s.ErrorIs(err, model.ErrFeedNotFound)
+	// user who has left the workspace
+	_, err = s.a.GetCalendarFeed(ctx, "mallory", "bob-token")
+	s.ErrorIs(err, model.ErrFeedNotFound)
+}
+
+func (s *appTestSuite) TestGetTasksByDateRange() {
+	ctx := context.Background()
+	first := model.Date{Year: 2027, Month: time.December, Day: 31}
+	second := model.Date{Year: 2028, Month: time.January, Day: 1}
+	s.taskRepo.On("GetTasksByDateAndStatus", mock.Anything, first, false).Return([]model.TodoTask{{Id: 1251}}, nil).Once()
+	s.taskRepo.On("GetTasksByDateAndStatus", mock.Anything, first, true).Return([]model.TodoTask{{Id: 1252}}, nil).Once()
+	s.taskRepo.On("GetTasksByDateAndStatus", mock.Anything, second, false).Return([]model.TodoTask{}, nil).Once()
+	s.taskRepo.On("GetTasksByDateAndStatus", mock.Anything, second, true).Return([]model.TodoTask{{Id: 1253}}, nil).Once()
+
+	tasks, err := s.a.GetTasksByDateRange(ctx, first, second)
+	s.NoError(err)
+	s.Equal([]model.TodoTask{{Id: 1251}, {Id: 1252}, {Id: 1253}}, tasks)
+
+	_, err = s.a.GetTasksByDateRange(ctx, second, first)
+	s.ErrorIs(err, model.ErrInvalidInput)
+	_, err = s.a.GetTasksByDateRange(ctx, first, model.Date{Year: 2029, Month: time.January, Day: 1})
+	s.ErrorIs(err, model.ErrInvalidInput)
+	_, err = s.a.GetTasksByDateRange(ctx, model.Date{Year: 2027, Month: time.February, Day: 30}, second)
+	s.ErrorIs(err, model.ErrInvalidInput)
+}
+
+func (s *appTestSuite) TestGetCalendarObjects() {
+	ctx := context.Background()
+	s.taskRepo.On("GetTasksByAssignee", mock.Anything, "alice", 0, feedPageSize).Return([]model.TodoTask{{Id: 1301}, {Id: 1302}}, nil).Once()
+	s.calendarRepo.On("GetObjectsByUser", mock.Anything, "alice").Return([]model.CalendarObject{
+		{User: "alice", Name: "a1b2.ics", UID: "a1b2", Task: model.TodoTask{Id: 1302}},
+	}, nil).Once()
+
+	objects, err := s.a.GetCalendarObjects(ctx, "alice")
+	s.NoError(err)
+	s.Equal([]model.CalendarObject{
+		{User: "alice", Name: "task-1301.ics", Task: model.TodoTask{Id: 1301}},
+		{User: "alice", Name: "a1b2.ics", UID: "a1b2", Task: model.TodoTask{Id: 1302}},
+	}, objects)
+}
+
+func (s *appTestSuite) TestGetCalendarObject() {
+	ctx := context.Background()
+	assigned := model.TodoTask{Id: 1303, Assignees: []string{"alice"}}
+	s.calendarRepo.On("GetObjectByName", mock.Anything, "alice", "c3d4.ics").
+		Return(model.CalendarObject{User: "alice", Name: "c3d4.ics", UID: "c3d4", Task: model.TodoTask{Id: 1303}}, nil).Once()
+	s.taskRepo.On("GetTaskById", mock.Anything, 1303).Return(assigned, nil).Twice()
+
+	o, err := s.a.GetCalendarObject(ctx, "alice", "c3d4.ics")
+	s.NoError(err)
+	s.Equal(model.CalendarObject{User: "alice", Name: "c3d4.ics", UID: "c3d4", Task: assigned}, o)
+
+	// tasks created by the other clients have default names
+	s.calendarRepo.On("GetObjectByName", mock.Anything, "alice", "task-1303.ics").Return(model.CalendarObject{}, model.ErrObjectNotFound).Once()
+	o, err = s.a.GetCalendarObject(ctx, "alice", "task-1303.ics")
+	s.NoError(err)
+	s.Equal(model.CalendarObject{User: "alice", Name: "task-1303.ics", Task: assigned}, o)
+
+	s.calendarRepo.On("GetObjectByName", mock.Anything, "bob", "task-1304.ics").Return(model.CalendarObject{}, model.ErrObjectNotFound).Once()
+	s.taskRepo.On("GetTaskById", mock.Anything, 1304).Return(model.TodoTask{Id: 1304, Assignees: []string{"alice"}}, nil).Once()
+	_, err = s.a.GetCalendarObject(ctx, "bob", "task-1304.ics")
+	s.ErrorIs(err, model.ErrObjectNotFound)
+
+	s.calendarRepo.On("GetObjectByName", mock.Anything, "bob", "task-01.ics").Return(model.CalendarObject{}, model.ErrObjectNotFound).Once()
+	_, err = s.a.GetCalendarObject(ctx, "bob", "task-01.ics")
+	s.ErrorIs(err, model.ErrObjectNotFound)
+}
+
+func (s *appTestSuite) TestPutCalendarObject() {
+	ctx := context.Background()
+	date := model.Date{Year: 2027, Month: time.March, Day: 3}
+
+	s.Run("new object creates assigned task", func() {
+		task := model.TodoTask{Title: "From phone", PlanningDate: date}
+		s.calendarRepo.On("GetObjectByName", mock.Anything, "alice", "e5f6.ics").Return(model.CalendarObject{}, model.ErrObjectNotFound).Once()
+		s.taskRepo.On("AddTask", mock.Anything, model.TodoTask{Title: "From phone", PlanningDate: date, State: "todo", Rank: "i"}).
+			Return(model.TodoTask{Id: 1305, Title: "From phone", PlanningDate: date, State: "todo", Version: 1}, nil).Once()
+		assigned := model.TodoTask{Id: 1305, Title: "From phone", PlanningDate: date, State: "todo", Assignees: []string{"alice"}, Version: 2}
+		s.taskRepo.On("AssignTask", mock.Anything, 1305, "alice").Return(assigned, nil).Once()
+		s.calendarRepo.On("AddObject", mock.Anything, model.CalendarObject{User: "alice", Name: "e5f6.ics", UID: "e5f6", Task: assigned}).Return(nil).Once()
+
+		o, created, err := s.a.PutCalendarObject(ctx, model.CalendarObject{User: "alice", Name: "e5f6.ics", UID: "e5f6", Task: task})
+		s.NoError(err)
+		s.True(created)
+		s.Equal(model.CalendarObject{User: "alice", Name: "e5f6.ics", UID: "e5f6", Task: assigned}, o)
+	})
+
+	s.Run("existing object updates its task", func() {
+		current := model.TodoTask{Id: 1306, Title: "Call", PlanningDate: date, State: "todo", Assignees: []string{"alice"}, Version: 4}
+		s.calendarRepo.On("GetObjectByName", mock.Anything, "alice", "task-1306.ics").Return(model.CalendarObject{}, model.ErrObjectNotFound).Once()
+		s.taskRepo.On("GetTaskById", mock.Anything, 1306).Return(current, nil).Twice()
+		done := model.TodoTask{Id: 1306, Title: "Call", PlanningDate: date, Status: true, State: "done", Assignees: []string{"alice"}, Version: 5}
+		s.taskRepo.On("UpdateTask", mock.Anything, 1306, model.TodoTask{Title: "Call", PlanningDate: date, Status: true, State: "done", Rank: "i", Version: 4}).
+			Return(done, nil).Once()
+
+		o, created, err := s.a.PutCalendarObject(ctx, model.CalendarObject{
+			User: "alice",
+			Name: "task-1306.ics",
+			Task: model.TodoTask{Title: "Call", PlanningDate: date, Status: true, Version: 4},
+		})
+		s.NoError(err)
+		s.False(created)
+		s.Equal(done, o.Task)
+	})
+
+	s.Run("default name of missing task", func() {
+		s.calendarRepo.On("GetObjectByName", mock.Anything, "alice", "task-1307.ics").Return(model.CalendarObject{}, model.ErrObjectNotFound).Once()
+		s.taskRepo.On("GetTaskById", mock.Anything, 1307).Return(model.TodoTask{}, model.ErrTaskNotFound).Once()
+
+		_, _, err := s.a.PutCalendarObject(ctx, model.CalendarObject{User: "alice", Name: "task-1307.ics", Task: model.TodoTask{Title: "New", PlanningDate: date}})
+		s.ErrorIs(err, model.ErrForbidden)
+	})
+
+	s.Run("name taken concurrently", func() {
+		s.calendarRepo.On("GetObjectByName", mock.Anything, "alice", "g7h8.ics").Return(model.CalendarObject{}, model.ErrObjectNotFound).Once()
+		s.taskRepo.On("AddTask", mock.Anything, mock.MatchedBy(func(t model.TodoTask) bool { return t.Title == "Twice" })).
+			Return(model.TodoTask{Id: 1308, Title: "Twice", PlanningDate: date}, nil).Once()
+		s.taskRepo.On("AssignTask", mock.Anything, 1308, "alice").Return(model.TodoTask{Id: 1308, Assignees: []string{"alice"}}, nil).Once()
+		s.calendarRepo.On("AddObject", mock.Anything, mock.Anything).Return(model.ErrObjectExists).Once()
+		s.taskRepo.On("DeleteTask", mock.Anything, 1308).Return(nil).Once()
+
+		_, _, err := s.a.PutCalendarObject(ctx, model.CalendarObject{User: "alice", Name: "g7h8.ics", Task: model.TodoTask{Title: "Twice", PlanningDate: date}})
+		s.ErrorIs(err, model.ErrObjectExists)
+	})
+
+	s.Run("invalid name", func() {
+		_, _, err := s.a.PutCalendarObject(ctx, model.CalendarObject{User: "alice", Name: "..", Task: model.TodoTask{Title: "Dots", PlanningDate: date}})
+		s.ErrorIs(err, model.ErrInvalidObject)
+	})
+}
+
+func (s *appTestSuite) TestDeleteCalendarObject() {
+	ctx := context.Background()
+
+	s.Run("shared task is unassigned", func() {
+		s.calendarRepo.On("GetObjectByName", mock.Anything, "bob", "i9j0.ics").
+			Return(model.CalendarObject{User: "bob", Name: "i9j0.ics", UID: "i9j0", Task: model.TodoTask{Id: 1309}}, nil).Once()
+		s.taskRepo.On("GetTaskById", mock.Anything, 1309).Return(model.TodoTask{Id: 1309, Assignees: []string{"alice", "bob"}, Version: 2}, nil).Once()
+		s.taskRepo.On("UnassignTask", mock.Anything, 1309, "bob").Return(model.TodoTask{Id: 1309, Assignees: []string{"alice"}}, nil).Once()
+		s.calendarRepo.On("DeleteObject", mock.Anything, "bob", "i9j0.ics").Return(nil).Once()
+
+		s.NoError(s.a.DeleteCalendarObject(ctx, "bob", "i9j0.ics", 2))
+	})
+
+	s.Run("task of the only assignee is deleted", func() {
+		s.calendarRepo.On("GetObjectByName", mock.Anything, "bob", "task-1310.ics").Return(model.CalendarObject{}, model.ErrObjectNotFound).Once()
+		s.taskRepo.On("GetTaskById", mock.Anything, 1310).Return(model.TodoTask{Id: 1310, Assignees: []string{"bob"}, Version: 1}, nil).Once()
+		s.attachmentRepo.On("GetAttachmentsByTask", mock.Anything, 1310).Return(nil, nil).Once()
+		s.taskRepo.On("DeleteTask", mock.Anything, 1310).Return(nil).Once()
+
+		s.NoError(s.a.DeleteCalendarObject(ctx, "bob", "task-1310.ics", 0))
+	})
+
+	s.Run("stale version", func() {
+		s.calendarRepo.On("GetObjectByName", mock.Anything, "bob", "task-1311.ics").Return(model.CalendarObject{}, model.ErrObjectNotFound).Once()
+		s.taskRepo.On("GetTaskById", mock.Anything, 1311).Return(model.TodoTask{Id: 1311, Assignees: []string{"bob"}, Version: 3}, nil).Once()
+
+		s.ErrorIs(s.a.DeleteCalendarObject(ctx, "bob", "task-1311.ics", 2), model.ErrVersionConflict)
+	})
+}
+
+func (s *appTestSuite) TestImportTasks() {
+	ctx := context.Background()
+	planned := model.Date{Year: 2099, Month: time.April, Day: 11}
+	s.taskRepo.On("GetTasksByDateAndStatus", mock.Anything, planned, false).Return([]model.TodoTask{{Id: 1401, Title: "Existing"}}, nil).Once()
+	s.taskRepo.On("GetTasksByDateAndStatus", mock.Anything, planned, true).Return([]model.TodoTask{}, nil).Once()
+	s.taskRepo.On("AddTask", mock.Anything, model.TodoTask{Title: "Imported", PlanningDate: planned, State: "todo", Rank: "i"}).
+		Return(model.TodoTask{Id: 1402, Title: "Imported", PlanningDate: planned, State: "todo", Rank: "i", Version: 1}, nil).Once()
+	s.taskRepo.On("AddTask", mock.Anything, model.TodoTask{Title: "Done", PlanningDate: planned, Status: true, State: "done", Rank: "i"}).
+		Return(model.TodoTask{Id: 1403, Title: "Done", PlanningDate: planned, Status: true, State: "done", Rank: "i", Version: 1}, nil).Once()
+
+	report, err := s.a.ImportTasks(ctx, []model.ImportRow{
+		{Line: 1, Task: model.TodoTask{Title: "Imported", PlanningDate: planned}},
+		{Line: 2, Task: model.TodoTask{Title: "Existing", PlanningDate: planned}},
+		{Line: 3, Task: model.TodoTask{Title: "Imported", PlanningDate: planned}},
+		{Line: 5, Task: model.TodoTask{PlanningDate: planned}},
+		{Line: 6, Err: model.ErrInvalidInput},
+		{Line: 7, Task: model.TodoTask{Title: "Done", PlanningDate: planned, Status: true}},
+		{Line: 8, Task: model.TodoTask{Title: "Review", State: "review"}},
+	}, model.ImportBestEffort, false)
+	s.Require().NoError(err)
+	s.False(report.DryRun)
+	s.Equal(2, report.Created)
+	s.Equal(5, report.Skipped)
+	s.Require().Len(report.Results, 7)
+	s.Equal(model.ImportResult{Line: 1, Status: model.ImportCreated, Task: model.TodoTask{Id: 1402, Title: "Imported", PlanningDate: planned, State: "todo", Rank: "i", Version: 1}}, report.Results[0])
+	for i, expected := range []error{model.ErrDuplicateTask, model.ErrDuplicateTask, model.ErrInvalidTask, model.ErrInvalidInput} {
+		s.Equal(model.ImportSkipped, report.Results[i+1].Status)
+		s.ErrorIs(report.Results[i+1].Err, expected)
+	}
+	s.Equal(1403, report.Results[5].Task.Id)
+	s.ErrorIs(report.Results[6].Err, model.ErrUnknownState)
+	s.Equal(model.TodoTask{Title: "Review", State: "review"}, report.Results[6].Task)
+
+	// nothing is added in the dry run
+	dry := model.Date{Year: 2099, Month: time.April, Day: 12}
+	s.taskRepo.On("GetTasksByDateAndStatus", mock.Anything, dry, false).Return([]model.TodoTask{}, nil).Once()
+	s.taskRepo.On("GetTasksByDateAndStatus", mock.Anything, dry, true).Return([]model.TodoTask{}, nil).Once()
+	s.taskRepo.On("GetProject", mock.Anything, "home").Return(model.Project{Name: "home", Members: []string{"alice"}}, nil).Twice()
+	s.taskRepo.On("GetProject", mock.Anything, "work").Return(model.Project{}, model.ErrProjectNotFound).Once()
+	warnings := []string{"task has no priority, it is kept in the title as pri:A"}
+	report, err = s.a.ImportTasks(ctx, []model.ImportRow{
+		{Line: 1, Task: model.TodoTask{Title: "Dry", PlanningDate: dry}},
+		{Line: 2, Task: model.TodoTask{Title: "Call pri:A", PlanningDate: dry, Project: "home", Assignees: []string{"alice"}}, Warnings: warnings},
+		{Line: 3, Task: model.TodoTask{Title: "Fix", PlanningDate: dry, Project: "home", Assignees: []string{"bob"}}},
+		{Line: 4, Task: model.TodoTask{Title: "Plan", PlanningDate: dry, Project: "work"}},
+	}, model.ImportBestEffort, true)
+	s.Require().NoError(err)
+	s.Equal(model.ImportBestEffort, report.Mode)
+	s.True(report.DryRun)
+	s.Equal(2, report.Created)
+	s.Require().Len(report.Results, 4)
+	s.Equal(model.ImportResult{Line: 1, Status: model.ImportCreated, Task: model.TodoTask{Title: "Dry", PlanningDate: dry, State: "todo"}}, report.Results[0])
+	s.Equal(model.ImportResult{Line: 2, Status: model.ImportCreated, Warnings: warnings,
+		Task: model.TodoTask{Title: "Call pri:A", PlanningDate: dry, State: "todo", Project: "home", Assignees: []string{"alice"}}}, report.Results[1])
+	s.ErrorIs(report.Results[2].Err, model.ErrNotMember)
+	s.ErrorIs(report.Results[3].Err, model.ErrProjectNotFound)
+
+	_, err = s.a.ImportTasks(ctx, make([]model.ImportRow, maxImportRows+1), model.ImportBestEffort, true)
+	s.ErrorIs(err, model.ErrInvalidInput)
+	_, err = s.a.ImportTasks(ctx, nil, "all_or_nothing", false)
+	s.ErrorIs(err, model.ErrInvalidInput)
+}
+
+func (s *appTestSuite) TestImportTasksInTransaction() {
+	ctx := context.Background()
+	planned := model.Date{Year: 2099, Month: time.April, Day: 13}
+	rows := []model.ImportRow{
+		{Line: 2, Task: model.TodoTask{Title: "First", PlanningDate: planned}},
+		{Line: 3, Task: model.TodoTask{Title: "Second", PlanningDate: planned}},
+		{Line: 4, Task: model.TodoTask{Title: "Done", PlanningDate: planned, Status: true}},
+	}
+
+	// rows are added together with successive ranks in their columns
+	s.taskRepo.On("GetTasksByDateAndStatus", mock.Anything, planned, false).Return([]model.TodoTask{}, nil).Twice()
+	s.taskRepo.On("GetTasksByDateAndStatus", mock.Anything, planned, true).Return([]model.TodoTask{}, nil).Twice()
+	s.taskRepo.On("AddTasks", mock.Anything, []model.TodoTask{
+		{Title: "First", PlanningDate: planned, State: "todo", Rank: "i"},
+		{Title: "Second", PlanningDate: planned, State: "todo", Rank: "r"},
+		{Title: "Done", PlanningDate: planned, Status: true, State: "done", Rank: "i"},
+	}).Return([]model.TodoTask{{Id: 1421}, {Id: 1422}, {Id: 1423}}, nil).Once()
+
+	report, err := s.a.ImportTasks(ctx, rows, model.ImportTransaction, false)
+	s.Require().NoError(err)
+	s.Equal(model.ImportReport{Mode: model.ImportTransaction, Created: 3, Results: []model.ImportResult{
+		{Line: 2, Status: model.ImportCreated, Task: model.TodoTask{Id: 1421}},
+		{Line: 3, Status: model.ImportCreated, Task: model.TodoTask{Id: 1422}},
+		{Line: 4, Status: model.ImportCreated, Task: model.TodoTask{Id: 1423}},
+	}}, report)
+
+	// nothing is added if any row is skipped
+	rows = append(rows, model.ImportRow{Line: 5, Task: model.TodoTask{Title: "First", PlanningDate: planned}})
+	report, err = s.a.ImportTasks(ctx, rows, model.ImportTransaction, false)
+	s.Require().NoError(err)
+	s.Equal(0, report.Created)
+	s.Equal(4, report.Skipped)
+	for _, r := range report.Results[:3] {
+		s.Equal(model.ImportSkipped, r.Status)
+		s.ErrorIs(r.Err, model.ErrImportAborted)
+	}
+	s.Equal(model.ImportResult{Line: 2, Status: model.ImportSkipped, Task: rows[0].Task, Err: model.ErrImportAborted}, report.Results[0])
+	s.ErrorIs(report.Results[3].Err, model.ErrDuplicateTask)
+}
+
+func (s *appTestSuite) TestImportExportedTasks() {
+	ctx := context.Background()
+	past := model.Date{Year: 2024, Month: time.January, Day: 15}
+	s.taskRepo.On("GetTasksByDateAndStatus", mock.Anything, past, false).Return([]model.TodoTask{}, nil).Once()
+	s.taskRepo.On("GetTasksByDateAndStatus", mock.Anything, past, true).Return([]model.TodoTask{}, nil).Once()
+	s.taskRepo.On("AddTask", mock.Anything, model.TodoTask{Title: "Paid", PlanningDate: past, Status: true, State: "done", Rank: "i", Assignees: []string{"alice"}}).
+		Return(model.TodoTask{Id: 1431, Title: "Paid", PlanningDate: past, Status: true, State: "done", Assignees: []string{"alice"}}, nil).Once()
+
+	// done tasks keep their past dates, undone ones must be planned again
+	report, err := s.a.ImportTasks(ctx, []model.ImportRow{
+		{Line: 1, Task: model.TodoTask{Title: "Paid", PlanningDate: past, Status: true, Assignees: []string{"alice"}}},
+		{Line: 2, Task: model.TodoTask{Title: "Late", PlanningDate: past}},
+		{Line: 3, Task: model.TodoTask{Title: "Called", PlanningDate: past, Status: true, Assignees: []string{"phone"}}},
+	}, model.ImportBestEffort, false)
+	s.Require().NoError(err)
+	s.Equal(1, report.Created)
+	s.Require().Len(report.Results, 3)
+	s.Equal(1431, report.Results[0].Task.Id)
+	s.ErrorIs(report.Results[1].Err, model.ErrInvalidTask)
+	s.ErrorIs(report.Results[2].Err, model.ErrNotMember)
+}
+
+func (s *appTestSuite) TestExportTasks() {
+	ctx := context.Background()
+	undone := make([]model.TodoTask, exportPageSize)
+	for i := range undone {
+		undone[i] = model.TodoTask{Id: 1411 + i}
+	}
+	s.taskRepo.On("GetTasksByStatus", mock.Anything, false, 0, exportPageSize).Return(undone, nil).Once()
+	s.taskRepo.On("GetTasksByStatus", mock.Anything, false, exportPageSize, exportPageSize).Return([]model.TodoTask{}, nil).Once()
+	s.taskRepo.On("GetTasksByStatus", mock.Anything, true, 0, exportPageSize).Return([]model.TodoTask{{Id: 1404, Status: true}}, nil).Once()
+
+	tasks, err := s.a.ExportTasks(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(tasks, exportPageSize+1)
+	s.Equal(1411, tasks[0].Id)
+	s.Equal(1404, tasks[exportPageSize].Id)
+}
+
 func TestAppTestSuite(t *testing.T) {
 	suite.Run(t, new(appTestSuite))
 }
@@ -0,0 +1,133 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"todo-list/internal/app/valid"
+	"todo-list/internal/model"
+)
+
+// sniffLen is a number of bytes used by http.DetectContentType
+const sniffLen = 512
+
+// detectContentType returns content type of the file by its first bytes,
+// extension of the file is used only if the content is not recognized
+func detectContentType(name string, head []byte) string {
+	contentType := http.DetectContentType(head)
+	if contentType == "application/octet-stream" {
+		if byExt := mime.TypeByExtension(filepath.Ext(name)); byExt != "" {
+			return byExt
+		}
+	}
+	return contentType
+}
+
+// blobKey generates unique key of the content of the file attached to the task
+func blobKey(taskId int) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("tasks/%d/%s", taskId, hex.EncodeToString(b)), nil
+}
+
+func (a *app) MaxAttachmentSize() int64 {
+	return a.maxAttachmentSize
+}
+
+func (a *app) AddAttachment(ctx context.Context, taskId int, name string, size int64, r io.Reader) (model.Attachment, error) {
+	if err := valid.Attachment(name); err != nil {
+		return model.Attachment{}, errors.Join(model.ErrInvalidAttachment, err)
+	} else if size < 0 {
+		return model.Attachment{}, model.ErrInvalidInput
+	} else if size > a.maxAttachmentSize {
+		return model.Attachment{}, model.ErrAttachmentTooLarge
+	}
+
+	if _, err := a.TaskRepo.GetTaskById(ctx, taskId); err != nil {
+		return model.Attachment{}, err
+	}
+
+	r = io.LimitReader(r, size)
+	head := make([]byte, min(size, sniffLen))
+	if _, err := io.ReadFull(r, head); err != nil {
+		return model.Attachment{}, errors.Join(model.ErrInvalidInput, err)
+	}
+
+	key, err := blobKey(taskId)
+	if err != nil {
+		return model.Attachment{}, errors.Join(model.ErrBlobStore, err)
+	}
+
+	at := model.Attachment{
+		TaskId:      taskId,
+		Name:        name,
+		ContentType: detectContentType(name, head),
+		Size:        size,
+		Key:         key,
+	}
+	if err = a.blobs.Put(ctx, key, io.MultiReader(bytes.NewReader(head), r), size, at.ContentType); err != nil {
+		return model.Attachment{}, err
+	}
+
+	at, err = a.attachments.AddAttachment(ctx, at)
+	if err != nil {
+		if delErr := a.blobs.Delete(ctx, key); delErr != nil {
+			log.Printf("content %s of not added attachment was not deleted: %s\n", key, delErr.Error())
+		}
+		return model.Attachment{}, err
+	}
+	return at, nil
+}
+
+func (a *app) GetAttachmentsByTask(ctx context.Context, taskId int) ([]model.Attachment, error) {
+	if _, err := a.TaskRepo.GetTaskById(ctx, taskId); err != nil {
+		return nil, err
+	}
+	return a.attachments.GetAttachmentsByTask(ctx, taskId)
+}
+
+// taskAttachment returns attachment with given id if it belongs to the task
+func (a *app) taskAttachment(ctx context.Context, taskId int, id int) (model.Attachment, error) {
+	at, err := a.attachments.GetAttachmentById(ctx, id)
+	if err != nil {
+		return model.Attachment{}, err
+	} else if at.TaskId != taskId {
+		return model.Attachment{}, model.ErrAttachmentNotFound
+	}
+	return at, nil
+}
+
+func (a *app) GetAttachment(ctx context.Context, taskId int, id int) (model.Attachment, io.ReadCloser, error) {
+	at, err := a.taskAttachment(ctx, taskId, id)
+	if err != nil {
+		return model.Attachment{}, nil, err
+	}
+
+	content, err := a.blobs.Get(ctx, at.Key)
+	if err != nil {
+		return model.Attachment{}, nil, err
+	}
+	return at, content, nil
+}
+
+func (a *app) DeleteAttachment(ctx context.Context, taskId int, id int) error {
+	at, err := a.taskAttachment(ctx, taskId, id)
+	if err != nil {
+		return err
+	}
+
+	if err = a.attachments.DeleteAttachment(ctx, id); err != nil {
+		return err
+	}
+	return a.blobs.Delete(ctx, at.Key)
+}
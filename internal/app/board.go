@@ -0,0 +1,166 @@
+package app
+
+import (
+	"context"
+	"math"
+	"strings"
+	"todo-list/internal/model"
+)
+
+// rankDigits are digits of the ranks in ascending order, ranks are compared
+// as strings, so the order of the digits matches the order of the bytes
+const rankDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// rankBetween returns rank which is greater than prev and less than next,
+// empty prev is less than any rank and empty next is greater than any rank.
+// Calculated rank never ends with the lowest digit, so there is always place
+// for the rank between it and any lower rank
+func rankBetween(prev string, next string) string {
+	rank := make([]byte, 0, len(prev)+1)
+	bounded := next != "" // next bounds digits while rank is its prefix
+	for i := 0; ; i++ {
+		lo := 0
+		if i < len(prev) {
+			lo = strings.IndexByte(rankDigits, prev[i])
+		}
+		hi := len(rankDigits)
+		if bounded {
+			hi = 0
+			if i < len(next) {
+				hi = strings.IndexByte(rankDigits, next[i])
+			}
+		}
+
+		if hi-lo > 1 {
+			return string(append(rank, rankDigits[(lo+hi)/2]))
+		} else if lo < hi {
+			bounded = false
+		}
+		rank = append(rank, rankDigits[lo])
+	}
+}
+
+// spreadRanks returns n ascending ranks of the same length evenly distributed
+// over all possible values
+func spreadRanks(n int) []string {
+	width, space := 1, len(rankDigits)
+	for space < 2*(n+1) {
+		width++
+		space *= len(rankDigits)
+	}
+	step := space / (n + 1)
+
+	ranks := make([]string, 0, n)
+	for i := 1; i <= n; i++ {
+		rank := make([]byte, width)
+		for j, v := width-1, i*step; j >= 0; j, v = j-1, v/len(rankDigits) {
+			rank[j] = rankDigits[v%len(rankDigits)]
+		}
+		ranks = append(ranks, strings.TrimRight(string(rank), rankDigits[:1]))
+	}
+	return ranks
+}
+
+// lastRank returns rank which places task to the end of the column
+func (a *app) lastRank(ctx context.Context, state string) (string, error) {
+	last, err := a.board.GetLastRank(ctx, state)
+	if err != nil {
+		return "", err
+	}
+	return rankBetween(last, ""), nil
+}
+
+// rebalanceRanks spreads ranks of the tasks in the column evenly keeping their
+// order, it is needed when tasks have equal ranks after concurrent updates
+func (a *app) rebalanceRanks(ctx context.Context, state string) error {
+	tasks, err := a.TaskRepo.GetTasksByState(ctx, state, 0, math.MaxInt32)
+	if err != nil {
+		return err
+	}
+
+	ranks := spreadRanks(len(tasks))
+	newRanks := make(map[int]string, len(tasks))
+	for i, t := range tasks {
+		newRanks[t.Id] = ranks[i]
+	}
+	return a.board.SetRanks(ctx, newRanks)
+}
+
+// moveRank returns rank of the task with given id in the new position
+func (a *app) moveRank(ctx context.Context, id int, m model.Move) (string, error) {
+	anchor := m.Before
+	if m.After != 0 {
+		anchor = m.After
+	}
+	if anchor == 0 {
+		return a.lastRank(ctx, m.State)
+	}
+
+	for rebalanced := false; ; rebalanced = true {
+		prev, rank, next, err := a.board.GetRankNeighbours(ctx, anchor, id, m.State)
+		if err != nil {
+			return "", err
+		}
+
+		if m.Before != 0 {
+			next = rank
+		} else {
+			prev = rank
+		}
+		if next == "" || prev < next {
+			return rankBetween(prev, next), nil
+		} else if rebalanced {
+			return "", model.ErrTaskRepo
+		}
+
+		if err = a.rebalanceRanks(ctx, m.State); err != nil {
+			return "", err
+		}
+	}
+}
+
+func (a *app) MoveTask(ctx context.Context, id int, m model.Move) (model.TodoTask, error) {
+	if (m.Before != 0 && m.After != 0) || m.Before == id || m.After == id {
+		return model.TodoTask{}, model.ErrInvalidInput
+	}
+
+	current, err := a.TaskRepo.GetTaskById(ctx, id)
+	if err != nil {
+		return model.TodoTask{}, err
+	}
+
+	if m.State == "" {
+		m.State = current.State
+	}
+	state, err := a.transit(current, m.State)
+	if err != nil {
+		return model.TodoTask{}, err
+	}
+
+	rank, err := a.moveRank(ctx, id, m)
+	if err != nil {
+		return model.TodoTask{}, err
+	}
+	return a.board.MoveTask(ctx, id, state.Name, state.Done, rank)
+}
+
+func (a *app) GetBoard(ctx context.Context, limit int) (model.Board, error) {
+	if limit < 0 {
+		return model.Board{}, model.ErrInvalidInput
+	}
+
+	board := model.Board{
+		Columns: make([]model.Column, 0, len(a.workflow.States)),
+	}
+	for _, s := range a.workflow.States {
+		tasks, err := a.TaskRepo.GetTasksByState(ctx, s.Name, 0, limit)
+		if err != nil {
+			return model.Board{}, err
+		}
+		board.Columns = append(board.Columns, model.Column{
+			State: s,
+			Tasks: tasks,
+		})
+	}
+	return board, nil
+}
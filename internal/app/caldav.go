@@ -0,0 +1,147 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"todo-list/internal/app/valid"
+	"todo-list/internal/model"
+)
+
+// defaultObjectName returns name of the calendar object of the task which
+// was not created by a CalDAV client
+func defaultObjectName(id int) string {
+	return fmt.Sprintf("task-%d.ics", id)
+}
+
+// parseObjectName returns id of the task with the default name of the
+// calendar object
+func parseObjectName(name string) (int, bool) {
+	s, ok := strings.CutPrefix(name, "task-")
+	if !ok {
+		return 0, false
+	}
+	if s, ok = strings.CutSuffix(s, ".ics"); !ok {
+		return 0, false
+	}
+	id, err := strconv.Atoi(s)
+	if err != nil || defaultObjectName(id) != name {
+		return 0, false
+	}
+	return id, true
+}
+
+func (a *app) GetCalendarObjects(ctx context.Context, user string) ([]model.CalendarObject, error) {
+	tasks, err := a.assignedTasks(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	linked, err := a.calendars.GetObjectsByUser(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	byTask := make(map[int]model.CalendarObject, len(linked))
+	for _, o := range linked {
+		byTask[o.Task.Id] = o
+	}
+	objects := make([]model.CalendarObject, 0, len(tasks))
+	for _, t := range tasks {
+		o, ok := byTask[t.Id]
+		if !ok {
+			o = model.CalendarObject{User: user, Name: defaultObjectName(t.Id)}
+		}
+		o.Task = t
+		objects = append(objects, o)
+	}
+	return objects, nil
+}
+
+func (a *app) GetCalendarObject(ctx context.Context, user string, name string) (model.CalendarObject, error) {
+	o, err := a.calendars.GetObjectByName(ctx, user, name)
+	if errors.Is(err, model.ErrObjectNotFound) {
+		id, ok := parseObjectName(name)
+		if !ok {
+			return model.CalendarObject{}, model.ErrObjectNotFound
+		}
+		o = model.CalendarObject{User: user, Name: name, Task: model.TodoTask{Id: id}}
+	} else if err != nil {
+		return model.CalendarObject{}, err
+	}
+
+	// task which is not assigned to the user anymore left the calendar
+	t, err := a.TaskRepo.GetTaskById(ctx, o.Task.Id)
+	if errors.Is(err, model.ErrTaskNotFound) || (err == nil && !slices.Contains(t.Assignees, user)) {
+		return model.CalendarObject{}, model.ErrObjectNotFound
+	} else if err != nil {
+		return model.CalendarObject{}, err
+	}
+	o.Task = t
+	return o, nil
+}
+
+func (a *app) PutCalendarObject(ctx context.Context, o model.CalendarObject) (model.CalendarObject, bool, error) {
+	if err := valid.CalendarObject(o); err != nil {
+		return model.CalendarObject{}, false, errors.Join(model.ErrInvalidObject, err)
+	}
+
+	current, err := a.GetCalendarObject(ctx, o.User, o.Name)
+	if err == nil {
+		t, err := a.UpdateTask(ctx, current.Task.Id, o.Task)
+		if err != nil {
+			return model.CalendarObject{}, false, err
+		}
+		current.Task = t
+		return current, false, nil
+	} else if !errors.Is(err, model.ErrObjectNotFound) {
+		return model.CalendarObject{}, false, err
+	}
+
+	if _, ok := parseObjectName(o.Name); ok {
+		// default names belong to the tasks created by the other clients
+		return model.CalendarObject{}, false, model.ErrForbidden
+	}
+	t, err := a.AddTask(ctx, o.Task)
+	if err != nil {
+		return model.CalendarObject{}, false, err
+	}
+	if o.Task, err = a.AssignTask(ctx, t.Id, o.User); err != nil {
+		a.discardTask(ctx, t.Id)
+		return model.CalendarObject{}, false, err
+	}
+	if err = a.calendars.AddObject(ctx, o); err != nil {
+		a.discardTask(ctx, t.Id)
+		return model.CalendarObject{}, false, err
+	}
+	return o, true, nil
+}
+
+// discardTask deletes the task which was added for the calendar object which
+// failed to be created, failure to delete it leaves the task without the object
+func (a *app) discardTask(ctx context.Context, id int) {
+	if err := a.TaskRepo.DeleteTask(ctx, id); err != nil {
+		log.Printf("task %d of failed calendar object was not deleted: %s\n", id, err.Error())
+	}
+}
+
+func (a *app) DeleteCalendarObject(ctx context.Context, user string, name string, version int) error {
+	o, err := a.GetCalendarObject(ctx, user, name)
+	if err != nil {
+		return err
+	} else if version != 0 && o.Task.Version != version {
+		return model.ErrVersionConflict
+	}
+
+	if len(o.Task.Assignees) > 1 {
+		if _, err = a.TaskRepo.UnassignTask(ctx, o.Task.Id, user); err != nil {
+			return err
+		}
+		return a.calendars.DeleteObject(ctx, user, name)
+	}
+	// the object is deleted by cascade
+	return a.DeleteTask(ctx, o.Task.Id)
+}
@@ -0,0 +1,175 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"time"
+	"todo-list/internal/app/valid"
+	"todo-list/internal/model"
+)
+
+const (
+	// maxFeedTasks is a max number of tasks in the calendar feed
+	maxFeedTasks = 1000
+
+	// feedPageSize is a number of tasks read from the repo at once
+	feedPageSize = 100
+
+	// maxExportDays is a max number of days in the exported range
+	maxExportDays = 366
+
+	// calendarCredential is a kind of the credential of the calendar feed
+	calendarCredential = "calendar"
+
+	// caldavCredential is a kind of the password of the user in CalDAV
+	// clients, it is issued separately from the token of the read-only feed,
+	// so the leaked link to the feed doesn't give write access to the tasks
+	caldavCredential = "caldav"
+)
+
+// hashToken returns hash of the token which is kept instead of the token
+func hashToken(token string) []byte {
+	h := sha256.Sum256([]byte(token))
+	return h[:]
+}
+
+// generateToken returns random token of the calendar link
+func generateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// issueCredential stores hash of new random secret of given kind of the user
+// and returns the secret, the previous secret of this kind stops working
+func (a *app) issueCredential(ctx context.Context, user string, kind string) (string, error) {
+	if !a.calendarEnabled {
+		return "", model.ErrCalendarDisabled
+	}
+	if err := valid.User(user); err != nil {
+		return "", errors.Join(model.ErrInvalidInput, err)
+	} else if !a.isMember(user) {
+		return "", model.ErrNotMember
+	}
+
+	secret, err := generateToken()
+	if err != nil {
+		return "", errors.Join(model.ErrUnknown, err)
+	}
+	if err = a.credentials.SetCredential(ctx, user, kind, hashToken(secret)); err != nil {
+		return "", err
+	}
+	return secret, nil
+}
+
+// revokeCredential deletes secret of given kind of the user
+func (a *app) revokeCredential(ctx context.Context, user string, kind string) error {
+	if err := valid.User(user); err != nil {
+		return errors.Join(model.ErrInvalidInput, err)
+	}
+	return a.credentials.DeleteCredential(ctx, user, kind)
+}
+
+// checkCredential returns ErrCredentialNotFound if the secret is not the
+// current secret of given kind of the member of the workspace
+func (a *app) checkCredential(ctx context.Context, user string, kind string, secret string) error {
+	if !a.calendarEnabled || valid.User(user) != nil || !a.isMember(user) {
+		return model.ErrCredentialNotFound
+	}
+	hash, err := a.credentials.GetCredential(ctx, user, kind)
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare(hash, hashToken(secret)) != 1 {
+		return model.ErrCredentialNotFound
+	}
+	return nil
+}
+
+func (a *app) IssueCalendarToken(ctx context.Context, user string) (string, error) {
+	return a.issueCredential(ctx, user, calendarCredential)
+}
+
+func (a *app) RevokeCalendarToken(ctx context.Context, user string) error {
+	err := a.revokeCredential(ctx, user, calendarCredential)
+	if errors.Is(err, model.ErrCredentialNotFound) {
+		return model.ErrFeedNotFound
+	}
+	return err
+}
+
+func (a *app) IssueCalDAVPassword(ctx context.Context, user string) (string, error) {
+	return a.issueCredential(ctx, user, caldavCredential)
+}
+
+func (a *app) RevokeCalDAVPassword(ctx context.Context, user string) error {
+	err := a.revokeCredential(ctx, user, caldavCredential)
+	if errors.Is(err, model.ErrCredentialNotFound) {
+		return model.ErrWrongPassword
+	}
+	return err
+}
+
+func (a *app) CheckCalDAVPassword(ctx context.Context, user string, password string) error {
+	err := a.checkCredential(ctx, user, caldavCredential, password)
+	if errors.Is(err, model.ErrCredentialNotFound) {
+		return model.ErrWrongPassword
+	}
+	return err
+}
+
+func (a *app) GetCalendarFeed(ctx context.Context, user string, token string) ([]model.TodoTask, error) {
+	err := a.checkCredential(ctx, user, calendarCredential, token)
+	if errors.Is(err, model.ErrCredentialNotFound) {
+		return nil, model.ErrFeedNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return a.assignedTasks(ctx, user)
+}
+
+// assignedTasks returns at most maxFeedTasks tasks assigned to the user
+func (a *app) assignedTasks(ctx context.Context, user string) ([]model.TodoTask, error) {
+	var tasks []model.TodoTask
+	for offset := 0; offset < maxFeedTasks; offset += feedPageSize {
+		page, err := a.TaskRepo.GetTasksByAssignee(ctx, user, offset, feedPageSize)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, page...)
+		if len(page) < feedPageSize {
+			break
+		}
+	}
+	return tasks, nil
+}
+
+func (a *app) GetTasksByDateRange(ctx context.Context, from model.Date, to model.Date) ([]model.TodoTask, error) {
+	if valid.Date(from) != nil || valid.Date(to) != nil {
+		return nil, model.ErrInvalidInput
+	}
+	start := time.Date(from.Year, from.Month, from.Day, 0, 0, 0, 0, time.UTC)
+	end := time.Date(to.Year, to.Month, to.Day, 0, 0, 0, 0, time.UTC)
+	if end.Before(start) || end.Sub(start) >= maxExportDays*24*time.Hour {
+		return nil, model.ErrInvalidInput
+	}
+
+	var tasks []model.TodoTask
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		date := model.Date{Year: day.Year(), Month: day.Month(), Day: day.Day()}
+		for _, status := range []bool{false, true} {
+			dayTasks, err := a.TaskRepo.GetTasksByDateAndStatus(ctx, date, status)
+			if err != nil {
+				return nil, err
+			}
+			tasks = append(tasks, dayTasks...)
+		}
+	}
+	return tasks, nil
+}
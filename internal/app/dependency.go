@@ -0,0 +1,78 @@
+package app
+
+import (
+	"context"
+	"todo-list/internal/model"
+)
+
+// blocks returns true if task with id from transitively blocks task with id to
+func blocks(deps []model.Dependency, from int, to int) bool {
+	next := make(map[int][]int, len(deps))
+	for _, d := range deps {
+		next[d.BlockerId] = append(next[d.BlockerId], d.BlockedId)
+	}
+
+	visited := map[int]bool{from: true}
+	queue := []int{from}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, n := range next[id] {
+			if n == to {
+				return true
+			} else if !visited[n] {
+				visited[n] = true
+				queue = append(queue, n)
+			}
+		}
+	}
+	return false
+}
+
+func (a *app) BlockTask(ctx context.Context, blockerId int, blockedId int) (model.TodoTask, error) {
+	if blockerId == blockedId {
+		return model.TodoTask{}, model.ErrDependencyCycle
+	}
+
+	if _, err := a.TaskRepo.GetTaskById(ctx, blockerId); err != nil {
+		return model.TodoTask{}, err
+	}
+
+	// graph of the blocked task contains all tasks blocked by it, so the new
+	// link makes a cycle if the blocker is one of them. The repo checks it
+	// again while adding the link, since links can be added concurrently
+	graph, err := a.TaskRepo.GetDependencyGraph(ctx, blockedId)
+	if err != nil {
+		return model.TodoTask{}, err
+	} else if len(graph.Tasks) == 0 {
+		return model.TodoTask{}, model.ErrTaskNotFound
+	} else if blocks(graph.Dependencies, blockedId, blockerId) {
+		return model.TodoTask{}, model.ErrDependencyCycle
+	}
+
+	if err = a.dependencies.AddDependency(ctx, blockerId, blockedId); err != nil {
+		return model.TodoTask{}, err
+	}
+	return a.TaskRepo.GetTaskById(ctx, blockedId)
+}
+
+func (a *app) UnblockTask(ctx context.Context, blockerId int, blockedId int) (model.TodoTask, error) {
+	if _, err := a.TaskRepo.GetTaskById(ctx, blockedId); err != nil {
+		return model.TodoTask{}, err
+	}
+
+	if err := a.dependencies.DeleteDependency(ctx, blockerId, blockedId); err != nil {
+		return model.TodoTask{}, err
+	}
+	return a.TaskRepo.GetTaskById(ctx, blockedId)
+}
+
+func (a *app) GetDependencyGraph(ctx context.Context, id int) (model.DependencyGraph, error) {
+	graph, err := a.TaskRepo.GetDependencyGraph(ctx, id)
+	if err != nil {
+		return model.DependencyGraph{}, err
+	} else if len(graph.Tasks) == 0 {
+		return model.DependencyGraph{}, model.ErrTaskNotFound
+	}
+	return graph, nil
+}
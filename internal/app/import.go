@@ -0,0 +1,177 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"todo-list/internal/app/valid"
+	"todo-list/internal/model"
+)
+
+const (
+	// maxImportRows is a max number of rows of the imported file
+	maxImportRows = 1000
+
+	// maxExportTasks is a max number of exported tasks
+	maxExportTasks = 10000
+
+	// exportPageSize is a number of tasks read from the repo at once
+	exportPageSize = 100
+)
+
+func (a *app) ImportTasks(ctx context.Context, rows []model.ImportRow, mode string, dryRun bool) (model.ImportReport, error) {
+	if len(rows) > maxImportRows || (mode != model.ImportBestEffort && mode != model.ImportTransaction) {
+		return model.ImportReport{}, model.ErrInvalidInput
+	}
+
+	report := model.ImportReport{Mode: mode, DryRun: dryRun, Results: make([]model.ImportResult, 0, len(rows))}
+	planned := make(map[model.Date]map[string]struct{})
+	for _, row := range rows {
+		t, err := a.checkRow(ctx, row, planned)
+		if err == nil && mode == model.ImportBestEffort && !dryRun {
+			if t.Rank, err = a.lastRank(ctx, t.State); err == nil {
+				t, err = a.TaskRepo.AddTask(ctx, t)
+			}
+		}
+		if err != nil {
+			report.Skipped++
+			report.Results = append(report.Results, model.ImportResult{Line: row.Line, Status: model.ImportSkipped, Task: row.Task, Warnings: row.Warnings, Err: err})
+			continue
+		}
+		report.Created++
+		report.Results = append(report.Results, model.ImportResult{Line: row.Line, Status: model.ImportCreated, Task: t, Warnings: row.Warnings})
+	}
+	if mode == model.ImportBestEffort {
+		return report, nil
+	}
+
+	// rows of the transaction are added only if none of them is skipped
+	if report.Skipped > 0 {
+		for i, r := range report.Results {
+			if r.Status == model.ImportCreated {
+				report.Results[i] = model.ImportResult{Line: r.Line, Status: model.ImportSkipped, Task: rows[i].Task, Warnings: r.Warnings, Err: model.ErrImportAborted}
+			}
+		}
+		report.Created, report.Skipped = 0, len(report.Results)
+		return report, nil
+	} else if dryRun || len(report.Results) == 0 {
+		return report, nil
+	}
+
+	tasks := make([]model.TodoTask, 0, len(report.Results))
+	last := make(map[string]string)
+	for _, r := range report.Results {
+		t := r.Task
+		if rank, ok := last[t.State]; ok {
+			t.Rank = rankBetween(rank, "")
+		} else {
+			var err error
+			if t.Rank, err = a.lastRank(ctx, t.State); err != nil {
+				return model.ImportReport{}, err
+			}
+		}
+		last[t.State] = t.Rank
+		tasks = append(tasks, t)
+	}
+	added, err := a.TaskRepo.AddTasks(ctx, tasks)
+	if err != nil {
+		return model.ImportReport{}, err
+	}
+	for i := range report.Results {
+		report.Results[i].Task = added[i]
+	}
+	return report, nil
+}
+
+// checkRow returns the task of the row which passes the same checks as in
+// AddTask, except that done tasks may keep their past dates, so the exported
+// tasks can be imported back. Assignees of the task must be the members of
+// the workspace and of its project, the project is read here, so the rows of
+// the transaction and of the dry run are checked before adding, and the repo
+// checks it again on adding. Titles of the tasks planned on the dates are
+// read once for all rows, so the rows repeating the task of the previous row
+// are skipped too
+func (a *app) checkRow(ctx context.Context, row model.ImportRow, planned map[model.Date]map[string]struct{}) (model.TodoTask, error) {
+	if row.Err != nil {
+		return model.TodoTask{}, row.Err
+	}
+
+	t := row.Task
+	if t.PlanningDate == (model.Date{}) {
+		t.PlanningDate = today()
+	}
+	if t.State == "" {
+		t.State = defaultState(a.workflow, t.Status)
+	}
+	state, ok := findState(a.workflow, t.State)
+	if !ok {
+		return model.TodoTask{}, model.ErrUnknownState
+	}
+	t.Status = state.Done
+
+	check := valid.TodoTask
+	if t.Status {
+		check = valid.UpdatedTodoTask
+	}
+	if err := check(t); err != nil {
+		return model.TodoTask{}, errors.Join(model.ErrInvalidTask, err)
+	}
+	for _, assignee := range t.Assignees {
+		if err := valid.User(assignee); err != nil {
+			return model.TodoTask{}, errors.Join(model.ErrInvalidInput, err)
+		} else if !a.isMember(assignee) {
+			return model.TodoTask{}, model.ErrNotMember
+		}
+	}
+	if t.Project != "" {
+		p, err := a.TaskRepo.GetProject(ctx, t.Project)
+		if err != nil {
+			return model.TodoTask{}, err
+		}
+		for _, assignee := range t.Assignees {
+			if !slices.Contains(p.Members, assignee) {
+				return model.TodoTask{}, model.ErrNotMember
+			}
+		}
+	}
+
+	titles, ok := planned[t.PlanningDate]
+	if !ok {
+		titles = make(map[string]struct{})
+		for _, status := range []bool{false, true} {
+			tasks, err := a.TaskRepo.GetTasksByDateAndStatus(ctx, t.PlanningDate, status)
+			if err != nil {
+				return model.TodoTask{}, err
+			}
+			for _, planned := range tasks {
+				titles[planned.Title] = struct{}{}
+			}
+		}
+		planned[t.PlanningDate] = titles
+	}
+	if _, ok = titles[t.Title]; ok {
+		return model.TodoTask{}, model.ErrDuplicateTask
+	}
+	titles[t.Title] = struct{}{}
+	return t, nil
+}
+
+func (a *app) ExportTasks(ctx context.Context) ([]model.TodoTask, error) {
+	var tasks []model.TodoTask
+	for _, status := range []bool{false, true} {
+		for offset := 0; len(tasks) < maxExportTasks; offset += exportPageSize {
+			page, err := a.TaskRepo.GetTasksByStatus(ctx, status, offset, exportPageSize)
+			if err != nil {
+				return nil, err
+			}
+			tasks = append(tasks, page...)
+			if len(page) < exportPageSize {
+				break
+			}
+		}
+	}
+	if len(tasks) > maxExportTasks {
+		tasks = tasks[:maxExportTasks]
+	}
+	return tasks, nil
+}
@@ -11,6 +11,48 @@ type TaskRepo struct {
 	mock.Mock
 }
 
+// AddProject provides a mock function with given fields: ctx, p
+func (_m *TaskRepo) AddProject(ctx context.Context, p model.Project) (model.Project, error) {
+	ret := _m.Called(ctx, p)
+
+	var r0 model.Project
+	if rf, ok := ret.Get(0).(func(context.Context, model.Project) model.Project); ok {
+		r0 = rf(ctx, p)
+	} else {
+		r0 = ret.Get(0).(model.Project)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.Project) error); ok {
+		r1 = rf(ctx, p)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddProjectMember provides a mock function with given fields: ctx, name, member
+func (_m *TaskRepo) AddProjectMember(ctx context.Context, name string, member string) (model.Project, error) {
+	ret := _m.Called(ctx, name, member)
+
+	var r0 model.Project
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) model.Project); ok {
+		r0 = rf(ctx, name, member)
+	} else {
+		r0 = ret.Get(0).(model.Project)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, name, member)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AddTask provides a mock function with given fields: ctx, t
 func (_m *TaskRepo) AddTask(ctx context.Context, t model.TodoTask) (model.TodoTask, error) {
 	ret := _m.Called(ctx, t)
@@ -32,6 +74,50 @@ func (_m *TaskRepo) AddTask(ctx context.Context, t model.TodoTask) (model.TodoTa
 	return r0, r1
 }
 
+// AddTasks provides a mock function with given fields: ctx, tasks
+func (_m *TaskRepo) AddTasks(ctx context.Context, tasks []model.TodoTask) ([]model.TodoTask, error) {
+	ret := _m.Called(ctx, tasks)
+
+	var r0 []model.TodoTask
+	if rf, ok := ret.Get(0).(func(context.Context, []model.TodoTask) []model.TodoTask); ok {
+		r0 = rf(ctx, tasks)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.TodoTask)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []model.TodoTask) error); ok {
+		r1 = rf(ctx, tasks)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AssignTask provides a mock function with given fields: ctx, id, assignee
+func (_m *TaskRepo) AssignTask(ctx context.Context, id int, assignee string) (model.TodoTask, error) {
+	ret := _m.Called(ctx, id, assignee)
+
+	var r0 model.TodoTask
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) model.TodoTask); ok {
+		r0 = rf(ctx, id, assignee)
+	} else {
+		r0 = ret.Get(0).(model.TodoTask)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, id, assignee)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteTask provides a mock function with given fields: ctx, id
 func (_m *TaskRepo) DeleteTask(ctx context.Context, id int) error {
 	ret := _m.Called(ctx, id)
@@ -46,6 +132,115 @@ func (_m *TaskRepo) DeleteTask(ctx context.Context, id int) error {
 	return r0
 }
 
+// ForceUpdateTask provides a mock function with given fields: ctx, id, t
+func (_m *TaskRepo) ForceUpdateTask(ctx context.Context, id int, t model.TodoTask) (model.TodoTask, error) {
+	ret := _m.Called(ctx, id, t)
+
+	var r0 model.TodoTask
+	if rf, ok := ret.Get(0).(func(context.Context, int, model.TodoTask) model.TodoTask); ok {
+		r0 = rf(ctx, id, t)
+	} else {
+		r0 = ret.Get(0).(model.TodoTask)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, model.TodoTask) error); ok {
+		r1 = rf(ctx, id, t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDependencyGraph provides a mock function with given fields: ctx, id
+func (_m *TaskRepo) GetDependencyGraph(ctx context.Context, id int) (model.DependencyGraph, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 model.DependencyGraph
+	if rf, ok := ret.Get(0).(func(context.Context, int) model.DependencyGraph); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(model.DependencyGraph)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOverdueTasks provides a mock function with given fields: ctx, offset, limit
+func (_m *TaskRepo) GetOverdueTasks(ctx context.Context, offset int, limit int) ([]model.TodoTask, error) {
+	ret := _m.Called(ctx, offset, limit)
+
+	var r0 []model.TodoTask
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []model.TodoTask); ok {
+		r0 = rf(ctx, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.TodoTask)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProject provides a mock function with given fields: ctx, name
+func (_m *TaskRepo) GetProject(ctx context.Context, name string) (model.Project, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 model.Project
+	if rf, ok := ret.Get(0).(func(context.Context, string) model.Project); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(model.Project)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProjects provides a mock function with given fields: ctx
+func (_m *TaskRepo) GetProjects(ctx context.Context) ([]model.Project, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []model.Project
+	if rf, ok := ret.Get(0).(func(context.Context) []model.Project); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Project)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTaskById provides a mock function with given fields: ctx, id
 func (_m *TaskRepo) GetTaskById(ctx context.Context, id int) (model.TodoTask, error) {
 	ret := _m.Called(ctx, id)
@@ -90,6 +285,29 @@ func (_m *TaskRepo) GetTaskByText(ctx context.Context, text string) ([]model.Tod
 	return r0, r1
 }
 
+// GetTasksByAssignee provides a mock function with given fields: ctx, assignee, offset, limit
+func (_m *TaskRepo) GetTasksByAssignee(ctx context.Context, assignee string, offset int, limit int) ([]model.TodoTask, error) {
+	ret := _m.Called(ctx, assignee, offset, limit)
+
+	var r0 []model.TodoTask
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []model.TodoTask); ok {
+		r0 = rf(ctx, assignee, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.TodoTask)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, assignee, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTasksByDateAndStatus provides a mock function with given fields: ctx, date, status
 func (_m *TaskRepo) GetTasksByDateAndStatus(ctx context.Context, date model.Date, status bool) ([]model.TodoTask, error) {
 	ret := _m.Called(ctx, date, status)
@@ -113,6 +331,29 @@ func (_m *TaskRepo) GetTasksByDateAndStatus(ctx context.Context, date model.Date
 	return r0, r1
 }
 
+// GetTasksByState provides a mock function with given fields: ctx, state, offset, limit
+func (_m *TaskRepo) GetTasksByState(ctx context.Context, state string, offset int, limit int) ([]model.TodoTask, error) {
+	ret := _m.Called(ctx, state, offset, limit)
+
+	var r0 []model.TodoTask
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []model.TodoTask); ok {
+		r0 = rf(ctx, state, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.TodoTask)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, state, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTasksByStatus provides a mock function with given fields: ctx, status, offset, limit
 func (_m *TaskRepo) GetTasksByStatus(ctx context.Context, status bool, offset int, limit int) ([]model.TodoTask, error) {
 	ret := _m.Called(ctx, status, offset, limit)
@@ -136,6 +377,69 @@ func (_m *TaskRepo) GetTasksByStatus(ctx context.Context, status bool, offset in
 	return r0, r1
 }
 
+// RemoveProjectMember provides a mock function with given fields: ctx, name, member
+func (_m *TaskRepo) RemoveProjectMember(ctx context.Context, name string, member string) (model.Project, error) {
+	ret := _m.Called(ctx, name, member)
+
+	var r0 model.Project
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) model.Project); ok {
+		r0 = rf(ctx, name, member)
+	} else {
+		r0 = ret.Get(0).(model.Project)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, name, member)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetTaskProject provides a mock function with given fields: ctx, id, project
+func (_m *TaskRepo) SetTaskProject(ctx context.Context, id int, project string) (model.TodoTask, error) {
+	ret := _m.Called(ctx, id, project)
+
+	var r0 model.TodoTask
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) model.TodoTask); ok {
+		r0 = rf(ctx, id, project)
+	} else {
+		r0 = ret.Get(0).(model.TodoTask)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, id, project)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnassignTask provides a mock function with given fields: ctx, id, assignee
+func (_m *TaskRepo) UnassignTask(ctx context.Context, id int, assignee string) (model.TodoTask, error) {
+	ret := _m.Called(ctx, id, assignee)
+
+	var r0 model.TodoTask
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) model.TodoTask); ok {
+		r0 = rf(ctx, id, assignee)
+	} else {
+		r0 = ret.Get(0).(model.TodoTask)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, id, assignee)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateTask provides a mock function with given fields: ctx, id, t
 func (_m *TaskRepo) UpdateTask(ctx context.Context, id int, t model.TodoTask) (model.TodoTask, error) {
 	ret := _m.Called(ctx, id, t)
@@ -0,0 +1,126 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"time"
+	"todo-list/internal/app/valid"
+	"todo-list/internal/model"
+)
+
+const (
+	// defaultOutboxRetention is a time for which published events are kept
+	// in the outbox if it is not configured
+	defaultOutboxRetention = 7 * 24 * time.Hour
+
+	// outboxBatch is a max number of events published in one run
+	outboxBatch = 100
+
+	// outboxLease is a time for which claimed event is not claimed again,
+	// events of the crashed server are published after it
+	outboxLease = time.Minute
+
+	// outboxRetryDelay is a delay before the first retry of the event which
+	// was not published, it doubles with every next attempt up to
+	// maxOutboxRetryDelay, events are retried until they are published
+	outboxRetryDelay    = 5 * time.Second
+	maxOutboxRetryDelay = 10 * time.Minute
+)
+
+func (a *app) RelayEvents(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	due, err := a.outbox.ClaimEvents(ctx, now, now.Add(outboxLease), outboxBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for _, e := range due {
+		// not published events stay claimed and are retried after the lease
+		if err = ctx.Err(); err != nil {
+			return published, err
+		}
+
+		// the event is published to all sinks again if any of them fails,
+		// so the sinks which have received it skip it by its key
+		var errs []error
+		for _, sink := range a.sinks {
+			if err = sink.Publish(ctx, e); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		if err = errors.Join(errs...); err != nil {
+			log.Printf("event %d publishing error: %s\n", e.Id, err.Error())
+			if err = a.outbox.RetryEvent(ctx, e.Id, err.Error(), now.Add(backoff(e.Attempts, outboxRetryDelay, maxOutboxRetryDelay))); err != nil {
+				return published, err
+			}
+			continue
+		}
+
+		if err = a.outbox.MarkPublished(ctx, e.Id, now); err != nil {
+			return published, err
+		}
+		published++
+	}
+
+	if _, err = a.outbox.PurgeEvents(ctx, now.Add(-a.outboxRetention)); err != nil {
+		return published, err
+	}
+	return published, nil
+}
+
+// streamedEvents are types of the events passed to the subscribers, completion
+// of the task is streamed as its update
+var streamedEvents = []string{model.EventTaskCreated, model.EventTaskUpdated, model.EventTaskDeleted}
+
+// inScope returns true if the task belongs to the scope
+func inScope(scope model.EventScope, t model.TodoTask) bool {
+	if scope.Assignee != "" && !slices.Contains(t.Assignees, scope.Assignee) {
+		return false
+	} else if scope.Date != (model.Date{}) && scope.Date != t.PlanningDate {
+		return false
+	} else if scope.Project != "" && scope.Project != t.Project {
+		return false
+	}
+	return true
+}
+
+// matchScope returns true if the event is streamed and the task belongs to
+// the scope before or after the change, so the subscriber also sees the task
+// leaving the scope, e.g. moved to another day or unassigned
+func matchScope(scope model.EventScope, event string, t model.TodoTask, prev *model.TodoTask) bool {
+	if !slices.Contains(streamedEvents, event) {
+		return false
+	}
+	return inScope(scope, t) || (prev != nil && inScope(scope, *prev))
+}
+
+func (a *app) SubscribeEvents(ctx context.Context, scope model.EventScope, afterId int) (model.EventStream, error) {
+	if scope.Assignee != "" {
+		if err := valid.User(scope.Assignee); err != nil {
+			return model.EventStream{}, errors.Join(model.ErrInvalidInput, err)
+		} else if !a.isMember(scope.Assignee) {
+			return model.EventStream{}, model.ErrNotMember
+		}
+	}
+	if scope.Date != (model.Date{}) {
+		if err := valid.Date(scope.Date); err != nil {
+			return model.EventStream{}, errors.Join(model.ErrInvalidInput, err)
+		}
+	}
+	if scope.Project != "" {
+		if err := valid.ProjectName(scope.Project); err != nil {
+			return model.EventStream{}, errors.Join(model.ErrInvalidInput, err)
+		}
+	}
+	if afterId < 0 {
+		return model.EventStream{}, model.ErrInvalidInput
+	}
+
+	return a.broker.Subscribe(ctx, afterId, func(event string, t model.TodoTask, prev *model.TodoTask) bool {
+		return matchScope(scope, event, t, prev)
+	}), nil
+}
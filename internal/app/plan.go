@@ -0,0 +1,27 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"todo-list/internal/app/valid"
+	"todo-list/internal/model"
+)
+
+func (a *app) ReorderDay(ctx context.Context, date model.Date, ids []int) ([]model.TodoTask, error) {
+	if err := valid.Date(date); err != nil {
+		return nil, errors.Join(model.ErrInvalidInput, err)
+	}
+
+	seen := make(map[int]struct{}, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			return nil, model.ErrInvalidInput
+		}
+		seen[id] = struct{}{}
+	}
+
+	if err := a.plans.ReorderDay(ctx, date, ids); err != nil {
+		return nil, err
+	}
+	return a.plans.GetTasksByDate(ctx, date)
+}
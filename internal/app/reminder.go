@@ -0,0 +1,151 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+	"todo-list/internal/app/valid"
+	"todo-list/internal/model"
+)
+
+const (
+	// defaultReminderAttempts is a number of attempts to deliver the reminder
+	// if it is not configured
+	defaultReminderAttempts = 5
+
+	// reminderBatch is a max number of reminders delivered in one run
+	reminderBatch = 100
+
+	// reminderLease is a time for which claimed reminder is not claimed
+	// again, reminders of the crashed server are retried after it
+	reminderLease = 5 * time.Minute
+
+	// reminderRetryDelay is a delay before the first retry of failed
+	// delivery, it doubles with every next attempt up to maxReminderRetryDelay
+	reminderRetryDelay    = time.Minute
+	maxReminderRetryDelay = time.Hour
+)
+
+// backoff returns delay before the next attempt after given number of failed
+// attempts, the delay starts from base and doubles up to limit
+func backoff(attempts int, base time.Duration, limit time.Duration) time.Duration {
+	d := base
+	for i := 1; i < attempts && d < limit; i++ {
+		d *= 2
+	}
+	return min(d, limit)
+}
+
+func (a *app) AddReminder(ctx context.Context, taskId int, r model.Reminder) (model.Reminder, error) {
+	if err := valid.Reminder(r); err != nil {
+		return model.Reminder{}, errors.Join(model.ErrInvalidReminder, err)
+	} else if _, ok := a.notifiers[r.Channel]; !ok {
+		return model.Reminder{}, errors.Join(model.ErrInvalidReminder, fmt.Errorf("channel %s is not configured", r.Channel))
+	} else if !r.At.IsZero() && r.At.Before(time.Now()) {
+		return model.Reminder{}, errors.Join(model.ErrInvalidReminder, errors.New("time of the reminder has passed"))
+	}
+
+	if _, err := a.TaskRepo.GetTaskById(ctx, taskId); err != nil {
+		return model.Reminder{}, err
+	}
+
+	r.TaskId = taskId
+	r.At = r.At.UTC()
+	return a.reminders.AddReminder(ctx, r)
+}
+
+func (a *app) GetRemindersByTask(ctx context.Context, taskId int) ([]model.Reminder, error) {
+	if _, err := a.TaskRepo.GetTaskById(ctx, taskId); err != nil {
+		return nil, err
+	}
+	return a.reminders.GetRemindersByTask(ctx, taskId)
+}
+
+// taskReminder returns reminder with given id if it belongs to the task
+func (a *app) taskReminder(ctx context.Context, taskId int, id int) (model.Reminder, error) {
+	r, err := a.reminders.GetReminderById(ctx, id)
+	if err != nil {
+		return model.Reminder{}, err
+	} else if r.TaskId != taskId {
+		return model.Reminder{}, model.ErrReminderNotFound
+	}
+	return r, nil
+}
+
+func (a *app) DeleteReminder(ctx context.Context, taskId int, id int) error {
+	if _, err := a.taskReminder(ctx, taskId, id); err != nil {
+		return err
+	}
+	return a.reminders.DeleteReminder(ctx, id)
+}
+
+func (a *app) GetDeliveriesByReminder(ctx context.Context, taskId int, id int, offset int, limit int) ([]model.Delivery, error) {
+	if limit < 0 || offset < 0 {
+		return nil, model.ErrInvalidInput
+	}
+
+	if _, err := a.taskReminder(ctx, taskId, id); err != nil {
+		return nil, err
+	}
+	return a.reminders.GetDeliveriesByReminder(ctx, id, offset, limit)
+}
+
+// notify sends the reminder through the notifier of its channel
+func (a *app) notify(ctx context.Context, r model.Reminder) error {
+	t, err := a.TaskRepo.GetTaskById(ctx, r.TaskId)
+	if err != nil {
+		return err
+	}
+
+	notifier, ok := a.notifiers[r.Channel]
+	if !ok {
+		return errors.Join(model.ErrNotifier, fmt.Errorf("channel %s is not configured", r.Channel))
+	}
+	return notifier.Notify(ctx, r, t)
+}
+
+func (a *app) DeliverReminders(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	due, err := a.reminders.ClaimDueReminders(ctx, now, now.Add(reminderLease), reminderBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, r := range due {
+		// not delivered reminders stay claimed and are retried after the lease
+		if err = ctx.Err(); err != nil {
+			return sent, err
+		}
+
+		err = a.notify(ctx, r)
+		if errors.Is(err, model.ErrTaskNotFound) { // reminder is deleted with its task
+			continue
+		}
+
+		d := model.Delivery{
+			ReminderId: r.Id,
+			Attempt:    r.Attempts,
+		}
+		status, retryAt := model.ReminderSent, now
+		if err != nil {
+			log.Printf("reminder %d delivery error: %s\n", r.Id, err.Error())
+			d.Error = err.Error()
+			if r.Attempts >= a.reminderAttempts {
+				status = model.ReminderFailed
+			} else {
+				status, retryAt = model.ReminderPending, now.Add(backoff(r.Attempts, reminderRetryDelay, maxReminderRetryDelay))
+			}
+		}
+
+		if err = a.reminders.RecordDelivery(ctx, d, status, retryAt); err != nil {
+			return sent, err
+		}
+		if status == model.ReminderSent {
+			sent++
+		}
+	}
+	return sent, nil
+}
@@ -0,0 +1,150 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"time"
+	"todo-list/internal/app/valid"
+	"todo-list/internal/model"
+)
+
+// Policies of the rollover of undone tasks which planning date has passed
+const (
+	// RolloverMove moves overdue tasks to the current day
+	RolloverMove = "move"
+
+	// RolloverFlag keeps planning date of overdue tasks and only flags them
+	RolloverFlag = "flag"
+
+	// RolloverOff disables the rollover
+	RolloverOff = "off"
+)
+
+// maxSnoozeDays is a limit of days the task can be snoozed for at once
+const maxSnoozeDays = 366
+
+// today returns current date in UTC which is used by validation of the tasks
+func today() model.Date {
+	return dateOf(time.Now().UTC())
+}
+
+// dateOf returns date of the time
+func dateOf(t time.Time) model.Date {
+	var d model.Date
+	d.Year, d.Month, d.Day = t.Date()
+	return d
+}
+
+// timeOf returns midnight of the date in UTC
+func timeOf(d model.Date) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC)
+}
+
+// snoozeBase returns date from which the task is snoozed, overdue tasks are
+// snoozed from the current date
+func snoozeBase(t model.TodoTask) time.Time {
+	planned, now := timeOf(t.PlanningDate), timeOf(today())
+	if planned.Before(now) {
+		return now
+	}
+	return planned
+}
+
+func (a *app) RolloverOverdue(ctx context.Context) (int, error) {
+	switch a.rolloverPolicy {
+	case RolloverMove:
+		moves, err := a.schedule.MoveOverdue(ctx, today())
+		if err != nil {
+			return 0, err
+		}
+		return len(moves), nil
+	case RolloverFlag:
+		return a.schedule.FlagOverdue(ctx, today())
+	default:
+		return 0, nil
+	}
+}
+
+func (a *app) GetOverdueTasks(ctx context.Context, offset int, limit int) ([]model.TodoTask, error) {
+	if limit < 0 || offset < 0 {
+		return nil, model.ErrInvalidInput
+	}
+	return a.TaskRepo.GetOverdueTasks(ctx, offset, limit)
+}
+
+func (a *app) GetHistoryByTask(ctx context.Context, taskId int, offset int, limit int) ([]model.HistoryEntry, error) {
+	if limit < 0 || offset < 0 {
+		return nil, model.ErrInvalidInput
+	}
+
+	if _, err := a.TaskRepo.GetTaskById(ctx, taskId); err != nil {
+		return nil, err
+	}
+	return a.schedule.GetHistoryByTask(ctx, taskId, offset, limit)
+}
+
+// rescheduleTask moves the task to the date and writes the move to the
+// history, task which is already planned on the date is returned as is
+func (a *app) rescheduleTask(ctx context.Context, t model.TodoTask, date model.Date, action string) (model.TodoTask, error) {
+	if err := valid.PlanningDate(date); err != nil {
+		return model.TodoTask{}, errors.Join(model.ErrInvalidInput, err)
+	} else if t.PlanningDate == date {
+		return t, nil
+	}
+
+	if _, err := a.schedule.RescheduleTask(ctx, t.Id, date, action); err != nil {
+		return model.TodoTask{}, err
+	}
+	return a.TaskRepo.GetTaskById(ctx, t.Id)
+}
+
+func (a *app) SnoozeTask(ctx context.Context, id int, days int) (model.TodoTask, error) {
+	if days < 1 || days > maxSnoozeDays {
+		return model.TodoTask{}, model.ErrInvalidInput
+	}
+
+	t, err := a.TaskRepo.GetTaskById(ctx, id)
+	if err != nil {
+		return model.TodoTask{}, err
+	}
+	return a.rescheduleTask(ctx, t, dateOf(snoozeBase(t).AddDate(0, 0, days)), model.ActionSnooze)
+}
+
+func (a *app) RescheduleToWeekday(ctx context.Context, id int, weekday time.Weekday) (model.TodoTask, error) {
+	if weekday < time.Sunday || weekday > time.Saturday {
+		return model.TodoTask{}, model.ErrInvalidInput
+	}
+
+	t, err := a.TaskRepo.GetTaskById(ctx, id)
+	if err != nil {
+		return model.TodoTask{}, err
+	}
+
+	// the nearest given day of the week strictly after the base date
+	base := snoozeBase(t)
+	days := (int(weekday)-int(base.Weekday())+6)%7 + 1
+	return a.rescheduleTask(ctx, t, dateOf(base.AddDate(0, 0, days)), model.ActionReschedule)
+}
+
+func (a *app) RescheduleTask(ctx context.Context, id int, date model.Date) (model.TodoTask, error) {
+	t, err := a.TaskRepo.GetTaskById(ctx, id)
+	if err != nil {
+		return model.TodoTask{}, err
+	}
+	return a.rescheduleTask(ctx, t, date, model.ActionReschedule)
+}
+
+func (a *app) RescheduleDay(ctx context.Context, from model.Date, to model.Date) ([]model.TodoTask, error) {
+	if err := valid.Date(from); err != nil {
+		return nil, errors.Join(model.ErrInvalidInput, err)
+	} else if err = valid.PlanningDate(to); err != nil {
+		return nil, errors.Join(model.ErrInvalidInput, err)
+	} else if from == to {
+		return nil, model.ErrInvalidInput
+	}
+
+	if _, err := a.schedule.RescheduleDay(ctx, from, to, model.ActionRescheduleDay); err != nil {
+		return nil, err
+	}
+	return a.plans.GetTasksByDate(ctx, to)
+}
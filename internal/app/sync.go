@@ -0,0 +1,190 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"todo-list/internal/model"
+)
+
+const (
+	// defaultTombstoneRetention is a time for which deleted tasks are kept
+	// for the sync clients if it is not configured
+	defaultTombstoneRetention = 30 * 24 * time.Hour
+
+	// tombstoneGrace keeps tombstones a bit longer than tokens are valid,
+	// transactions in progress when the token was issued could delete tasks
+	// before it
+	tombstoneGrace = time.Hour
+
+	// maxSyncChanges is a max number of changes pushed in one batch
+	maxSyncChanges = 100
+
+	// maxSyncRefLen is a max length of the id of the client and the refs of
+	// its changes
+	maxSyncRefLen = 100
+)
+
+// encodeToken returns sync token of the changes read when the transaction
+// with id xmin was the oldest one in progress
+func encodeToken(xmin uint64, at time.Time) string {
+	return fmt.Sprintf("%d.%d", xmin, at.Unix())
+}
+
+// decodeToken returns transaction id and time of issue of the sync token
+func decodeToken(token string) (uint64, time.Time, error) {
+	xid, at, ok := strings.Cut(token, ".")
+	if !ok {
+		return 0, time.Time{}, model.ErrInvalidInput
+	}
+	xmin, err := strconv.ParseUint(xid, 10, 64)
+	if err != nil || xmin == 0 {
+		return 0, time.Time{}, model.ErrInvalidInput
+	}
+	sec, err := strconv.ParseInt(at, 10, 64)
+	if err != nil {
+		return 0, time.Time{}, model.ErrInvalidInput
+	}
+	return xmin, time.Unix(sec, 0).UTC(), nil
+}
+
+func (a *app) GetChanges(ctx context.Context, token string) (model.SyncChanges, error) {
+	now := time.Now().UTC()
+
+	var since uint64
+	reset := true
+	if token != "" {
+		xmin, at, err := decodeToken(token)
+		if err != nil {
+			return model.SyncChanges{}, err
+		} else if at.After(now.Add(time.Minute)) {
+			return model.SyncChanges{}, model.ErrInvalidInput
+		}
+
+		// tombstones of the older tokens could be purged already
+		if !at.Before(now.Add(-a.tombstoneRetention)) {
+			since, reset = xmin, false
+		}
+	}
+
+	changes, xmin, err := a.sync.GetChanges(ctx, since)
+	if err != nil {
+		return model.SyncChanges{}, err
+	}
+	changes.Reset = reset
+	changes.Token = encodeToken(xmin, now)
+	return changes, nil
+}
+
+func (a *app) PushChanges(ctx context.Context, client string, changes []model.SyncChange) ([]model.SyncResult, error) {
+	if len(changes) > maxSyncChanges || len(client) > maxSyncRefLen {
+		return nil, model.ErrInvalidInput
+	}
+	for _, c := range changes {
+		if len(c.Ref) > maxSyncRefLen {
+			return nil, model.ErrInvalidInput
+		}
+	}
+
+	results := make([]model.SyncResult, 0, len(changes))
+	for _, c := range changes {
+		results = append(results, a.pushChange(ctx, client, c))
+	}
+	return results, nil
+}
+
+// pushChange applies the change through the same checks as the requests of
+// online clients, failures of the repository reject only this change
+func (a *app) pushChange(ctx context.Context, client string, c model.SyncChange) model.SyncResult {
+	switch c.Op {
+	case model.SyncCreate:
+		return a.createTask(ctx, client, c)
+	case model.SyncUpdate:
+		if r, ok := a.checkVersion(ctx, c); !ok {
+			return r
+		}
+		c.Task.Version = c.Version
+		t, err := a.UpdateTask(ctx, c.TaskId, c.Task)
+		if errors.Is(err, model.ErrVersionConflict) || errors.Is(err, model.ErrTaskNotFound) {
+			// task was changed or deleted after the check
+			if r, ok := a.checkVersion(ctx, c); !ok {
+				return r
+			}
+		}
+		return syncResult(c, t, err)
+	case model.SyncDelete:
+		r, ok := a.checkVersion(ctx, c)
+		if !ok {
+			return r
+		}
+		// task deleted after the check is deleted as the client wants
+		if err := a.DeleteTask(ctx, c.TaskId); err != nil && !errors.Is(err, model.ErrTaskNotFound) {
+			return syncResult(c, model.TodoTask{}, err)
+		}
+		return model.SyncResult{Ref: c.Ref, Status: model.SyncApplied, Task: model.TodoTask{Id: c.TaskId}}
+	default:
+		return syncResult(c, model.TodoTask{}, model.ErrInvalidInput)
+	}
+}
+
+// createTask adds the task of the change once for the client and its ref, so
+// the batch pushed again after a lost response returns the tasks created
+// before. Task created before and deleted since is reported as a conflict
+func (a *app) createTask(ctx context.Context, client string, c model.SyncChange) model.SyncResult {
+	if client == "" || c.Ref == "" {
+		t, err := a.AddTask(ctx, c.Task)
+		return syncResult(c, t, err)
+	}
+
+	id, err := a.sync.GetCreatedTask(ctx, client, c.Ref)
+	if err != nil {
+		return syncResult(c, model.TodoTask{}, err)
+	}
+	if id == 0 {
+		t, err := a.newTask(ctx, c.Task)
+		if err != nil {
+			return syncResult(c, model.TodoTask{}, err)
+		}
+		if id, err = a.sync.AddCreatedTask(ctx, client, c.Ref, t); err != nil {
+			return syncResult(c, model.TodoTask{}, err)
+		}
+	}
+
+	c.TaskId = id
+	t, err := a.TaskRepo.GetTaskById(ctx, id)
+	if errors.Is(err, model.ErrTaskNotFound) {
+		return model.SyncResult{Ref: c.Ref, Status: model.SyncConflict, Task: model.TodoTask{Id: id}, Deleted: true}
+	}
+	return syncResult(c, t, err)
+}
+
+// checkVersion returns false and the conflict if the task of the change was
+// deleted or changed since the version known by the client, or rejection if
+// the task can't be read
+func (a *app) checkVersion(ctx context.Context, c model.SyncChange) (model.SyncResult, bool) {
+	current, err := a.TaskRepo.GetTaskById(ctx, c.TaskId)
+	switch {
+	case errors.Is(err, model.ErrTaskNotFound):
+		return model.SyncResult{Ref: c.Ref, Status: model.SyncConflict, Task: model.TodoTask{Id: c.TaskId}, Deleted: true}, false
+	case err != nil:
+		return syncResult(c, model.TodoTask{}, err), false
+	case current.Version != c.Version:
+		return model.SyncResult{Ref: c.Ref, Status: model.SyncConflict, Task: current}, false
+	}
+	return model.SyncResult{}, true
+}
+
+// syncResult returns applied change with the task or rejected change with the error
+func syncResult(c model.SyncChange, t model.TodoTask, err error) model.SyncResult {
+	if err != nil {
+		return model.SyncResult{Ref: c.Ref, Status: model.SyncRejected, Task: model.TodoTask{Id: c.TaskId}, Err: err}
+	}
+	return model.SyncResult{Ref: c.Ref, Status: model.SyncApplied, Task: t}
+}
+
+func (a *app) PurgeTombstones(ctx context.Context) (int, error) {
+	return a.sync.PurgeTombstones(ctx, time.Now().UTC().Add(-a.tombstoneRetention-tombstoneGrace))
+}
@@ -2,13 +2,29 @@ package valid
 
 import (
 	"errors"
+	"net"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strings"
 	"time"
 	"todo-list/internal/model"
+	"unicode/utf8"
 )
 
 const (
 	maxTitleLen       = 100
 	maxDescriptionLen = 500
+	maxUserLen        = 50
+	maxCommentLen     = 5000
+	maxProjectLen     = 50
+	maxAttachmentLen  = 255
+	maxStateLen       = 50
+	maxRecipientLen   = 500
+	maxReminderOffset = 366 * 24 * time.Hour
+	maxSecretLen      = 255
+	maxObjectNameLen  = 255
+	maxUIDLen         = 255
 )
 
 var (
@@ -17,6 +33,41 @@ var (
 	descriptionTooLong = errors.New("description of task is very long")
 	dateInvalid        = errors.New("date is invalid")
 	dateExpired        = errors.New("planning date of the task is expired")
+	noUser             = errors.New("no name of the user")
+	userTooLong        = errors.New("name of the user is very long")
+	userInvalid        = errors.New("name of the user contains spaces")
+	noComment          = errors.New("no text of the comment")
+	commentTooLong     = errors.New("text of the comment is very long")
+	commentInvalid     = errors.New("text of the comment is not valid UTF-8 or contains NUL bytes")
+	noProject          = errors.New("no name of the project")
+	projectTooLong     = errors.New("name of the project is very long")
+	projectInvalid     = errors.New("name of the project contains spaces")
+	noAttachment       = errors.New("no name of the attachment")
+	attachmentTooLong  = errors.New("name of the attachment is very long")
+	attachmentInvalid  = errors.New("name of the attachment contains path separators or invalid characters")
+	noState            = errors.New("no name of the state")
+	stateTooLong       = errors.New("name of the state is very long")
+	stateDuplicated    = errors.New("name of the state is duplicated")
+	noDoneState        = errors.New("workflow has no done state")
+	noOpenState        = errors.New("workflow has no state which is not done")
+	transitionInvalid  = errors.New("transition refers to unknown state")
+	channelInvalid     = errors.New("channel of the reminder is unknown")
+	addressNotPublic   = errors.New("address is loopback, private or link-local")
+	noRecipient        = errors.New("no recipient of the reminder")
+	recipientTooLong   = errors.New("recipient of the reminder is very long")
+	recipientInvalid   = errors.New("recipient is not an email address or URL of the webhook")
+	reminderTimeBoth   = errors.New("reminder has both time and offset from the planning date")
+	offsetTooLong      = errors.New("offset of the reminder from the planning date is very long")
+	urlInvalid         = errors.New("URL of the webhook is invalid")
+	urlTooLong         = errors.New("URL of the webhook is very long")
+	noEvents           = errors.New("no events of the subscription")
+	eventInvalid       = errors.New("type of the event is unknown")
+	eventDuplicated    = errors.New("type of the event is duplicated")
+	secretTooLong      = errors.New("secret of the subscription is very long")
+	noObjectName       = errors.New("no name of the calendar object")
+	objectNameTooLong  = errors.New("name of the calendar object is very long")
+	objectNameInvalid  = errors.New("name of the calendar object is not a single segment of the path")
+	uidTooLong         = errors.New("UID of the calendar object is very long")
 )
 
 // isLater checks if given date is later or equal than current date
@@ -30,6 +81,33 @@ func isLeapYear(y int) bool {
 	return (y%4 == 0 && y%100 != 0) || (y%400 == 0)
 }
 
+// PublicIP checks if ip is a public address, so requests to the webhooks
+// given by users can't reach services of the internal network
+func PublicIP(ip net.IP) error {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsMulticast() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return addressNotPublic
+	}
+	return nil
+}
+
+// isWebhookURL returns true if s is an absolute http or https URL whose host
+// is not local, names resolving to internal addresses are refused when the
+// request is sent
+func isWebhookURL(s string) bool {
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	} else if ip := net.ParseIP(host); ip != nil {
+		return PublicIP(ip) == nil
+	}
+	return true
+}
+
 // Date returns true if date is valid
 func Date(d model.Date) error {
 	if d.Month == time.February && d.Day == 29 && isLeapYear(d.Year) {
@@ -57,8 +135,30 @@ func Date(d model.Date) error {
 	}
 }
 
+// PlanningDate checks if date is valid and is not earlier than current date
+func PlanningDate(d model.Date) error {
+	if err := Date(d); err != nil {
+		return err
+	} else if !isLater(d) {
+		return dateExpired
+	} else {
+		return nil
+	}
+}
+
 // TodoTask checks if all fields of task struct are valid
 func TodoTask(t model.TodoTask) error {
+	return todoTask(t, PlanningDate)
+}
+
+// UpdatedTodoTask checks fields of the updated task like TodoTask, but its
+// planning date may be expired, since overdue task can keep its date
+func UpdatedTodoTask(t model.TodoTask) error {
+	return todoTask(t, Date)
+}
+
+// todoTask checks fields of task struct with given check of planning date
+func todoTask(t model.TodoTask, date func(model.Date) error) error {
 	errs := make([]error, 0, 3)
 
 	if t.Title == "" { // check if task has a title
@@ -71,10 +171,230 @@ func TodoTask(t model.TodoTask) error {
 		errs = append(errs, descriptionTooLong)
 	}
 
-	if err := Date(t.PlanningDate); err != nil { // check if date is valid
+	if err := date(t.PlanningDate); err != nil { // check if date is valid
 		errs = append(errs, err)
-	} else if !isLater(t.PlanningDate) { // check if date is expired
-		errs = append(errs, dateExpired)
+	}
+
+	if t.Project != "" { // task without project belongs only to the workspace
+		if err := ProjectName(t.Project); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	} else {
+		return errors.Join(errs...)
+	}
+}
+
+// User checks if name of the user (assignee of the task or author of the
+// comment) is valid
+func User(name string) error {
+	if name == "" {
+		return noUser
+	} else if len(name) > maxUserLen {
+		return userTooLong
+	} else if strings.ContainsAny(name, " \t\n\r") {
+		return userInvalid
+	} else {
+		return nil
+	}
+}
+
+// Comment checks if Markdown text of the comment can be safely stored
+func Comment(text string) error {
+	if strings.TrimSpace(text) == "" {
+		return noComment
+	} else if len(text) > maxCommentLen {
+		return commentTooLong
+	} else if !utf8.ValidString(text) || strings.ContainsRune(text, 0) {
+		return commentInvalid
+	} else {
+		return nil
+	}
+}
+
+// ProjectName checks if name of the project is valid
+func ProjectName(name string) error {
+	if name == "" {
+		return noProject
+	} else if len(name) > maxProjectLen {
+		return projectTooLong
+	} else if strings.ContainsAny(name, " \t\n\r") {
+		return projectInvalid
+	} else {
+		return nil
+	}
+}
+
+// Attachment checks if name of the attached file is valid and can't be
+// interpreted as a path
+func Attachment(name string) error {
+	if name == "" {
+		return noAttachment
+	} else if len(name) > maxAttachmentLen {
+		return attachmentTooLong
+	} else if !utf8.ValidString(name) || strings.ContainsAny(name, "/\\\x00") || name == "." || name == ".." {
+		return attachmentInvalid
+	} else {
+		return nil
+	}
+}
+
+// Project checks if name and members of the project are valid
+func Project(p model.Project) error {
+	errs := make([]error, 0, len(p.Members)+1)
+	if err := ProjectName(p.Name); err != nil {
+		errs = append(errs, err)
+	}
+	for _, member := range p.Members {
+		if err := User(member); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Workflow checks if states of the workflow have unique names, there are
+// done and not done states and transitions refer only to known states
+func Workflow(w model.Workflow) error {
+	errs := make([]error, 0)
+
+	states := make(map[string]struct{}, len(w.States))
+	hasDone, hasOpen := false, false
+	for _, s := range w.States {
+		if s.Name == "" {
+			errs = append(errs, noState)
+		} else if len(s.Name) > maxStateLen {
+			errs = append(errs, stateTooLong)
+		} else if _, ok := states[s.Name]; ok {
+			errs = append(errs, stateDuplicated)
+		}
+		states[s.Name] = struct{}{}
+
+		if s.Done {
+			hasDone = true
+		} else {
+			hasOpen = true
+		}
+	}
+
+	if !hasDone {
+		errs = append(errs, noDoneState)
+	}
+	if !hasOpen {
+		errs = append(errs, noOpenState)
+	}
+
+	for from, to := range w.Transitions {
+		if _, ok := states[from]; !ok {
+			errs = append(errs, transitionInvalid)
+			continue
+		}
+		for _, s := range to {
+			if _, ok := states[s]; !ok {
+				errs = append(errs, transitionInvalid)
+				break
+			}
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	} else {
+		return errors.Join(errs...)
+	}
+}
+
+// Reminder checks if reminder has known channel with matching recipient and
+// either time or offset from the planning date of the task
+func Reminder(r model.Reminder) error {
+	errs := make([]error, 0, 3)
+
+	if r.Recipient == "" {
+		errs = append(errs, noRecipient)
+	} else if len(r.Recipient) > maxRecipientLen {
+		errs = append(errs, recipientTooLong)
+	} else {
+		switch r.Channel {
+		case model.ChannelEmail:
+			if addr, err := mail.ParseAddress(r.Recipient); err != nil || addr.Address != r.Recipient {
+				errs = append(errs, recipientInvalid)
+			}
+		case model.ChannelWebhook:
+			if !isWebhookURL(r.Recipient) {
+				errs = append(errs, recipientInvalid)
+			}
+		default:
+			errs = append(errs, channelInvalid)
+		}
+	}
+
+	if !r.At.IsZero() && r.Offset != 0 {
+		errs = append(errs, reminderTimeBoth)
+	} else if r.Offset > maxReminderOffset || r.Offset < -maxReminderOffset {
+		errs = append(errs, offsetTooLong)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	} else {
+		return errors.Join(errs...)
+	}
+}
+
+// Subscription checks if webhook has valid URL and known types of the events
+func Subscription(sub model.Subscription) error {
+	errs := make([]error, 0, 3)
+
+	if len(sub.URL) > maxRecipientLen {
+		errs = append(errs, urlTooLong)
+	} else if !isWebhookURL(sub.URL) {
+		errs = append(errs, urlInvalid)
+	}
+
+	if len(sub.Events) == 0 {
+		errs = append(errs, noEvents)
+	}
+	events := make(map[string]struct{}, len(sub.Events))
+	for _, e := range sub.Events {
+		if !slices.Contains(model.EventTypes, e) {
+			errs = append(errs, eventInvalid)
+			break
+		} else if _, ok := events[e]; ok {
+			errs = append(errs, eventDuplicated)
+			break
+		}
+		events[e] = struct{}{}
+	}
+
+	if len(sub.Secret) > maxSecretLen {
+		errs = append(errs, secretTooLong)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	} else {
+		return errors.Join(errs...)
+	}
+}
+
+// CalendarObject checks if name of the calendar object is a valid segment of
+// the path and its UID can be stored
+func CalendarObject(o model.CalendarObject) error {
+	errs := make([]error, 0, 2)
+
+	if o.Name == "" {
+		errs = append(errs, noObjectName)
+	} else if len(o.Name) > maxObjectNameLen {
+		errs = append(errs, objectNameTooLong)
+	} else if !utf8.ValidString(o.Name) || strings.ContainsAny(o.Name, "/\\\x00") || o.Name == "." || o.Name == ".." {
+		errs = append(errs, objectNameInvalid)
+	}
+
+	if len(o.UID) > maxUIDLen {
+		errs = append(errs, uidTooLong)
 	}
 
 	if len(errs) == 0 {
@@ -2,7 +2,10 @@ package valid
 
 import (
 	"github.com/stretchr/testify/assert"
+	"net"
+	"strings"
 	"testing"
+	"time"
 	"todo-list/internal/model"
 )
 
@@ -109,3 +112,522 @@ func TestTodoTask(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdatedTodoTask(t *testing.T) {
+	expired := model.TodoTask{Title: "Overdue task", PlanningDate: model.Date{Year: 2024, Month: time.January, Day: 1}}
+	assert.NoError(t, UpdatedTodoTask(expired))
+
+	invalid := model.TodoTask{PlanningDate: model.Date{Year: 2024, Month: time.February, Day: 30}}
+	err := UpdatedTodoTask(invalid)
+	assert.ErrorIs(t, err, noTitle)
+	assert.ErrorIs(t, err, dateInvalid)
+}
+
+type PlanningDateTest struct {
+	description string
+	givenDate   model.Date
+	expectedErr error
+}
+
+func TestPlanningDate(t *testing.T) {
+	year, month, day := time.Now().UTC().Date()
+	tests := []PlanningDateTest{
+		{
+			description: "validation of current date",
+			givenDate:   model.Date{Year: year, Month: month, Day: day},
+			expectedErr: nil,
+		},
+		{
+			description: "validation of future date",
+			givenDate:   model.Date{Year: year + 1, Month: time.January, Day: 1},
+			expectedErr: nil,
+		},
+		{
+			description: "validation of expired date",
+			givenDate:   model.Date{Year: year - 1, Month: time.January, Day: 1},
+			expectedErr: dateExpired,
+		},
+		{
+			description: "validation of invalid date",
+			givenDate:   model.Date{Year: year + 1, Month: time.April, Day: 31},
+			expectedErr: dateInvalid,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			assert.ErrorIs(t, PlanningDate(test.givenDate), test.expectedErr)
+		})
+	}
+}
+
+type UserTest struct {
+	description string
+	givenName   string
+	expectedErr error
+}
+
+func TestUser(t *testing.T) {
+	tests := []UserTest{
+		{
+			description: "validation of valid user",
+			givenName:   "alice",
+			expectedErr: nil,
+		},
+		{
+			description: "validation of empty user",
+			givenName:   "",
+			expectedErr: noUser,
+		},
+		{
+			description: "validation of user with very long name",
+			givenName:   "V5ZidDlMxou0aJaQf1VhBgWD9AMxFlF3ChnpK6av3YPFkIhzYULJq",
+			expectedErr: userTooLong,
+		},
+		{
+			description: "validation of user with spaces in name",
+			givenName:   "alice bob",
+			expectedErr: userInvalid,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			assert.ErrorIs(t, User(test.givenName), test.expectedErr)
+		})
+	}
+}
+
+type CommentTest struct {
+	description string
+	givenText   string
+	expectedErr error
+}
+
+func TestComment(t *testing.T) {
+	tests := []CommentTest{
+		{
+			description: "validation of valid comment",
+			givenText:   "**Markdown** comment with [link](https://example.com)",
+			expectedErr: nil,
+		},
+		{
+			description: "validation of comment of spaces",
+			givenText:   "  \n\t",
+			expectedErr: noComment,
+		},
+		{
+			description: "validation of very long comment",
+			givenText:   strings.Repeat("a", maxCommentLen+1),
+			expectedErr: commentTooLong,
+		},
+		{
+			description: "validation of comment with NUL byte",
+			givenText:   "text\x00text",
+			expectedErr: commentInvalid,
+		},
+		{
+			description: "validation of comment with invalid UTF-8",
+			givenText:   "text\xfftext",
+			expectedErr: commentInvalid,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			assert.ErrorIs(t, Comment(test.givenText), test.expectedErr)
+		})
+	}
+}
+
+type AttachmentTest struct {
+	description string
+	givenName   string
+	expectedErr error
+}
+
+func TestAttachment(t *testing.T) {
+	tests := []AttachmentTest{
+		{
+			description: "validation of valid attachment",
+			givenName:   "screenshot 1.png",
+			expectedErr: nil,
+		},
+		{
+			description: "validation of attachment without name",
+			givenName:   "",
+			expectedErr: noAttachment,
+		},
+		{
+			description: "validation of attachment with very long name",
+			givenName:   strings.Repeat("a", maxAttachmentLen+1),
+			expectedErr: attachmentTooLong,
+		},
+		{
+			description: "validation of attachment with path in name",
+			givenName:   "../../etc/passwd",
+			expectedErr: attachmentInvalid,
+		},
+		{
+			description: "validation of attachment with windows path in name",
+			givenName:   "C:\\report.pdf",
+			expectedErr: attachmentInvalid,
+		},
+		{
+			description: "validation of attachment with dots as name",
+			givenName:   "..",
+			expectedErr: attachmentInvalid,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			assert.ErrorIs(t, Attachment(test.givenName), test.expectedErr)
+		})
+	}
+}
+
+type WorkflowTest struct {
+	description  string
+	givenFlow    model.Workflow
+	expectedErrs []error
+}
+
+func TestWorkflow(t *testing.T) {
+	tests := []WorkflowTest{
+		{
+			description: "validation of valid workflow",
+			givenFlow: model.Workflow{
+				States: []model.State{{Name: "todo"}, {Name: "done", Done: true}},
+				Transitions: map[string][]string{
+					"todo": {"done"},
+					"done": {"todo"},
+				},
+			},
+			expectedErrs: nil,
+		},
+		{
+			description: "validation of workflow without transitions",
+			givenFlow: model.Workflow{
+				States: []model.State{{Name: "todo"}, {Name: "done", Done: true}},
+			},
+			expectedErrs: nil,
+		},
+		{
+			description: "validation of workflow with empty and duplicated states",
+			givenFlow: model.Workflow{
+				States: []model.State{{Name: ""}, {Name: "done", Done: true}, {Name: "done"}},
+			},
+			expectedErrs: []error{noState, stateDuplicated},
+		},
+		{
+			description: "validation of workflow with very long name of the state",
+			givenFlow: model.Workflow{
+				States: []model.State{{Name: strings.Repeat("a", 51)}, {Name: "done", Done: true}},
+			},
+			expectedErrs: []error{stateTooLong},
+		},
+		{
+			description: "validation of workflow without done and open states",
+			givenFlow: model.Workflow{
+				States: []model.State{},
+			},
+			expectedErrs: []error{noDoneState, noOpenState},
+		},
+		{
+			description: "validation of workflow with unknown states in transitions",
+			givenFlow: model.Workflow{
+				States: []model.State{{Name: "todo"}, {Name: "done", Done: true}},
+				Transitions: map[string][]string{
+					"todo":    {"blocked"},
+					"blocked": {"todo"},
+				},
+			},
+			expectedErrs: []error{transitionInvalid},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			err := Workflow(test.givenFlow)
+			if test.expectedErrs == nil {
+				assert.NoError(t, err)
+			}
+			for _, expectedErr := range test.expectedErrs {
+				assert.ErrorIs(t, err, expectedErr)
+			}
+		})
+	}
+}
+
+type ReminderTest struct {
+	description   string
+	givenReminder model.Reminder
+	expectedErrs  []error
+}
+
+func TestReminder(t *testing.T) {
+	tests := []ReminderTest{
+		{
+			description: "validation of valid email reminder at the time",
+			givenReminder: model.Reminder{
+				At:        time.Date(2099, time.January, 1, 9, 0, 0, 0, time.UTC),
+				Channel:   model.ChannelEmail,
+				Recipient: "alice@example.com",
+			},
+			expectedErrs: []error{},
+		},
+		{
+			description: "validation of valid webhook reminder before the planning date",
+			givenReminder: model.Reminder{
+				Offset:    -time.Hour,
+				Channel:   model.ChannelWebhook,
+				Recipient: "https://example.com/hooks/reminders",
+			},
+			expectedErrs: []error{},
+		},
+		{
+			description: "validation of reminder with unknown channel",
+			givenReminder: model.Reminder{
+				Channel:   "pigeon",
+				Recipient: "alice",
+			},
+			expectedErrs: []error{channelInvalid},
+		},
+		{
+			description: "validation of email reminder with name in recipient",
+			givenReminder: model.Reminder{
+				Channel:   model.ChannelEmail,
+				Recipient: "Alice <alice@example.com>",
+			},
+			expectedErrs: []error{recipientInvalid},
+		},
+		{
+			description: "validation of webhook reminder with not http URL",
+			givenReminder: model.Reminder{
+				Channel:   model.ChannelWebhook,
+				Recipient: "ftp://example.com/hooks",
+			},
+			expectedErrs: []error{recipientInvalid},
+		},
+		{
+			description: "validation of webhook reminder to the localhost",
+			givenReminder: model.Reminder{
+				Channel:   model.ChannelWebhook,
+				Recipient: "http://localhost:8080/hooks",
+			},
+			expectedErrs: []error{recipientInvalid},
+		},
+		{
+			description: "validation of webhook reminder to the private address",
+			givenReminder: model.Reminder{
+				Channel:   model.ChannelWebhook,
+				Recipient: "http://10.0.0.5/hooks",
+			},
+			expectedErrs: []error{recipientInvalid},
+		},
+		{
+			description: "validation of webhook reminder to the metadata service",
+			givenReminder: model.Reminder{
+				Channel:   model.ChannelWebhook,
+				Recipient: "http://169.254.169.254/latest/meta-data",
+			},
+			expectedErrs: []error{recipientInvalid},
+		},
+		{
+			description: "validation of reminder without recipient and with time and offset",
+			givenReminder: model.Reminder{
+				At:      time.Date(2099, time.January, 1, 9, 0, 0, 0, time.UTC),
+				Offset:  time.Hour,
+				Channel: model.ChannelEmail,
+			},
+			expectedErrs: []error{noRecipient, reminderTimeBoth},
+		},
+		{
+			description: "validation of reminder with very long offset",
+			givenReminder: model.Reminder{
+				Offset:    maxReminderOffset + time.Hour,
+				Channel:   model.ChannelEmail,
+				Recipient: "alice@example.com",
+			},
+			expectedErrs: []error{offsetTooLong},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			err := Reminder(test.givenReminder)
+			if len(test.expectedErrs) == 0 {
+				assert.NoError(t, err)
+			}
+			for _, expectedErr := range test.expectedErrs {
+				assert.ErrorIs(t, err, expectedErr)
+			}
+		})
+	}
+}
+
+func TestPublicIP(t *testing.T) {
+	for _, ip := range []string{"127.0.0.1", "::1", "::ffff:127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "fe80::1", "fd00::1", "0.0.0.0"} {
+		assert.ErrorIs(t, PublicIP(net.ParseIP(ip)), addressNotPublic, ip)
+	}
+	for _, ip := range []string{"93.184.216.34", "2606:2800:220:1::"} {
+		assert.NoError(t, PublicIP(net.ParseIP(ip)), ip)
+	}
+}
+
+type ProjectTest struct {
+	description  string
+	givenProject model.Project
+	expectedErr  error
+}
+
+func TestProject(t *testing.T) {
+	tests := []ProjectTest{
+		{
+			description: "validation of valid project",
+			givenProject: model.Project{
+				Name:    "backend",
+				Members: []string{"alice", "bob"},
+			},
+			expectedErr: nil,
+		},
+		{
+			description: "validation of project without name",
+			givenProject: model.Project{
+				Name:    "",
+				Members: []string{"alice"},
+			},
+			expectedErr: noProject,
+		},
+		{
+			description: "validation of project with spaces in name",
+			givenProject: model.Project{
+				Name:    "back end",
+				Members: []string{},
+			},
+			expectedErr: projectInvalid,
+		},
+		{
+			description: "validation of project with invalid member",
+			givenProject: model.Project{
+				Name:    "backend",
+				Members: []string{"alice bob"},
+			},
+			expectedErr: userInvalid,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			assert.ErrorIs(t, Project(test.givenProject), test.expectedErr)
+		})
+	}
+}
+
+type SubscriptionTest struct {
+	description       string
+	givenSubscription model.Subscription
+	expectedErrs      []error
+}
+
+func TestSubscription(t *testing.T) {
+	tests := []SubscriptionTest{
+		{
+			description: "validation of valid subscription",
+			givenSubscription: model.Subscription{
+				URL:    "https://example.com/hooks/tasks",
+				Events: []string{model.EventTaskCreated, model.EventTaskCompleted},
+				Secret: "secret",
+			},
+			expectedErrs: []error{},
+		},
+		{
+			description: "validation of subscription with relative URL and without events",
+			givenSubscription: model.Subscription{
+				URL: "/hooks/tasks",
+			},
+			expectedErrs: []error{urlInvalid, noEvents},
+		},
+		{
+			description: "validation of subscription to the address of the internal network",
+			givenSubscription: model.Subscription{
+				URL:    "http://192.168.0.10:9000/hooks",
+				Events: []string{model.EventTaskCreated},
+			},
+			expectedErrs: []error{urlInvalid},
+		},
+		{
+			description: "validation of subscription with unknown event",
+			givenSubscription: model.Subscription{
+				URL:    "http://hooks.example.com:9000/hooks",
+				Events: []string{model.EventTaskCreated, "task.archived"},
+			},
+			expectedErrs: []error{eventInvalid},
+		},
+		{
+			description: "validation of subscription with duplicated event and very long secret",
+			givenSubscription: model.Subscription{
+				URL:    "http://hooks.example.com:9000/hooks",
+				Events: []string{model.EventTaskDeleted, model.EventTaskDeleted},
+				Secret: strings.Repeat("s", maxSecretLen+1),
+			},
+			expectedErrs: []error{eventDuplicated, secretTooLong},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			err := Subscription(test.givenSubscription)
+			if len(test.expectedErrs) == 0 {
+				assert.NoError(t, err)
+			}
+			for _, expectedErr := range test.expectedErrs {
+				assert.ErrorIs(t, err, expectedErr)
+			}
+		})
+	}
+}
+
+type CalendarObjectTest struct {
+	description  string
+	givenObject  model.CalendarObject
+	expectedErrs []error
+}
+
+func TestCalendarObject(t *testing.T) {
+	tests := []CalendarObjectTest{
+		{
+			description:  "validation of valid calendar object",
+			givenObject:  model.CalendarObject{Name: "8b1c2f0e-6a3d.ics", UID: "8b1c2f0e-6a3d"},
+			expectedErrs: []error{},
+		},
+		{
+			description:  "validation of calendar object without name and with very long UID",
+			givenObject:  model.CalendarObject{UID: strings.Repeat("u", maxUIDLen+1)},
+			expectedErrs: []error{noObjectName, uidTooLong},
+		},
+		{
+			description:  "validation of calendar object with very long name",
+			givenObject:  model.CalendarObject{Name: strings.Repeat("a", maxObjectNameLen+1)},
+			expectedErrs: []error{objectNameTooLong},
+		},
+		{
+			description:  "validation of calendar object with path in name",
+			givenObject:  model.CalendarObject{Name: "../task.ics"},
+			expectedErrs: []error{objectNameInvalid},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			err := CalendarObject(test.givenObject)
+			if len(test.expectedErrs) == 0 {
+				assert.NoError(t, err)
+			}
+			for _, expectedErr := range test.expectedErrs {
+				assert.ErrorIs(t, err, expectedErr)
+			}
+		})
+	}
+}
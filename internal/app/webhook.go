@@ -0,0 +1,127 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log"
+	"time"
+	"todo-list/internal/app/valid"
+	"todo-list/internal/model"
+)
+
+const (
+	// defaultWebhookAttempts is a number of attempts to deliver the event to
+	// the webhook if it is not configured
+	defaultWebhookAttempts = 8
+
+	// webhookBatch is a max number of deliveries sent in one run
+	webhookBatch = 100
+
+	// webhookLease is a time for which claimed delivery is not claimed again
+	webhookLease = 5 * time.Minute
+
+	// webhookRetryDelay is a delay before the first retry of failed
+	// delivery, it doubles with every next attempt up to maxWebhookRetryDelay
+	webhookRetryDelay    = 30 * time.Second
+	maxWebhookRetryDelay = 6 * time.Hour
+)
+
+// generateSecret returns random secret of the webhook subscription
+func generateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func (a *app) AddSubscription(ctx context.Context, sub model.Subscription) (model.Subscription, error) {
+	if err := valid.Subscription(sub); err != nil {
+		return model.Subscription{}, errors.Join(model.ErrInvalidSubscription, err)
+	}
+
+	if sub.Secret == "" {
+		secret, err := generateSecret()
+		if err != nil {
+			return model.Subscription{}, errors.Join(model.ErrUnknown, err)
+		}
+		sub.Secret = secret
+	}
+	return a.webhooks.AddSubscription(ctx, sub)
+}
+
+func (a *app) GetSubscriptions(ctx context.Context) ([]model.Subscription, error) {
+	subs, err := a.webhooks.GetSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range subs {
+		subs[i].Secret = ""
+	}
+	return subs, nil
+}
+
+func (a *app) DeleteSubscription(ctx context.Context, id int) error {
+	return a.webhooks.DeleteSubscription(ctx, id)
+}
+
+func (a *app) GetDeliveriesBySubscription(ctx context.Context, id int, offset int, limit int) ([]model.WebhookDelivery, error) {
+	if limit < 0 || offset < 0 {
+		return nil, model.ErrInvalidInput
+	}
+
+	if _, err := a.webhooks.GetSubscriptionById(ctx, id); err != nil {
+		return nil, err
+	}
+	return a.webhooks.GetDeliveriesBySubscription(ctx, id, offset, limit)
+}
+
+func (a *app) DeliverWebhooks(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	due, err := a.webhooks.ClaimDueDeliveries(ctx, now, now.Add(webhookLease), webhookBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	subs := make(map[int]model.Subscription)
+	delivered := 0
+	for _, d := range due {
+		// not sent deliveries stay claimed and are retried after the lease
+		if err = ctx.Err(); err != nil {
+			return delivered, err
+		}
+
+		sub, ok := subs[d.SubscriptionId]
+		if !ok {
+			sub, err = a.webhooks.GetSubscriptionById(ctx, d.SubscriptionId)
+			if errors.Is(err, model.ErrSubscriptionNotFound) { // delivery is deleted with its subscription
+				continue
+			} else if err != nil {
+				return delivered, err
+			}
+			subs[d.SubscriptionId] = sub
+		}
+
+		code, err := a.sender.Send(ctx, sub.URL, sub.Secret, d)
+		status, errText, next := model.DeliveryDelivered, "", now
+		if err != nil {
+			log.Printf("webhook delivery %d error: %s\n", d.Id, err.Error())
+			errText = err.Error()
+			if d.Attempts >= a.webhookAttempts {
+				status = model.DeliveryFailed
+			} else {
+				status, next = model.DeliveryPending, now.Add(backoff(d.Attempts, webhookRetryDelay, maxWebhookRetryDelay))
+			}
+		}
+
+		if err = a.webhooks.RecordAttempt(ctx, d.Id, status, code, errText, next); err != nil {
+			return delivered, err
+		}
+		if status == model.DeliveryDelivered {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+	"todo-list/internal/model"
+)
+
+// DefaultWorkflow returns workflow used when states are not configured
+func DefaultWorkflow() model.Workflow {
+	return model.Workflow{
+		States: []model.State{
+			{Name: "todo", Done: false},
+			{Name: "in_progress", Done: false},
+			{Name: "in_review", Done: false},
+			{Name: "done", Done: true},
+			{Name: "cancelled", Done: true},
+		},
+		Transitions: map[string][]string{
+			"todo":        {"in_progress", "done", "cancelled"},
+			"in_progress": {"todo", "in_review", "done", "cancelled"},
+			"in_review":   {"in_progress", "done", "cancelled"},
+			"done":        {"todo", "in_progress"},
+			"cancelled":   {"todo"},
+		},
+	}
+}
+
+// findState searches state with given name in the workflow
+func findState(w model.Workflow, name string) (model.State, bool) {
+	for _, s := range w.States {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return model.State{}, false
+}
+
+// defaultState returns name of the first state of the workflow which is done
+// or not done as required
+func defaultState(w model.Workflow, done bool) string {
+	for _, s := range w.States {
+		if s.Done == done {
+			return s.Name
+		}
+	}
+	return ""
+}
+
+// canTransit returns true if task can be moved between given states
+func canTransit(w model.Workflow, from string, to string) bool {
+	if from == to || w.Transitions == nil {
+		return true
+	}
+	for _, s := range w.Transitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *app) Workflow() model.Workflow {
+	return a.workflow
+}
+
+func (a *app) GetTasksByState(ctx context.Context, state string, offset int, limit int) ([]model.TodoTask, error) {
+	if limit < 0 || offset < 0 {
+		return nil, model.ErrInvalidInput
+	} else if _, ok := findState(a.workflow, state); !ok {
+		return nil, model.ErrUnknownState
+	}
+	return a.TaskRepo.GetTasksByState(ctx, state, offset, limit)
+}
@@ -0,0 +1,105 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+type localStore struct {
+	root string
+}
+
+// path returns path of the file with content stored under the key, keys
+// which point outside of the root directory are rejected
+func (s *localStore) path(key string) (string, error) {
+	p := filepath.Join(s.root, filepath.FromSlash(key))
+	if key == "" || !strings.HasPrefix(p, s.root+string(filepath.Separator)) {
+		return "", errors.Join(model.ErrBlobStore, errors.New("invalid key "+key))
+	}
+	return p, nil
+}
+
+func (s *localStore) Put(_ context.Context, key string, r io.Reader, size int64, _ string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+		return errors.Join(model.ErrBlobStore, err)
+	}
+
+	// content is written to the temporary file first, so readers never see
+	// partially written blob
+	tmp, err := os.CreateTemp(filepath.Dir(p), ".upload-*")
+	if err != nil {
+		return errors.Join(model.ErrBlobStore, err)
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	n, err := io.Copy(tmp, r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Join(model.ErrBlobStore, err)
+	} else if n != size {
+		return errors.Join(model.ErrBlobStore, io.ErrUnexpectedEOF)
+	}
+
+	if err = os.Rename(tmp.Name(), p); err != nil {
+		return errors.Join(model.ErrBlobStore, err)
+	}
+	return nil
+}
+
+func (s *localStore) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, model.ErrBlobNotFound
+	} else if err != nil {
+		return nil, errors.Join(model.ErrBlobStore, err)
+	}
+	return f, nil
+}
+
+func (s *localStore) Delete(_ context.Context, key string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return errors.Join(model.ErrBlobStore, err)
+	}
+	return nil
+}
+
+// NewLocal creates blob storage which keeps content in files inside of the
+// root directory
+func NewLocal(root string) (app.BlobStore, error) {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.MkdirAll(abs, 0o750); err != nil {
+		return nil, err
+	}
+	return &localStore{
+		root: abs,
+	}, nil
+}
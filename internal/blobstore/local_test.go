@@ -0,0 +1,60 @@
+package blobstore
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"todo-list/internal/model"
+)
+
+func TestLocalStore(t *testing.T) {
+	root := t.TempDir()
+	store, err := NewLocal(root)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	content := "\x89PNG screenshot"
+
+	t.Run("test of storing of the content", func(t *testing.T) {
+		err := store.Put(ctx, "tasks/1/abc", strings.NewReader(content), int64(len(content)), "image/png")
+		assert.NoError(t, err)
+		b, err := os.ReadFile(filepath.Join(root, "tasks", "1", "abc"))
+		assert.NoError(t, err)
+		assert.Equal(t, content, string(b))
+	})
+
+	t.Run("test of getting of the stored content", func(t *testing.T) {
+		r, err := store.Get(ctx, "tasks/1/abc")
+		require.NoError(t, err)
+		defer func() {
+			_ = r.Close()
+		}()
+		b, err := io.ReadAll(r)
+		assert.NoError(t, err)
+		assert.Equal(t, content, string(b))
+	})
+
+	t.Run("test of storing of the truncated content", func(t *testing.T) {
+		err := store.Put(ctx, "tasks/1/def", strings.NewReader(content), int64(len(content))+1, "image/png")
+		assert.ErrorIs(t, err, model.ErrBlobStore)
+		_, err = store.Get(ctx, "tasks/1/def")
+		assert.ErrorIs(t, err, model.ErrBlobNotFound)
+	})
+
+	t.Run("test of deleting of the stored content", func(t *testing.T) {
+		assert.NoError(t, store.Delete(ctx, "tasks/1/abc"))
+		_, err := store.Get(ctx, "tasks/1/abc")
+		assert.ErrorIs(t, err, model.ErrBlobNotFound)
+		assert.NoError(t, store.Delete(ctx, "tasks/1/abc"))
+	})
+
+	t.Run("test of key outside of the root directory", func(t *testing.T) {
+		_, err := store.Get(ctx, "../secret")
+		assert.ErrorIs(t, err, model.ErrBlobStore)
+	})
+}
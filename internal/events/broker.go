@@ -0,0 +1,125 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+// subscriberBuffer is a number of events waiting for the subscriber, the
+// subscriber which falls behind further is dropped
+const subscriberBuffer = 64
+
+// buffered is a published event with the task and the task before the
+// change decoded from its payload
+type buffered struct {
+	event model.Event
+	task  model.TodoTask
+	prev  *model.TodoTask
+}
+
+type subscriber struct {
+	ch    chan model.Event
+	match func(string, model.TodoTask, *model.TodoTask) bool
+}
+
+// broker keeps the last published events in memory and passes new events to
+// the subscribers
+type broker struct {
+	mu     sync.Mutex
+	size   int
+	events []buffered
+	ids    map[int]struct{}
+	subs   map[*subscriber]struct{}
+}
+
+func (b *broker) Publish(_ context.Context, e model.Event) error {
+	t, prev, err := Decode(e.Payload)
+	if err != nil {
+		return errors.Join(model.ErrEventSink, err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// the event is published again if another sink has failed
+	if _, ok := b.ids[e.Id]; ok {
+		return nil
+	}
+	if len(b.events) == b.size {
+		delete(b.ids, b.events[0].event.Id)
+		b.events = append(b.events[:0], b.events[1:]...)
+	}
+	b.events = append(b.events, buffered{event: e, task: t, prev: prev})
+	b.ids[e.Id] = struct{}{}
+
+	for s := range b.subs {
+		if !s.match(e.Type, t, prev) {
+			continue
+		}
+		select {
+		case s.ch <- e:
+		default: // subscriber resumes from the buffer after reconnection
+			delete(b.subs, s)
+			close(s.ch)
+		}
+	}
+	return nil
+}
+
+func (b *broker) Subscribe(ctx context.Context, afterId int, match func(string, model.TodoTask, *model.TodoTask) bool) model.EventStream {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stream := model.EventStream{
+		Missed: make([]model.Event, 0),
+	}
+	if afterId != 0 {
+		start := -1
+		for i, e := range b.events {
+			if e.event.Id == afterId {
+				start = i + 1
+				break
+			}
+		}
+		if start < 0 {
+			stream.Lost = true
+		} else {
+			for _, e := range b.events[start:] {
+				if match(e.event.Type, e.task, e.prev) {
+					stream.Missed = append(stream.Missed, e.event)
+				}
+			}
+		}
+	}
+
+	s := &subscriber{
+		ch:    make(chan model.Event, subscriberBuffer),
+		match: match,
+	}
+	b.subs[s] = struct{}{}
+	stream.Events = s.ch
+
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subs[s]; ok {
+			delete(b.subs, s)
+			close(s.ch)
+		}
+	}()
+	return stream
+}
+
+// NewBroker creates sink which keeps size last published events in memory
+// and passes new events to the subscribers of the stream
+func NewBroker(size int) app.EventBroker {
+	return &broker{
+		size: max(size, 1),
+		ids:  make(map[int]struct{}, size),
+		subs: make(map[*subscriber]struct{}),
+	}
+}
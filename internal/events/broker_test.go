@@ -0,0 +1,106 @@
+package events
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	"todo-list/internal/model"
+)
+
+// brokerEvent returns event with given id about the task assigned to the user
+func brokerEvent(t *testing.T, id int, user string) model.Event {
+	e, err := New(model.EventTaskUpdated, model.TodoTask{Id: id, Assignees: []string{user}}, nil)
+	require.NoError(t, err)
+	e.Id = id
+	return e
+}
+
+// assignedTo returns matcher of the tasks assigned to the user
+func assignedTo(user string) func(string, model.TodoTask, *model.TodoTask) bool {
+	return func(_ string, t model.TodoTask, _ *model.TodoTask) bool {
+		return len(t.Assignees) > 0 && t.Assignees[0] == user
+	}
+}
+
+func ids(events []model.Event) []int {
+	res := make([]int, 0, len(events))
+	for _, e := range events {
+		res = append(res, e.Id)
+	}
+	return res
+}
+
+func TestBroker(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("resuming from the buffered event", func(t *testing.T) {
+		b := NewBroker(10)
+		for i := 1; i <= 4; i++ {
+			require.NoError(t, b.Publish(ctx, brokerEvent(t, i, "alice")))
+		}
+		require.NoError(t, b.Publish(ctx, brokerEvent(t, 5, "bob")))
+		// the event published again is skipped
+		require.NoError(t, b.Publish(ctx, brokerEvent(t, 3, "alice")))
+
+		stream := b.Subscribe(ctx, 2, assignedTo("alice"))
+		assert.False(t, stream.Lost)
+		assert.Equal(t, []int{3, 4}, ids(stream.Missed))
+
+		stream = b.Subscribe(ctx, 0, assignedTo("alice"))
+		assert.False(t, stream.Lost)
+		assert.Empty(t, stream.Missed)
+	})
+
+	t.Run("resuming from the evicted event", func(t *testing.T) {
+		b := NewBroker(2)
+		for i := 1; i <= 3; i++ {
+			require.NoError(t, b.Publish(ctx, brokerEvent(t, i, "alice")))
+		}
+
+		stream := b.Subscribe(ctx, 1, assignedTo("alice"))
+		assert.True(t, stream.Lost)
+		assert.Empty(t, stream.Missed)
+		stream = b.Subscribe(ctx, 2, assignedTo("alice"))
+		assert.False(t, stream.Lost)
+		assert.Equal(t, []int{3}, ids(stream.Missed))
+	})
+
+	t.Run("receiving of new events until the end of the subscription", func(t *testing.T) {
+		b := NewBroker(10)
+		subCtx, cancel := context.WithCancel(ctx)
+		stream := b.Subscribe(subCtx, 0, assignedTo("alice"))
+
+		require.NoError(t, b.Publish(ctx, brokerEvent(t, 1, "bob")))
+		require.NoError(t, b.Publish(ctx, brokerEvent(t, 2, "alice")))
+		assert.Equal(t, 2, (<-stream.Events).Id)
+
+		cancel()
+		select {
+		case _, ok := <-stream.Events:
+			assert.False(t, ok)
+		case <-time.After(time.Second):
+			t.Fatal("stream is not closed")
+		}
+	})
+
+	t.Run("dropping of the subscriber which falls behind", func(t *testing.T) {
+		b := NewBroker(1000)
+		stream := b.Subscribe(ctx, 0, assignedTo("alice"))
+		for i := 1; i <= subscriberBuffer+1; i++ {
+			require.NoError(t, b.Publish(ctx, brokerEvent(t, i, "alice")))
+		}
+
+		received := 0
+		for range stream.Events {
+			received++
+		}
+		assert.Equal(t, subscriberBuffer, received)
+	})
+
+	t.Run("publishing of the event with invalid payload", func(t *testing.T) {
+		b := NewBroker(10)
+		assert.ErrorIs(t, b.Publish(ctx, model.Event{Id: 1, Payload: []byte("{")}), model.ErrEventSink)
+	})
+}
@@ -0,0 +1,129 @@
+package events
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"time"
+	"todo-list/internal/model"
+)
+
+// date is a planning date of the task in the payload
+type date struct {
+	Year  int `json:"year"`
+	Month int `json:"month"`
+	Day   int `json:"day"`
+}
+
+// previous contains fields of the task before the change which define
+// scopes of the subscribers, so the task leaving the scope is streamed too
+type previous struct {
+	PlanningDate date     `json:"planning_date"`
+	Assignees    []string `json:"assignees"`
+	Project      string   `json:"project"`
+}
+
+// payload is a JSON body of the event published to the sinks
+type payload struct {
+	Id        string    `json:"id"`
+	Event     string    `json:"event"`
+	CreatedAt time.Time `json:"created_at"`
+	Task      struct {
+		Id           int      `json:"id"`
+		Title        string   `json:"title"`
+		Description  string   `json:"description"`
+		PlanningDate date     `json:"planning_date"`
+		Status       bool     `json:"status"`
+		State        string   `json:"state"`
+		Assignees    []string `json:"assignees"`
+		Project      string   `json:"project"`
+	} `json:"task"`
+	Previous *previous `json:"previous,omitempty"`
+}
+
+// newKey returns random key of the event in the form of UUID version 4
+func newKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// New returns event of given type about the task with new key and JSON
+// payload, prev is the task before the change or nil if it is created or
+// deleted
+func New(event string, t model.TodoTask, prev *model.TodoTask) (model.Event, error) {
+	key, err := newKey()
+	if err != nil {
+		return model.Event{}, err
+	}
+
+	var p payload
+	p.Id, p.Event, p.CreatedAt = key, event, time.Now().UTC()
+	p.Task.Id, p.Task.Title, p.Task.Description = t.Id, t.Title, t.Description
+	p.Task.PlanningDate.Year, p.Task.PlanningDate.Month, p.Task.PlanningDate.Day = t.PlanningDate.Year, int(t.PlanningDate.Month), t.PlanningDate.Day
+	p.Task.Status, p.Task.State, p.Task.Assignees, p.Task.Project = t.Status, t.State, t.Assignees, t.Project
+	if p.Task.Assignees == nil {
+		p.Task.Assignees = []string{}
+	}
+	if prev != nil {
+		p.Previous = &previous{
+			PlanningDate: date{Year: prev.PlanningDate.Year, Month: int(prev.PlanningDate.Month), Day: prev.PlanningDate.Day},
+			Assignees:    prev.Assignees,
+			Project:      prev.Project,
+		}
+		if p.Previous.Assignees == nil {
+			p.Previous.Assignees = []string{}
+		}
+	}
+
+	b, err := json.Marshal(p)
+	if err != nil {
+		return model.Event{}, err
+	}
+	return model.Event{
+		Key:       key,
+		Type:      event,
+		TaskId:    t.Id,
+		Payload:   b,
+		CreatedAt: p.CreatedAt,
+	}, nil
+}
+
+// Decode returns the task and the task before the change from the JSON
+// payload of the event, the previous task is nil if it is not in the payload
+func Decode(b []byte) (model.TodoTask, *model.TodoTask, error) {
+	var p payload
+	if err := json.Unmarshal(b, &p); err != nil {
+		return model.TodoTask{}, nil, err
+	}
+	t := model.TodoTask{
+		Id:          p.Task.Id,
+		Title:       p.Task.Title,
+		Description: p.Task.Description,
+		PlanningDate: model.Date{
+			Year:  p.Task.PlanningDate.Year,
+			Month: time.Month(p.Task.PlanningDate.Month),
+			Day:   p.Task.PlanningDate.Day,
+		},
+		Status:    p.Task.Status,
+		State:     p.Task.State,
+		Assignees: p.Task.Assignees,
+		Project:   p.Task.Project,
+	}
+	if p.Previous == nil {
+		return t, nil, nil
+	}
+
+	prev := t
+	prev.PlanningDate = model.Date{
+		Year:  p.Previous.PlanningDate.Year,
+		Month: time.Month(p.Previous.PlanningDate.Month),
+		Day:   p.Previous.PlanningDate.Day,
+	}
+	prev.Assignees, prev.Project = p.Previous.Assignees, p.Previous.Project
+	return t, &prev, nil
+}
@@ -0,0 +1,67 @@
+package events
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"regexp"
+	"testing"
+	"time"
+	"todo-list/internal/model"
+)
+
+func TestNew(t *testing.T) {
+	task := model.TodoTask{
+		Id:           7,
+		Title:        "Title",
+		PlanningDate: model.Date{Year: 2099, Month: time.March, Day: 4},
+		Status:       true,
+		State:        "done",
+		Project:      "backend",
+	}
+
+	e, err := New(model.EventTaskCompleted, task, nil)
+	require.NoError(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), e.Key)
+	assert.Equal(t, model.EventTaskCompleted, e.Type)
+	assert.Equal(t, 7, e.TaskId)
+
+	var p payload
+	require.NoError(t, json.Unmarshal(e.Payload, &p))
+	assert.Equal(t, e.Key, p.Id)
+	assert.Equal(t, model.EventTaskCompleted, p.Event)
+	assert.Equal(t, "Title", p.Task.Title)
+	assert.Equal(t, 3, p.Task.PlanningDate.Month)
+	assert.Equal(t, []string{}, p.Task.Assignees)
+	assert.Equal(t, "backend", p.Task.Project)
+
+	other, err := New(model.EventTaskCompleted, task, nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, e.Key, other.Key)
+}
+
+func TestDecode(t *testing.T) {
+	task := model.TodoTask{
+		Id:           8,
+		Title:        "Title",
+		PlanningDate: model.Date{Year: 2099, Month: time.March, Day: 5},
+		State:        "todo",
+		Assignees:    []string{"bob"},
+	}
+	prev := task
+	prev.PlanningDate, prev.Assignees, prev.Project = model.Date{Year: 2099, Month: time.March, Day: 4}, []string{"alice"}, "backend"
+
+	e, err := New(model.EventTaskUpdated, task, &prev)
+	require.NoError(t, err)
+	decoded, decodedPrev, err := Decode(e.Payload)
+	require.NoError(t, err)
+	assert.Equal(t, task, decoded)
+	require.NotNil(t, decodedPrev)
+	assert.Equal(t, prev, *decodedPrev)
+
+	e, err = New(model.EventTaskCreated, task, nil)
+	require.NoError(t, err)
+	_, decodedPrev, err = Decode(e.Payload)
+	require.NoError(t, err)
+	assert.Nil(t, decodedPrev)
+}
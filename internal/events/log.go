@@ -0,0 +1,24 @@
+package events
+
+import (
+	"context"
+	"log"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+type logSink struct {
+	logger *log.Logger
+}
+
+func (s *logSink) Publish(_ context.Context, e model.Event) error {
+	s.logger.Printf("event %s %s of task %d: %s\n", e.Key, e.Type, e.TaskId, e.Payload)
+	return nil
+}
+
+// NewLog creates sink which writes events to the logger
+func NewLog(logger *log.Logger) app.EventSink {
+	return &logSink{
+		logger: logger,
+	}
+}
@@ -0,0 +1,167 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+// MsgIdHeader is a header of the message with the key of the event, JetStream
+// streams use it to skip duplicates
+const MsgIdHeader = "Nats-Msg-Id"
+
+// NATSConfig contains settings of the connection to NATS server
+type NATSConfig struct {
+	// Addr is host and port of the server
+	Addr string
+
+	// Subject is a prefix of the subjects, event of type task.created is
+	// published to <Subject>.task.created
+	Subject string
+
+	// Timeout limits connecting to the server and publishing of one event
+	Timeout time.Duration
+}
+
+// natsInfo is a part of the INFO message of the server used by the sink
+type natsInfo struct {
+	Headers bool `json:"headers"`
+}
+
+// natsSink publishes events over the text protocol of NATS keeping a single
+// connection which is reopened after an error
+type natsSink struct {
+	cfg     NATSConfig
+	mu      sync.Mutex
+	conn    net.Conn
+	r       *bufio.Reader
+	headers bool
+}
+
+// connect opens connection to the server and introduces the client
+func (s *natsSink) connect(ctx context.Context) error {
+	d := net.Dialer{Timeout: s.cfg.Timeout}
+	conn, err := d.DialContext(ctx, "tcp", s.cfg.Addr)
+	if err != nil {
+		return err
+	}
+	if err = conn.SetDeadline(time.Now().Add(s.cfg.Timeout)); err != nil {
+		_ = conn.Close()
+		return err
+	}
+
+	r := bufio.NewReader(conn)
+	line, err := r.ReadString('\n')
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	var info natsInfo
+	if !strings.HasPrefix(line, "INFO ") {
+		_ = conn.Close()
+		return fmt.Errorf("unexpected greeting %q", strings.TrimSpace(line))
+	} else if err = json.Unmarshal([]byte(line[len("INFO "):]), &info); err != nil {
+		_ = conn.Close()
+		return err
+	}
+
+	connect := fmt.Sprintf(`CONNECT {"verbose":false,"pedantic":false,"name":"todo-list","lang":"go","protocol":1,"headers":%t}`+"\r\n", info.Headers)
+	if _, err = conn.Write([]byte(connect)); err != nil {
+		_ = conn.Close()
+		return err
+	}
+
+	s.conn, s.r, s.headers = conn, r, info.Headers
+	return nil
+}
+
+// close closes broken connection, so the next event opens a new one
+func (s *natsSink) close() {
+	if s.conn != nil {
+		_ = s.conn.Close()
+		s.conn, s.r = nil, nil
+	}
+}
+
+// publish writes the message and waits for the reply to PING, so the message
+// is processed by the server when it returns
+func (s *natsSink) publish(ctx context.Context, e model.Event) error {
+	deadline := time.Now().Add(s.cfg.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := s.conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+
+	subject := s.cfg.Subject + "." + e.Type
+	var msg string
+	if s.headers {
+		hdr := "NATS/1.0\r\n" + MsgIdHeader + ": " + e.Key + "\r\n\r\n"
+		msg = fmt.Sprintf("HPUB %s %d %d\r\n%s%s\r\nPING\r\n", subject, len(hdr), len(hdr)+len(e.Payload), hdr, e.Payload)
+	} else {
+		// the key is also a part of the payload, so consumers of old servers
+		// skip duplicates by it
+		msg = fmt.Sprintf("PUB %s %d\r\n%s\r\nPING\r\n", subject, len(e.Payload), e.Payload)
+	}
+	if _, err := s.conn.Write([]byte(msg)); err != nil {
+		return err
+	}
+
+	for {
+		line, err := s.r.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err = s.conn.Write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return errors.New(strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+}
+
+func (s *natsSink) Publish(ctx context.Context, e model.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		if err := s.connect(ctx); err != nil {
+			return errors.Join(model.ErrEventSink, err)
+		}
+	}
+	if err := s.publish(ctx, e); err != nil {
+		s.close()
+		return errors.Join(model.ErrEventSink, err)
+	}
+	return nil
+}
+
+// NewNATS creates sink which publishes events to NATS server, connection is
+// opened on the first event
+func NewNATS(cfg NATSConfig) (app.EventSink, error) {
+	if cfg.Addr == "" {
+		return nil, errors.New("address of NATS server is not set")
+	} else if cfg.Subject == "" {
+		return nil, errors.New("subject of the events is not set")
+	} else if cfg.Timeout <= 0 {
+		return nil, errors.New("timeout must be positive")
+	}
+	return &natsSink{
+		cfg: cfg,
+	}, nil
+}
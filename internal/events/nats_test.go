@@ -0,0 +1,177 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"todo-list/internal/model"
+)
+
+// natsMsg is a message received by the stand-in of NATS server
+type natsMsg struct {
+	subject string
+	header  string
+	payload string
+}
+
+// natsStandIn is a local stand-in of NATS server which records published
+// messages and rejects the ones sent to subjects ending with .rejected
+type natsStandIn struct {
+	ln      net.Listener
+	headers bool
+	mu      sync.Mutex
+	msgs    []natsMsg
+	conns   int
+}
+
+func newNATSStandIn(t *testing.T, headers bool) *natsStandIn {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = ln.Close()
+	})
+
+	s := &natsStandIn{ln: ln, headers: headers}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.conns++
+			s.mu.Unlock()
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *natsStandIn) serve(conn net.Conn) {
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	_, _ = fmt.Fprintf(conn, "INFO {\"server_id\":\"stand-in\",\"headers\":%t,\"max_payload\":1048576}\r\n", s.headers)
+	r := bufio.NewReader(conn)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "PING":
+			_, _ = conn.Write([]byte("PONG\r\n"))
+		case "HPUB", "PUB":
+			var msg natsMsg
+			msg.subject = fields[1]
+			hdrLen, total := 0, 0
+			if fields[0] == "HPUB" {
+				hdrLen, _ = strconv.Atoi(fields[2])
+				total, _ = strconv.Atoi(fields[3])
+			} else {
+				total, _ = strconv.Atoi(fields[2])
+			}
+			buf := make([]byte, total+2)
+			if _, err = io.ReadFull(r, buf); err != nil {
+				return
+			}
+			msg.header, msg.payload = string(buf[:hdrLen]), string(buf[hdrLen:total])
+
+			if strings.HasSuffix(msg.subject, ".rejected") {
+				_, _ = conn.Write([]byte("-ERR 'Permissions Violation for Publish to " + msg.subject + "'\r\n"))
+				return
+			}
+			s.mu.Lock()
+			s.msgs = append(s.msgs, msg)
+			s.mu.Unlock()
+		}
+	}
+}
+
+func (s *natsStandIn) received() ([]natsMsg, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]natsMsg(nil), s.msgs...), s.conns
+}
+
+func TestNATSPublish(t *testing.T) {
+	ctx := context.Background()
+	e := model.Event{Id: 1, Key: "6f1c0a52-3c1e-4b8e-9d0e-4c5a8f2b7e10", Type: model.EventTaskCreated, TaskId: 2, Payload: []byte(`{"id":"6f1c0a52-3c1e-4b8e-9d0e-4c5a8f2b7e10"}`)}
+
+	t.Run("publishing of the event with the key in the header", func(t *testing.T) {
+		server := newNATSStandIn(t, true)
+		sink, err := NewNATS(NATSConfig{Addr: server.ln.Addr().String(), Subject: "todo.events", Timeout: time.Second})
+		require.NoError(t, err)
+
+		require.NoError(t, sink.Publish(ctx, e))
+		require.NoError(t, sink.Publish(ctx, e))
+
+		msgs, conns := server.received()
+		require.Len(t, msgs, 2)
+		assert.Equal(t, 1, conns)
+		assert.Equal(t, "todo.events.task.created", msgs[0].subject)
+		assert.Equal(t, "NATS/1.0\r\n"+MsgIdHeader+": "+e.Key+"\r\n\r\n", msgs[0].header)
+		assert.Equal(t, string(e.Payload), msgs[0].payload)
+	})
+
+	t.Run("publishing of the event to the server without headers", func(t *testing.T) {
+		server := newNATSStandIn(t, false)
+		sink, err := NewNATS(NATSConfig{Addr: server.ln.Addr().String(), Subject: "todo.events", Timeout: time.Second})
+		require.NoError(t, err)
+
+		require.NoError(t, sink.Publish(ctx, e))
+
+		msgs, _ := server.received()
+		require.Len(t, msgs, 1)
+		assert.Empty(t, msgs[0].header)
+		assert.Equal(t, string(e.Payload), msgs[0].payload)
+	})
+
+	t.Run("reconnection after the event is rejected", func(t *testing.T) {
+		server := newNATSStandIn(t, true)
+		sink, err := NewNATS(NATSConfig{Addr: server.ln.Addr().String(), Subject: "todo.events", Timeout: time.Second})
+		require.NoError(t, err)
+
+		rejected := e
+		rejected.Type = "rejected"
+		assert.ErrorIs(t, sink.Publish(ctx, rejected), model.ErrEventSink)
+		require.NoError(t, sink.Publish(ctx, e))
+
+		msgs, conns := server.received()
+		assert.Len(t, msgs, 1)
+		assert.Equal(t, 2, conns)
+	})
+
+	t.Run("publishing to unavailable server", func(t *testing.T) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := ln.Addr().String()
+		require.NoError(t, ln.Close())
+
+		sink, err := NewNATS(NATSConfig{Addr: addr, Subject: "todo.events", Timeout: time.Second})
+		require.NoError(t, err)
+		assert.ErrorIs(t, sink.Publish(ctx, e), model.ErrEventSink)
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		_, err := NewNATS(NATSConfig{Subject: "todo.events", Timeout: time.Second})
+		assert.Error(t, err)
+		_, err = NewNATS(NATSConfig{Addr: "localhost:4222", Timeout: time.Second})
+		assert.Error(t, err)
+	})
+}
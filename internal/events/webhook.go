@@ -0,0 +1,23 @@
+package events
+
+import (
+	"context"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+type webhookSink struct {
+	webhooks app.WebhookRepo
+}
+
+func (s *webhookSink) Publish(ctx context.Context, e model.Event) error {
+	return s.webhooks.AddDeliveries(ctx, e)
+}
+
+// NewWebhook creates sink which enqueues deliveries of the events to the
+// webhooks subscribed to them, the deliveries are sent by the app
+func NewWebhook(wr app.WebhookRepo) app.EventSink {
+	return &webhookSink{
+		webhooks: wr,
+	}
+}
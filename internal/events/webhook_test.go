@@ -0,0 +1,18 @@
+package events
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"todo-list/internal/app/mocks"
+	"todo-list/internal/model"
+)
+
+func TestWebhookPublish(t *testing.T) {
+	wr := new(mocks.WebhookRepo)
+	e := model.Event{Id: 1, Key: "key", Type: model.EventTaskCreated, TaskId: 2, Payload: []byte(`{}`)}
+	wr.On("AddDeliveries", context.Background(), e).Return(nil).Once()
+
+	assert.NoError(t, NewWebhook(wr).Publish(context.Background(), e))
+	wr.AssertExpectations(t)
+}
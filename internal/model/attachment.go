@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// Attachment is a struct for file attached to the task. Content of the file is
+// kept in the blob storage under the Key
+type Attachment struct {
+	Id          int
+	TaskId      int
+	Name        string
+	ContentType string
+	Size        int64
+	Key         string
+	CreatedAt   time.Time
+}
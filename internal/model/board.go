@@ -0,0 +1,21 @@
+package model
+
+// Move describes new position of the task on the board: column of the state
+// and place before or after other task of this column. Task is moved to the
+// end of the column if neither Before nor After is set
+type Move struct {
+	State  string
+	Before int
+	After  int
+}
+
+// Column contains tasks in the same state of the workflow ordered by rank
+type Column struct {
+	State State
+	Tasks []TodoTask
+}
+
+// Board contains columns for all states of the workflow in its order
+type Board struct {
+	Columns []Column
+}
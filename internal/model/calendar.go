@@ -0,0 +1,12 @@
+package model
+
+// CalendarObject is a task assigned to the user as a resource of their CalDAV
+// calendar. Name is a name of the resource chosen by the client which created
+// the task, other tasks get the default name. UID is kept as the client sent
+// it and is empty for the tasks which were not created by CalDAV clients
+type CalendarObject struct {
+	User string
+	Name string
+	UID  string
+	Task TodoTask
+}
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// Comment is a struct for message in discussion of the task. Text is stored
+// as Markdown source and is never rendered on the server side
+type Comment struct {
+	Id        int
+	TaskId    int
+	Author    string
+	Text      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,15 @@
+package model
+
+// Dependency is a link between tasks: task with BlockerId must be done before
+// task with BlockedId can be done
+type Dependency struct {
+	BlockerId int
+	BlockedId int
+}
+
+// DependencyGraph contains the task with all tasks which transitively block it
+// or are blocked by it
+type DependencyGraph struct {
+	Tasks        []TodoTask
+	Dependencies []Dependency
+}
@@ -3,9 +3,49 @@ package model
 import "errors"
 
 var (
-	ErrTaskRepo     = errors.New("something wrong with task database")
-	ErrTaskNotFound = errors.New("todo task with required id was not found")
-	ErrInvalidTask  = errors.New("some of the fields of task are invalid")
-	ErrInvalidInput = errors.New("invalid input in request")
-	ErrUnknown      = errors.New("unknown error")
+	ErrTaskRepo        = errors.New("something wrong with task database")
+	ErrTaskNotFound    = errors.New("todo task with required id was not found")
+	ErrInvalidTask     = errors.New("some of the fields of task are invalid")
+	ErrInvalidInput    = errors.New("invalid input in request")
+	ErrTaskBlocked     = errors.New("todo task is blocked by tasks which are not done")
+	ErrDependencyCycle = errors.New("dependency between tasks makes a cycle")
+	ErrUnknownState    = errors.New("state of the task is not in the workflow")
+	ErrTransition      = errors.New("transition between states of the task is not allowed")
+	ErrVersionConflict = errors.New("todo task was changed since the version known by the client")
+	ErrDayOrder        = errors.New("ids don't match the tasks planned on the date")
+	ErrDuplicateTask   = errors.New("todo task with the same title is already planned on the date")
+	ErrImportAborted   = errors.New("row is not imported because other rows are skipped")
+	ErrNotMember       = errors.New("user is not a member of the workspace or project")
+	ErrUnknownUser     = errors.New("user of the request is not specified")
+	ErrForbidden       = errors.New("user has no rights for this action")
+	ErrUnknown         = errors.New("unknown error")
+
+	ErrCommentNotFound = errors.New("comment with required id was not found")
+	ErrInvalidComment  = errors.New("text of the comment is invalid")
+
+	ErrProjectNotFound    = errors.New("project with required name was not found")
+	ErrProjectExists      = errors.New("project with required name already exists")
+	ErrMemberAssigned     = errors.New("member of the project is assigned to its tasks")
+	ErrBlobStore          = errors.New("something wrong with blob storage")
+	ErrBlobNotFound       = errors.New("blob with required key was not found")
+	ErrAttachmentNotFound = errors.New("attachment with required id was not found")
+	ErrInvalidAttachment  = errors.New("name of the attachment is invalid")
+	ErrAttachmentTooLarge = errors.New("attachment is too large")
+
+	ErrReminderNotFound = errors.New("reminder with required id was not found")
+	ErrInvalidReminder  = errors.New("some of the fields of reminder are invalid")
+	ErrNotifier         = errors.New("something wrong with delivery of the notification")
+
+	ErrSubscriptionNotFound = errors.New("webhook subscription with required id was not found")
+	ErrInvalidSubscription  = errors.New("some of the fields of webhook subscription are invalid")
+
+	ErrEventSink = errors.New("something wrong with publishing of the event")
+
+	ErrCalendarDisabled   = errors.New("calendar feeds are not enabled")
+	ErrFeedNotFound       = errors.New("calendar feed with required token was not found")
+	ErrCredentialNotFound = errors.New("calendar credential of the user was not found")
+	ErrWrongPassword      = errors.New("CalDAV password of the user is wrong")
+	ErrObjectNotFound     = errors.New("calendar object with required name was not found")
+	ErrObjectExists       = errors.New("calendar object with required name already exists")
+	ErrInvalidObject      = errors.New("calendar object is invalid")
 )
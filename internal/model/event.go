@@ -0,0 +1,44 @@
+package model
+
+import "time"
+
+// Event is a change of the task written to the outbox in the same
+// transaction as the change itself
+type Event struct {
+	// Id is a position of the event in the outbox, later events have greater ids
+	Id int
+
+	// Key identifies the event for the consumers. The event could be
+	// published more than once, so consumers skip the keys they have seen
+	Key string
+
+	Type      string
+	TaskId    int
+	Payload   []byte
+	Attempts  int
+	CreatedAt time.Time
+}
+
+// EventScope limits the events received by the subscriber to the tasks
+// assigned to Assignee, planned on Date and belonging to Project before or
+// after the change, empty fields don't limit them
+type EventScope struct {
+	Assignee string
+	Date     Date
+	Project  string
+}
+
+// EventStream is a subscription to the events about the tasks
+type EventStream struct {
+	// Missed are buffered events published after the last event seen by the
+	// subscriber in order of publishing
+	Missed []Event
+
+	// Lost is true if events after the last seen one are not buffered any
+	// more, so the subscriber has to reload the tasks
+	Lost bool
+
+	// Events receives next events. It is closed when the subscription ends or
+	// the subscriber falls behind, so it resumes from the last received event
+	Events <-chan Event
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// Actions which move the task from one planning date to another
+const (
+	ActionRollover      = "rollover"
+	ActionSnooze        = "snooze"
+	ActionReschedule    = "reschedule"
+	ActionRescheduleDay = "reschedule_day"
+)
+
+// HistoryEntry is a record about moving of the task from one planning date to
+// another, Action shows what has moved the task
+type HistoryEntry struct {
+	Id        int
+	TaskId    int
+	Action    string
+	FromDate  Date
+	ToDate    Date
+	CreatedAt time.Time
+}
@@ -0,0 +1,47 @@
+package model
+
+// modes of the import: in the best effort mode valid rows are added and the
+// others are skipped, in the transaction mode rows are added in a single
+// transaction only if all of them are valid
+const (
+	ImportBestEffort  = "best_effort"
+	ImportTransaction = "transaction"
+)
+
+// outcomes of the rows of the imported file
+const (
+	ImportCreated = "created"
+	ImportSkipped = "skipped"
+)
+
+// ImportRow is a task read from the row of the imported file. Line is a
+// number of the row in the file, Warnings are the parts of the row which
+// could not be read into the fields of the task and Err is the reason why the
+// row could not be read
+type ImportRow struct {
+	Line     int
+	Task     TodoTask
+	Warnings []string
+	Err      error
+}
+
+// ImportResult is an outcome of the row. Task is the created task or the task
+// which would be created in the dry run, Warnings are the warnings of the row
+// and Err is the reason why the row is skipped
+type ImportResult struct {
+	Line     int
+	Status   string
+	Task     TodoTask
+	Warnings []string
+	Err      error
+}
+
+// ImportReport contains outcomes of all rows of the imported file, nothing is
+// created in the dry run
+type ImportReport struct {
+	Mode    string
+	DryRun  bool
+	Created int
+	Skipped int
+	Results []ImportResult
+}
@@ -0,0 +1,7 @@
+package model
+
+// Project is a struct for the group of tasks and users who can be assigned to them
+type Project struct {
+	Name    string
+	Members []string
+}
@@ -0,0 +1,44 @@
+package model
+
+import "time"
+
+// Channels through which reminders are delivered
+const (
+	ChannelEmail   = "email"
+	ChannelWebhook = "webhook"
+)
+
+// Statuses of the reminders
+const (
+	ReminderPending = "pending"
+	ReminderSent    = "sent"
+	ReminderFailed  = "failed"
+)
+
+// Reminder is a notification about the task sent to the recipient (email
+// address or URL of the webhook) through the channel. It fires at the time At
+// or, if At is zero, at Offset from the beginning of the planning date of the
+// task in UTC, so such reminder follows the task when it is rescheduled.
+// FireAt is the time of the next attempt to deliver the reminder
+type Reminder struct {
+	Id        int
+	TaskId    int
+	At        time.Time
+	Offset    time.Duration
+	Channel   string
+	Recipient string
+	Status    string
+	Attempts  int
+	FireAt    time.Time
+	CreatedAt time.Time
+}
+
+// Delivery is a record about an attempt to deliver the reminder, Error is
+// empty if the attempt was successful
+type Delivery struct {
+	Id         int
+	ReminderId int
+	Attempt    int
+	Error      string
+	CreatedAt  time.Time
+}
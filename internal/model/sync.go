@@ -0,0 +1,61 @@
+package model
+
+import "time"
+
+// operations of the changes made by offline clients
+const (
+	SyncCreate = "create"
+	SyncUpdate = "update"
+	SyncDelete = "delete"
+)
+
+// outcomes of the changes made by offline clients
+const (
+	SyncApplied  = "applied"
+	SyncConflict = "conflict"
+	SyncRejected = "rejected"
+)
+
+// Tombstone marks the deleted task, so offline clients delete their copy of it
+type Tombstone struct {
+	TaskId    int
+	Version   int
+	DeletedAt time.Time
+}
+
+// SyncChanges are tasks changed and deleted since the sync token of the client
+type SyncChanges struct {
+	Tasks      []TodoTask
+	Tombstones []Tombstone
+
+	// Reset is true if the client has no token or its token is too old, then
+	// Tasks are all the tasks and the client replaces its copy with them
+	Reset bool
+
+	// Token is passed by the client to get the next changes
+	Token string
+}
+
+// SyncChange is a change of the task made by the client while it was offline.
+// Ref is the client's id of the change returned in its result. Version is the
+// last version of the task known by the client, update and deletion are
+// applied only if the task still has it
+type SyncChange struct {
+	Ref     string
+	Op      string
+	TaskId  int
+	Version int
+	Task    TodoTask
+}
+
+// SyncResult is an outcome of the change. Task is the task after the applied
+// change or the current task on the server in case of conflict, Deleted is
+// true if the conflicting task was deleted on the server. Err is the reason
+// of rejection
+type SyncResult struct {
+	Ref     string
+	Status  string
+	Task    TodoTask
+	Deleted bool
+	Err     error
+}
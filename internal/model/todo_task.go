@@ -1,10 +1,26 @@
 package model
 
-// TodoTask is a struct for planning task
+// TodoTask is a struct for planning task. State is a name of the state of the
+// task in the workflow and Status shows if this state is done. Rank defines
+// order of the tasks in the same state on the board and Position defines order
+// of the tasks planned on the same day. Overdue shows that the task was not
+// done in time and was moved to the next day or flagged by the rollover job
+// and Postponed counts moves of the task to later dates. Blocked is calculated by repository and shows that some of the tasks
+// blocking this task are not done. Version is increased by every change of the
+// task, so offline clients detect changes made since they have seen it
 type TodoTask struct {
 	Id           int
 	Title        string
 	Description  string
 	PlanningDate Date
 	Status       bool
+	State        string
+	Rank         string
+	Position     int
+	Overdue      bool
+	Postponed    int
+	Assignees    []string
+	Project      string
+	Blocked      bool
+	Version      int
 }
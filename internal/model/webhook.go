@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+// Types of the events about changes of the tasks
+const (
+	EventTaskCreated   = "task.created"
+	EventTaskUpdated   = "task.updated"
+	EventTaskCompleted = "task.completed"
+	EventTaskDeleted   = "task.deleted"
+)
+
+// EventTypes are all types of the events webhooks can subscribe to
+var EventTypes = []string{EventTaskCreated, EventTaskUpdated, EventTaskCompleted, EventTaskDeleted}
+
+// Statuses of deliveries of the events to the webhooks
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+// Subscription is a webhook which receives events of given types, payloads
+// of the events are signed with the secret
+type Subscription struct {
+	Id        int
+	URL       string
+	Events    []string
+	Secret    string
+	CreatedAt time.Time
+}
+
+// WebhookDelivery is a delivery of the event to the webhook. ResponseCode
+// and Error describe the last attempt, NextAttemptAt is a time of the next
+// attempt of pending delivery
+type WebhookDelivery struct {
+	Id             int
+	SubscriptionId int
+	Event          string
+	Payload        []byte
+	Status         string
+	Attempts       int
+	ResponseCode   int
+	Error          string
+	NextAttemptAt  time.Time
+	CreatedAt      time.Time
+}
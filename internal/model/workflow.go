@@ -0,0 +1,18 @@
+package model
+
+// State is a step of the workflow of the task. Tasks in states with Done are
+// considered done by filters of tasks by status
+type State struct {
+	Name string
+	Done bool
+}
+
+// Workflow contains states of the tasks and allowed transitions between them.
+// First state which is not done is the initial state of new tasks and first
+// done state is used when task is marked as done without specifying the state.
+// Transitions maps name of the state to names of the states the task can be
+// moved to, nil Transitions allows any transition
+type Workflow struct {
+	States      []State
+	Transitions map[string][]string
+}
@@ -0,0 +1,42 @@
+package notify
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+	"todo-list/internal/app/valid"
+)
+
+// newClient creates HTTP client which connects only to public addresses, so
+// URLs given by users can't reach services of the internal network even if
+// their names resolve to internal addresses or redirect there
+func newClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil {
+				return fmt.Errorf("address %s is not an IP address", host)
+			}
+			if err = valid.PublicIP(ip); err != nil {
+				return fmt.Errorf("connection to %s is refused: %w", host, err)
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			// proxy from the environment would connect to the target instead of the dialer
+			Proxy:               nil,
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+		},
+	}
+}
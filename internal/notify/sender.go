@@ -0,0 +1,78 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+// Headers of the requests sent to the webhooks
+const (
+	// SignatureHeader contains "sha256=" followed by hex encoded HMAC-SHA256
+	// of the body with the secret of the subscription
+	SignatureHeader = "X-Todo-Signature"
+
+	// EventHeader contains type of the event
+	EventHeader = "X-Todo-Event"
+
+	// DeliveryHeader contains id of the delivery which is the same for all
+	// attempts, so receiver can skip repeated events
+	DeliveryHeader = "X-Todo-Delivery"
+)
+
+// Sign returns value of SignatureHeader for the body signed with the secret
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type webhookSender struct {
+	client *http.Client
+}
+
+func (s *webhookSender) Send(ctx context.Context, url string, secret string, d model.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, errors.Join(model.ErrNotifier, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(secret, d.Payload))
+	req.Header.Set(EventHeader, d.Event)
+	req.Header.Set(DeliveryHeader, strconv.Itoa(d.Id))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, errors.Join(model.ErrNotifier, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, errors.Join(model.ErrNotifier, fmt.Errorf("webhook responded with status %d", resp.StatusCode))
+	}
+	return resp.StatusCode, nil
+}
+
+// NewWebhookSender creates sender which posts signed events to the webhooks,
+// webhooks on the loopback, private or link-local addresses are refused
+func NewWebhookSender(timeout time.Duration) app.WebhookSender {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &webhookSender{
+		client: newClient(timeout),
+	}
+}
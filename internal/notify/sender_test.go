@@ -0,0 +1,60 @@
+package notify
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"todo-list/internal/model"
+)
+
+func TestSign(t *testing.T) {
+	// example of HMAC-SHA256 from RFC 4231, test case 2
+	assert.Equal(t, "sha256=5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843",
+		Sign("Jefe", []byte("what do ya want for nothing?")))
+}
+
+func TestWebhookSend(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		if r.Header.Get(SignatureHeader) != Sign("secret", body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, model.EventTaskCreated, r.Header.Get(EventHeader))
+		assert.Equal(t, "7", r.Header.Get(DeliveryHeader))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	// test server listens on the loopback, which is refused by NewWebhookSender
+	s := &webhookSender{client: server.Client()}
+	d := model.WebhookDelivery{
+		Id:      7,
+		Event:   model.EventTaskCreated,
+		Payload: []byte(`{"event":"task.created","task":{"id":1}}`),
+	}
+
+	t.Run("sending of the event signed with the secret of the subscription", func(t *testing.T) {
+		code, err := s.Send(context.Background(), server.URL, "secret", d)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusAccepted, code)
+	})
+
+	t.Run("sending of the event signed with another secret", func(t *testing.T) {
+		code, err := s.Send(context.Background(), server.URL, "another", d)
+		assert.ErrorIs(t, err, model.ErrNotifier)
+		assert.Equal(t, http.StatusUnauthorized, code)
+	})
+
+	t.Run("sending of the event to the webhook on the loopback", func(t *testing.T) {
+		code, err := NewWebhookSender(time.Second).Send(context.Background(), server.URL, "secret", d)
+		assert.ErrorIs(t, err, model.ErrNotifier)
+		assert.Equal(t, 0, code)
+	})
+}
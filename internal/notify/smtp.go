@@ -0,0 +1,148 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"time"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+// defaultTimeout limits delivery of one notification if timeout is not configured
+const defaultTimeout = 30 * time.Second
+
+// SMTPConfig contains settings of the mail server which sends reminders
+type SMTPConfig struct {
+	// Addr is a host and port of the server, STARTTLS is used if the server
+	// supports it
+	Addr string
+
+	// From is an address of the sender of the reminders
+	From string
+
+	// Username and Password are used for PLAIN authentication, it is
+	// skipped if Username is empty
+	Username string
+	Password string
+
+	// Timeout limits delivery of one message
+	Timeout time.Duration
+}
+
+type smtpNotifier struct {
+	cfg  SMTPConfig
+	host string
+}
+
+// message returns headers and quoted-printable body of the email about the task
+func (n *smtpNotifier) message(r model.Reminder, t model.TodoTask) ([]byte, error) {
+	var b bytes.Buffer
+	_, _ = fmt.Fprintf(&b, "From: %s\r\n", n.cfg.From)
+	_, _ = fmt.Fprintf(&b, "To: %s\r\n", r.Recipient)
+	_, _ = fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", "Reminder: "+t.Title))
+	_, _ = fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	w := quotedprintable.NewWriter(&b)
+	_, _ = fmt.Fprintf(w, "%s\r\n\r\n", t.Title)
+	if t.Description != "" {
+		_, _ = fmt.Fprintf(w, "%s\r\n\r\n", t.Description)
+	}
+	_, _ = fmt.Fprintf(w, "Planning date: %04d-%02d-%02d\r\n", t.PlanningDate.Year, t.PlanningDate.Month, t.PlanningDate.Day)
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// send delivers the message to the recipient through the connection
+func (n *smtpNotifier) send(conn net.Conn, to string, msg []byte) error {
+	c, err := smtp.NewClient(conn, n.host)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = c.Close()
+	}()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err = c.StartTLS(&tls.Config{ServerName: n.host}); err != nil {
+			return err
+		}
+	}
+	if n.cfg.Username != "" {
+		if err = c.Auth(smtp.PlainAuth("", n.cfg.Username, n.cfg.Password, n.host)); err != nil {
+			return err
+		}
+	}
+
+	if err = c.Mail(n.cfg.From); err != nil {
+		return err
+	}
+	if err = c.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(msg); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+func (n *smtpNotifier) Notify(ctx context.Context, r model.Reminder, t model.TodoTask) error {
+	msg, err := n.message(r, t)
+	if err != nil {
+		return errors.Join(model.ErrNotifier, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, n.cfg.Timeout)
+	defer cancel()
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", n.cfg.Addr)
+	if err != nil {
+		return errors.Join(model.ErrNotifier, err)
+	}
+	deadline, _ := ctx.Deadline()
+	if err = conn.SetDeadline(deadline); err != nil {
+		_ = conn.Close()
+		return errors.Join(model.ErrNotifier, err)
+	}
+
+	if err = n.send(conn, r.Recipient, msg); err != nil {
+		return errors.Join(model.ErrNotifier, err)
+	}
+	return nil
+}
+
+// NewSMTP creates notifier which sends reminders by email through the mail server
+func NewSMTP(cfg SMTPConfig) (app.Notifier, error) {
+	host, _, err := net.SplitHostPort(cfg.Addr)
+	if err != nil {
+		return nil, err
+	} else if cfg.From == "" {
+		return nil, errors.New("sender of the emails is required")
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	return &smtpNotifier{
+		cfg:  cfg,
+		host: host,
+	}, nil
+}
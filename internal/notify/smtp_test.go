@@ -0,0 +1,186 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"todo-list/internal/model"
+)
+
+// smtpStandIn is a minimal SMTP server which accepts messages for allowed
+// recipients and keeps them in memory
+type smtpStandIn struct {
+	ln       net.Listener
+	allowed  string
+	auth     string
+	mu       sync.Mutex
+	messages []smtpMessage
+}
+
+type smtpMessage struct {
+	auth string
+	from string
+	to   string
+	data string
+}
+
+func newSMTPStandIn(t *testing.T, allowed string) *smtpStandIn {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &smtpStandIn{ln: ln, allowed: allowed}
+	t.Cleanup(func() {
+		_ = ln.Close()
+	})
+	go s.serve()
+	return s
+}
+
+func (s *smtpStandIn) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.session(conn)
+	}
+}
+
+func (s *smtpStandIn) session(conn net.Conn) {
+	defer func() {
+		_ = conn.Close()
+	}()
+	r := bufio.NewReader(conn)
+	reply := func(line string) {
+		_, _ = io.WriteString(conn, line+"\r\n")
+	}
+
+	var msg smtpMessage
+	reply("220 localhost stand-in")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+
+		switch cmd {
+		case "EHLO":
+			reply("250-localhost")
+			reply("250 AUTH PLAIN")
+		case "AUTH":
+			msg.auth = strings.TrimPrefix(line, "AUTH PLAIN ")
+			reply("235 authenticated")
+		case "MAIL":
+			msg.from = strings.Trim(strings.TrimPrefix(line, "MAIL FROM:"), "<>")
+			reply("250 ok")
+		case "RCPT":
+			msg.to = strings.Trim(strings.TrimPrefix(line, "RCPT TO:"), "<>")
+			if msg.to != s.allowed {
+				reply("550 no such user")
+			} else {
+				reply("250 ok")
+			}
+		case "DATA":
+			reply("354 go ahead")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				data.WriteString(l)
+			}
+			msg.data = data.String()
+			s.mu.Lock()
+			s.messages = append(s.messages, msg)
+			s.mu.Unlock()
+			reply("250 queued")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
+
+func (s *smtpStandIn) received() []smtpMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]smtpMessage(nil), s.messages...)
+}
+
+func TestSMTPNotify(t *testing.T) {
+	server := newSMTPStandIn(t, "alice@example.com")
+	n, err := NewSMTP(SMTPConfig{
+		Addr:     server.ln.Addr().String(),
+		From:     "todo@example.com",
+		Username: "todo",
+		Password: "secret",
+		Timeout:  time.Second,
+	})
+	require.NoError(t, err)
+
+	task := model.TodoTask{
+		Id:           1,
+		Title:        "Купить молоко",
+		Description:  "Two bottles",
+		PlanningDate: model.Date{Year: 2099, Month: time.January, Day: 2},
+	}
+
+	t.Run("delivery of the reminder to existing recipient", func(t *testing.T) {
+		err := n.Notify(context.Background(), model.Reminder{Id: 1, TaskId: 1, Recipient: "alice@example.com"}, task)
+		require.NoError(t, err)
+
+		messages := server.received()
+		require.Len(t, messages, 1)
+		assert.Equal(t, "todo@example.com", messages[0].from)
+		assert.Equal(t, "alice@example.com", messages[0].to)
+		auth, _ := base64.StdEncoding.DecodeString(messages[0].auth)
+		assert.Equal(t, "\x00todo\x00secret", string(auth))
+
+		m, err := mail.ReadMessage(strings.NewReader(messages[0].data))
+		require.NoError(t, err)
+		subject, err := new(mime.WordDecoder).DecodeHeader(m.Header.Get("Subject"))
+		require.NoError(t, err)
+		assert.Equal(t, "Reminder: Купить молоко", subject)
+		body, err := io.ReadAll(quotedprintable.NewReader(m.Body))
+		require.NoError(t, err)
+		assert.Contains(t, string(body), "Two bottles")
+		assert.Contains(t, string(body), "Planning date: 2099-01-02")
+	})
+
+	t.Run("delivery of the reminder to rejected recipient", func(t *testing.T) {
+		err := n.Notify(context.Background(), model.Reminder{Id: 2, TaskId: 1, Recipient: "bob@example.com"}, task)
+		assert.ErrorIs(t, err, model.ErrNotifier)
+		assert.Len(t, server.received(), 1)
+	})
+}
+
+func TestSMTPNotifyUnavailable(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	n, err := NewSMTP(SMTPConfig{Addr: addr, From: "todo@example.com", Timeout: time.Second})
+	require.NoError(t, err)
+
+	err = n.Notify(context.Background(), model.Reminder{Recipient: "alice@example.com"}, model.TodoTask{Title: "Title"})
+	assert.ErrorIs(t, err, model.ErrNotifier)
+}
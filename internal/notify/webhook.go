@@ -0,0 +1,79 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+// webhookPayload is a JSON body of the request sent to the webhook
+type webhookPayload struct {
+	ReminderId int       `json:"reminder_id"`
+	FireAt     time.Time `json:"fire_at"`
+	Task       struct {
+		Id           int    `json:"id"`
+		Title        string `json:"title"`
+		Description  string `json:"description"`
+		PlanningDate struct {
+			Year  int `json:"year"`
+			Month int `json:"month"`
+			Day   int `json:"day"`
+		} `json:"planning_date"`
+		State string `json:"state"`
+	} `json:"task"`
+}
+
+type webhookNotifier struct {
+	client *http.Client
+}
+
+func (n *webhookNotifier) Notify(ctx context.Context, r model.Reminder, t model.TodoTask) error {
+	var p webhookPayload
+	p.ReminderId, p.FireAt = r.Id, r.FireAt
+	p.Task.Id, p.Task.Title, p.Task.Description, p.Task.State = t.Id, t.Title, t.Description, t.State
+	p.Task.PlanningDate.Year, p.Task.PlanningDate.Month, p.Task.PlanningDate.Day = t.PlanningDate.Year, int(t.PlanningDate.Month), t.PlanningDate.Day
+
+	body, err := json.Marshal(p)
+	if err != nil {
+		return errors.Join(model.ErrNotifier, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.Recipient, bytes.NewReader(body))
+	if err != nil {
+		return errors.Join(model.ErrNotifier, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return errors.Join(model.ErrNotifier, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Join(model.ErrNotifier, fmt.Errorf("webhook responded with status %d", resp.StatusCode))
+	}
+	return nil
+}
+
+// NewWebhook creates notifier which posts reminders as JSON to the URL of
+// the recipient, any response except 2xx is an error. Recipients on the
+// loopback, private or link-local addresses are refused
+func NewWebhook(timeout time.Duration) app.Notifier {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &webhookNotifier{
+		client: newClient(timeout),
+	}
+}
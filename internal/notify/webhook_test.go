@@ -0,0 +1,59 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"todo-list/internal/model"
+)
+
+func TestWebhookNotify(t *testing.T) {
+	var received webhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	// test server listens on the loopback, which is refused by NewWebhook
+	n := &webhookNotifier{client: server.Client()}
+	task := model.TodoTask{
+		Id:           1,
+		Title:        "Title",
+		PlanningDate: model.Date{Year: 2099, Month: time.January, Day: 2},
+		State:        "todo",
+	}
+	fireAt := time.Date(2099, time.January, 2, 9, 0, 0, 0, time.UTC)
+
+	t.Run("delivery of the reminder to the webhook", func(t *testing.T) {
+		err := n.Notify(context.Background(), model.Reminder{Id: 5, TaskId: 1, Recipient: server.URL + "/hook", FireAt: fireAt}, task)
+		require.NoError(t, err)
+		assert.Equal(t, 5, received.ReminderId)
+		assert.True(t, fireAt.Equal(received.FireAt))
+		assert.Equal(t, 1, received.Task.Id)
+		assert.Equal(t, "Title", received.Task.Title)
+		assert.Equal(t, 2099, received.Task.PlanningDate.Year)
+		assert.Equal(t, "todo", received.Task.State)
+	})
+
+	t.Run("delivery of the reminder to the failing webhook", func(t *testing.T) {
+		err := n.Notify(context.Background(), model.Reminder{Id: 6, TaskId: 1, Recipient: server.URL + "/broken"}, task)
+		assert.ErrorIs(t, err, model.ErrNotifier)
+	})
+
+	t.Run("delivery of the reminder to the webhook on the loopback", func(t *testing.T) {
+		err := NewWebhook(time.Second).Notify(context.Background(), model.Reminder{Id: 7, TaskId: 1, Recipient: server.URL + "/hook"}, task)
+		assert.ErrorIs(t, err, model.ErrNotifier)
+	})
+}
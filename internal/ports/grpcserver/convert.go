@@ -0,0 +1,155 @@
+package grpcserver
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+	"todo-list/internal/model"
+	"todo-list/pkg/taskpb"
+)
+
+// weekdays maps days of the week of the protocol to their numbers
+var weekdays = map[taskpb.Weekday]time.Weekday{
+	taskpb.Weekday_MONDAY:    time.Monday,
+	taskpb.Weekday_TUESDAY:   time.Tuesday,
+	taskpb.Weekday_WEDNESDAY: time.Wednesday,
+	taskpb.Weekday_THURSDAY:  time.Thursday,
+	taskpb.Weekday_FRIDAY:    time.Friday,
+	taskpb.Weekday_SATURDAY:  time.Saturday,
+	taskpb.Weekday_SUNDAY:    time.Sunday,
+}
+
+func fromDate(d *taskpb.Date) model.Date {
+	return model.Date{
+		Year:  int(d.GetYear()),
+		Month: time.Month(d.GetMonth()),
+		Day:   int(d.GetDay()),
+	}
+}
+
+func toDate(d model.Date) *taskpb.Date {
+	return &taskpb.Date{
+		Year:  int32(d.Year),
+		Month: int32(d.Month),
+		Day:   int32(d.Day),
+	}
+}
+
+func toTask(t model.TodoTask) *taskpb.Task {
+	return &taskpb.Task{
+		Id:           int64(t.Id),
+		Title:        t.Title,
+		Description:  t.Description,
+		PlanningDate: toDate(t.PlanningDate),
+		Status:       t.Status,
+		State:        t.State,
+		Rank:         t.Rank,
+		Position:     int32(t.Position),
+		Overdue:      t.Overdue,
+		Postponed:    int32(t.Postponed),
+		Assignees:    t.Assignees,
+		Blocked:      t.Blocked,
+		Version:      int32(t.Version),
+		Project:      t.Project,
+	}
+}
+
+func toTasks(tasks []model.TodoTask) []*taskpb.Task {
+	res := make([]*taskpb.Task, 0, len(tasks))
+	for _, t := range tasks {
+		res = append(res, toTask(t))
+	}
+	return res
+}
+
+func toTaskList(tasks []model.TodoTask) *taskpb.TaskList {
+	return &taskpb.TaskList{Tasks: toTasks(tasks)}
+}
+
+func toState(s model.State) *taskpb.State {
+	return &taskpb.State{Name: s.Name, Done: s.Done}
+}
+
+func toWorkflow(w model.Workflow) *taskpb.Workflow {
+	res := &taskpb.Workflow{
+		States:      make([]*taskpb.State, 0, len(w.States)),
+		Transitions: make(map[string]*taskpb.StateNames, len(w.Transitions)),
+	}
+	for _, s := range w.States {
+		res.States = append(res.States, toState(s))
+	}
+	for from, to := range w.Transitions {
+		res.Transitions[from] = &taskpb.StateNames{Names: to}
+	}
+	return res
+}
+
+func toBoard(b model.Board) *taskpb.Board {
+	res := &taskpb.Board{Columns: make([]*taskpb.Column, 0, len(b.Columns))}
+	for _, col := range b.Columns {
+		res.Columns = append(res.Columns, &taskpb.Column{
+			State: toState(col.State),
+			Tasks: toTasks(col.Tasks),
+		})
+	}
+	return res
+}
+
+func toDependencyGraph(g model.DependencyGraph) *taskpb.DependencyGraph {
+	res := &taskpb.DependencyGraph{
+		Tasks:        toTasks(g.Tasks),
+		Dependencies: make([]*taskpb.Dependency, 0, len(g.Dependencies)),
+	}
+	for _, d := range g.Dependencies {
+		res.Dependencies = append(res.Dependencies, &taskpb.Dependency{
+			BlockerId: int64(d.BlockerId),
+			BlockedId: int64(d.BlockedId),
+		})
+	}
+	return res
+}
+
+func toHistory(entries []model.HistoryEntry) *taskpb.HistoryList {
+	res := &taskpb.HistoryList{Entries: make([]*taskpb.HistoryEntry, 0, len(entries))}
+	for _, e := range entries {
+		res.Entries = append(res.Entries, &taskpb.HistoryEntry{
+			Id:        int64(e.Id),
+			TaskId:    int64(e.TaskId),
+			Action:    e.Action,
+			FromDate:  toDate(e.FromDate),
+			ToDate:    toDate(e.ToDate),
+			CreatedAt: timestamppb.New(e.CreatedAt),
+		})
+	}
+	return res
+}
+
+func toProject(p model.Project) *taskpb.Project {
+	return &taskpb.Project{Name: p.Name, Members: p.Members}
+}
+
+func toProjects(projects []model.Project) *taskpb.ProjectList {
+	res := &taskpb.ProjectList{Projects: make([]*taskpb.Project, 0, len(projects))}
+	for _, p := range projects {
+		res.Projects = append(res.Projects, toProject(p))
+	}
+	return res
+}
+
+func toComment(c model.Comment) *taskpb.Comment {
+	return &taskpb.Comment{
+		Id:        int64(c.Id),
+		TaskId:    int64(c.TaskId),
+		Author:    c.Author,
+		Text:      c.Text,
+		CreatedAt: timestamppb.New(c.CreatedAt),
+		UpdatedAt: timestamppb.New(c.UpdatedAt),
+	}
+}
+
+func toComments(comments []model.Comment) *taskpb.CommentList {
+	res := &taskpb.CommentList{Comments: make([]*taskpb.Comment, 0, len(comments))}
+	for _, c := range comments {
+		res.Comments = append(res.Comments, toComment(c))
+	}
+	return res
+}
@@ -0,0 +1,48 @@
+package grpcserver
+
+import (
+	"errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"todo-list/internal/model"
+)
+
+// statusCodes maps errors of the app to codes of the gRPC status in order of
+// checking, the first matching error is reported
+var statusCodes = []struct {
+	err  error
+	code codes.Code
+}{
+	{model.ErrTaskNotFound, codes.NotFound},
+	{model.ErrCommentNotFound, codes.NotFound},
+	{model.ErrProjectNotFound, codes.NotFound},
+	{model.ErrInvalidInput, codes.InvalidArgument},
+	{model.ErrInvalidTask, codes.InvalidArgument},
+	{model.ErrUnknownState, codes.InvalidArgument},
+	{model.ErrInvalidComment, codes.InvalidArgument},
+	{model.ErrDayOrder, codes.InvalidArgument},
+	{model.ErrTaskBlocked, codes.FailedPrecondition},
+	{model.ErrTransition, codes.FailedPrecondition},
+	{model.ErrDependencyCycle, codes.FailedPrecondition},
+	{model.ErrNotMember, codes.FailedPrecondition},
+	{model.ErrMemberAssigned, codes.FailedPrecondition},
+	{model.ErrProjectExists, codes.AlreadyExists},
+	{model.ErrVersionConflict, codes.Aborted},
+	{model.ErrUnknownUser, codes.Unauthenticated},
+	{model.ErrForbidden, codes.PermissionDenied},
+	{model.ErrTaskRepo, codes.Internal},
+}
+
+// toStatus returns gRPC status error for the error of the app, details of the
+// internal errors are not passed to the client
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	for _, sc := range statusCodes {
+		if errors.Is(err, sc.err) {
+			return status.Error(sc.code, sc.err.Error())
+		}
+	}
+	return status.Error(codes.Unknown, model.ErrUnknown.Error())
+}
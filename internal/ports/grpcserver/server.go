@@ -0,0 +1,55 @@
+package grpcserver
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"net"
+	"todo-list/internal/app"
+	"todo-list/pkg/taskpb"
+)
+
+// Server is a gRPC server of the task service
+type Server struct {
+	srv *grpc.Server
+
+	// streams don't end by themselves, so they are cancelled on shutdown
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// New creates gRPC server which works with given app
+func New(a app.App) *Server {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Server{
+		srv:    grpc.NewServer(),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	taskpb.RegisterTaskServiceServer(s.srv, &taskService{a: a, done: ctx.Done()})
+	return s
+}
+
+// Serve accepts connections on the listener until the server is stopped
+func (s *Server) Serve(lis net.Listener) error {
+	return s.srv.Serve(lis)
+}
+
+// Shutdown stops accepting connections, ends the streams and waits for the
+// active calls to finish, calls still running when ctx is done are cancelled
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.srv.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.srv.Stop()
+		return ctx.Err()
+	}
+}
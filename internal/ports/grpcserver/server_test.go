@@ -0,0 +1,164 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"io"
+	"net"
+	"testing"
+	"time"
+	"todo-list/internal/app"
+	"todo-list/internal/app/mocks"
+	"todo-list/internal/events"
+	"todo-list/internal/model"
+	"todo-list/pkg/taskpb"
+)
+
+// startServer serves the app on the in-memory listener and returns the
+// server with the client connected to it
+func startServer(t *testing.T, tr app.TaskRepo, broker app.EventBroker) (*Server, taskpb.TaskServiceClient) {
+	a := app.New(app.Deps{Tasks: tr, Broker: broker}, app.Config{})
+	srv := New(a)
+	lis := bufconn.Listen(1 << 20)
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(func() {
+		_ = srv.Shutdown(context.Background())
+	})
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return srv, taskpb.NewTaskServiceClient(conn)
+}
+
+func TestToStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+		msg  string
+	}{
+		{"not found", model.ErrTaskNotFound, codes.NotFound, model.ErrTaskNotFound.Error()},
+		{"invalid", model.ErrInvalidTask, codes.InvalidArgument, model.ErrInvalidTask.Error()},
+		{"blocked", model.ErrTaskBlocked, codes.FailedPrecondition, model.ErrTaskBlocked.Error()},
+		{"project exists", model.ErrProjectExists, codes.AlreadyExists, model.ErrProjectExists.Error()},
+		{"conflict", model.ErrVersionConflict, codes.Aborted, model.ErrVersionConflict.Error()},
+		{"no user", model.ErrUnknownUser, codes.Unauthenticated, model.ErrUnknownUser.Error()},
+		{"forbidden", model.ErrForbidden, codes.PermissionDenied, model.ErrForbidden.Error()},
+		{"repo", errors.Join(model.ErrTaskRepo, errors.New("connection refused")), codes.Internal, model.ErrTaskRepo.Error()},
+		{"unknown", errors.New("boom"), codes.Unknown, model.ErrUnknown.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, ok := status.FromError(toStatus(tt.err))
+			require.True(t, ok)
+			assert.Equal(t, tt.code, st.Code())
+			assert.Equal(t, tt.msg, st.Message())
+		})
+	}
+	assert.NoError(t, toStatus(nil))
+}
+
+func TestTaskService(t *testing.T) {
+	tr := new(mocks.TaskRepo)
+	_, client := startServer(t, tr, nil)
+	ctx := context.Background()
+
+	task := model.TodoTask{
+		Id:           1081,
+		Title:        "gRPC",
+		PlanningDate: model.Date{Year: 2027, Month: time.May, Day: 1},
+		State:        "todo",
+		Version:      3,
+	}
+	tr.On("GetTaskById", mock.Anything, 1081).Return(task, nil).Once()
+	tr.On("GetTaskById", mock.Anything, 1082).Return(model.TodoTask{}, model.ErrTaskNotFound).Once()
+
+	got, err := client.GetTask(ctx, &taskpb.GetTaskRequest{Id: 1081})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1081), got.GetId())
+	assert.Equal(t, "gRPC", got.GetTitle())
+	assert.Equal(t, &taskpb.Date{Year: 2027, Month: 5, Day: 1}, got.GetPlanningDate())
+	assert.Equal(t, int32(3), got.GetVersion())
+	assert.Empty(t, got.GetAssignees())
+
+	_, err = client.GetTask(ctx, &taskpb.GetTaskRequest{Id: 1082})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.DeleteComment(ctx, &taskpb.DeleteCommentRequest{TaskId: 1081, Id: 1})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = client.RescheduleToWeekday(ctx, &taskpb.RescheduleToWeekdayRequest{Id: 1081})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	tr.AssertExpectations(t)
+}
+
+func TestWatchTasks(t *testing.T) {
+	broker := events.NewBroker(10)
+	srv, client := startServer(t, new(mocks.TaskRepo), broker)
+
+	stream, err := client.WatchTasks(context.Background(), &taskpb.WatchTasksRequest{})
+	require.NoError(t, err)
+
+	// subscription is made when the call reaches the server, so events are
+	// published until the first one is received
+	task := model.TodoTask{Id: 1083, Title: "Watched", Assignees: []string{"alice"}, Project: "backend"}
+	prev := model.TodoTask{Id: 1083, Title: "Watched", Assignees: []string{}}
+	e, err := events.New(model.EventTaskUpdated, task, &prev)
+	require.NoError(t, err)
+	received := make(chan *taskpb.TaskEvent)
+	go func() {
+		if m, err := stream.Recv(); err == nil {
+			received <- m
+		}
+	}()
+
+	var m *taskpb.TaskEvent
+	deadline := time.Now().Add(5 * time.Second)
+	for id := 1; m == nil; id++ {
+		require.True(t, time.Now().Before(deadline), "event was not received")
+		e.Id = id
+		require.NoError(t, broker.Publish(context.Background(), e))
+		select {
+		case m = <-received:
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+	assert.Equal(t, model.EventTaskUpdated, m.GetEvent())
+	assert.Equal(t, int64(1083), m.GetTask().GetId())
+	assert.Equal(t, "Watched", m.GetTask().GetTitle())
+	assert.Equal(t, []string{"alice"}, m.GetTask().GetAssignees())
+	assert.Equal(t, "backend", m.GetTask().GetProject())
+	require.NotNil(t, m.GetPrevious())
+	assert.Empty(t, m.GetPrevious().GetAssignees())
+	assert.Empty(t, m.GetPrevious().GetProject())
+
+	// shutdown ends the stream
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, srv.Shutdown(ctx))
+	for {
+		if _, err = stream.Recv(); err != nil {
+			break
+		}
+	}
+	assert.ErrorIs(t, err, io.EOF)
+}
@@ -0,0 +1,366 @@
+package grpcserver
+
+import (
+	"context"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"todo-list/internal/app"
+	"todo-list/internal/events"
+	"todo-list/internal/model"
+	"todo-list/pkg/taskpb"
+)
+
+// userKey is a key of the metadata with name of the current user
+const userKey = "x-user"
+
+// eventReset is a type of the event telling the client to reload the tasks
+const eventReset = "reset"
+
+// taskService implements the gRPC task service with the app
+type taskService struct {
+	taskpb.UnimplementedTaskServiceServer
+
+	a app.App
+
+	// done is closed when the server is shut down
+	done <-chan struct{}
+}
+
+// currentUser returns name of the user of the call from the metadata
+func currentUser(ctx context.Context) (string, error) {
+	if vals := metadata.ValueFromIncomingContext(ctx, userKey); len(vals) > 0 && vals[0] != "" {
+		return vals[0], nil
+	}
+	return "", model.ErrUnknownUser
+}
+
+// taskReply returns the task or status of the error
+func taskReply(t model.TodoTask, err error) (*taskpb.Task, error) {
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toTask(t), nil
+}
+
+// tasksReply returns the tasks or status of the error
+func tasksReply(tasks []model.TodoTask, err error) (*taskpb.TaskList, error) {
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toTaskList(tasks), nil
+}
+
+func (s *taskService) AddTask(ctx context.Context, req *taskpb.AddTaskRequest) (*taskpb.Task, error) {
+	return taskReply(s.a.AddTask(ctx, model.TodoTask{
+		Title:        req.GetTitle(),
+		Description:  req.GetDescription(),
+		PlanningDate: fromDate(req.GetPlanningDate()),
+		Status:       req.GetStatus(),
+		State:        req.GetState(),
+		Project:      req.GetProject(),
+	}))
+}
+
+func (s *taskService) GetTask(ctx context.Context, req *taskpb.GetTaskRequest) (*taskpb.Task, error) {
+	return taskReply(s.a.GetTaskById(ctx, int(req.GetId())))
+}
+
+func (s *taskService) SearchTasks(ctx context.Context, req *taskpb.SearchTasksRequest) (*taskpb.TaskList, error) {
+	return tasksReply(s.a.GetTaskByText(ctx, req.GetText()))
+}
+
+func (s *taskService) UpdateTask(ctx context.Context, req *taskpb.UpdateTaskRequest) (*taskpb.Task, error) {
+	update := s.a.UpdateTask
+	if req.GetForce() {
+		update = s.a.ForceUpdateTask
+	}
+	return taskReply(update(ctx, int(req.GetId()), model.TodoTask{
+		Title:        req.GetTitle(),
+		Description:  req.GetDescription(),
+		PlanningDate: fromDate(req.GetPlanningDate()),
+		Status:       req.GetStatus(),
+		State:        req.GetState(),
+	}))
+}
+
+func (s *taskService) DeleteTask(ctx context.Context, req *taskpb.DeleteTaskRequest) (*emptypb.Empty, error) {
+	if err := s.a.DeleteTask(ctx, int(req.GetId())); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *taskService) ListTasksByStatus(ctx context.Context, req *taskpb.ListTasksByStatusRequest) (*taskpb.TaskList, error) {
+	return tasksReply(s.a.GetTasksByStatus(ctx, req.GetStatus(), int(req.GetOffset()), int(req.GetLimit())))
+}
+
+func (s *taskService) ListTasksByState(ctx context.Context, req *taskpb.ListTasksByStateRequest) (*taskpb.TaskList, error) {
+	return tasksReply(s.a.GetTasksByState(ctx, req.GetState(), int(req.GetOffset()), int(req.GetLimit())))
+}
+
+func (s *taskService) ListTasksByDate(ctx context.Context, req *taskpb.ListTasksByDateRequest) (*taskpb.TaskList, error) {
+	return tasksReply(s.a.GetTasksByDateAndStatus(ctx, fromDate(req.GetPlanningDate()), req.GetStatus()))
+}
+
+func (s *taskService) ListOverdueTasks(ctx context.Context, req *taskpb.ListOverdueTasksRequest) (*taskpb.TaskList, error) {
+	return tasksReply(s.a.GetOverdueTasks(ctx, int(req.GetOffset()), int(req.GetLimit())))
+}
+
+func (s *taskService) AssignTask(ctx context.Context, req *taskpb.AssignTaskRequest) (*taskpb.Task, error) {
+	return taskReply(s.a.AssignTask(ctx, int(req.GetId()), req.GetAssignee()))
+}
+
+func (s *taskService) UnassignTask(ctx context.Context, req *taskpb.AssignTaskRequest) (*taskpb.Task, error) {
+	return taskReply(s.a.UnassignTask(ctx, int(req.GetId()), req.GetAssignee()))
+}
+
+func (s *taskService) ListTasksByAssignee(ctx context.Context, req *taskpb.ListTasksByAssigneeRequest) (*taskpb.TaskList, error) {
+	return tasksReply(s.a.GetTasksByAssignee(ctx, req.GetAssignee(), int(req.GetOffset()), int(req.GetLimit())))
+}
+
+func (s *taskService) ListMyTasks(ctx context.Context, req *taskpb.ListMyTasksRequest) (*taskpb.TaskList, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return tasksReply(s.a.GetTasksByAssignee(ctx, user, int(req.GetOffset()), int(req.GetLimit())))
+}
+
+func (s *taskService) BlockTask(ctx context.Context, req *taskpb.BlockTaskRequest) (*taskpb.Task, error) {
+	return taskReply(s.a.BlockTask(ctx, int(req.GetBlockerId()), int(req.GetBlockedId())))
+}
+
+func (s *taskService) UnblockTask(ctx context.Context, req *taskpb.BlockTaskRequest) (*taskpb.Task, error) {
+	return taskReply(s.a.UnblockTask(ctx, int(req.GetBlockerId()), int(req.GetBlockedId())))
+}
+
+func (s *taskService) GetDependencyGraph(ctx context.Context, req *taskpb.GetDependencyGraphRequest) (*taskpb.DependencyGraph, error) {
+	g, err := s.a.GetDependencyGraph(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toDependencyGraph(g), nil
+}
+
+func (s *taskService) GetWorkflow(context.Context, *emptypb.Empty) (*taskpb.Workflow, error) {
+	return toWorkflow(s.a.Workflow()), nil
+}
+
+func (s *taskService) MoveTask(ctx context.Context, req *taskpb.MoveTaskRequest) (*taskpb.Task, error) {
+	return taskReply(s.a.MoveTask(ctx, int(req.GetId()), model.Move{
+		State:  req.GetState(),
+		Before: int(req.GetBeforeId()),
+		After:  int(req.GetAfterId()),
+	}))
+}
+
+func (s *taskService) GetBoard(ctx context.Context, req *taskpb.GetBoardRequest) (*taskpb.Board, error) {
+	b, err := s.a.GetBoard(ctx, int(req.GetLimit()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toBoard(b), nil
+}
+
+func (s *taskService) ReorderDay(ctx context.Context, req *taskpb.ReorderDayRequest) (*taskpb.TaskList, error) {
+	ids := make([]int, 0, len(req.GetIds()))
+	for _, id := range req.GetIds() {
+		ids = append(ids, int(id))
+	}
+	return tasksReply(s.a.ReorderDay(ctx, fromDate(req.GetPlanningDate()), ids))
+}
+
+func (s *taskService) SnoozeTask(ctx context.Context, req *taskpb.SnoozeTaskRequest) (*taskpb.Task, error) {
+	return taskReply(s.a.SnoozeTask(ctx, int(req.GetId()), int(req.GetDays())))
+}
+
+func (s *taskService) RescheduleToWeekday(ctx context.Context, req *taskpb.RescheduleToWeekdayRequest) (*taskpb.Task, error) {
+	weekday, ok := weekdays[req.GetWeekday()]
+	if !ok {
+		return nil, toStatus(model.ErrInvalidInput)
+	}
+	return taskReply(s.a.RescheduleToWeekday(ctx, int(req.GetId()), weekday))
+}
+
+func (s *taskService) RescheduleTask(ctx context.Context, req *taskpb.RescheduleTaskRequest) (*taskpb.Task, error) {
+	return taskReply(s.a.RescheduleTask(ctx, int(req.GetId()), fromDate(req.GetPlanningDate())))
+}
+
+func (s *taskService) RescheduleDay(ctx context.Context, req *taskpb.RescheduleDayRequest) (*taskpb.TaskList, error) {
+	return tasksReply(s.a.RescheduleDay(ctx, fromDate(req.GetFrom()), fromDate(req.GetTo())))
+}
+
+func (s *taskService) ListHistory(ctx context.Context, req *taskpb.ListHistoryRequest) (*taskpb.HistoryList, error) {
+	entries, err := s.a.GetHistoryByTask(ctx, int(req.GetTaskId()), int(req.GetOffset()), int(req.GetLimit()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toHistory(entries), nil
+}
+
+func (s *taskService) AddComment(ctx context.Context, req *taskpb.AddCommentRequest) (*taskpb.Comment, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	c, err := s.a.AddComment(ctx, int(req.GetTaskId()), user, req.GetText())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toComment(c), nil
+}
+
+func (s *taskService) UpdateComment(ctx context.Context, req *taskpb.UpdateCommentRequest) (*taskpb.Comment, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	c, err := s.a.UpdateComment(ctx, int(req.GetTaskId()), int(req.GetId()), user, req.GetText())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toComment(c), nil
+}
+
+func (s *taskService) DeleteComment(ctx context.Context, req *taskpb.DeleteCommentRequest) (*emptypb.Empty, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if err = s.a.DeleteComment(ctx, int(req.GetTaskId()), int(req.GetId()), user); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *taskService) ListComments(ctx context.Context, req *taskpb.ListCommentsRequest) (*taskpb.CommentList, error) {
+	comments, err := s.a.GetCommentsByTask(ctx, int(req.GetTaskId()), int(req.GetOffset()), int(req.GetLimit()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toComments(comments), nil
+}
+
+func (s *taskService) SetTaskProject(ctx context.Context, req *taskpb.SetTaskProjectRequest) (*taskpb.Task, error) {
+	return taskReply(s.a.SetTaskProject(ctx, int(req.GetId()), req.GetProject()))
+}
+
+// projectReply returns the project or status of the error
+func projectReply(p model.Project, err error) (*taskpb.Project, error) {
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProject(p), nil
+}
+
+func (s *taskService) AddProject(ctx context.Context, req *taskpb.Project) (*taskpb.Project, error) {
+	return projectReply(s.a.AddProject(ctx, model.Project{
+		Name:    req.GetName(),
+		Members: req.GetMembers(),
+	}))
+}
+
+func (s *taskService) GetProject(ctx context.Context, req *taskpb.GetProjectRequest) (*taskpb.Project, error) {
+	return projectReply(s.a.GetProject(ctx, req.GetName()))
+}
+
+func (s *taskService) ListProjects(ctx context.Context, _ *emptypb.Empty) (*taskpb.ProjectList, error) {
+	projects, err := s.a.GetProjects(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProjects(projects), nil
+}
+
+func (s *taskService) AddProjectMember(ctx context.Context, req *taskpb.ProjectMemberRequest) (*taskpb.Project, error) {
+	return projectReply(s.a.AddProjectMember(ctx, req.GetName(), req.GetMember()))
+}
+
+func (s *taskService) RemoveProjectMember(ctx context.Context, req *taskpb.ProjectMemberRequest) (*taskpb.Project, error) {
+	return projectReply(s.a.RemoveProjectMember(ctx, req.GetName(), req.GetMember()))
+}
+
+func (s *taskService) WatchTasks(req *taskpb.WatchTasksRequest, stream taskpb.TaskService_WatchTasksServer) error {
+	scope := model.EventScope{Project: req.GetProject()}
+	if req.GetAssignedToMe() {
+		user, err := currentUser(stream.Context())
+		if err != nil {
+			return toStatus(err)
+		}
+		scope.Assignee = user
+	}
+	if req.GetPlanningDate() != nil {
+		scope.Date = fromDate(req.GetPlanningDate())
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	go func() {
+		select {
+		case <-s.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	lastId := int(req.GetAfterEventId())
+	sub, err := s.a.SubscribeEvents(ctx, scope, lastId)
+	if err != nil {
+		return toStatus(err)
+	}
+
+	// the broker drops subscribers which fall behind, so the events are
+	// resubscribed from the last sent one
+	for {
+		if sub.Lost {
+			if err = stream.Send(&taskpb.TaskEvent{Id: int64(lastId), Event: eventReset}); err != nil {
+				return err
+			}
+		}
+		for _, e := range sub.Missed {
+			if err = sendEvent(stream, e); err != nil {
+				return err
+			}
+			lastId = e.Id
+		}
+		for e := range sub.Events {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if err = sendEvent(stream, e); err != nil {
+				return err
+			}
+			lastId = e.Id
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if sub, err = s.a.SubscribeEvents(ctx, scope, lastId); err != nil {
+			return toStatus(err)
+		}
+	}
+}
+
+// sendEvent sends the event with the task and the task before the change
+// decoded from its payload
+func sendEvent(stream taskpb.TaskService_WatchTasksServer, e model.Event) error {
+	t, prev, err := events.Decode(e.Payload)
+	if err != nil {
+		return toStatus(err)
+	}
+	msg := &taskpb.TaskEvent{
+		Id:    int64(e.Id),
+		Event: e.Type,
+		Task:  toTask(t),
+	}
+	if prev != nil {
+		msg.Previous = &taskpb.Task{
+			Id:           int64(prev.Id),
+			PlanningDate: toDate(prev.PlanningDate),
+			Assignees:    prev.Assignees,
+			Project:      prev.Project,
+		}
+	}
+	return stream.Send(msg)
+}
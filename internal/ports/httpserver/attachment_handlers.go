@@ -0,0 +1,200 @@
+package httpserver
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"mime"
+	"net/http"
+	"strconv"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+// multipartOverhead is a size of the boundaries and headers of the multipart
+// request added to the configured size of the attachment, so the request with
+// too large file is rejected while it is read. Exact size of the attachment
+// itself is checked by the app
+const multipartOverhead = 64 << 10
+
+// @Summary		Прикрепление файла к задаче
+// @Description	Загружает файл из поля file формы multipart/form-data и возвращает информацию о нём
+// @Accept		multipart/form-data
+// @Produce		json
+// @Param 		id path int true "id задачи"
+// @Param		file formData file true "Прикрепляемый файл"
+// @Success		200	{object} attachmentResponse "Успешное прикрепление"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных"
+// @Failure 	404 {object} taskResponse "Задача с заданным id не найдена"
+// @Failure 	413 {object} taskResponse "Слишком большой файл"
+// @Router		/task/{id}/attachments [post]
+func addAttachment(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		taskId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, a.MaxAttachmentSize()+multipartOverhead)
+		fh, err := c.FormFile("file")
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, errorResponse(model.ErrAttachmentTooLarge))
+			return
+		} else if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		f, err := fh.Open()
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+			return
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+
+		at, err := a.AddAttachment(c, taskId, fh.Filename, fh.Size, f)
+
+		switch {
+		case errors.Is(err, model.ErrTaskNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(model.ErrTaskNotFound))
+		case errors.Is(err, model.ErrInvalidAttachment):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidAttachment))
+		case errors.Is(err, model.ErrInvalidInput):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+		case errors.Is(err, model.ErrAttachmentTooLarge):
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, errorResponse(model.ErrAttachmentTooLarge))
+		case errors.Is(err, model.ErrBlobStore):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrBlobStore))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, attachmentSuccessResponse(at))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
+
+// @Summary		Получение списка файлов, прикреплённых к задаче
+// @Description	Возвращает информацию о прикреплённых файлах без их содержимого
+// @Produce		json
+// @Param 		id path int true "id задачи"
+// @Success		200	{object} attachmentsResponse "Успешное получение"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных"
+// @Failure 	404 {object} taskResponse "Задача с заданным id не найдена"
+// @Router		/task/{id}/attachments [get]
+func getAttachmentsByTask(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		taskId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		attachments, err := a.GetAttachmentsByTask(c, taskId)
+
+		switch {
+		case errors.Is(err, model.ErrTaskNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(model.ErrTaskNotFound))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, attachmentsSuccessResponse(attachments))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
+
+// @Summary		Скачивание прикреплённого файла
+// @Description	Возвращает содержимое файла с его типом и именем
+// @Produce		octet-stream
+// @Param 		id path int true "id задачи"
+// @Param 		attachment_id path int true "id прикреплённого файла"
+// @Success		200	{file} file "Содержимое файла"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных"
+// @Failure 	404 {object} taskResponse "Файл с заданным id не найден"
+// @Router		/task/{id}/attachments/{attachment_id} [get]
+func getAttachment(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		taskId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		id, err := strconv.Atoi(c.Param("attachment_id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		at, content, err := a.GetAttachment(c, taskId, id)
+
+		switch {
+		case errors.Is(err, model.ErrAttachmentNotFound), errors.Is(err, model.ErrBlobNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(model.ErrAttachmentNotFound))
+		case errors.Is(err, model.ErrBlobStore):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrBlobStore))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			defer func() {
+				_ = content.Close()
+			}()
+			c.DataFromReader(http.StatusOK, at.Size, at.ContentType, content, map[string]string{
+				"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": at.Name}),
+				"X-Content-Type-Options": "nosniff",
+			})
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
+
+// @Summary		Удаление прикреплённого файла
+// @Description	Удаляет файл вместе с его содержимым
+// @Produce		json
+// @Param 		id path int true "id задачи"
+// @Param 		attachment_id path int true "id прикреплённого файла"
+// @Success		200	{object} taskResponse "Успешное удаление"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных"
+// @Failure 	404 {object} taskResponse "Файл с заданным id не найден"
+// @Router		/task/{id}/attachments/{attachment_id} [delete]
+func deleteAttachment(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		taskId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		id, err := strconv.Atoi(c.Param("attachment_id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		err = a.DeleteAttachment(c, taskId, id)
+
+		switch {
+		case errors.Is(err, model.ErrAttachmentNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(model.ErrAttachmentNotFound))
+		case errors.Is(err, model.ErrBlobStore):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrBlobStore))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, deleteSuccessResponse())
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
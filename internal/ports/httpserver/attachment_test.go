@@ -0,0 +1,34 @@
+package httpserver
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"todo-list/internal/app"
+	"todo-list/internal/app/mocks"
+)
+
+func TestAddAttachmentTooLarge(t *testing.T) {
+	tr := new(mocks.TaskRepo)
+	a := app.New(app.Deps{Tasks: tr}, app.Config{MaxAttachmentSize: 1024})
+	h := New("", a).Handler
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, err := mw.CreateFormFile("file", "notes.txt")
+	require.NoError(t, err)
+	_, err = fw.Write(bytes.Repeat([]byte("a"), 1024+multipartOverhead))
+	require.NoError(t, err)
+	require.NoError(t, mw.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/todo-list/api/task/1451/attachments", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	tr.AssertExpectations(t)
+}
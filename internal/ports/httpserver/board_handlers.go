@@ -0,0 +1,93 @@
+package httpserver
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+// @Summary		Перемещение задачи на доске
+// @Description	Перемещает задачу в колонку заданного состояния перед задачей before_id или после задачи after_id, если они не указаны, задача перемещается в конец колонки. Если состояние не указано, задача перемещается внутри своей колонки
+// @Produce		json
+// @Param 		id path int true "id перемещаемой задачи"
+// @Param		input body moveTaskRequest true "Новое место задачи"
+// @Success		200	{object} taskResponse "Успешное перемещение"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных или неизвестное состояние"
+// @Failure 	404 {object} taskResponse "Задача не найдена или задача-ориентир не найдена в колонке"
+// @Failure 	409 {object} taskResponse "Задача заблокирована невыполненными задачами или переход в состояние запрещён"
+// @Router		/task/{id}/move [post]
+func moveTask(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		var req moveTaskRequest
+		if err = c.BindJSON(&req); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		t, err := a.MoveTask(c, id, model.Move{
+			State:  req.State,
+			Before: req.BeforeId,
+			After:  req.AfterId,
+		})
+
+		switch {
+		case errors.Is(err, model.ErrInvalidInput):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+		case errors.Is(err, model.ErrUnknownState):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrUnknownState))
+		case errors.Is(err, model.ErrTaskNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(model.ErrTaskNotFound))
+		case errors.Is(err, model.ErrTaskBlocked):
+			c.AbortWithStatusJSON(http.StatusConflict, errorResponse(model.ErrTaskBlocked))
+		case errors.Is(err, model.ErrTransition):
+			c.AbortWithStatusJSON(http.StatusConflict, errorResponse(model.ErrTransition))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, taskSuccessResponse(t))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
+
+// @Summary		Получение доски задач
+// @Description	Возвращает колонки для всех состояний задач, в каждой из которых не больше limit задач в порядке их ранга
+// @Produce		json
+// @Param		input body getBoardRequest true "Количество задач в колонке"
+// @Success		200	{object} boardResponse "Успешное получение доски"
+// @Failure		500	{object} taskResponse  "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse  "Неверный формат входных данных"
+// @Router		/board [get]
+func getBoard(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req getBoardRequest
+		if err := c.BindJSON(&req); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		board, err := a.GetBoard(c, req.Limit)
+
+		switch {
+		case errors.Is(err, model.ErrInvalidInput):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, boardSuccessResponse(board))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
@@ -0,0 +1,358 @@
+package httpserver
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"todo-list/internal/model"
+)
+
+const (
+	caldavPrefix = "/todo-list/caldav"
+
+	// caldavCalendar is a name of the calendar collection of every user
+	caldavCalendar = "tasks"
+
+	// caldavUserKey is a key of the authenticated user in the context of the request
+	caldavUserKey = "caldav_user"
+
+	// maxObjectSize is a max size of the calendar object sent by PUT in bytes
+	maxObjectSize = 1 << 20
+)
+
+// namespaces of the properties of CalDAV (RFC 4791) and WebDAV (RFC 4918)
+// resources, getctag of Calendar Server lets clients skip unchanged calendars
+const (
+	nsDAV    = "DAV:"
+	nsCalDAV = "urn:ietf:params:xml:ns:caldav"
+	nsCS     = "http://calendarserver.org/ns/"
+)
+
+// davPrefixes are prefixes of the known namespaces declared in every response
+var davPrefixes = map[string]string{nsDAV: "d", nsCalDAV: "c", nsCS: "cs"}
+
+// caldavMethods are methods supported by the CalDAV resources
+var caldavMethods = []string{http.MethodOptions, "PROPFIND", "REPORT", http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete}
+
+// calendarData is a property of the calendar object with its iCalendar, it
+// is returned only if it is requested by name
+var calendarData = xml.Name{Space: nsCalDAV, Local: "calendar-data"}
+
+// davPath is a resource of the CalDAV server: the root without user, the
+// principal of the user which is also home of their calendars, the calendar
+// of the tasks or the object in it
+type davPath struct {
+	user     string
+	calendar bool
+	object   string
+}
+
+// parseDavPath parses the path of the resource relative to caldavPrefix
+func parseDavPath(p string) (davPath, bool) {
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	switch {
+	case len(segments) == 1 && segments[0] == "":
+		return davPath{}, true
+	case len(segments) == 1:
+		return davPath{user: segments[0]}, true
+	case segments[1] != caldavCalendar || segments[0] == "":
+		return davPath{}, false
+	case len(segments) == 2:
+		return davPath{user: segments[0], calendar: true}, true
+	case len(segments) == 3 && segments[2] != "":
+		return davPath{user: segments[0], calendar: true, object: segments[2]}, true
+	default:
+		return davPath{}, false
+	}
+}
+
+// parseHref parses href of the resource from the request, it is either an
+// absolute URL or an absolute path
+func parseHref(href string) (davPath, bool) {
+	u, err := url.Parse(strings.TrimSpace(href))
+	if err != nil {
+		return davPath{}, false
+	}
+	p, ok := strings.CutPrefix(u.Path, caldavPrefix+"/")
+	if !ok {
+		return davPath{}, false
+	}
+	return parseDavPath(p)
+}
+
+func (p davPath) isObject() bool {
+	return p.object != ""
+}
+
+func (p davPath) isCalendar() bool {
+	return p.calendar && p.object == ""
+}
+
+// href returns escaped absolute path of the resource, paths of the
+// collections end with slash
+func (p davPath) href() string {
+	href := caldavPrefix + "/"
+	if p.user == "" {
+		return href
+	}
+	href += url.PathEscape(p.user) + "/"
+	if !p.calendar {
+		return href
+	}
+	href += caldavCalendar + "/"
+	if p.object == "" {
+		return href
+	}
+	return href + url.PathEscape(p.object)
+}
+
+// davProp is a property of the resource, value is its content in XML
+type davProp struct {
+	name  xml.Name
+	value string
+}
+
+// xmlText escapes the text for XML
+func xmlText(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+func hrefValue(p davPath) string {
+	return "<d:href>" + xmlText(p.href()) + "</d:href>"
+}
+
+// objectETag returns ETag of the calendar object, it changes with every
+// change of its task
+func objectETag(o model.CalendarObject) string {
+	return fmt.Sprintf(`"%d-%d"`, o.Task.Id, o.Task.Version)
+}
+
+// etagMatches returns true if the value of If-Match or If-None-Match header
+// contains the ETag
+func etagMatches(header string, etag string) bool {
+	for _, v := range strings.Split(header, ",") {
+		if v = strings.TrimSpace(v); v == "*" || v == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// renderObject returns iCalendar of the calendar object
+func renderObject(o model.CalendarObject) string {
+	var b bytes.Buffer
+	// writes to the buffer don't fail
+	_ = writeObject(&b, o, time.Now())
+	return b.String()
+}
+
+// commonProps returns properties which every resource has
+func commonProps(user string) []davProp {
+	return []davProp{
+		{xml.Name{Space: nsDAV, Local: "current-user-principal"}, hrefValue(davPath{user: user})},
+	}
+}
+
+func rootProps(user string) []davProp {
+	return append(commonProps(user),
+		davProp{xml.Name{Space: nsDAV, Local: "resourcetype"}, "<d:collection/>"},
+	)
+}
+
+func homeProps(user string) []davProp {
+	home := hrefValue(davPath{user: user})
+	return append(commonProps(user),
+		davProp{xml.Name{Space: nsDAV, Local: "resourcetype"}, "<d:collection/><d:principal/>"},
+		davProp{xml.Name{Space: nsDAV, Local: "displayname"}, xmlText(user)},
+		davProp{xml.Name{Space: nsDAV, Local: "principal-URL"}, home},
+		davProp{xml.Name{Space: nsCalDAV, Local: "calendar-home-set"}, home},
+	)
+}
+
+func calendarProps(user string, objects []model.CalendarObject) []davProp {
+	// ctag changes when any object of the calendar is added, changed or removed
+	h := sha256.New()
+	for _, o := range objects {
+		_, _ = fmt.Fprintf(h, "%s %s\n", o.Name, objectETag(o))
+	}
+
+	return append(commonProps(user),
+		davProp{xml.Name{Space: nsDAV, Local: "resourcetype"}, "<d:collection/><c:calendar/>"},
+		davProp{xml.Name{Space: nsDAV, Local: "displayname"}, "Tasks"},
+		davProp{xml.Name{Space: nsDAV, Local: "owner"}, hrefValue(davPath{user: user})},
+		davProp{xml.Name{Space: nsDAV, Local: "current-user-privilege-set"},
+			"<d:privilege><d:read/></d:privilege><d:privilege><d:write/></d:privilege>" +
+				"<d:privilege><d:write-content/></d:privilege><d:privilege><d:bind/></d:privilege>" +
+				"<d:privilege><d:unbind/></d:privilege>"},
+		davProp{xml.Name{Space: nsDAV, Local: "supported-report-set"},
+			"<d:supported-report><d:report><c:calendar-multiget/></d:report></d:supported-report>" +
+				"<d:supported-report><d:report><c:calendar-query/></d:report></d:supported-report>"},
+		davProp{xml.Name{Space: nsCalDAV, Local: "calendar-description"}, "Tasks assigned to " + xmlText(user)},
+		davProp{xml.Name{Space: nsCalDAV, Local: "supported-calendar-component-set"}, `<c:comp name="VTODO"/>`},
+		davProp{xml.Name{Space: nsCS, Local: "getctag"}, hex.EncodeToString(h.Sum(nil)[:16])},
+	)
+}
+
+func objectProps(o model.CalendarObject) []davProp {
+	return append(commonProps(o.User),
+		davProp{xml.Name{Space: nsDAV, Local: "resourcetype"}, ""},
+		davProp{xml.Name{Space: nsDAV, Local: "getetag"}, xmlText(objectETag(o))},
+		davProp{xml.Name{Space: nsDAV, Local: "getcontenttype"}, "text/calendar; charset=utf-8; component=VTODO"},
+		davProp{calendarData, xmlText(renderObject(o))},
+	)
+}
+
+// davElement returns name of the element with the prefix of its namespace
+// and the declaration of the namespace if it is unknown
+func davElement(n xml.Name) (string, string) {
+	if n.Space == "" {
+		return n.Local, ""
+	} else if prefix, ok := davPrefixes[n.Space]; ok {
+		return prefix + ":" + n.Local, ""
+	}
+	return "x:" + n.Local, ` xmlns:x="` + xmlText(n.Space) + `"`
+}
+
+// multistatus collects responses of PROPFIND and REPORT
+type multistatus struct {
+	b bytes.Buffer
+}
+
+func newMultistatus() *multistatus {
+	m := &multistatus{}
+	m.b.WriteString(`<?xml version="1.0" encoding="utf-8"?>` + "\n")
+	m.b.WriteString(`<d:multistatus xmlns:d="DAV:" xmlns:c="` + nsCalDAV + `" xmlns:cs="` + nsCS + `">`)
+	return m
+}
+
+// propstat writes the properties with the status
+func (m *multistatus) propstat(props []davProp, status int, values bool) {
+	if len(props) == 0 {
+		return
+	}
+	m.b.WriteString("<d:propstat><d:prop>")
+	for _, p := range props {
+		name, ns := davElement(p.name)
+		if !values || p.value == "" {
+			m.b.WriteString("<" + name + ns + "/>")
+			continue
+		}
+		m.b.WriteString("<" + name + ns + ">" + p.value + "</" + name + ">")
+	}
+	m.b.WriteString(fmt.Sprintf("</d:prop><d:status>HTTP/1.1 %d %s</d:status></d:propstat>", status, http.StatusText(status)))
+}
+
+// response writes the properties of the resource requested by names, all
+// properties except calendar data are written if names are nil and only
+// names of the properties are written if names is false
+func (m *multistatus) response(p davPath, props []davProp, names []xml.Name, values bool) {
+	m.b.WriteString("<d:response>" + hrefValue(p))
+	if names == nil {
+		found := make([]davProp, 0, len(props))
+		for _, prop := range props {
+			if prop.name != calendarData {
+				found = append(found, prop)
+			}
+		}
+		m.propstat(found, http.StatusOK, values)
+	} else {
+		var found, missing []davProp
+		for _, n := range names {
+			i := 0
+			for i < len(props) && props[i].name != n {
+				i++
+			}
+			if i < len(props) {
+				found = append(found, props[i])
+			} else {
+				missing = append(missing, davProp{name: n})
+			}
+		}
+		m.propstat(found, http.StatusOK, values)
+		m.propstat(missing, http.StatusNotFound, false)
+	}
+	m.b.WriteString("</d:response>")
+}
+
+// notFound writes the response of the missing resource
+func (m *multistatus) notFound(href string) {
+	m.b.WriteString("<d:response><d:href>" + xmlText(href) + "</d:href><d:status>HTTP/1.1 404 Not Found</d:status></d:response>")
+}
+
+func (m *multistatus) write(c *gin.Context) {
+	m.b.WriteString("</d:multistatus>")
+	c.Data(http.StatusMultiStatus, "application/xml; charset=utf-8", m.b.Bytes())
+}
+
+// davPropNames is a list of names of the requested properties
+type davPropNames struct {
+	Props []struct {
+		XMLName xml.Name
+	} `xml:",any"`
+}
+
+// names returns the names of the properties, it is not nil even if the
+// list is empty
+func (p *davPropNames) names() []xml.Name {
+	names := make([]xml.Name, 0, len(p.Props))
+	for _, prop := range p.Props {
+		names = append(names, prop.XMLName)
+	}
+	return names
+}
+
+type propfindRequest struct {
+	XMLName  xml.Name      `xml:"DAV: propfind"`
+	PropName *struct{}     `xml:"DAV: propname"`
+	Prop     *davPropNames `xml:"DAV: prop"`
+}
+
+// compFilter is a filter of the components of calendar-query report
+type compFilter struct {
+	Name  string       `xml:"name,attr"`
+	Comps []compFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+}
+
+type reportRequest struct {
+	XMLName xml.Name
+	Prop    *davPropNames `xml:"DAV: prop"`
+	Hrefs   []string      `xml:"DAV: href"`
+	Filter  *struct {
+		Comp compFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+	} `xml:"urn:ietf:params:xml:ns:caldav filter"`
+}
+
+// matchesTodo returns true if the filter of calendar-query report matches
+// VTODO components, conditions on their properties are not checked and
+// clients filter the objects themselves
+func (r reportRequest) matchesTodo() bool {
+	if r.Filter == nil {
+		return true
+	} else if !strings.EqualFold(r.Filter.Comp.Name, "VCALENDAR") {
+		return false
+	}
+	for _, comp := range r.Filter.Comp.Comps {
+		if strings.EqualFold(comp.Name, componentTodo) {
+			return true
+		}
+	}
+	return len(r.Filter.Comp.Comps) == 0
+}
+
+// davPrecondition aborts the request with the error of the failed
+// precondition of WebDAV or CalDAV
+func davPrecondition(c *gin.Context, code int, name xml.Name) {
+	element, ns := davElement(name)
+	c.Data(code, "application/xml; charset=utf-8", []byte(`<?xml version="1.0" encoding="utf-8"?>`+"\n"+
+		`<d:error xmlns:d="DAV:" xmlns:c="`+nsCalDAV+`"><`+element+ns+`/></d:error>`))
+	c.Abort()
+}
@@ -0,0 +1,378 @@
+package httpserver
+
+import (
+	"encoding/xml"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+// davError aborts the request with the status of the error of the app
+func davError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, model.ErrObjectNotFound), errors.Is(err, model.ErrTaskNotFound):
+		c.String(http.StatusNotFound, model.ErrObjectNotFound.Error())
+	case errors.Is(err, model.ErrVersionConflict), errors.Is(err, model.ErrObjectExists):
+		c.String(http.StatusPreconditionFailed, err.Error())
+	case errors.Is(err, model.ErrInvalidObject), errors.Is(err, model.ErrInvalidTask), errors.Is(err, model.ErrUnknownState):
+		c.String(http.StatusBadRequest, err.Error())
+	case errors.Is(err, model.ErrForbidden), errors.Is(err, model.ErrNotMember):
+		c.String(http.StatusForbidden, err.Error())
+	case errors.Is(err, model.ErrTaskBlocked), errors.Is(err, model.ErrTransition):
+		c.String(http.StatusConflict, err.Error())
+	default:
+		c.String(http.StatusInternalServerError, model.ErrUnknown.Error())
+	}
+	c.Abort()
+}
+
+// caldavAuth authenticates users of CalDAV clients by HTTP Basic
+// authentication with their CalDAV password, OPTIONS requests are allowed
+// without it
+func caldavAuth(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodOptions {
+			return
+		}
+		user, password, ok := c.Request.BasicAuth()
+		var err error
+		if ok {
+			err = a.CheckCalDAVPassword(c, user, password)
+		}
+		if !ok || errors.Is(err, model.ErrWrongPassword) {
+			c.Header("WWW-Authenticate", `Basic realm="todo-list", charset="UTF-8"`)
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		} else if err != nil {
+			davError(c, err)
+			return
+		}
+		c.Set(caldavUserKey, user)
+	}
+}
+
+// davResource returns the resource of the request, the request is aborted
+// if there is no such resource or it belongs to another user
+func davResource(c *gin.Context) (davPath, string, bool) {
+	user := c.GetString(caldavUserKey)
+	p, ok := parseDavPath(c.Param("path"))
+	if !ok {
+		c.AbortWithStatus(http.StatusNotFound)
+		return davPath{}, "", false
+	} else if p.user != "" && p.user != user {
+		c.AbortWithStatus(http.StatusForbidden)
+		return davPath{}, "", false
+	}
+	return p, user, true
+}
+
+// davMethodNotAllowed aborts the request which method is not supported by the resource
+func davMethodNotAllowed(c *gin.Context) {
+	c.Header("Allow", strings.Join(caldavMethods, ", "))
+	c.AbortWithStatus(http.StatusMethodNotAllowed)
+}
+
+// davDepth returns depth of PROPFIND, infinite depth is limited by one level
+func davDepth(c *gin.Context) int {
+	if c.GetHeader("Depth") == "0" {
+		return 0
+	}
+	return 1
+}
+
+// davBody decodes XML body of the request into v, false is returned if the
+// body is empty
+func davBody(c *gin.Context, v any) (bool, bool) {
+	err := xml.NewDecoder(io.LimitReader(c.Request.Body, maxObjectSize)).Decode(v)
+	if errors.Is(err, io.EOF) {
+		return false, true
+	} else if err != nil {
+		c.String(http.StatusBadRequest, "invalid XML body: %s", err.Error())
+		c.Abort()
+		return false, false
+	}
+	return true, true
+}
+
+func caldavOptions(c *gin.Context) {
+	c.Header("DAV", "1, 3, calendar-access")
+	c.Header("Allow", strings.Join(caldavMethods, ", "))
+	c.Status(http.StatusOK)
+}
+
+// caldavWellKnown redirects clients which discover CalDAV server by the
+// well-known URI (RFC 6764)
+func caldavWellKnown(c *gin.Context) {
+	c.Redirect(http.StatusMovedPermanently, caldavPrefix+"/")
+}
+
+func caldavPropfind(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		p, user, ok := davResource(c)
+		if !ok {
+			return
+		}
+		var req propfindRequest
+		hasBody, ok := davBody(c, &req)
+		if !ok {
+			return
+		}
+		// empty body and allprop request all properties
+		var names []xml.Name
+		values := true
+		if hasBody && req.Prop != nil {
+			names = req.Prop.names()
+		} else if hasBody && req.PropName != nil {
+			values = false
+		}
+
+		ms := newMultistatus()
+		depth := davDepth(c)
+		switch {
+		case p.user == "":
+			ms.response(p, rootProps(user), names, values)
+			if depth > 0 {
+				ms.response(davPath{user: user}, homeProps(user), names, values)
+			}
+		case !p.calendar:
+			ms.response(p, homeProps(user), names, values)
+			if depth > 0 {
+				objects, err := a.GetCalendarObjects(c, user)
+				if err != nil {
+					davError(c, err)
+					return
+				}
+				ms.response(davPath{user: user, calendar: true}, calendarProps(user, objects), names, values)
+			}
+		case p.isCalendar():
+			objects, err := a.GetCalendarObjects(c, user)
+			if err != nil {
+				davError(c, err)
+				return
+			}
+			ms.response(p, calendarProps(user, objects), names, values)
+			if depth > 0 {
+				for _, o := range objects {
+					ms.response(davPath{user: user, calendar: true, object: o.Name}, objectProps(o), names, values)
+				}
+			}
+		default:
+			o, err := a.GetCalendarObject(c, user, p.object)
+			if err != nil {
+				davError(c, err)
+				return
+			}
+			ms.response(p, objectProps(o), names, values)
+		}
+		ms.write(c)
+	}
+}
+
+func caldavReport(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		p, user, ok := davResource(c)
+		if !ok {
+			return
+		} else if !p.isCalendar() {
+			davMethodNotAllowed(c)
+			return
+		}
+		var req reportRequest
+		if hasBody, ok := davBody(c, &req); !ok {
+			return
+		} else if !hasBody {
+			c.String(http.StatusBadRequest, "no body of the report")
+			c.Abort()
+			return
+		}
+		var names []xml.Name
+		if req.Prop != nil {
+			names = req.Prop.names()
+		}
+
+		ms := newMultistatus()
+		switch req.XMLName {
+		case xml.Name{Space: nsCalDAV, Local: "calendar-multiget"}:
+			for _, href := range req.Hrefs {
+				target, ok := parseHref(href)
+				if !ok || !target.isObject() || target.user != user {
+					ms.notFound(href)
+					continue
+				}
+				o, err := a.GetCalendarObject(c, user, target.object)
+				if errors.Is(err, model.ErrObjectNotFound) {
+					ms.notFound(href)
+					continue
+				} else if err != nil {
+					davError(c, err)
+					return
+				}
+				ms.response(target, objectProps(o), names, true)
+			}
+		case xml.Name{Space: nsCalDAV, Local: "calendar-query"}:
+			if req.matchesTodo() {
+				objects, err := a.GetCalendarObjects(c, user)
+				if err != nil {
+					davError(c, err)
+					return
+				}
+				for _, o := range objects {
+					ms.response(davPath{user: user, calendar: true, object: o.Name}, objectProps(o), names, true)
+				}
+			}
+		default:
+			davPrecondition(c, http.StatusForbidden, xml.Name{Space: nsDAV, Local: "supported-report"})
+			return
+		}
+		ms.write(c)
+	}
+}
+
+func caldavGet(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		p, user, ok := davResource(c)
+		if !ok {
+			return
+		} else if !p.isObject() {
+			davMethodNotAllowed(c)
+			return
+		}
+		o, err := a.GetCalendarObject(c, user, p.object)
+		if err != nil {
+			davError(c, err)
+			return
+		}
+
+		etag := objectETag(o)
+		c.Header("ETag", etag)
+		if inm := c.GetHeader("If-None-Match"); inm != "" && etagMatches(inm, etag) {
+			c.Status(http.StatusNotModified)
+			return
+		}
+		c.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(renderObject(o)))
+	}
+}
+
+// todoState returns the state of the workflow from the categories of VTODO
+// if it matches the status, otherwise the app chooses the state by the status
+func todoState(w model.Workflow, categories []string, status bool) string {
+	for _, s := range w.States {
+		if s.Done == status && slices.Contains(categories, s.Name) {
+			return s.Name
+		}
+	}
+	return ""
+}
+
+func caldavPut(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		p, user, ok := davResource(c)
+		if !ok {
+			return
+		} else if !p.isObject() {
+			davMethodNotAllowed(c)
+			return
+		}
+		if ct := c.GetHeader("Content-Type"); ct != "" {
+			if mediaType, _, err := mime.ParseMediaType(ct); err != nil || mediaType != "text/calendar" {
+				c.AbortWithStatus(http.StatusUnsupportedMediaType)
+				return
+			}
+		}
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxObjectSize))
+		if err != nil {
+			c.AbortWithStatus(http.StatusRequestEntityTooLarge)
+			return
+		}
+		todo, err := parseTodo(string(body))
+		if errors.Is(err, errNoTodo) {
+			davPrecondition(c, http.StatusForbidden, xml.Name{Space: nsCalDAV, Local: "supported-calendar-component"})
+			return
+		} else if err != nil {
+			davError(c, err)
+			return
+		}
+
+		current, err := a.GetCalendarObject(c, user, p.object)
+		exists := err == nil
+		if err != nil && !errors.Is(err, model.ErrObjectNotFound) {
+			davError(c, err)
+			return
+		}
+		ifMatch := c.GetHeader("If-Match")
+		if (c.GetHeader("If-None-Match") == "*" && exists) || (ifMatch != "" && (!exists || !etagMatches(ifMatch, objectETag(current)))) {
+			c.AbortWithStatus(http.StatusPreconditionFailed)
+			return
+		}
+
+		t := model.TodoTask{
+			Title:        todo.summary,
+			Description:  todo.description,
+			PlanningDate: todo.due,
+			Status:       todo.completed,
+			State:        todoState(a.Workflow(), todo.categories, todo.completed),
+		}
+		// tasks without due date are planned on the day they are created
+		if t.PlanningDate == (model.Date{}) && exists {
+			t.PlanningDate = current.Task.PlanningDate
+		} else if t.PlanningDate == (model.Date{}) {
+			t.PlanningDate = today()
+		}
+		// task changed since the client has seen it is not overwritten
+		if exists && ifMatch != "" {
+			t.Version = current.Task.Version
+		}
+
+		o, created, err := a.PutCalendarObject(c, model.CalendarObject{User: user, Name: p.object, UID: todo.uid, Task: t})
+		if err != nil {
+			davError(c, err)
+			return
+		}
+		c.Header("ETag", objectETag(o))
+		if created {
+			c.Status(http.StatusCreated)
+		} else {
+			c.Status(http.StatusNoContent)
+		}
+	}
+}
+
+func caldavDelete(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		p, user, ok := davResource(c)
+		if !ok {
+			return
+		} else if !p.isObject() {
+			davMethodNotAllowed(c)
+			return
+		}
+
+		version := 0
+		if ifMatch := c.GetHeader("If-Match"); ifMatch != "" {
+			current, err := a.GetCalendarObject(c, user, p.object)
+			if errors.Is(err, model.ErrObjectNotFound) {
+				c.AbortWithStatus(http.StatusPreconditionFailed)
+				return
+			} else if err != nil {
+				davError(c, err)
+				return
+			} else if !etagMatches(ifMatch, objectETag(current)) {
+				c.AbortWithStatus(http.StatusPreconditionFailed)
+				return
+			}
+			version = current.Task.Version
+		}
+
+		if err := a.DeleteCalendarObject(c, user, p.object, version); err != nil {
+			davError(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,303 @@
+package httpserver
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"todo-list/internal/app"
+	"todo-list/internal/app/mocks"
+	"todo-list/internal/model"
+)
+
+func TestParseTodo(t *testing.T) {
+	t.Run("properties of the first VTODO", func(t *testing.T) {
+		todo, err := parseTodo(strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"VERSION:2.0",
+			"BEGIN:VTODO",
+			"UID:0f1e2d3c",
+			"SUMMARY:Buy milk\\, bread",
+			"  and eggs",
+			"DESCRIPTION;LANGUAGE=en:Two\\nlines",
+			"DTSTART;TZID=\"Europe/Berlin\":20270601T090000",
+			"COMPLETED:20270601T100000Z",
+			"CATEGORIES:shop,in_progress",
+			"BEGIN:VALARM",
+			"DESCRIPTION:Alarm",
+			"END:VALARM",
+			"END:VTODO",
+			"BEGIN:VTODO",
+			"UID:second",
+			"END:VTODO",
+			"END:VCALENDAR",
+		}, "\r\n"))
+		require.NoError(t, err)
+		assert.Equal(t, todoComponent{
+			uid:         "0f1e2d3c",
+			summary:     "Buy milk, bread and eggs",
+			description: "Two\nlines",
+			categories:  []string{"shop", "in_progress"},
+			completed:   true,
+			due:         model.Date{Year: 2027, Month: time.June, Day: 1},
+		}, todo)
+	})
+
+	t.Run("DUE is preferred and STATUS decides completion", func(t *testing.T) {
+		todo, err := parseTodo("BEGIN:VCALENDAR\nBEGIN:VTODO\nDUE;VALUE=DATE:20270602\nDTSTART:20270601T090000Z\n" +
+			"STATUS:NEEDS-ACTION\nPERCENT-COMPLETE:100\nEND:VTODO\nEND:VCALENDAR\n")
+		require.NoError(t, err)
+		assert.Equal(t, model.Date{Year: 2027, Month: time.June, Day: 2}, todo.due)
+		assert.False(t, todo.completed)
+	})
+
+	t.Run("invalid calendars", func(t *testing.T) {
+		_, err := parseTodo("SUMMARY:Not a calendar")
+		assert.ErrorIs(t, err, model.ErrInvalidObject)
+		_, err = parseTodo("BEGIN:VCALENDAR\nBEGIN:VTODO\nDUE:2027-06-01\nEND:VTODO\nEND:VCALENDAR")
+		assert.ErrorIs(t, err, model.ErrInvalidObject)
+		_, err = parseTodo("BEGIN:VCALENDAR\nBEGIN:VTODO\nEND:VEVENT\nEND:VCALENDAR")
+		assert.ErrorIs(t, err, model.ErrInvalidObject)
+		_, err = parseTodo("BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:Event\nEND:VEVENT\nEND:VCALENDAR")
+		assert.ErrorIs(t, err, errNoTodo)
+	})
+}
+
+// davClient is a scripted CalDAV client which sends requests like the
+// calendar apps do
+type davClient struct {
+	url      string
+	user     string
+	password string
+}
+
+type davResponse struct {
+	code   int
+	header http.Header
+	body   string
+}
+
+func (d davClient) do(t *testing.T, method string, path string, headers map[string]string, body string) davResponse {
+	req, err := http.NewRequest(method, d.url+path, strings.NewReader(body))
+	require.NoError(t, err)
+	if d.user != "" {
+		req.SetBasicAuth(d.user, d.password)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	// clients repeat the method at the new location themselves
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return davResponse{code: resp.StatusCode, header: resp.Header, body: string(b)}
+}
+
+func TestCalDAV(t *testing.T) {
+	tr, br := new(mocks.TaskRepo), new(mocks.BoardRepo)
+	ar, calr := new(mocks.AttachmentRepo), new(mocks.CalendarRepo)
+	a := app.New(app.Deps{Tasks: tr, Board: br, Attachments: ar, Credentials: credentialRepo(), Calendars: calr}, app.Config{
+		Members:         []string{"alice", "bob"},
+		CalendarEnabled: true,
+	})
+	srv := httptest.NewServer(New("", a).Handler)
+	defer srv.Close()
+	br.On("GetLastRank", mock.Anything, mock.AnythingOfType("string")).Return("", nil)
+
+	password, err := a.IssueCalDAVPassword(context.Background(), "alice")
+	require.NoError(t, err)
+	alice := davClient{url: srv.URL, user: "alice", password: password}
+	date := model.Date{Year: 2027, Month: time.June, Day: 1}
+	shared := model.TodoTask{Id: 1171, Title: "Shared", PlanningDate: date, State: "todo", Assignees: []string{"alice", "bob"}, Version: 4}
+	created := model.TodoTask{Id: 1172, Title: "Buy milk", PlanningDate: date, State: "todo", Assignees: []string{"alice"}, Version: 2}
+	object := model.CalendarObject{User: "alice", Name: "0f1e2d3c.ics", UID: "0f1e2d3c", Task: model.TodoTask{Id: 1172}}
+	calendarPath := caldavPrefix + "/alice/tasks/"
+	objectPath := calendarPath + "0f1e2d3c.ics"
+	vtodo := func(status string) string {
+		return "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nBEGIN:VTODO\r\nUID:0f1e2d3c\r\nSUMMARY:Buy milk\r\n" +
+			"DUE;VALUE=DATE:20270601\r\nSTATUS:" + status + "\r\nEND:VTODO\r\nEND:VCALENDAR\r\n"
+	}
+	// object of the client is read by its name and then by id of its task
+	expectObject := func(task model.TodoTask) {
+		calr.On("GetObjectByName", mock.Anything, "alice", "0f1e2d3c.ics").Return(object, nil).Once()
+		tr.On("GetTaskById", mock.Anything, 1172).Return(task, nil).Once()
+	}
+	expectCalendar := func(tasks ...model.TodoTask) {
+		tr.On("GetTasksByAssignee", mock.Anything, "alice", 0, 100).Return(tasks, nil).Once()
+		calr.On("GetObjectsByUser", mock.Anything, "alice").Return([]model.CalendarObject{object}, nil).Once()
+	}
+
+	t.Run("discovery", func(t *testing.T) {
+		resp := alice.do(t, "PROPFIND", "/.well-known/caldav", nil, "")
+		assert.Equal(t, http.StatusMovedPermanently, resp.code)
+		assert.Equal(t, caldavPrefix+"/", resp.header.Get("Location"))
+
+		resp = alice.do(t, http.MethodOptions, caldavPrefix+"/", nil, "")
+		assert.Equal(t, http.StatusOK, resp.code)
+		assert.Contains(t, resp.header.Get("DAV"), "calendar-access")
+
+		resp = alice.do(t, "PROPFIND", caldavPrefix+"/", map[string]string{"Depth": "0"},
+			`<?xml version="1.0"?><propfind xmlns="DAV:"><prop><current-user-principal/></prop></propfind>`)
+		require.Equal(t, http.StatusMultiStatus, resp.code)
+		assert.Contains(t, resp.body, "<d:current-user-principal><d:href>/todo-list/caldav/alice/</d:href></d:current-user-principal>")
+
+		expectCalendar(shared)
+		resp = alice.do(t, "PROPFIND", caldavPrefix+"/alice/", map[string]string{"Depth": "1"},
+			`<d:propfind xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav" xmlns:a="http://apple.com/ns/ical/">`+
+				`<d:prop><d:resourcetype/><c:calendar-home-set/><c:supported-calendar-component-set/><a:calendar-color/></d:prop></d:propfind>`)
+		require.Equal(t, http.StatusMultiStatus, resp.code)
+		assert.Contains(t, resp.body, "<c:calendar-home-set><d:href>/todo-list/caldav/alice/</d:href></c:calendar-home-set>")
+		assert.Contains(t, resp.body, "<d:href>/todo-list/caldav/alice/tasks/</d:href>")
+		assert.Contains(t, resp.body, "<d:resourcetype><d:collection/><c:calendar/></d:resourcetype>")
+		assert.Contains(t, resp.body, `<c:supported-calendar-component-set><c:comp name="VTODO"/></c:supported-calendar-component-set>`)
+		assert.Contains(t, resp.body, `<x:calendar-color xmlns:x="http://apple.com/ns/ical/"/></d:prop><d:status>HTTP/1.1 404 Not Found</d:status>`)
+	})
+
+	t.Run("authentication", func(t *testing.T) {
+		resp := davClient{url: srv.URL}.do(t, "PROPFIND", caldavPrefix+"/", nil, "")
+		assert.Equal(t, http.StatusUnauthorized, resp.code)
+		assert.Contains(t, resp.header.Get("WWW-Authenticate"), "Basic")
+
+		resp = davClient{url: srv.URL, user: "bob", password: password}.do(t, "PROPFIND", caldavPrefix+"/", nil, "")
+		assert.Equal(t, http.StatusUnauthorized, resp.code)
+
+		// link to the read-only feed doesn't give access to CalDAV
+		token, err := a.IssueCalendarToken(context.Background(), "alice")
+		require.NoError(t, err)
+		resp = davClient{url: srv.URL, user: "alice", password: token}.do(t, "PROPFIND", caldavPrefix+"/", nil, "")
+		assert.Equal(t, http.StatusUnauthorized, resp.code)
+
+		resp = alice.do(t, "PROPFIND", caldavPrefix+"/bob/tasks/", nil, "")
+		assert.Equal(t, http.StatusForbidden, resp.code)
+	})
+
+	t.Run("create", func(t *testing.T) {
+		calr.On("GetObjectByName", mock.Anything, "alice", "0f1e2d3c.ics").Return(model.CalendarObject{}, model.ErrObjectNotFound).Twice()
+		tr.On("AddTask", mock.Anything, mock.MatchedBy(func(task model.TodoTask) bool {
+			return task.Title == "Buy milk" && task.PlanningDate == date && !task.Status
+		})).Return(model.TodoTask{Id: 1172, Title: "Buy milk", PlanningDate: date, State: "todo", Version: 1}, nil).Once()
+		tr.On("AssignTask", mock.Anything, 1172, "alice").Return(created, nil).Once()
+		calr.On("AddObject", mock.Anything, model.CalendarObject{User: "alice", Name: "0f1e2d3c.ics", UID: "0f1e2d3c", Task: created}).Return(nil).Once()
+
+		resp := alice.do(t, http.MethodPut, objectPath, map[string]string{"Content-Type": "text/calendar; charset=utf-8", "If-None-Match": "*"}, vtodo("NEEDS-ACTION"))
+		assert.Equal(t, http.StatusCreated, resp.code)
+		assert.Equal(t, `"1172-2"`, resp.header.Get("ETag"))
+
+		resp = alice.do(t, http.MethodPut, calendarPath+"event.ics", nil,
+			"BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nSUMMARY:Meeting\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n")
+		assert.Equal(t, http.StatusForbidden, resp.code)
+		assert.Contains(t, resp.body, "<c:supported-calendar-component/>")
+	})
+
+	t.Run("sync", func(t *testing.T) {
+		expectCalendar(shared, created)
+		resp := alice.do(t, "PROPFIND", calendarPath, map[string]string{"Depth": "1"},
+			`<d:propfind xmlns:d="DAV:" xmlns:cs="http://calendarserver.org/ns/"><d:prop><d:getetag/><cs:getctag/></d:prop></d:propfind>`)
+		require.Equal(t, http.StatusMultiStatus, resp.code)
+		assert.Contains(t, resp.body, "<cs:getctag>")
+		assert.Contains(t, resp.body, "<d:href>/todo-list/caldav/alice/tasks/task-1171.ics</d:href><d:propstat><d:prop><d:getetag>&#34;1171-4&#34;</d:getetag>")
+		assert.Contains(t, resp.body, "<d:href>/todo-list/caldav/alice/tasks/0f1e2d3c.ics</d:href><d:propstat><d:prop><d:getetag>&#34;1172-2&#34;</d:getetag>")
+
+		expectObject(created)
+		calr.On("GetObjectByName", mock.Anything, "alice", "gone.ics").Return(model.CalendarObject{}, model.ErrObjectNotFound).Once()
+		resp = alice.do(t, "REPORT", calendarPath, map[string]string{"Depth": "1"},
+			`<c:calendar-multiget xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav"><d:prop><d:getetag/><c:calendar-data/></d:prop>`+
+				`<d:href>`+objectPath+`</d:href><d:href>`+calendarPath+`gone.ics</d:href></c:calendar-multiget>`)
+		require.Equal(t, http.StatusMultiStatus, resp.code)
+		assert.Contains(t, resp.body, "UID:0f1e2d3c&#xD;&#xA;")
+		assert.Contains(t, resp.body, "SUMMARY:Buy milk&#xD;&#xA;")
+		assert.Contains(t, resp.body, "<d:href>/todo-list/caldav/alice/tasks/gone.ics</d:href><d:status>HTTP/1.1 404 Not Found</d:status>")
+
+		expectCalendar(shared, created)
+		resp = alice.do(t, "REPORT", calendarPath, map[string]string{"Depth": "1"},
+			`<c:calendar-query xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav"><d:prop><d:getetag/></d:prop>`+
+				`<c:filter><c:comp-filter name="VCALENDAR"><c:comp-filter name="VTODO"/></c:comp-filter></c:filter></c:calendar-query>`)
+		require.Equal(t, http.StatusMultiStatus, resp.code)
+		assert.Equal(t, 2, strings.Count(resp.body, "<d:response>"))
+
+		resp = alice.do(t, "REPORT", calendarPath, map[string]string{"Depth": "1"},
+			`<c:calendar-query xmlns:c="urn:ietf:params:xml:ns:caldav"><c:filter><c:comp-filter name="VCALENDAR">`+
+				`<c:comp-filter name="VEVENT"/></c:comp-filter></c:filter></c:calendar-query>`)
+		require.Equal(t, http.StatusMultiStatus, resp.code)
+		assert.NotContains(t, resp.body, "<d:response>")
+
+		expectObject(created)
+		resp = alice.do(t, http.MethodGet, objectPath, nil, "")
+		require.Equal(t, http.StatusOK, resp.code)
+		assert.Equal(t, `"1172-2"`, resp.header.Get("ETag"))
+		assert.Contains(t, resp.body, "STATUS:NEEDS-ACTION\r\n")
+	})
+
+	t.Run("complete", func(t *testing.T) {
+		expectObject(created)
+		resp := alice.do(t, http.MethodPut, objectPath, map[string]string{"If-Match": `"1172-1"`}, vtodo("COMPLETED"))
+		assert.Equal(t, http.StatusPreconditionFailed, resp.code)
+
+		done := model.TodoTask{Id: 1172, Title: "Buy milk", PlanningDate: date, Status: true, State: "done", Assignees: []string{"alice"}, Version: 3}
+		expectObject(created)
+		expectObject(created)
+		tr.On("GetTaskById", mock.Anything, 1172).Return(created, nil).Once()
+		tr.On("UpdateTask", mock.Anything, 1172, mock.MatchedBy(func(task model.TodoTask) bool {
+			return task.Status && task.State == "done" && task.Version == 2
+		})).Return(done, nil).Once()
+		resp = alice.do(t, http.MethodPut, objectPath, map[string]string{"If-Match": `"1172-2"`}, vtodo("COMPLETED"))
+		assert.Equal(t, http.StatusNoContent, resp.code)
+		assert.Equal(t, `"1172-3"`, resp.header.Get("ETag"))
+	})
+
+	t.Run("complete overdue", func(t *testing.T) {
+		past := model.Date{Year: 2024, Month: time.January, Day: 15}
+		overdue := model.TodoTask{Id: 1173, Title: "Pay rent", PlanningDate: past, State: "todo", Assignees: []string{"alice"}, Version: 5}
+		calr.On("GetObjectByName", mock.Anything, "alice", "task-1173.ics").Return(model.CalendarObject{}, model.ErrObjectNotFound).Twice()
+		tr.On("GetTaskById", mock.Anything, 1173).Return(overdue, nil).Times(3)
+		tr.On("UpdateTask", mock.Anything, 1173, mock.MatchedBy(func(task model.TodoTask) bool {
+			return task.Status && task.State == "done" && task.PlanningDate == past
+		})).Return(model.TodoTask{Id: 1173, Title: "Pay rent", PlanningDate: past, Status: true, State: "done", Assignees: []string{"alice"}, Version: 6}, nil).Once()
+
+		resp := alice.do(t, http.MethodPut, calendarPath+"task-1173.ics", map[string]string{"If-Match": `"1173-5"`},
+			"BEGIN:VCALENDAR\r\nVERSION:2.0\r\nBEGIN:VTODO\r\nUID:task-1173-vtodo@todo-list\r\nSUMMARY:Pay rent\r\n"+
+				"DUE;VALUE=DATE:20240115\r\nSTATUS:COMPLETED\r\nEND:VTODO\r\nEND:VCALENDAR\r\n")
+		assert.Equal(t, http.StatusNoContent, resp.code)
+		assert.Equal(t, `"1173-6"`, resp.header.Get("ETag"))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		expectObject(model.TodoTask{Id: 1172, Assignees: []string{"alice"}, Version: 3})
+		resp := alice.do(t, http.MethodDelete, objectPath, map[string]string{"If-Match": `"1172-2"`}, "")
+		assert.Equal(t, http.StatusPreconditionFailed, resp.code)
+
+		expectObject(model.TodoTask{Id: 1172, Assignees: []string{"alice"}, Version: 3})
+		expectObject(model.TodoTask{Id: 1172, Assignees: []string{"alice"}, Version: 3})
+		ar.On("GetAttachmentsByTask", mock.Anything, 1172).Return(nil, nil).Once()
+		tr.On("DeleteTask", mock.Anything, 1172).Return(nil).Once()
+		resp = alice.do(t, http.MethodDelete, objectPath, map[string]string{"If-Match": `"1172-3"`}, "")
+		assert.Equal(t, http.StatusNoContent, resp.code)
+
+		resp = alice.do(t, http.MethodDelete, calendarPath, nil, "")
+		assert.Equal(t, http.StatusMethodNotAllowed, resp.code)
+	})
+
+	t.Run("revoked password", func(t *testing.T) {
+		require.NoError(t, a.RevokeCalDAVPassword(context.Background(), "alice"))
+		resp := alice.do(t, "PROPFIND", caldavPrefix+"/", nil, "")
+		assert.Equal(t, http.StatusUnauthorized, resp.code)
+	})
+
+	tr.AssertExpectations(t)
+	ar.AssertExpectations(t)
+	calr.AssertExpectations(t)
+}
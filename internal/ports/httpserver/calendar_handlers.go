@@ -0,0 +1,251 @@
+package httpserver
+
+import (
+	"bytes"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+// writeICS writes the calendar as the response, it is rendered to the buffer
+// first, so the error of rendering is returned with status 500
+func writeICS(c *gin.Context, name string, tasks []model.TodoTask, components []string, headers map[string]string) {
+	var b bytes.Buffer
+	if err := writeCalendar(&b, name, tasks, components, time.Now()); err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		return
+	}
+	for k, v := range headers {
+		c.Header(k, v)
+	}
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", b.Bytes())
+}
+
+// @Summary		Выпуск ссылки на календарь задач пользователя
+// @Description	Возвращает новый случайный токен календаря текущего пользователя и путь к его ICS ленте относительно префикса API, предыдущая ссылка пользователя перестаёт работать. Сервер хранит только хеш токена, поэтому ссылка показывается один раз. Лента доступна без заголовка X-User любому, кто знает ссылку, поэтому X-User должен выставлять прокси, который аутентифицирует пользователей
+// @Produce		json
+// @Param		X-User header string true "Имя текущего пользователя"
+// @Success		200	{object} calendarFeedResponse "Успешный выпуск"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверное имя пользователя"
+// @Failure 	401 {object} taskResponse "Не указан текущий пользователь"
+// @Failure 	403 {object} taskResponse "Пользователь не является участником рабочего пространства"
+// @Failure		501	{object} taskResponse "Календари не включены"
+// @Router		/calendar/token [post]
+func issueCalendarToken(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := currentUser(c)
+		if !ok {
+			return
+		}
+
+		token, err := a.IssueCalendarToken(c, user)
+
+		switch {
+		case errors.Is(err, model.ErrCalendarDisabled):
+			c.AbortWithStatusJSON(http.StatusNotImplemented, errorResponse(model.ErrCalendarDisabled))
+		case errors.Is(err, model.ErrInvalidInput):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+		case errors.Is(err, model.ErrNotMember):
+			c.AbortWithStatusJSON(http.StatusForbidden, errorResponse(model.ErrNotMember))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, calendarFeedSuccessResponse(user, token))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
+
+// @Summary		Отзыв ссылки на календарь задач пользователя
+// @Description	Ссылка на ICS ленту текущего пользователя перестаёт работать
+// @Produce		json
+// @Param		X-User header string true "Имя текущего пользователя"
+// @Success		200	{object} taskResponse "Успешный отзыв"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверное имя пользователя"
+// @Failure 	401 {object} taskResponse "Не указан текущий пользователь"
+// @Failure 	404 {object} taskResponse "У пользователя нет ссылки на календарь"
+// @Router		/calendar/token [delete]
+func revokeCalendarToken(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := currentUser(c)
+		if !ok {
+			return
+		}
+
+		err := a.RevokeCalendarToken(c, user)
+
+		switch {
+		case errors.Is(err, model.ErrFeedNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(model.ErrFeedNotFound))
+		case errors.Is(err, model.ErrInvalidInput):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, deleteSuccessResponse())
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
+
+// @Summary		Выпуск пароля CalDAV пользователя
+// @Description	Возвращает новый случайный пароль текущего пользователя для входа CalDAV клиентов по HTTP Basic и путь к его календарю на сервере, предыдущий пароль пользователя перестаёт работать. Пароль выпускается отдельно от токена ICS ленты, поэтому ссылка на ленту не даёт изменять задачи. Сервер хранит только хеш пароля, поэтому он показывается один раз
+// @Produce		json
+// @Param		X-User header string true "Имя текущего пользователя"
+// @Success		200	{object} caldavPasswordResponse "Успешный выпуск"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверное имя пользователя"
+// @Failure 	401 {object} taskResponse "Не указан текущий пользователь"
+// @Failure 	403 {object} taskResponse "Пользователь не является участником рабочего пространства"
+// @Failure		501	{object} taskResponse "Календари не включены"
+// @Router		/calendar/caldav [post]
+func issueCalDAVPassword(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := currentUser(c)
+		if !ok {
+			return
+		}
+
+		password, err := a.IssueCalDAVPassword(c, user)
+
+		switch {
+		case errors.Is(err, model.ErrCalendarDisabled):
+			c.AbortWithStatusJSON(http.StatusNotImplemented, errorResponse(model.ErrCalendarDisabled))
+		case errors.Is(err, model.ErrInvalidInput):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+		case errors.Is(err, model.ErrNotMember):
+			c.AbortWithStatusJSON(http.StatusForbidden, errorResponse(model.ErrNotMember))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, caldavPasswordSuccessResponse(user, password))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
+
+// @Summary		Отзыв пароля CalDAV пользователя
+// @Description	CalDAV клиенты текущего пользователя больше не могут войти с его паролем
+// @Produce		json
+// @Param		X-User header string true "Имя текущего пользователя"
+// @Success		200	{object} taskResponse "Успешный отзыв"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверное имя пользователя"
+// @Failure 	401 {object} taskResponse "Не указан текущий пользователь"
+// @Failure 	404 {object} taskResponse "У пользователя нет пароля CalDAV"
+// @Router		/calendar/caldav [delete]
+func revokeCalDAVPassword(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := currentUser(c)
+		if !ok {
+			return
+		}
+
+		err := a.RevokeCalDAVPassword(c, user)
+
+		switch {
+		case errors.Is(err, model.ErrWrongPassword):
+			c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(model.ErrWrongPassword))
+		case errors.Is(err, model.ErrInvalidInput):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, deleteSuccessResponse())
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
+
+// @Summary		ICS лента задач пользователя
+// @Description	Возвращает задачи, назначенные пользователю, в формате iCalendar для подписки в приложениях календаря. Каждая задача передаётся событием на весь день (VEVENT) и задачей (VTODO) со статусом COMPLETED для выполненных и NEEDS-ACTION для невыполненных задач
+// @Produce		text/calendar
+// @Param 		user path string true "Имя пользователя"
+// @Param 		token path string true "Токен календаря пользователя с расширением .ics"
+// @Param		components query string false "Компоненты через запятую: vevent, vtodo (по умолчанию оба)"
+// @Success		200	{string} string "Календарь"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных"
+// @Failure 	404 {object} taskResponse "Календарь с таким токеном не найден"
+// @Router		/calendar/{user}/{token} [get]
+func getCalendarFeed(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		components, ok := parseComponents(c.Query("components"))
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+		user := c.Param("user")
+
+		tasks, err := a.GetCalendarFeed(c, user, strings.TrimSuffix(c.Param("token"), ".ics"))
+
+		switch {
+		case errors.Is(err, model.ErrFeedNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(model.ErrFeedNotFound))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			writeICS(c, "Tasks of "+user, tasks, components, map[string]string{
+				"Cache-Control": "private, max-age=300",
+			})
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
+
+// @Summary		Экспорт задач за период в ICS файл
+// @Description	Возвращает файл iCalendar с невыполненными и выполненными задачами каждого дня периода включительно, период не может быть длиннее 366 дней
+// @Produce		text/calendar
+// @Param		from query string true "Первый день периода в формате YYYY-MM-DD"
+// @Param		to query string true "Последний день периода в формате YYYY-MM-DD"
+// @Param		components query string false "Компоненты через запятую: vevent, vtodo (по умолчанию оба)"
+// @Success		200	{string} string "Файл календаря"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных"
+// @Router		/calendar/export [get]
+func exportCalendar(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		components, ok := parseComponents(c.Query("components"))
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+		from, fromErr := time.Parse(time.DateOnly, c.Query("from"))
+		to, toErr := time.Parse(time.DateOnly, c.Query("to"))
+		if fromErr != nil || toErr != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		tasks, err := a.GetTasksByDateRange(c,
+			model.Date{Year: from.Year(), Month: from.Month(), Day: from.Day()},
+			model.Date{Year: to.Year(), Month: to.Month(), Day: to.Day()},
+		)
+
+		switch {
+		case errors.Is(err, model.ErrInvalidInput):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			name := "tasks-" + from.Format(time.DateOnly) + "-" + to.Format(time.DateOnly)
+			writeICS(c, "Tasks "+from.Format(time.DateOnly)+" - "+to.Format(time.DateOnly), tasks, components, map[string]string{
+				"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": name + ".ics"}),
+			})
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
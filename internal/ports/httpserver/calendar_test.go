@@ -0,0 +1,162 @@
+package httpserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"todo-list/internal/app"
+	"todo-list/internal/app/mocks"
+	"todo-list/internal/model"
+)
+
+func TestWriteCalendar(t *testing.T) {
+	date := model.Date{Year: 2027, Month: time.December, Day: 31}
+	tasks := []model.TodoTask{
+		{Id: 1151, Title: "Plan; review, \\ready", Description: "First line\nsecond line", PlanningDate: date, State: "todo", Version: 2},
+		{Id: 1152, Title: strings.Repeat("Долгий ", 12), PlanningDate: date, Status: true, State: "done"},
+	}
+
+	var b bytes.Buffer
+	require.NoError(t, writeCalendar(&b, "Tasks", tasks, []string{componentEvent, componentTodo}, time.Date(2027, time.June, 1, 12, 30, 0, 0, time.UTC)))
+	ics := b.String()
+
+	assert.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(ics, "END:VCALENDAR\r\n"))
+	assert.Contains(t, ics, "BEGIN:VEVENT\r\nUID:task-1151-vevent@todo-list\r\nDTSTAMP:20270601T123000Z\r\nSEQUENCE:2\r\n"+
+		`SUMMARY:Plan\; review\, \\ready`+"\r\n"+`DESCRIPTION:First line\nsecond line`+"\r\nCATEGORIES:todo\r\n"+
+		"DTSTART;VALUE=DATE:20271231\r\nDTEND;VALUE=DATE:20280101\r\nTRANSP:TRANSPARENT\r\nEND:VEVENT\r\n")
+	assert.Contains(t, ics, "UID:task-1151-vtodo@todo-list\r\n")
+	assert.Contains(t, ics, "DUE;VALUE=DATE:20271231\r\nSTATUS:NEEDS-ACTION\r\nEND:VTODO\r\n")
+	assert.Contains(t, ics, "STATUS:COMPLETED\r\nPERCENT-COMPLETE:100\r\n")
+
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), icalMaxLine, line)
+	}
+	unfolded := strings.ReplaceAll(ics, "\r\n ", "")
+	assert.Contains(t, unfolded, "SUMMARY:"+strings.Repeat("Долгий ", 12)+"\r\n")
+}
+
+// credentialRepo returns mock of the repo which keeps hashes of the
+// credentials in memory
+func credentialRepo() *mocks.CredentialRepo {
+	hashes := make(map[string][]byte)
+	crr := new(mocks.CredentialRepo)
+	crr.On("SetCredential", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		hashes[args.String(1)+"/"+args.String(2)] = args.Get(3).([]byte)
+	}).Return(nil)
+	crr.On("GetCredential", mock.Anything, mock.Anything, mock.Anything).Return(func(_ context.Context, user string, kind string) []byte {
+		return hashes[user+"/"+kind]
+	}, func(_ context.Context, user string, kind string) error {
+		if _, ok := hashes[user+"/"+kind]; !ok {
+			return model.ErrCredentialNotFound
+		}
+		return nil
+	})
+	crr.On("DeleteCredential", mock.Anything, mock.Anything, mock.Anything).Return(func(_ context.Context, user string, kind string) error {
+		if _, ok := hashes[user+"/"+kind]; !ok {
+			return model.ErrCredentialNotFound
+		}
+		delete(hashes, user+"/"+kind)
+		return nil
+	})
+	return crr
+}
+
+func TestCalendar(t *testing.T) {
+	tr := new(mocks.TaskRepo)
+	crr := credentialRepo()
+	a := app.New(app.Deps{Tasks: tr, Credentials: crr}, app.Config{CalendarEnabled: true})
+	h := New("", a).Handler
+	date := model.Date{Year: 2027, Month: time.June, Day: 1}
+
+	send := func(method string, path string, user string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/todo-list/api"+path, nil)
+		if user != "" {
+			req.Header.Set(userHeader, user)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w
+	}
+	get := func(path string, user string) *httptest.ResponseRecorder {
+		return send(http.MethodGet, path, user)
+	}
+	issue := func(t *testing.T) string {
+		w := send(http.MethodPost, "/calendar/token", "alice")
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp calendarFeedResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.NotNil(t, resp.Data)
+		assert.Equal(t, "/calendar/alice/"+resp.Data.Token+".ics", resp.Data.Path)
+		return resp.Data.Path
+	}
+
+	var feedPath string
+	t.Run("token", func(t *testing.T) {
+		old := issue(t)
+		feedPath = issue(t)
+		assert.NotEqual(t, old, feedPath)
+		// only the last issued link works
+		assert.Equal(t, http.StatusNotFound, get(old, "").Code)
+
+		assert.Equal(t, http.StatusUnauthorized, send(http.MethodPost, "/calendar/token", "").Code)
+	})
+
+	t.Run("caldav password", func(t *testing.T) {
+		w := send(http.MethodPost, "/calendar/caldav", "alice")
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp caldavPasswordResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.NotNil(t, resp.Data)
+		assert.Equal(t, "/todo-list/caldav/alice/tasks/", resp.Data.Path)
+		assert.NotEmpty(t, resp.Data.Password)
+		assert.NotContains(t, feedPath, resp.Data.Password)
+
+		assert.Equal(t, http.StatusOK, send(http.MethodDelete, "/calendar/caldav", "alice").Code)
+		assert.Equal(t, http.StatusNotFound, send(http.MethodDelete, "/calendar/caldav", "alice").Code)
+	})
+
+	t.Run("feed", func(t *testing.T) {
+		tr.On("GetTasksByAssignee", mock.Anything, "alice", 0, 100).Return([]model.TodoTask{
+			{Id: 1153, Title: "Assigned", PlanningDate: date, Assignees: []string{"alice"}},
+		}, nil).Once()
+
+		w := get(feedPath+"?components=vtodo", "")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/calendar; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), "UID:task-1153-vtodo@todo-list")
+		assert.NotContains(t, w.Body.String(), "VEVENT")
+
+		assert.Equal(t, http.StatusNotFound, get("/calendar/bob/"+strings.TrimPrefix(feedPath, "/calendar/alice/"), "").Code)
+		assert.Equal(t, http.StatusBadRequest, get(feedPath+"?components=vjournal", "").Code)
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, send(http.MethodDelete, "/calendar/token", "alice").Code)
+		assert.Equal(t, http.StatusNotFound, get(feedPath, "").Code)
+	})
+
+	t.Run("export", func(t *testing.T) {
+		tr.On("GetTasksByDateAndStatus", mock.Anything, date, false).Return([]model.TodoTask{{Id: 1154, Title: "Undone", PlanningDate: date}}, nil).Once()
+		tr.On("GetTasksByDateAndStatus", mock.Anything, date, true).Return([]model.TodoTask{{Id: 1155, Title: "Done", PlanningDate: date, Status: true}}, nil).Once()
+
+		w := get("/calendar/export?from=2027-06-01&to=2027-06-01&components=vevent", "")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `attachment; filename=tasks-2027-06-01-2027-06-01.ics`, w.Header().Get("Content-Disposition"))
+		body := w.Body.String()
+		assert.Less(t, strings.Index(body, "task-1154"), strings.Index(body, "task-1155"))
+
+		assert.Equal(t, http.StatusBadRequest, get("/calendar/export?from=2027-06-02&to=2027-06-01", "").Code)
+		assert.Equal(t, http.StatusBadRequest, get("/calendar/export?from=tomorrow&to=2027-06-01", "").Code)
+	})
+
+	tr.AssertExpectations(t)
+}
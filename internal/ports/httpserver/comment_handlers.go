@@ -0,0 +1,210 @@
+package httpserver
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+// @Summary		Добавление комментария к задаче
+// @Description	Возвращает добавленный комментарий текущего пользователя
+// @Produce		json
+// @Param		X-User header string true "Имя текущего пользователя"
+// @Param		input body addCommentRequest true "Текст комментария в формате Markdown"
+// @Param 		id path int true "id задачи"
+// @Success		200	{object} commentResponse "Успешное добавление"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных"
+// @Failure 	401 {object} taskResponse "Не указан текущий пользователь"
+// @Failure 	404 {object} taskResponse "Задача с заданным id не найдена"
+// @Router		/task/{id}/comments [post]
+func addComment(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		author, ok := currentUser(c)
+		if !ok {
+			return
+		}
+
+		taskId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		var req addCommentRequest
+		if err = c.BindJSON(&req); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		comment, err := a.AddComment(c, taskId, author, req.Text)
+
+		switch {
+		case errors.Is(err, model.ErrTaskNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(model.ErrTaskNotFound))
+		case errors.Is(err, model.ErrInvalidInput):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+		case errors.Is(err, model.ErrInvalidComment):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidComment))
+		case errors.Is(err, model.ErrNotMember):
+			c.AbortWithStatusJSON(http.StatusForbidden, errorResponse(model.ErrNotMember))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, commentSuccessResponse(comment))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
+
+// @Summary		Получение комментариев к задаче с пагинацией
+// @Description	Возвращает список комментариев от старых к новым
+// @Produce		json
+// @Param		input body getCommentsByTaskRequest true "Пагинация"
+// @Param 		id path int true "id задачи"
+// @Success		200	{object} commentsResponse "Успешное получение комментариев"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных"
+// @Failure 	404 {object} taskResponse "Задача с заданным id не найдена"
+// @Router		/task/{id}/comments [get]
+func getCommentsByTask(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		taskId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		var req getCommentsByTaskRequest
+		if err = c.BindJSON(&req); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		comments, err := a.GetCommentsByTask(c, taskId, req.Offset, req.Limit)
+
+		switch {
+		case errors.Is(err, model.ErrTaskNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(model.ErrTaskNotFound))
+		case errors.Is(err, model.ErrInvalidInput):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, commentsSuccessResponse(comments))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
+
+// @Summary		Изменение текста комментария
+// @Description	Возвращает изменённый комментарий, изменить комментарий может только его автор
+// @Produce		json
+// @Param		X-User header string true "Имя текущего пользователя"
+// @Param		input body updateCommentRequest true "Новый текст комментария в формате Markdown"
+// @Param 		id path int true "id задачи"
+// @Param 		comment_id path int true "id комментария"
+// @Success		200	{object} commentResponse "Успешное изменение"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных"
+// @Failure 	401 {object} taskResponse "Не указан текущий пользователь"
+// @Failure 	403 {object} taskResponse "Текущий пользователь не автор комментария"
+// @Failure 	404 {object} taskResponse "Комментарий с заданным id не найден"
+// @Router		/task/{id}/comments/{comment_id} [put]
+func updateComment(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		author, ok := currentUser(c)
+		if !ok {
+			return
+		}
+
+		taskId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		id, err := strconv.Atoi(c.Param("comment_id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		var req updateCommentRequest
+		if err = c.BindJSON(&req); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		comment, err := a.UpdateComment(c, taskId, id, author, req.Text)
+
+		switch {
+		case errors.Is(err, model.ErrCommentNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(model.ErrCommentNotFound))
+		case errors.Is(err, model.ErrInvalidComment):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidComment))
+		case errors.Is(err, model.ErrForbidden):
+			c.AbortWithStatusJSON(http.StatusForbidden, errorResponse(model.ErrForbidden))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, commentSuccessResponse(comment))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
+
+// @Summary		Удаление комментария
+// @Description	Удаляет комментарий, удалить комментарий может только его автор
+// @Produce		json
+// @Param		X-User header string true "Имя текущего пользователя"
+// @Param 		id path int true "id задачи"
+// @Param 		comment_id path int true "id комментария"
+// @Success		200	{object} taskResponse "Успешное удаление"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных"
+// @Failure 	401 {object} taskResponse "Не указан текущий пользователь"
+// @Failure 	403 {object} taskResponse "Текущий пользователь не автор комментария"
+// @Failure 	404 {object} taskResponse "Комментарий с заданным id не найден"
+// @Router		/task/{id}/comments/{comment_id} [delete]
+func deleteComment(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		author, ok := currentUser(c)
+		if !ok {
+			return
+		}
+
+		taskId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		id, err := strconv.Atoi(c.Param("comment_id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		err = a.DeleteComment(c, taskId, id, author)
+
+		switch {
+		case errors.Is(err, model.ErrCommentNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(model.ErrCommentNotFound))
+		case errors.Is(err, model.ErrForbidden):
+			c.AbortWithStatusJSON(http.StatusForbidden, errorResponse(model.ErrForbidden))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, deleteSuccessResponse())
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
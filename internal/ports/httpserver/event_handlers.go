@@ -0,0 +1,136 @@
+package httpserver
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"time"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+const (
+	// heartbeatInterval is a period of the comments sent to the idle stream,
+	// so proxies don't close it
+	heartbeatInterval = 15 * time.Second
+
+	// retryDelay is a delay before reconnection of the client to the stream
+	retryDelay = 3 * time.Second
+)
+
+// writeEvent writes the event to the stream in format of Server-Sent Events
+func writeEvent(c *gin.Context, e model.Event) error {
+	_, err := fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", e.Id, e.Type, e.Payload)
+	return err
+}
+
+// @Summary		Поток событий изменения задач (Server-Sent Events)
+// @Description	Передаёт события task.created, task.updated и task.deleted задач, которые были в области подписки до или после изменения. После переподключения поток продолжается с события из заголовка Last-Event-ID, а если оно уже вытеснено из буфера, приходит событие reset. Каждые 15 секунд отправляется комментарий heartbeat
+// @Produce		text/event-stream
+// @Param		X-User header string false "Имя текущего пользователя"
+// @Param		Last-Event-ID header int false "id последнего полученного события"
+// @Param		user query string false "Имя текущего пользователя для клиентов, которые не могут передать заголовок X-User"
+// @Param		last_event_id query int false "id последнего полученного события для клиентов, которые не могут передать заголовок Last-Event-ID"
+// @Param		assigned_to_me query bool false "Только задачи, назначенные текущему пользователю"
+// @Param		date query string false "Только задачи, запланированные на дату в формате YYYY-MM-DD"
+// @Param		project query string false "Только задачи проекта"
+// @Success		200	{string} string "Поток событий"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных"
+// @Failure 	401 {object} taskResponse "Не указан текущий пользователь"
+// @Failure 	403 {object} taskResponse "Пользователь не является участником рабочего пространства"
+// @Router		/events [get]
+func streamEvents(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var scope model.EventScope
+		if c.Query("assigned_to_me") == "true" {
+			// EventSource of the browsers can't set headers of the request
+			scope.Assignee = c.GetHeader(userHeader)
+			if scope.Assignee == "" {
+				scope.Assignee = c.Query("user")
+			}
+			if scope.Assignee == "" {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(model.ErrUnknownUser))
+				return
+			}
+		}
+		if date := c.Query("date"); date != "" {
+			d, err := time.Parse(time.DateOnly, date)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+				return
+			}
+			scope.Date.Year, scope.Date.Month, scope.Date.Day = d.Date()
+		}
+		scope.Project = c.Query("project")
+
+		lastEventId := c.GetHeader("Last-Event-ID")
+		if lastEventId == "" {
+			lastEventId = c.Query("last_event_id")
+		}
+		afterId := 0
+		if lastEventId != "" {
+			var err error
+			if afterId, err = strconv.Atoi(lastEventId); err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+				return
+			}
+		}
+
+		stream, err := a.SubscribeEvents(c.Request.Context(), scope, afterId)
+
+		switch {
+		case errors.Is(err, model.ErrInvalidInput):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		case errors.Is(err, model.ErrNotMember):
+			c.AbortWithStatusJSON(http.StatusForbidden, errorResponse(model.ErrNotMember))
+			return
+		case err != nil:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+			return
+		}
+
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+		c.Header("X-Accel-Buffering", "no")
+		c.Status(http.StatusOK)
+
+		if _, err = fmt.Fprintf(c.Writer, "retry: %d\n\n", retryDelay.Milliseconds()); err != nil {
+			return
+		}
+		if stream.Lost {
+			// client reloads the tasks and continues from the next event
+			if _, err = fmt.Fprint(c.Writer, "event: reset\ndata: {}\n\n"); err != nil {
+				return
+			}
+		}
+		for _, e := range stream.Missed {
+			if err = writeEvent(c, e); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+
+		heartbeat := time.NewTicker(heartbeatInterval)
+		defer heartbeat.Stop()
+		for {
+			select {
+			case e, ok := <-stream.Events:
+				if !ok { // client resumes from the last received event
+					return
+				}
+				if err = writeEvent(c, e); err != nil {
+					return
+				}
+			case <-heartbeat.C:
+				if _, err = fmt.Fprint(c.Writer, ": heartbeat\n\n"); err != nil {
+					return
+				}
+			}
+			c.Writer.Flush()
+		}
+	}
+}
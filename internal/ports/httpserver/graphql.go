@@ -0,0 +1,453 @@
+package httpserver
+
+import (
+	"errors"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"strconv"
+	"strings"
+	"time"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+const (
+	// maxGraphQLDepth limits nesting of the fields of the query, fields of
+	// the introspection are not counted, so the tools can load the schema
+	maxGraphQLDepth = 5
+
+	// maxGraphQLComplexity limits number of the fields resolved by the
+	// query, fields of the lists of tasks are counted once for every task
+	// the list can contain
+	maxGraphQLComplexity = 1000
+
+	// defaultListSize is the number of tasks expected in the lists without
+	// the limit argument
+	defaultListSize = 20
+)
+
+var (
+	errQueryTooDeep    = errors.New("query is too deep")
+	errQueryTooComplex = errors.New("query is too complex")
+)
+
+// taskListFields are the fields returning the lists of tasks
+var taskListFields = map[string]bool{
+	"searchTasks":   true,
+	"tasksByStatus": true,
+	"tasksByState":  true,
+	"tasksByDate":   true,
+	"overdueTasks":  true,
+}
+
+var dateType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Date",
+	Fields: graphql.Fields{
+		"year":  dateField(func(d model.Date) int { return d.Year }),
+		"month": dateField(func(d model.Date) int { return int(d.Month) }),
+		"day":   dateField(func(d model.Date) int { return d.Day }),
+	},
+})
+
+var dateInputType = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "DateInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"year":  &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int)},
+		"month": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int)},
+		"day":   &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int)},
+	},
+})
+
+var taskType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Task",
+	Fields: graphql.Fields{
+		"id":           taskField(graphql.NewNonNull(graphql.Int), func(t model.TodoTask) any { return t.Id }),
+		"title":        taskField(graphql.NewNonNull(graphql.String), func(t model.TodoTask) any { return t.Title }),
+		"description":  taskField(graphql.NewNonNull(graphql.String), func(t model.TodoTask) any { return t.Description }),
+		"planningDate": taskField(graphql.NewNonNull(dateType), func(t model.TodoTask) any { return t.PlanningDate }),
+		"status":       taskField(graphql.NewNonNull(graphql.Boolean), func(t model.TodoTask) any { return t.Status }),
+		"state":        taskField(graphql.NewNonNull(graphql.String), func(t model.TodoTask) any { return t.State }),
+		"rank":         taskField(graphql.NewNonNull(graphql.String), func(t model.TodoTask) any { return t.Rank }),
+		"position":     taskField(graphql.NewNonNull(graphql.Int), func(t model.TodoTask) any { return t.Position }),
+		"overdue":      taskField(graphql.NewNonNull(graphql.Boolean), func(t model.TodoTask) any { return t.Overdue }),
+		"postponed":    taskField(graphql.NewNonNull(graphql.Int), func(t model.TodoTask) any { return t.Postponed }),
+		"assignees": taskField(graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))), func(t model.TodoTask) any {
+			if t.Assignees == nil {
+				return []string{}
+			}
+			return t.Assignees
+		}),
+		"blocked": taskField(graphql.NewNonNull(graphql.Boolean), func(t model.TodoTask) any { return t.Blocked }),
+		"version": taskField(graphql.NewNonNull(graphql.Int), func(t model.TodoTask) any { return t.Version }),
+		"project": taskField(graphql.NewNonNull(graphql.String), func(t model.TodoTask) any { return t.Project }),
+	},
+})
+
+var projectType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Project",
+	Fields: graphql.Fields{
+		"name": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.String),
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(model.Project).Name, nil
+			},
+		},
+		"members": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))),
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				return assigneesData(p.Source.(model.Project).Members), nil
+			},
+		},
+	},
+})
+
+var taskInputType = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "TaskInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"title":        &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		"description":  &graphql.InputObjectFieldConfig{Type: graphql.String, DefaultValue: ""},
+		"planningDate": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(dateInputType)},
+		"status":       &graphql.InputObjectFieldConfig{Type: graphql.Boolean, DefaultValue: false},
+		"state":        &graphql.InputObjectFieldConfig{Type: graphql.String, DefaultValue: ""},
+	},
+})
+
+func dateField(get func(model.Date) int) *graphql.Field {
+	return &graphql.Field{
+		Type: graphql.NewNonNull(graphql.Int),
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return get(p.Source.(model.Date)), nil
+		},
+	}
+}
+
+func taskField(typ graphql.Output, get func(model.TodoTask) any) *graphql.Field {
+	return &graphql.Field{
+		Type: typ,
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return get(p.Source.(model.TodoTask)), nil
+		},
+	}
+}
+
+// dateArg returns the date from the DateInput argument
+func dateArg(v any) model.Date {
+	m, _ := v.(map[string]any)
+	year, _ := m["year"].(int)
+	month, _ := m["month"].(int)
+	day, _ := m["day"].(int)
+	return model.Date{Year: year, Month: time.Month(month), Day: day}
+}
+
+// taskArg returns the task from the TaskInput argument
+func taskArg(v any) model.TodoTask {
+	m, _ := v.(map[string]any)
+	t := model.TodoTask{PlanningDate: dateArg(m["planningDate"])}
+	t.Title, _ = m["title"].(string)
+	t.Description, _ = m["description"].(string)
+	t.Status, _ = m["status"].(bool)
+	t.State, _ = m["state"].(string)
+	return t
+}
+
+// stringsArg returns the strings from the list argument
+func stringsArg(v any) []string {
+	items, _ := v.([]any)
+	res := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+// resolved returns the result of the app or the reported error
+func resolved(v any, err error) (any, error) {
+	if err != nil {
+		return nil, reportedError(err)
+	}
+	return v, nil
+}
+
+// paged returns the page of the tasks which the app reads without pagination,
+// so the list is not longer than its limit counted in the complexity
+func paged(p graphql.ResolveParams, tasks []model.TodoTask, err error) (any, error) {
+	offset, limit := p.Args["offset"].(int), p.Args["limit"].(int)
+	if err != nil {
+		return nil, reportedError(err)
+	} else if offset < 0 || limit < 0 {
+		return nil, reportedError(model.ErrInvalidInput)
+	}
+	tasks = tasks[min(offset, len(tasks)):]
+	return tasks[:min(limit, len(tasks))], nil
+}
+
+// taskSchema returns GraphQL schema of the queries and mutations of the tasks
+func taskSchema(a app.App) (graphql.Schema, error) {
+	tasksType := graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(taskType)))
+	pageArgs := func(args graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+		args["offset"] = &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 0}
+		args["limit"] = &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultListSize}
+		return args
+	}
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"task": &graphql.Field{
+				Type: taskType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return resolved(a.GetTaskById(p.Context, p.Args["id"].(int)))
+				},
+			},
+			"searchTasks": &graphql.Field{
+				Type: tasksType,
+				Args: pageArgs(graphql.FieldConfigArgument{
+					"text": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				}),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					tasks, err := a.GetTaskByText(p.Context, p.Args["text"].(string))
+					return paged(p, tasks, err)
+				},
+			},
+			"tasksByStatus": &graphql.Field{
+				Type: tasksType,
+				Args: pageArgs(graphql.FieldConfigArgument{
+					"status": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Boolean)},
+				}),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return resolved(a.GetTasksByStatus(p.Context, p.Args["status"].(bool), p.Args["offset"].(int), p.Args["limit"].(int)))
+				},
+			},
+			"tasksByState": &graphql.Field{
+				Type: tasksType,
+				Args: pageArgs(graphql.FieldConfigArgument{
+					"state": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				}),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return resolved(a.GetTasksByState(p.Context, p.Args["state"].(string), p.Args["offset"].(int), p.Args["limit"].(int)))
+				},
+			},
+			"tasksByDate": &graphql.Field{
+				Type: tasksType,
+				Args: pageArgs(graphql.FieldConfigArgument{
+					"date":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(dateInputType)},
+					"status": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Boolean)},
+				}),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					tasks, err := a.GetTasksByDateAndStatus(p.Context, dateArg(p.Args["date"]), p.Args["status"].(bool))
+					return paged(p, tasks, err)
+				},
+			},
+			"overdueTasks": &graphql.Field{
+				Type: tasksType,
+				Args: pageArgs(graphql.FieldConfigArgument{}),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return resolved(a.GetOverdueTasks(p.Context, p.Args["offset"].(int), p.Args["limit"].(int)))
+				},
+			},
+			"project": &graphql.Field{
+				Type: projectType,
+				Args: graphql.FieldConfigArgument{
+					"name": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return resolved(a.GetProject(p.Context, p.Args["name"].(string)))
+				},
+			},
+			"projects": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(projectType))),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return resolved(a.GetProjects(p.Context))
+				},
+			},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"addTask": &graphql.Field{
+				Type: graphql.NewNonNull(taskType),
+				Args: graphql.FieldConfigArgument{
+					"task":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(taskInputType)},
+					"project": &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: ""},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					t := taskArg(p.Args["task"])
+					t.Project = p.Args["project"].(string)
+					return resolved(a.AddTask(p.Context, t))
+				},
+			},
+			"updateTask": &graphql.Field{
+				Type: graphql.NewNonNull(taskType),
+				Args: graphql.FieldConfigArgument{
+					"id":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+					"task":  &graphql.ArgumentConfig{Type: graphql.NewNonNull(taskInputType)},
+					"force": &graphql.ArgumentConfig{Type: graphql.Boolean, DefaultValue: false},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					update := a.UpdateTask
+					if p.Args["force"].(bool) {
+						update = a.ForceUpdateTask
+					}
+					return resolved(update(p.Context, p.Args["id"].(int), taskArg(p.Args["task"])))
+				},
+			},
+			"deleteTask": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					id := p.Args["id"].(int)
+					return resolved(id, a.DeleteTask(p.Context, id))
+				},
+			},
+			"setTaskProject": &graphql.Field{
+				Type: graphql.NewNonNull(taskType),
+				Args: graphql.FieldConfigArgument{
+					"id":      &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+					"project": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return resolved(a.SetTaskProject(p.Context, p.Args["id"].(int), p.Args["project"].(string)))
+				},
+			},
+			"addProject": &graphql.Field{
+				Type: graphql.NewNonNull(projectType),
+				Args: graphql.FieldConfigArgument{
+					"name":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"members": &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String)), DefaultValue: []any{}},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return resolved(a.AddProject(p.Context, model.Project{
+						Name:    p.Args["name"].(string),
+						Members: stringsArg(p.Args["members"]),
+					}))
+				},
+			},
+			"addProjectMember": &graphql.Field{
+				Type: graphql.NewNonNull(projectType),
+				Args: graphql.FieldConfigArgument{
+					"name":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"member": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return resolved(a.AddProjectMember(p.Context, p.Args["name"].(string), p.Args["member"].(string)))
+				},
+			},
+			"removeProjectMember": &graphql.Field{
+				Type: graphql.NewNonNull(projectType),
+				Args: graphql.FieldConfigArgument{
+					"name":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"member": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return resolved(a.RemoveProjectMember(p.Context, p.Args["name"].(string), p.Args["member"].(string)))
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query:    query,
+		Mutation: mutation,
+	})
+}
+
+// queryCost counts depth and complexity of the selections of the query,
+// fragment cycles are rejected by the validation before it
+type queryCost struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]any
+}
+
+// checkLimits returns error if any operation of the document exceeds the
+// depth or complexity limits
+func checkLimits(doc *ast.Document, variables map[string]any) error {
+	qc := queryCost{fragments: make(map[string]*ast.FragmentDefinition), variables: variables}
+	for _, def := range doc.Definitions {
+		if f, ok := def.(*ast.FragmentDefinition); ok {
+			qc.fragments[f.Name.Value] = f
+		}
+	}
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		depth, complexity := qc.selections(op.SelectionSet, false)
+		if depth > maxGraphQLDepth {
+			return errQueryTooDeep
+		} else if complexity > maxGraphQLComplexity {
+			return errQueryTooComplex
+		}
+	}
+	return nil
+}
+
+func (qc queryCost) selections(set *ast.SelectionSet, introspection bool) (int, int) {
+	if set == nil {
+		return 0, 0
+	}
+
+	depth, complexity := 0, 0
+	for _, sel := range set.Selections {
+		var d, c int
+		switch s := sel.(type) {
+		case *ast.Field:
+			// fields of the introspection are not counted in the depth
+			inner := introspection || strings.HasPrefix(s.Name.Value, "__")
+			d, c = qc.selections(s.SelectionSet, inner)
+			if taskListFields[s.Name.Value] {
+				c *= qc.listSize(s)
+			}
+			if !inner {
+				d++
+			}
+			c++
+		case *ast.InlineFragment:
+			d, c = qc.selections(s.SelectionSet, introspection)
+		case *ast.FragmentSpread:
+			if f, ok := qc.fragments[s.Name.Value]; ok {
+				d, c = qc.selections(f.SelectionSet, introspection)
+			}
+		}
+		depth = max(depth, d)
+		complexity += c
+	}
+	return depth, complexity
+}
+
+// listSize returns the limit of the list field from the literal argument or
+// the variable, lists without the limit count as defaultListSize tasks
+func (qc queryCost) listSize(f *ast.Field) int {
+	for _, arg := range f.Arguments {
+		if arg.Name.Value != "limit" {
+			continue
+		}
+		switch v := arg.Value.(type) {
+		case *ast.IntValue:
+			if n, err := strconv.Atoi(v.Value); err == nil && n > 0 {
+				return n
+			}
+		case *ast.Variable:
+			switch n := qc.variables[v.Name.Value].(type) {
+			case float64:
+				if n > 0 {
+					return int(n)
+				}
+			case int:
+				if n > 0 {
+					return n
+				}
+			}
+		}
+	}
+	return defaultListSize
+}
@@ -0,0 +1,57 @@
+package httpserver
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"net/http"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+// @Summary		GraphQL запросы задач
+// @Description	Выполняет GraphQL запрос или мутацию и возвращает только запрошенные поля задач. Запросы: task(id), searchTasks(text, offset, limit), tasksByStatus(status, offset, limit), tasksByState(state, offset, limit), tasksByDate(date, status, offset, limit) и overdueTasks(offset, limit), по умолчанию limit = 20, мутации: addTask(task), updateTask(id, task, force) и deleteTask(id). Ответ имеет формат GraphQL с полями data и errors. Запросы глубже 5 уровней или со сложностью больше 1000 полей не выполняются, поля списков задач учитываются для каждой задачи списка (limit или 20 задач)
+// @Accept		json
+// @Produce		json
+// @Param		input body graphqlRequest true "GraphQL запрос, имя операции и переменные"
+// @Success		200	{object} graphqlResponse "Результат запроса, ошибки отдельных полей возвращаются в errors"
+// @Failure 	400 {object} graphqlResponse "Неверный формат запроса, запрос не прошёл проверку или превысил ограничения"
+// @Router		/graphql [post]
+func graphqlQuery(a app.App) gin.HandlerFunc {
+	schema, err := taskSchema(a)
+	if err != nil {
+		panic(err) // schema is static, so it is a bug
+	}
+
+	return func(c *gin.Context) {
+		var req graphqlRequest
+		if err := c.BindJSON(&req); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, graphqlErrors(model.ErrInvalidInput))
+			return
+		}
+
+		doc, err := parser.Parse(parser.ParseParams{Source: req.Query})
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, graphqlResponse{Errors: gqlerrors.FormatErrors(err)})
+			return
+		}
+		if res := graphql.ValidateDocument(&schema, doc, nil); !res.IsValid {
+			c.AbortWithStatusJSON(http.StatusBadRequest, graphqlResponse{Errors: res.Errors})
+			return
+		}
+		if err = checkLimits(doc, req.Variables); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, graphqlErrors(err))
+			return
+		}
+
+		res := graphql.Execute(graphql.ExecuteParams{
+			Schema:        schema,
+			AST:           doc,
+			OperationName: req.OperationName,
+			Args:          req.Variables,
+			Context:       c.Request.Context(),
+		})
+		c.JSON(http.StatusOK, graphqlResponse{Data: res.Data, Errors: res.Errors})
+	}
+}
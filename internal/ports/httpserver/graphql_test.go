@@ -0,0 +1,195 @@
+package httpserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"todo-list/internal/app"
+	"todo-list/internal/app/mocks"
+	"todo-list/internal/model"
+)
+
+type graphqlTestResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// doGraphQL sends GraphQL request to the handler of the app and returns status
+// and body of the response
+func doGraphQL(t *testing.T, h http.Handler, query string, variables map[string]any) (int, graphqlTestResponse) {
+	b, err := json.Marshal(graphqlRequest{Query: query, Variables: variables})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/todo-list/api/graphql", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	var resp graphqlTestResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return w.Code, resp
+}
+
+func TestGraphQL(t *testing.T) {
+	tr, br := new(mocks.TaskRepo), new(mocks.BoardRepo)
+	a := app.New(app.Deps{Tasks: tr, Board: br}, app.Config{})
+	h := New("", a).Handler
+	date := model.Date{Year: 2027, Month: time.June, Day: 1}
+
+	t.Run("only requested fields are returned", func(t *testing.T) {
+		tr.On("GetTaskById", mock.Anything, 1091).Return(model.TodoTask{
+			Id:           1091,
+			Title:        "GraphQL",
+			Description:  "Not requested",
+			PlanningDate: date,
+			State:        "todo",
+		}, nil).Once()
+
+		code, resp := doGraphQL(t, h, `{ task(id: 1091) { title planningDate { month } } }`, nil)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Empty(t, resp.Errors)
+		assert.JSONEq(t, `{"task":{"title":"GraphQL","planningDate":{"month":6}}}`, string(resp.Data))
+	})
+
+	t.Run("list with the limit from the variables", func(t *testing.T) {
+		tr.On("GetTasksByStatus", mock.Anything, false, 0, 2).Return([]model.TodoTask{
+			{Id: 1092, Title: "First"},
+			{Id: 1093, Title: "Second"},
+		}, nil).Once()
+
+		code, resp := doGraphQL(t, h, `query Undone($limit: Int) { tasksByStatus(status: false, limit: $limit) { id } }`, map[string]any{"limit": 2})
+		assert.Equal(t, http.StatusOK, code)
+		assert.Empty(t, resp.Errors)
+		assert.JSONEq(t, `{"tasksByStatus":[{"id":1092},{"id":1093}]}`, string(resp.Data))
+	})
+
+	t.Run("page of the list read without pagination", func(t *testing.T) {
+		tasks := make([]model.TodoTask, 0, 25)
+		for i := 0; i < 25; i++ {
+			tasks = append(tasks, model.TodoTask{Id: 1101 + i, PlanningDate: date})
+		}
+		tr.On("GetTasksByDateAndStatus", mock.Anything, date, false).Return(tasks, nil).Twice()
+
+		code, resp := doGraphQL(t, h, `{ tasksByDate(date: {year: 2027, month: 6, day: 1}, status: false) { id } }`, nil)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Empty(t, resp.Errors)
+		var data struct {
+			TasksByDate []struct{ Id int } `json:"tasksByDate"`
+		}
+		require.NoError(t, json.Unmarshal(resp.Data, &data))
+		assert.Len(t, data.TasksByDate, defaultListSize)
+
+		code, resp = doGraphQL(t, h, `{ tasksByDate(date: {year: 2027, month: 6, day: 1}, status: false, offset: 23, limit: 5) { id } }`, nil)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Empty(t, resp.Errors)
+		assert.JSONEq(t, `{"tasksByDate":[{"id":1124},{"id":1125}]}`, string(resp.Data))
+	})
+
+	t.Run("errors of the app are reported", func(t *testing.T) {
+		tr.On("GetTaskById", mock.Anything, 1094).Return(model.TodoTask{}, model.ErrTaskNotFound).Once()
+
+		code, resp := doGraphQL(t, h, `{ task(id: 1094) { id } }`, nil)
+		assert.Equal(t, http.StatusOK, code)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, model.ErrTaskNotFound.Error(), resp.Errors[0].Message)
+		assert.JSONEq(t, `{"task":null}`, string(resp.Data))
+	})
+
+	t.Run("mutation", func(t *testing.T) {
+		br.On("GetLastRank", mock.Anything, "todo").Return("", nil).Once()
+		tr.On("AddTask", mock.Anything, mock.MatchedBy(func(task model.TodoTask) bool {
+			return task.Title == "Added" && task.PlanningDate == date && task.State == "todo"
+		})).Return(model.TodoTask{Id: 1095, Title: "Added", PlanningDate: date, State: "todo"}, nil).Once()
+
+		code, resp := doGraphQL(t, h, `mutation { addTask(task: {title: "Added", planningDate: {year: 2027, month: 6, day: 1}}) { id state } }`, nil)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Empty(t, resp.Errors)
+		assert.JSONEq(t, `{"addTask":{"id":1095,"state":"todo"}}`, string(resp.Data))
+	})
+
+	t.Run("task moved to the project", func(t *testing.T) {
+		tr.On("SetTaskProject", mock.Anything, 1096, "backend").Return(model.TodoTask{Id: 1096, Project: "backend"}, nil).Once()
+		tr.On("SetTaskProject", mock.Anything, 1097, "missing").Return(model.TodoTask{}, model.ErrProjectNotFound).Once()
+
+		code, resp := doGraphQL(t, h, `mutation { setTaskProject(id: 1096, project: "backend") { id project } }`, nil)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Empty(t, resp.Errors)
+		assert.JSONEq(t, `{"setTaskProject":{"id":1096,"project":"backend"}}`, string(resp.Data))
+
+		_, resp = doGraphQL(t, h, `mutation { setTaskProject(id: 1097, project: "missing") { id } }`, nil)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, model.ErrProjectNotFound.Error(), resp.Errors[0].Message)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		code, resp := doGraphQL(t, h, `{ task(id: 1) { unknown } }`, nil)
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.NotEmpty(t, resp.Errors)
+	})
+
+	t.Run("too complex query", func(t *testing.T) {
+		code, resp := doGraphQL(t, h, `{ tasksByState(state: "todo", limit: 100) { id title description planningDate { year month day } status state rank position } }`, nil)
+		assert.Equal(t, http.StatusBadRequest, code)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, errQueryTooComplex.Error(), resp.Errors[0].Message)
+	})
+
+	t.Run("introspection is not limited by depth", func(t *testing.T) {
+		code, resp := doGraphQL(t, h, `{ __schema { types { name fields { name type { kind ofType { kind ofType { kind ofType { name } } } } } } } }`, nil)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Empty(t, resp.Errors)
+	})
+
+	tr.AssertExpectations(t)
+	br.AssertExpectations(t)
+}
+
+func TestCheckLimits(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]any
+		err       error
+	}{
+		{
+			name:  "fragments are counted",
+			query: `{ tasksByDate(date: {year: 2027, month: 6, day: 1}, status: true) { ...Date } } fragment Date on Task { planningDate { ... on Date { year } } }`,
+		},
+		{
+			name:  "too deep query",
+			query: `{ a { b { c { d { e { f } } } } } }`,
+			err:   errQueryTooDeep,
+		},
+		{
+			name:  "limit of the list",
+			query: `{ t1: overdueTasks(limit: 499) { id } }`,
+		},
+		{
+			name:  "fields of the query are summed",
+			query: `{ t1: overdueTasks(limit: 500) { id } t2: overdueTasks(limit: 500) { id } }`,
+			err:   errQueryTooComplex,
+		},
+		{
+			name:      "limit from the variables",
+			query:     `query Overdue($limit: Int) { overdueTasks(limit: $limit) { id title } }`,
+			variables: map[string]any{"limit": float64(500)},
+			err:       errQueryTooComplex,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.Parse(parser.ParseParams{Source: tt.query})
+			require.NoError(t, err)
+			assert.Equal(t, tt.err, checkLimits(doc, tt.variables))
+		})
+	}
+}
@@ -10,6 +10,20 @@ import (
 	"todo-list/internal/model"
 )
 
+// userHeader is a header with the name of the user who makes the request
+const userHeader = "X-User"
+
+// currentUser returns name of the user who makes the request or aborts the
+// request with 401 if the name is not specified
+func currentUser(c *gin.Context) (string, bool) {
+	user := c.GetHeader(userHeader)
+	if user == "" {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(model.ErrUnknownUser))
+		return "", false
+	}
+	return user, true
+}
+
 // @Summary		Добавление новой задачи
 // @Description	Возвращает добавленную задачу с её id в postgres
 // @Produce		json
@@ -17,6 +31,7 @@ import (
 // @Success		200	{object} taskResponse "Успешное добавление"
 // @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
 // @Failure 	400 {object} taskResponse "Неверный формат входных данных"
+// @Failure 	404 {object} taskResponse "Проект задачи не найден"
 // @Router		/task [post]
 func addTask(a app.App) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -34,12 +49,18 @@ func addTask(a app.App) gin.HandlerFunc {
 				Month: time.Month(req.PlanningDate.Month),
 				Day:   req.PlanningDate.Day,
 			},
-			Status: req.Status,
+			Status:  req.Status,
+			Project: req.Project,
+			State:   req.State,
 		})
 
 		switch {
 		case errors.Is(err, model.ErrInvalidTask):
 			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidTask))
+		case errors.Is(err, model.ErrProjectNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(model.ErrProjectNotFound))
+		case errors.Is(err, model.ErrUnknownState):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrUnknownState))
 		case errors.Is(err, model.ErrTaskRepo):
 			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
 		case err == nil:
@@ -115,14 +136,16 @@ func getTaskByText(a app.App) gin.HandlerFunc {
 }
 
 // @Summary		Обновление полей задачи по её id в postgres
-// @Description	Возвращает задачу с заданным id и изменёнными полями
+// @Description	Возвращает задачу с заданным id и изменёнными полями. Состояние задачи меняется только по разрешённым переходам, если состояние не указано, оно выводится из статуса. Задачу нельзя отметить выполненной, пока не выполнены блокирующие её задачи, если не указан параметр force
 // @Produce		json
 // @Param		input body updateTaskRequest true "Новые поля задачи"
 // @Param 		id path int true "id изменяемой задачи"
+// @Param 		force query bool false "Отметить задачу выполненной несмотря на блокирующие задачи"
 // @Success		200	{object} taskResponse "Успешное обновление"
 // @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
 // @Failure 	400 {object} taskResponse "Неверный формат входных данных"
 // @Failure 	404 {object} taskResponse "Задача с заданным id не найдена"
+// @Failure 	409 {object} taskResponse "Задача заблокирована невыполненными задачами или переход в состояние запрещён"
 // @Router		/task/{id} [put]
 func updateTask(a app.App) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -138,7 +161,18 @@ func updateTask(a app.App) gin.HandlerFunc {
 			return
 		}
 
-		t, err := a.UpdateTask(c, id, model.TodoTask{
+		force, err := strconv.ParseBool(c.DefaultQuery("force", "false"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		update := a.UpdateTask
+		if force {
+			update = a.ForceUpdateTask
+		}
+
+		t, err := update(c, id, model.TodoTask{
 			Title:       req.Title,
 			Description: req.Description,
 			PlanningDate: model.Date{
@@ -147,6 +181,7 @@ func updateTask(a app.App) gin.HandlerFunc {
 				Day:   req.PlanningDate.Day,
 			},
 			Status: req.Status,
+			State:  req.State,
 		})
 
 		switch {
@@ -154,6 +189,12 @@ func updateTask(a app.App) gin.HandlerFunc {
 			c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(model.ErrTaskNotFound))
 		case errors.Is(err, model.ErrInvalidTask):
 			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidTask))
+		case errors.Is(err, model.ErrUnknownState):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrUnknownState))
+		case errors.Is(err, model.ErrTaskBlocked):
+			c.AbortWithStatusJSON(http.StatusConflict, errorResponse(model.ErrTaskBlocked))
+		case errors.Is(err, model.ErrTransition):
+			c.AbortWithStatusJSON(http.StatusConflict, errorResponse(model.ErrTransition))
 		case errors.Is(err, model.ErrTaskRepo):
 			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
 		case err == nil:
@@ -197,7 +238,7 @@ func deleteTask(a app.App) gin.HandlerFunc {
 }
 
 // @Summary		Получение списка задач с фильтром по статусу и пагинацией
-// @Description	Возвращает список задач
+// @Description	Возвращает список задач в выполненных (status = true) или невыполненных состояниях
 // @Produce		json
 // @Param		input body getTasksByStatusRequest true "Статус и пагинация"
 // @Success		200	{object} tasksResponse "Успешное получение задач"
@@ -261,3 +302,263 @@ func getTasksByDateAndStatus(a app.App) gin.HandlerFunc {
 		}
 	}
 }
+
+// @Summary		Назначение пользователя ответственным за задачу
+// @Description	Возвращает задачу с обновлённым списком ответственных
+// @Produce		json
+// @Param		input body assignTaskRequest true "Имя пользователя"
+// @Param 		id path int true "id задачи"
+// @Success		200	{object} taskResponse "Успешное назначение"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных или пользователь не состоит в рабочем пространстве"
+// @Failure 	404 {object} taskResponse "Задача с заданным id не найдена"
+// @Router		/task/{id}/assignees [post]
+func assignTask(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		var req assignTaskRequest
+		if err = c.BindJSON(&req); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		t, err := a.AssignTask(c, id, req.Assignee)
+
+		switch {
+		case errors.Is(err, model.ErrTaskNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(model.ErrTaskNotFound))
+		case errors.Is(err, model.ErrInvalidInput):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+		case errors.Is(err, model.ErrNotMember):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrNotMember))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, taskSuccessResponse(t))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
+
+// @Summary		Снятие пользователя с задачи
+// @Description	Возвращает задачу с обновлённым списком ответственных
+// @Produce		json
+// @Param 		id path int true "id задачи"
+// @Param 		assignee path string true "Имя пользователя"
+// @Success		200	{object} taskResponse "Успешное снятие"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных"
+// @Failure 	404 {object} taskResponse "Задача с заданным id не найдена"
+// @Router		/task/{id}/assignees/{assignee} [delete]
+func unassignTask(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		t, err := a.UnassignTask(c, id, c.Param("assignee"))
+
+		switch {
+		case errors.Is(err, model.ErrTaskNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(model.ErrTaskNotFound))
+		case errors.Is(err, model.ErrInvalidInput):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, taskSuccessResponse(t))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
+
+// @Summary		Получение списка задач, назначенных пользователю
+// @Description	Возвращает список задач с пагинацией
+// @Produce		json
+// @Param		input body getTasksByAssigneeRequest true "Имя пользователя и пагинация"
+// @Success		200	{object} tasksResponse "Успешное получение задач"
+// @Failure		500	{object} taskResponse  "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse  "Неверный формат входных данных"
+// @Router		/task/by_assignee [get]
+func getTasksByAssignee(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req getTasksByAssigneeRequest
+		if err := c.BindJSON(&req); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		tasks, err := a.GetTasksByAssignee(c, req.Assignee, req.Offset, req.Limit)
+
+		switch {
+		case errors.Is(err, model.ErrInvalidInput):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, tasksSuccessResponse(tasks))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
+
+// @Summary		Получение списка задач, назначенных текущему пользователю
+// @Description	Возвращает список задач пользователя из заголовка X-User с пагинацией
+// @Produce		json
+// @Param		X-User header string true "Имя текущего пользователя"
+// @Param		input body getTasksAssignedToMeRequest true "Пагинация"
+// @Success		200	{object} tasksResponse "Успешное получение задач"
+// @Failure		500	{object} taskResponse  "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse  "Неверный формат входных данных"
+// @Failure 	401 {object} taskResponse  "Не указан текущий пользователь"
+// @Router		/task/assigned_to_me [get]
+func getTasksAssignedToMe(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := currentUser(c)
+		if !ok {
+			return
+		}
+
+		var req getTasksAssignedToMeRequest
+		if err := c.BindJSON(&req); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		tasks, err := a.GetTasksByAssignee(c, user, req.Offset, req.Limit)
+
+		switch {
+		case errors.Is(err, model.ErrInvalidInput):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, tasksSuccessResponse(tasks))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
+
+// @Summary		Добавление задачи, блокирующей данную
+// @Description	Возвращает заблокированную задачу. Связь, образующая цикл зависимостей, не добавляется
+// @Produce		json
+// @Param		input body blockTaskRequest true "id блокирующей задачи"
+// @Param 		id path int true "id блокируемой задачи"
+// @Success		200	{object} taskResponse "Успешное добавление"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных"
+// @Failure 	404 {object} taskResponse "Задача с заданным id не найдена"
+// @Failure 	409 {object} taskResponse "Связь образует цикл зависимостей"
+// @Router		/task/{id}/blockers [post]
+func blockTask(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		var req blockTaskRequest
+		if err = c.BindJSON(&req); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		t, err := a.BlockTask(c, req.BlockerId, id)
+
+		switch {
+		case errors.Is(err, model.ErrTaskNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(model.ErrTaskNotFound))
+		case errors.Is(err, model.ErrDependencyCycle):
+			c.AbortWithStatusJSON(http.StatusConflict, errorResponse(model.ErrDependencyCycle))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, taskSuccessResponse(t))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
+
+// @Summary		Удаление задачи из блокирующих данную
+// @Description	Возвращает задачу, которая больше не блокируется заданной
+// @Produce		json
+// @Param 		id path int true "id блокируемой задачи"
+// @Param 		blocker_id path int true "id блокирующей задачи"
+// @Success		200	{object} taskResponse "Успешное удаление"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных"
+// @Failure 	404 {object} taskResponse "Задача с заданным id не найдена"
+// @Router		/task/{id}/blockers/{blocker_id} [delete]
+func unblockTask(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		blockerId, err := strconv.Atoi(c.Param("blocker_id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		t, err := a.UnblockTask(c, blockerId, id)
+
+		switch {
+		case errors.Is(err, model.ErrTaskNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(model.ErrTaskNotFound))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, taskSuccessResponse(t))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
+
+// @Summary		Получение графа зависимостей задачи
+// @Description	Возвращает задачу вместе со всеми задачами, которые прямо или косвенно блокируют её или блокируются ей, и связи между ними
+// @Produce		json
+// @Param 		id path int true "id задачи"
+// @Success		200	{object} dependencyGraphResponse "Успешное получение"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных"
+// @Failure 	404 {object} taskResponse "Задача с заданным id не найдена"
+// @Router		/task/{id}/dependencies [get]
+func getDependencyGraph(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		graph, err := a.GetDependencyGraph(c, id)
+
+		switch {
+		case errors.Is(err, model.ErrTaskNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(model.ErrTaskNotFound))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, dependencyGraphSuccessResponse(graph))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
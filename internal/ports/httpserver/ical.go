@@ -0,0 +1,340 @@
+package httpserver
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+	"todo-list/internal/model"
+)
+
+// components of the calendar the tasks are rendered as: VEVENT is an
+// all-day event shown by calendar apps, VTODO is a to-do with status shown
+// by task apps
+const (
+	componentEvent = "VEVENT"
+	componentTodo  = "VTODO"
+)
+
+// icalMaxLine is a max length of the line of iCalendar in octets, longer
+// lines are folded
+const icalMaxLine = 75
+
+// icalEscaper escapes special characters of the text values
+var icalEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// icalWriter writes lines of iCalendar (RFC 5545) ending with CRLF, the
+// first error is kept and returned by err
+type icalWriter struct {
+	w   io.Writer
+	err error
+}
+
+// line writes the line folding it into the lines of icalMaxLine octets
+// without splitting UTF-8 characters
+func (iw *icalWriter) line(s string) {
+	if iw.err != nil {
+		return
+	}
+	var b strings.Builder
+	limit := icalMaxLine
+	n := 0
+	for _, r := range s {
+		size := len(string(r))
+		if n+size > limit {
+			b.WriteString("\r\n ")
+			limit, n = icalMaxLine-1, 0
+		}
+		b.WriteRune(r)
+		n += size
+	}
+	b.WriteString("\r\n")
+	_, iw.err = io.WriteString(iw.w, b.String())
+}
+
+func icalDate(d model.Date) string {
+	return fmt.Sprintf("%04d%02d%02d", d.Year, d.Month, d.Day)
+}
+
+// nextDay returns the day after d, it is the end of the all-day event
+func nextDay(d model.Date) model.Date {
+	t := time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	return model.Date{Year: t.Year(), Month: t.Month(), Day: t.Day()}
+}
+
+// taskUID returns UID of the component of the task, the same task has
+// stable UID in every component, so calendar apps update it instead of adding
+// a copy
+func taskUID(id int, component string) string {
+	return fmt.Sprintf("task-%d-%s@todo-list", id, strings.ToLower(component))
+}
+
+// beginCalendar writes properties of the calendar, name is omitted if it is empty
+func (iw *icalWriter) beginCalendar(name string) {
+	iw.line("BEGIN:VCALENDAR")
+	iw.line("VERSION:2.0")
+	iw.line("PRODID:-//todo-list//tasks//EN")
+	iw.line("CALSCALE:GREGORIAN")
+	if name != "" {
+		iw.line("METHOD:PUBLISH")
+		iw.line("X-WR-CALNAME:" + icalEscaper.Replace(name))
+	}
+}
+
+// component writes the task as the component of the calendar with given UID
+func (iw *icalWriter) component(component string, uid string, t model.TodoTask, stamp string) {
+	iw.line("BEGIN:" + component)
+	iw.line("UID:" + icalEscaper.Replace(uid))
+	iw.line("DTSTAMP:" + stamp)
+	iw.line(fmt.Sprintf("SEQUENCE:%d", t.Version))
+	iw.line("SUMMARY:" + icalEscaper.Replace(t.Title))
+	if t.Description != "" {
+		iw.line("DESCRIPTION:" + icalEscaper.Replace(t.Description))
+	}
+	if t.State != "" {
+		iw.line("CATEGORIES:" + icalEscaper.Replace(t.State))
+	}
+
+	if component == componentEvent {
+		iw.line("DTSTART;VALUE=DATE:" + icalDate(t.PlanningDate))
+		iw.line("DTEND;VALUE=DATE:" + icalDate(nextDay(t.PlanningDate)))
+		iw.line("TRANSP:TRANSPARENT")
+	} else {
+		iw.line("DUE;VALUE=DATE:" + icalDate(t.PlanningDate))
+		if t.Status {
+			iw.line("STATUS:COMPLETED")
+			iw.line("PERCENT-COMPLETE:100")
+		} else {
+			iw.line("STATUS:NEEDS-ACTION")
+		}
+	}
+	iw.line("END:" + component)
+}
+
+// writeCalendar writes tasks as the components of the calendar with given name
+func writeCalendar(w io.Writer, name string, tasks []model.TodoTask, components []string, now time.Time) error {
+	iw := &icalWriter{w: w}
+	stamp := now.UTC().Format("20060102T150405Z")
+
+	iw.beginCalendar(name)
+	for _, t := range tasks {
+		for _, component := range components {
+			iw.component(component, taskUID(t.Id, component), t, stamp)
+		}
+	}
+	iw.line("END:VCALENDAR")
+	return iw.err
+}
+
+// writeObject writes the object of the CalDAV calendar as the calendar with
+// the single VTODO component, objects without UID get the UID of the feed
+func writeObject(w io.Writer, o model.CalendarObject, now time.Time) error {
+	iw := &icalWriter{w: w}
+	uid := o.UID
+	if uid == "" {
+		uid = taskUID(o.Task.Id, componentTodo)
+	}
+
+	iw.beginCalendar("")
+	iw.component(componentTodo, uid, o.Task, now.UTC().Format("20060102T150405Z"))
+	iw.line("END:VCALENDAR")
+	return iw.err
+}
+
+// parseComponents parses comma separated list of the components of the
+// calendar, empty list means both of them
+func parseComponents(s string) ([]string, bool) {
+	if s == "" {
+		return []string{componentEvent, componentTodo}, true
+	}
+	var components []string
+	for _, c := range strings.Split(strings.ToUpper(s), ",") {
+		if (c != componentEvent && c != componentTodo) || slices.Contains(components, c) {
+			return nil, false
+		}
+		components = append(components, c)
+	}
+	return components, true
+}
+
+// errNoTodo is returned for the calendar which has no VTODO component
+var errNoTodo = errors.New("calendar has no VTODO component")
+
+// todoComponent contains properties of the VTODO component which are mapped
+// to the fields of the task
+type todoComponent struct {
+	uid         string
+	summary     string
+	description string
+	categories  []string
+	completed   bool
+
+	// due is a date of DUE or DTSTART, it is zero if there are no such properties
+	due model.Date
+}
+
+// unfoldLines splits iCalendar into content lines joining the folded ones
+func unfoldLines(data string) []string {
+	var lines []string
+	for _, l := range strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n") {
+		if (strings.HasPrefix(l, " ") || strings.HasPrefix(l, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += l[1:]
+		} else if l != "" {
+			lines = append(lines, l)
+		}
+	}
+	return lines
+}
+
+// parseProperty splits the content line into upper case name, parameters
+// and value, quoted values of the parameters can contain separators
+func parseProperty(line string) (string, map[string]string, string, bool) {
+	end := strings.IndexAny(line, ";:")
+	if end <= 0 {
+		return "", nil, "", false
+	}
+	name := strings.ToUpper(line[:end])
+	params := make(map[string]string)
+	for line[end] == ';' {
+		rest := line[end+1:]
+		eq := strings.IndexByte(rest, '=')
+		if eq <= 0 {
+			return "", nil, "", false
+		}
+		key := strings.ToUpper(rest[:eq])
+		quoted := false
+		i := eq + 1
+		for ; i < len(rest); i++ {
+			if rest[i] == '"' {
+				quoted = !quoted
+			} else if !quoted && (rest[i] == ';' || rest[i] == ':') {
+				break
+			}
+		}
+		if i == len(rest) {
+			return "", nil, "", false
+		}
+		params[key] = strings.Trim(rest[eq+1:i], `"`)
+		end += 1 + i
+	}
+	return name, params, line[end+1:], true
+}
+
+// splitText splits the list of text values by not escaped commas and
+// unescapes them
+func splitText(v string) []string {
+	var values []string
+	var b strings.Builder
+	escaped := false
+	for _, r := range v {
+		switch {
+		case escaped:
+			if r == 'n' || r == 'N' {
+				r = '\n'
+			}
+			b.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ',':
+			values = append(values, b.String())
+			b.Reset()
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return append(values, b.String())
+}
+
+// unescapeText returns the text value without escaping
+func unescapeText(v string) string {
+	return strings.Join(splitText(v), ",")
+}
+
+// parseDateValue returns the date of DATE or DATE-TIME value, the date of
+// DATE-TIME is taken as it is written regardless of its time zone
+func parseDateValue(v string) (model.Date, bool) {
+	if len(v) != 8 && (len(v) < 15 || v[8] != 'T') {
+		return model.Date{}, false
+	}
+	t, err := time.Parse("20060102", v[:8])
+	if err != nil {
+		return model.Date{}, false
+	}
+	return model.Date{Year: t.Year(), Month: t.Month(), Day: t.Day()}, true
+}
+
+// parseTodo parses the first VTODO component of the calendar, properties of
+// the nested components like VALARM are skipped
+func parseTodo(data string) (todoComponent, error) {
+	lines := unfoldLines(data)
+	if len(lines) == 0 || !strings.EqualFold(lines[0], "BEGIN:VCALENDAR") {
+		return todoComponent{}, errors.Join(model.ErrInvalidObject, errors.New("body is not iCalendar"))
+	}
+
+	var todo todoComponent
+	var stack []string
+	var status, percent string
+	found, completedAt, hasDue := false, false, false
+	for _, l := range lines {
+		name, params, value, ok := parseProperty(l)
+		if !ok {
+			return todoComponent{}, errors.Join(model.ErrInvalidObject, fmt.Errorf("invalid content line %q", l))
+		}
+		switch name {
+		case "BEGIN":
+			stack = append(stack, strings.ToUpper(value))
+			continue
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1] != strings.ToUpper(value) {
+				return todoComponent{}, errors.Join(model.ErrInvalidObject, fmt.Errorf("unexpected END:%s", value))
+			}
+			if stack = stack[:len(stack)-1]; strings.EqualFold(value, componentTodo) {
+				found = true
+			}
+			continue
+		}
+		if found || len(stack) != 2 || stack[1] != componentTodo {
+			continue
+		}
+
+		switch name {
+		case "UID":
+			todo.uid = unescapeText(value)
+		case "SUMMARY":
+			todo.summary = unescapeText(value)
+		case "DESCRIPTION":
+			todo.description = unescapeText(value)
+		case "CATEGORIES":
+			todo.categories = append(todo.categories, splitText(value)...)
+		case "STATUS":
+			status = strings.ToUpper(value)
+		case "COMPLETED":
+			completedAt = true
+		case "PERCENT-COMPLETE":
+			percent = value
+		case "DUE", "DTSTART":
+			// DUE is the planning date, DTSTART is used only without it
+			if hasDue {
+				continue
+			}
+			d, ok := parseDateValue(value)
+			if !ok || (params["VALUE"] == "DATE" && len(value) != 8) {
+				return todoComponent{}, errors.Join(model.ErrInvalidObject, fmt.Errorf("invalid date %q", value))
+			}
+			todo.due, hasDue = d, name == "DUE"
+		}
+	}
+	if !found {
+		return todoComponent{}, errNoTodo
+	}
+
+	if status != "" {
+		todo.completed = status == "COMPLETED"
+	} else {
+		todo.completed = completedAt || percent == "100"
+	}
+	return todo, nil
+}
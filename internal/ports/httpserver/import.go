@@ -0,0 +1,150 @@
+package httpserver
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"todo-list/internal/model"
+)
+
+// media types of the files of the bulk import
+const (
+	mediaCSV       = "text/csv"
+	mediaNDJSON    = "application/x-ndjson"
+	mediaJSONLines = "application/jsonl"
+)
+
+// importColumns are the columns of the imported CSV file, only title is required
+var importColumns = []string{"title", "description", "planning_date", "status", "state", "project"}
+
+// importReaders read rows of the imported files by their media types
+var importReaders = map[string]func(io.Reader) ([]model.ImportRow, error){
+	mediaCSV:       readCSV,
+	mediaNDJSON:    readJSONLines,
+	mediaJSONLines: readJSONLines,
+}
+
+// readCSV reads tasks from the rows of CSV file after its header. Columns are
+// found by their names in the header, row which can't be read is returned
+// with the error
+func readCSV(r io.Reader) ([]model.ImportRow, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		// files saved by spreadsheets start with byte order mark
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := columns[name]; ok || !slices.Contains(importColumns, name) {
+			return nil, fmt.Errorf("column %q is unknown or duplicated", name)
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, errors.New("no title column")
+	}
+
+	var rows []model.ImportRow
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		line, _ := cr.FieldPos(0)
+		row := model.ImportRow{Line: line}
+		if len(record) != len(header) {
+			row.Err = errors.Join(model.ErrInvalidInput, fmt.Errorf("row has %d fields instead of %d", len(record), len(header)))
+		} else {
+			row.Task, row.Err = csvTask(record, columns)
+		}
+		rows = append(rows, row)
+	}
+}
+
+// csvTask returns task of the CSV record, empty planning date and status are
+// left for the defaults of the import
+func csvTask(record []string, columns map[string]int) (model.TodoTask, error) {
+	field := func(name string) string {
+		if i, ok := columns[name]; ok {
+			return record[i]
+		}
+		return ""
+	}
+	t := model.TodoTask{Title: field("title"), Description: field("description"), State: field("state"), Project: field("project")}
+
+	errs := []error{model.ErrInvalidInput}
+	if s := strings.TrimSpace(field("status")); s != "" {
+		status, err := strconv.ParseBool(s)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("status %q is not true or false", s))
+		}
+		t.Status = status
+	}
+	if s := strings.TrimSpace(field("planning_date")); s != "" {
+		date, err := time.Parse(time.DateOnly, s)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("planning date %q is not in the format YYYY-MM-DD", s))
+		} else {
+			t.PlanningDate = model.Date{Year: date.Year(), Month: date.Month(), Day: date.Day()}
+		}
+	}
+	if len(errs) > 1 {
+		return t, errors.Join(errs...)
+	}
+	return t, nil
+}
+
+// readJSONLines reads tasks from the lines of JSON lines file, every line is
+// the same as the body of the request adding the task. Empty lines are
+// skipped, line which can't be read is returned with the error
+func readJSONLines(r io.Reader) ([]model.ImportRow, error) {
+	var rows []model.ImportRow
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, maxImportSize)
+	for line := 1; s.Scan(); line++ {
+		if strings.TrimSpace(s.Text()) == "" {
+			continue
+		}
+
+		row := model.ImportRow{Line: line}
+		var req addTaskRequest
+		d := json.NewDecoder(strings.NewReader(s.Text()))
+		d.DisallowUnknownFields()
+		if err := d.Decode(&req); err != nil {
+			row.Err = errors.Join(model.ErrInvalidInput, err)
+		} else if d.More() {
+			row.Err = errors.Join(model.ErrInvalidInput, errors.New("line has more than one JSON value"))
+		} else {
+			row.Task = model.TodoTask{
+				Title:       req.Title,
+				Description: req.Description,
+				PlanningDate: model.Date{
+					Year:  req.PlanningDate.Year,
+					Month: time.Month(req.PlanningDate.Month),
+					Day:   req.PlanningDate.Day,
+				},
+				Status:  req.Status,
+				State:   req.State,
+				Project: req.Project,
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, s.Err()
+}
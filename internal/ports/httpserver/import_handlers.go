@@ -0,0 +1,146 @@
+package httpserver
+
+import (
+	"bytes"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+	"todo-list/internal/todotxt"
+)
+
+// maxImportSize is a limit of size of the imported file
+const maxImportSize = 1 << 20
+
+// importOptions returns mode of the import and dry run flag from the query,
+// the request is aborted if they are invalid
+func importOptions(c *gin.Context) (string, bool, bool) {
+	mode := c.DefaultQuery("mode", model.ImportBestEffort)
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil || (mode != model.ImportBestEffort && mode != model.ImportTransaction) {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+		return "", false, false
+	}
+	return mode, dryRun, true
+}
+
+// importRows reads rows of the imported file from the body of the request,
+// the request is aborted if the file is too large or can't be read
+func importRows(c *gin.Context, read func(io.Reader) ([]model.ImportRow, error)) ([]model.ImportRow, bool) {
+	rows, err := read(http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize))
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, errorResponse(model.ErrInvalidInput))
+		return nil, false
+	} else if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+		return nil, false
+	}
+	return rows, true
+}
+
+// importTasks imports the rows and responds with the report
+func importTasks(c *gin.Context, a app.App, rows []model.ImportRow, mode string, dryRun bool) {
+	report, err := a.ImportTasks(c, rows, mode, dryRun)
+
+	switch {
+	case errors.Is(err, model.ErrInvalidInput):
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+	case errors.Is(err, model.ErrTaskRepo):
+		c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+	case err == nil:
+		c.JSON(http.StatusOK, importReportSuccessResponse(report))
+	default:
+		c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+	}
+}
+
+// @Summary		Массовый импорт задач из CSV или JSON lines
+// @Description	Добавляет задачи из файла и возвращает отчёт по каждой строке с ошибками валидации. Формат файла задаётся заголовком Content-Type: text/csv — CSV с заголовком из колонок title, description, planning_date (YYYY-MM-DD), status, state и project, из которых обязательна только title; application/x-ndjson или application/jsonl — по одному JSON объекту в формате запроса добавления задачи на строку. Задачи без даты планируются на сегодня. Пропускаются строки с ошибками, невалидные задачи и задачи с тем же заголовком, что уже запланирована на ту же дату. В режиме best_effort добавляются все валидные строки, в режиме transaction строки добавляются в одной транзакции только если ни одна из них не пропущена. В режиме dry_run задачи не добавляются
+// @Accept		text/csv
+// @Accept		application/x-ndjson
+// @Produce		json
+// @Param		mode query string false "Режим импорта: best_effort (по умолчанию) или transaction"
+// @Param		dry_run query bool false "Только проверить файл без добавления задач"
+// @Param		file body string true "Содержимое файла"
+// @Success		200	{object} importReportResponse "Отчёт об импорте"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных или более 1000 строк"
+// @Failure 	413 {object} taskResponse "Слишком большой файл"
+// @Failure 	415 {object} taskResponse "Неподдерживаемый формат файла"
+// @Router		/task/import [post]
+func importFile(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		mode, dryRun, ok := importOptions(c)
+		if !ok {
+			return
+		}
+		read, ok := importReaders[c.ContentType()]
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnsupportedMediaType, errorResponse(model.ErrInvalidInput))
+			return
+		}
+		rows, ok := importRows(c, read)
+		if !ok {
+			return
+		}
+		importTasks(c, a, rows, mode, dryRun)
+	}
+}
+
+// @Summary		Импорт задач из todo.txt
+// @Description	Добавляет задачи из строк файла todo.txt и возвращает отчёт по каждой строке. Отметка x задаёт статус задачи, тег due:YYYY-MM-DD — дату планирования (без него задача планируется на сегодня), тег state:name — состояние, первый проект +name — проект задачи, контексты @name — ответственных из участников рабочего пространства и проекта. У задач нет приоритета, дат создания и выполнения и других проектов, поэтому приоритет (A) и даты сохраняются в заголовке тегами pri:A, created:YYYY-MM-DD и completed:YYYY-MM-DD, остальные проекты остаются в заголовке, и для каждого такого случая в отчёт по строке добавляется предупреждение. Выполненные задачи сохраняют прошедшие даты. Пропускаются строки с ошибками, невалидные задачи и задачи с тем же заголовком, что уже запланирована на ту же дату. Режимы mode и dry_run такие же, как у массового импорта: в режиме dry_run задачи не добавляются, а отчёт показывает, что было бы добавлено и пропущено
+// @Accept		plain
+// @Produce		json
+// @Param		mode query string false "Режим импорта: best_effort (по умолчанию) или transaction"
+// @Param		dry_run query bool false "Только проверить файл без добавления задач"
+// @Param		file body string true "Содержимое файла todo.txt"
+// @Success		200	{object} importReportResponse "Отчёт об импорте"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных или более 1000 строк"
+// @Failure 	413 {object} taskResponse "Слишком большой файл"
+// @Router		/task/import/todotxt [post]
+func importTodoTxt(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		mode, dryRun, ok := importOptions(c)
+		if !ok {
+			return
+		}
+		rows, ok := importRows(c, todotxt.Read)
+		if !ok {
+			return
+		}
+		importTasks(c, a, rows, mode, dryRun)
+	}
+}
+
+// @Summary		Экспорт задач в todo.txt
+// @Description	Возвращает файл todo.txt с невыполненными и затем выполненными задачами, не более 10000. Выполненные задачи отмечены x, дата планирования записывается тегом due:YYYY-MM-DD, проект — тегом +name, тег pri:A невыполненной задачи — приоритетом (A), а состояние, отличное от состояния по умолчанию, — тегом state:name. Описания задач не выгружаются
+// @Produce		plain
+// @Success		200	{string} string "Файл todo.txt"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Router		/task/export/todotxt [get]
+func exportTodoTxt(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tasks, err := a.ExportTasks(c)
+
+		switch {
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			var b bytes.Buffer
+			if err = todotxt.Write(&b, tasks, a.Workflow()); err != nil {
+				c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+				return
+			}
+			c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": "todo.txt"}))
+			c.Data(http.StatusOK, "text/plain; charset=utf-8", b.Bytes())
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"todo-list/internal/app"
+	"todo-list/internal/app/mocks"
+	"todo-list/internal/model"
+)
+
+func TestTodoTxt(t *testing.T) {
+	tr, br := new(mocks.TaskRepo), new(mocks.BoardRepo)
+	a := app.New(app.Deps{Tasks: tr, Board: br}, app.Config{})
+	h := New("", a).Handler
+	date := model.Date{Year: 2027, Month: time.June, Day: 1}
+	br.On("GetLastRank", mock.Anything, "todo").Return("", nil)
+
+	post := func(query string, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/todo-list/api/task/import/todotxt"+query, strings.NewReader(body))
+		req.Header.Set("Content-Type", "text/plain")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		tr.On("GetTasksByDateAndStatus", mock.Anything, date, false).Return([]model.TodoTask{}, nil).Once()
+		tr.On("GetTasksByDateAndStatus", mock.Anything, date, true).Return([]model.TodoTask{}, nil).Once()
+		tr.On("GetProject", mock.Anything, "family").Return(model.Project{Name: "family", Members: []string{"phone"}}, nil).Once()
+
+		w := post("?dry_run=true", "(A) 2026-10-01 Call mom +family @phone due:2027-06-01\n\nPay rent due:soon\n")
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp importReportResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.NotNil(t, resp.Data)
+		assert.True(t, resp.Data.DryRun)
+		assert.Equal(t, 1, resp.Data.Created)
+		assert.Equal(t, 1, resp.Data.Skipped)
+		require.Len(t, resp.Data.Results, 2)
+		assert.Equal(t, model.ImportCreated, resp.Data.Results[0].Status)
+		assert.Equal(t, "Call mom pri:A created:2026-10-01", resp.Data.Results[0].Task.Title)
+		assert.Equal(t, "family", resp.Data.Results[0].Task.Project)
+		assert.Equal(t, []string{"phone"}, resp.Data.Results[0].Task.Assignees)
+		assert.Equal(t, []string{
+			"task has no priority, it is kept in the title as pri:A",
+			"task has no creation date, it is kept in the title as created:2026-10-01",
+		}, resp.Data.Results[0].Warnings)
+		assert.Equal(t, "todo", resp.Data.Results[0].Task.State)
+		assert.Equal(t, []string{}, resp.Data.Results[0].Errors)
+		assert.Equal(t, importResultData{
+			Line:     3,
+			Status:   model.ImportSkipped,
+			Task:     *taskSuccessResponse(model.TodoTask{}).Data,
+			Warnings: []string{},
+			Errors:   []string{model.ErrInvalidInput.Error(), `due date "soon" is not in the format YYYY-MM-DD`},
+		}, resp.Data.Results[1])
+	})
+
+	t.Run("import", func(t *testing.T) {
+		tr.On("GetTasksByDateAndStatus", mock.Anything, date, false).Return([]model.TodoTask{}, nil).Once()
+		tr.On("GetTasksByDateAndStatus", mock.Anything, date, true).Return([]model.TodoTask{{Id: 1431, Title: "Pay rent"}}, nil).Once()
+		tr.On("AddTask", mock.Anything, mock.MatchedBy(func(task model.TodoTask) bool {
+			return task.Title == "Call mom" && task.PlanningDate == date
+		})).Return(model.TodoTask{Id: 1432, Title: "Call mom", PlanningDate: date, State: "todo", Version: 1}, nil).Once()
+
+		w := post("", "Call mom due:2027-06-01\nx Pay rent due:2027-06-01\n")
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp importReportResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Len(t, resp.Data.Results, 2)
+		assert.False(t, resp.Data.DryRun)
+		assert.Equal(t, 1432, resp.Data.Results[0].Task.Id)
+		assert.Equal(t, []string{model.ErrDuplicateTask.Error()}, resp.Data.Results[1].Errors)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, post("?dry_run=maybe", "Call mom").Code)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, post("", strings.Repeat("Call mom\n", maxImportSize/9+1)).Code)
+	})
+
+	t.Run("export", func(t *testing.T) {
+		tr.On("GetTasksByStatus", mock.Anything, false, 0, 100).Return([]model.TodoTask{
+			{Id: 1433, Title: "Call mom pri:A", PlanningDate: date, State: "todo", Project: "family"},
+		}, nil).Once()
+		tr.On("GetTasksByStatus", mock.Anything, true, 0, 100).Return([]model.TodoTask{
+			{Id: 1434, Title: "Pay rent", PlanningDate: date, Status: true, State: "done"},
+		}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/todo-list/api/task/export/todotxt", nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Equal(t, "attachment; filename=todo.txt", w.Header().Get("Content-Disposition"))
+		assert.Equal(t, "(A) Call mom +family due:2027-06-01\nx Pay rent due:2027-06-01\n", w.Body.String())
+	})
+
+	tr.AssertExpectations(t)
+}
+
+func TestImportFile(t *testing.T) {
+	tr, br := new(mocks.TaskRepo), new(mocks.BoardRepo)
+	a := app.New(app.Deps{Tasks: tr, Board: br}, app.Config{})
+	h := New("", a).Handler
+	date := model.Date{Year: 2027, Month: time.July, Day: 1}
+	br.On("GetLastRank", mock.Anything, "todo").Return("", nil)
+
+	post := func(query, contentType, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/todo-list/api/task/import"+query, strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w
+	}
+	report := func(t *testing.T, w *httptest.ResponseRecorder) importReportData {
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp importReportResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.NotNil(t, resp.Data)
+		return *resp.Data
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		tr.On("GetTasksByDateAndStatus", mock.Anything, date, false).Return([]model.TodoTask{}, nil).Once()
+		tr.On("GetTasksByDateAndStatus", mock.Anything, date, true).Return([]model.TodoTask{}, nil).Once()
+
+		data := report(t, post("?dry_run=true", "text/csv; charset=utf-8",
+			"\ufeffTitle,planning_date,status\n\"Call mom, dad\",2027-07-01,\nPay rent,soon,maybe\nTax\n"))
+		assert.Equal(t, model.ImportBestEffort, data.Mode)
+		assert.Equal(t, 1, data.Created)
+		assert.Equal(t, 2, data.Skipped)
+		require.Len(t, data.Results, 3)
+		assert.Equal(t, 2, data.Results[0].Line)
+		assert.Equal(t, "Call mom, dad", data.Results[0].Task.Title)
+		assert.Equal(t, []string{
+			model.ErrInvalidInput.Error(),
+			`status "maybe" is not true or false`,
+			`planning date "soon" is not in the format YYYY-MM-DD`,
+		}, data.Results[1].Errors)
+		assert.Equal(t, 4, data.Results[2].Line)
+		assert.Equal(t, []string{model.ErrInvalidInput.Error(), "row has 1 fields instead of 3"}, data.Results[2].Errors)
+	})
+
+	t.Run("json lines in transaction", func(t *testing.T) {
+		tr.On("GetTasksByDateAndStatus", mock.Anything, date, false).Return([]model.TodoTask{}, nil).Once()
+		tr.On("GetTasksByDateAndStatus", mock.Anything, date, true).Return([]model.TodoTask{}, nil).Once()
+		tr.On("GetProject", mock.Anything, "home").Return(model.Project{Name: "home", Members: []string{}}, nil).Once()
+		tr.On("AddTasks", mock.Anything, []model.TodoTask{
+			{Title: "Call mom", PlanningDate: date, State: "todo", Rank: "i"},
+			{Title: "Pay rent", PlanningDate: date, State: "todo", Rank: "r", Project: "home"},
+		}).Return([]model.TodoTask{
+			{Id: 1441, Title: "Call mom", PlanningDate: date, State: "todo", Rank: "i", Version: 1},
+			{Id: 1442, Title: "Pay rent", PlanningDate: date, State: "todo", Rank: "r", Project: "home", Version: 1},
+		}, nil).Once()
+
+		body := `{"title": "Call mom", "planning_date": {"year": 2027, "month": 7, "day": 1}}` + "\n\n" +
+			`{"title": "Pay rent", "planning_date": {"year": 2027, "month": 7, "day": 1}, "project": "home"}` + "\n"
+		data := report(t, post("?mode=transaction", "application/x-ndjson", body))
+		assert.Equal(t, model.ImportTransaction, data.Mode)
+		assert.Equal(t, 2, data.Created)
+		require.Len(t, data.Results, 2)
+		assert.Equal(t, 1441, data.Results[0].Task.Id)
+		assert.Equal(t, 3, data.Results[1].Line)
+		assert.Equal(t, 1442, data.Results[1].Task.Id)
+		assert.Equal(t, "home", data.Results[1].Task.Project)
+	})
+
+	t.Run("json lines aborted", func(t *testing.T) {
+		tr.On("GetTasksByDateAndStatus", mock.Anything, date, false).Return([]model.TodoTask{}, nil).Once()
+		tr.On("GetTasksByDateAndStatus", mock.Anything, date, true).Return([]model.TodoTask{}, nil).Once()
+
+		body := `{"title": "Call mom", "planning_date": {"year": 2027, "month": 7, "day": 1}}` + "\n" +
+			`{"title": "Pay rent", "due": "soon"}` + "\n" + `{"title": "Tax"} {}` + "\n"
+		data := report(t, post("?mode=transaction", "application/jsonl", body))
+		assert.Equal(t, 0, data.Created)
+		assert.Equal(t, 3, data.Skipped)
+		require.Len(t, data.Results, 3)
+		assert.Equal(t, []string{model.ErrImportAborted.Error()}, data.Results[0].Errors)
+		assert.Equal(t, []string{model.ErrInvalidInput.Error(), `json: unknown field "due"`}, data.Results[1].Errors)
+		assert.Equal(t, []string{model.ErrInvalidInput.Error(), "line has more than one JSON value"}, data.Results[2].Errors)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, post("?mode=all", "text/csv", "title\nCall mom\n").Code)
+		assert.Equal(t, http.StatusBadRequest, post("", "text/csv", "title,due\nCall mom,soon\n").Code)
+		assert.Equal(t, http.StatusBadRequest, post("", "text/csv", "description\nCall mom\n").Code)
+		assert.Equal(t, http.StatusUnsupportedMediaType, post("", "application/json", `[{"title": "Call mom"}]`).Code)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, post("", "text/csv", "title\n"+strings.Repeat("Call mom\n", maxImportSize/9+1)).Code)
+	})
+
+	tr.AssertExpectations(t)
+}
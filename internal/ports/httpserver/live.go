@@ -0,0 +1,116 @@
+package httpserver
+
+import (
+	"slices"
+	"sync"
+)
+
+// liveBuffer is a number of messages queued for the client of the live
+// channel, the client which falls behind by more messages is disconnected
+const liveBuffer = 64
+
+// liveConn is a connection of the client to the live channel
+type liveConn struct {
+	user string
+	send chan liveMessage
+
+	// done is closed when the connection has to be closed
+	done chan struct{}
+	once sync.Once
+}
+
+func newLiveConn(user string) *liveConn {
+	return &liveConn{
+		user: user,
+		send: make(chan liveMessage, liveBuffer),
+		done: make(chan struct{}),
+	}
+}
+
+// push queues the message to the client without blocking
+func (c *liveConn) push(m liveMessage) {
+	select {
+	case <-c.done:
+	case c.send <- m:
+	default:
+		c.close()
+	}
+}
+
+// close asks the writer of the connection to close it
+func (c *liveConn) close() {
+	c.once.Do(func() {
+		close(c.done)
+	})
+}
+
+// liveHub keeps rooms of the live channel with connections of the clients
+// viewing them, so they know who else is there
+type liveHub struct {
+	mu    sync.Mutex
+	rooms map[string]map[*liveConn]struct{}
+}
+
+func newLiveHub() *liveHub {
+	return &liveHub{
+		rooms: make(map[string]map[*liveConn]struct{}),
+	}
+}
+
+// join adds connection to the room and announces new list of its viewers
+func (h *liveHub) join(room string, c *liveConn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.rooms[room] == nil {
+		h.rooms[room] = make(map[*liveConn]struct{})
+	}
+	h.rooms[room][c] = struct{}{}
+	h.announce(room)
+}
+
+// leave removes connection from the room and announces new list of its viewers
+func (h *liveHub) leave(room string, c *liveConn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.rooms[room], c)
+	if len(h.rooms[room]) == 0 {
+		delete(h.rooms, room)
+		return
+	}
+	h.announce(room)
+}
+
+// viewers returns sorted names of the users viewing the room, user with
+// several connections is listed once
+func (h *liveHub) viewers(room string) []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.users(room)
+}
+
+// users must be called under lock
+func (h *liveHub) users(room string) []string {
+	users := make([]string, 0, len(h.rooms[room]))
+	for c := range h.rooms[room] {
+		if !slices.Contains(users, c.user) {
+			users = append(users, c.user)
+		}
+	}
+	slices.Sort(users)
+	return users
+}
+
+// announce sends viewers of the room to all of its connections, must be
+// called under lock
+func (h *liveHub) announce(room string) {
+	users := h.users(room)
+	for c := range h.rooms[room] {
+		c.push(liveMessage{
+			Type:  livePresence,
+			Room:  room,
+			Users: users,
+		})
+	}
+}
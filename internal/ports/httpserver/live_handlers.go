@@ -0,0 +1,328 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"net/http"
+	"strings"
+	"time"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+// types of the messages of the live channel
+const (
+	liveSubscribe    = "subscribe"
+	liveUnsubscribe  = "unsubscribe"
+	liveAddTask      = "add_task"
+	liveUpdateTask   = "update_task"
+	liveDeleteTask   = "delete_task"
+	liveMoveTask     = "move_task"
+	liveAssignTask   = "assign_task"
+	liveUnassignTask = "unassign_task"
+
+	liveResult   = "result"
+	liveEvent    = "event"
+	liveReset    = "reset"
+	livePresence = "presence"
+)
+
+const (
+	// liveBoardRoom is a room of the clients viewing all tasks
+	liveBoardRoom = "board"
+
+	// liveWriteTimeout limits writing of one message to the client
+	liveWriteTimeout = 10 * time.Second
+
+	// livePongTimeout is a time in which the client must answer the ping,
+	// pings are sent a bit more often
+	livePongTimeout  = time.Minute
+	livePingInterval = livePongTimeout * 9 / 10
+
+	// liveReadLimit is a max size of the message of the client
+	liveReadLimit = 1 << 16
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+// roomOf returns name of the room of the clients viewing tasks of the project
+// planned on the date, scope without project and date is a room of the whole
+// board
+func roomOf(scope model.EventScope) string {
+	parts := make([]string, 0, 2)
+	if scope.Project != "" {
+		parts = append(parts, "project:"+scope.Project)
+	}
+	if d := scope.Date; d != (model.Date{}) {
+		parts = append(parts, "date:"+time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC).Format(time.DateOnly))
+	}
+	if len(parts) == 0 {
+		return liveBoardRoom
+	}
+	return strings.Join(parts, "/")
+}
+
+// liveReply returns result of the request with the task or the error
+func liveReply(id string, data *taskData, err error) liveMessage {
+	if err == nil {
+		return liveMessage{Type: liveResult, Id: id, Data: data}
+	}
+
+	errStr := reportedError(err).Error()
+	return liveMessage{Type: liveResult, Id: id, Err: &errStr}
+}
+
+// liveTaskReply returns result of the request which changes the task
+func liveTaskReply(id string, t model.TodoTask, err error) liveMessage {
+	if err != nil {
+		return liveReply(id, nil, err)
+	}
+	return liveReply(id, taskSuccessResponse(t).Data, nil)
+}
+
+// liveSession is a state of one connection to the live channel, it is used
+// only by the goroutine reading the connection
+type liveSession struct {
+	a    app.App
+	hub  *liveHub
+	conn *liveConn
+
+	// room is viewed by the client, stop ends its subscription
+	room string
+	stop context.CancelFunc
+}
+
+func (s *liveSession) handle(ctx context.Context, req liveRequest) {
+	switch req.Type {
+	case liveSubscribe:
+		s.subscribe(ctx, req)
+	case liveUnsubscribe:
+		s.unsubscribe()
+		s.conn.push(liveReply(req.Id, nil, nil))
+	case liveAddTask:
+		var body addTaskRequest
+		if err := json.Unmarshal(req.Task, &body); err != nil {
+			s.conn.push(liveReply(req.Id, nil, model.ErrInvalidInput))
+			return
+		}
+		t, err := s.a.AddTask(ctx, model.TodoTask{
+			Title:       body.Title,
+			Description: body.Description,
+			PlanningDate: model.Date{
+				Year:  body.PlanningDate.Year,
+				Month: time.Month(body.PlanningDate.Month),
+				Day:   body.PlanningDate.Day,
+			},
+			Status: body.Status,
+			State:  body.State,
+		})
+		s.conn.push(liveTaskReply(req.Id, t, err))
+	case liveUpdateTask:
+		var body updateTaskRequest
+		if err := json.Unmarshal(req.Task, &body); err != nil {
+			s.conn.push(liveReply(req.Id, nil, model.ErrInvalidInput))
+			return
+		}
+		update := s.a.UpdateTask
+		if req.Force {
+			update = s.a.ForceUpdateTask
+		}
+		t, err := update(ctx, req.TaskId, model.TodoTask{
+			Title:       body.Title,
+			Description: body.Description,
+			PlanningDate: model.Date{
+				Year:  body.PlanningDate.Year,
+				Month: time.Month(body.PlanningDate.Month),
+				Day:   body.PlanningDate.Day,
+			},
+			Status: body.Status,
+			State:  body.State,
+		})
+		s.conn.push(liveTaskReply(req.Id, t, err))
+	case liveDeleteTask:
+		s.conn.push(liveReply(req.Id, nil, s.a.DeleteTask(ctx, req.TaskId)))
+	case liveMoveTask:
+		t, err := s.a.MoveTask(ctx, req.TaskId, model.Move{
+			State:  req.Move.State,
+			Before: req.Move.BeforeId,
+			After:  req.Move.AfterId,
+		})
+		s.conn.push(liveTaskReply(req.Id, t, err))
+	case liveAssignTask:
+		t, err := s.a.AssignTask(ctx, req.TaskId, req.Assignee)
+		s.conn.push(liveTaskReply(req.Id, t, err))
+	case liveUnassignTask:
+		t, err := s.a.UnassignTask(ctx, req.TaskId, req.Assignee)
+		s.conn.push(liveTaskReply(req.Id, t, err))
+	default:
+		s.conn.push(liveReply(req.Id, nil, model.ErrInvalidInput))
+	}
+}
+
+// subscribe moves the client to the room of the project and the date from
+// the request and starts passing events of its tasks
+func (s *liveSession) subscribe(ctx context.Context, req liveRequest) {
+	scope := model.EventScope{Project: req.Project}
+	if req.Date != "" {
+		d, err := time.Parse(time.DateOnly, req.Date)
+		if err != nil {
+			s.conn.push(liveReply(req.Id, nil, model.ErrInvalidInput))
+			return
+		}
+		scope.Date.Year, scope.Date.Month, scope.Date.Day = d.Date()
+	}
+
+	subCtx, stop := context.WithCancel(ctx)
+	stream, err := s.a.SubscribeEvents(subCtx, scope, req.LastEventId)
+	if err != nil {
+		stop()
+		s.conn.push(liveReply(req.Id, nil, err))
+		return
+	}
+
+	s.unsubscribe()
+	s.room, s.stop = roomOf(scope), stop
+	s.conn.push(liveReply(req.Id, nil, nil))
+	s.hub.join(s.room, s.conn)
+	go s.forward(subCtx, s.room, scope, stream)
+}
+
+// unsubscribe ends subscription to the current room if there is one
+func (s *liveSession) unsubscribe() {
+	if s.stop == nil {
+		return
+	}
+	s.stop()
+	s.hub.leave(s.room, s.conn)
+	s.room, s.stop = "", nil
+}
+
+// forward passes events of the subscription to the client until it ends. The
+// broker drops subscribers which fall behind, so the events are resubscribed
+// from the last passed one
+func (s *liveSession) forward(ctx context.Context, room string, scope model.EventScope, stream model.EventStream) {
+	lastId := 0
+	for {
+		if stream.Lost {
+			// client reloads the tasks of the room
+			s.conn.push(liveMessage{Type: liveReset, Room: room})
+		}
+		for _, e := range stream.Missed {
+			s.conn.push(liveMessage{Type: liveEvent, Room: room, EventId: e.Id, Event: e.Type, Payload: e.Payload})
+			lastId = e.Id
+		}
+		for e := range stream.Events {
+			if ctx.Err() != nil {
+				return
+			}
+			s.conn.push(liveMessage{Type: liveEvent, Room: room, EventId: e.Id, Event: e.Type, Payload: e.Payload})
+			lastId = e.Id
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		var err error
+		if stream, err = s.a.SubscribeEvents(ctx, scope, lastId); err != nil {
+			return
+		}
+	}
+}
+
+// writeLive writes queued messages and pings to the connection until it is
+// closed or the server is shut down
+func writeLive(ctx context.Context, ws *websocket.Conn, conn *liveConn) {
+	defer func() {
+		_ = ws.Close()
+	}()
+
+	ping := time.NewTicker(livePingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case m := <-conn.send:
+			_ = ws.SetWriteDeadline(time.Now().Add(liveWriteTimeout))
+			if err := ws.WriteJSON(m); err != nil {
+				conn.close()
+				return
+			}
+		case <-ping.C:
+			if err := ws.WriteControl(websocket.PingMessage, nil, time.Now().Add(liveWriteTimeout)); err != nil {
+				conn.close()
+				return
+			}
+		case <-conn.done:
+			_ = ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(liveWriteTimeout))
+			return
+		case <-ctx.Done():
+			conn.close()
+			_ = ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, ""), time.Now().Add(liveWriteTimeout))
+			return
+		}
+	}
+}
+
+// @Summary		Совместная работа с задачами через WebSocket
+// @Description	Открывает WebSocket соединение. Клиент отправляет JSON сообщения с полями id и type. Сообщение subscribe с необязательной датой date в формате YYYY-MM-DD и last_event_id переводит клиента в комнату задач этой даты или всей доски, unsubscribe выводит из неё. Сообщения add_task и update_task (поле task как в теле POST /task, task_id и force), delete_task, move_task (поле move как в теле POST /task/{id}/move), assign_task и unassign_task (поле assignee) изменяют задачи так же, как соответствующие запросы. На каждое сообщение сервер отвечает сообщением result с тем же id и полями data или error. Изменения задач комнаты приходят сообщениями event с полями event_id, event и payload как у вебхуков, сообщение reset означает, что клиенту нужно перезагрузить задачи. Сообщение presence со списком users приходит всем клиентам комнаты, когда в неё входят или из неё выходят
+// @Param		X-User header string false "Имя текущего пользователя"
+// @Param		user query string false "Имя текущего пользователя для клиентов, которые не могут передать заголовок X-User"
+// @Success		101	{string} string "Соединение переключено на протокол WebSocket"
+// @Failure 	400 {string} string "Запрос не является запросом на открытие WebSocket соединения"
+// @Failure 	401 {object} taskResponse "Не указан текущий пользователь"
+// @Router		/live [get]
+func liveChannel(a app.App, hub *liveHub) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// WebSocket of the browsers can't set headers of the request
+		user := c.GetHeader(userHeader)
+		if user == "" {
+			user = c.Query("user")
+		}
+		if user == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(model.ErrUnknownUser))
+			return
+		}
+
+		ws, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+		if err != nil { // upgrader has replied with the error
+			return
+		}
+		ws.SetReadLimit(liveReadLimit)
+		_ = ws.SetReadDeadline(time.Now().Add(livePongTimeout))
+		ws.SetPongHandler(func(string) error {
+			return ws.SetReadDeadline(time.Now().Add(livePongTimeout))
+		})
+
+		ctx, cancel := context.WithCancel(c.Request.Context())
+		defer cancel()
+
+		conn := newLiveConn(user)
+		written := make(chan struct{})
+		go func() {
+			defer close(written)
+			writeLive(ctx, ws, conn)
+		}()
+
+		s := &liveSession{a: a, hub: hub, conn: conn}
+		for {
+			_, b, err := ws.ReadMessage()
+			if err != nil {
+				break
+			}
+			var req liveRequest
+			if err = json.Unmarshal(b, &req); err != nil {
+				conn.push(liveReply("", nil, model.ErrInvalidInput))
+				continue
+			}
+			s.handle(ctx, req)
+		}
+
+		s.unsubscribe()
+		conn.close()
+		<-written
+	}
+}
@@ -0,0 +1,160 @@
+package httpserver
+
+import (
+	"context"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"todo-list/internal/app"
+	"todo-list/internal/app/mocks"
+	"todo-list/internal/events"
+	"todo-list/internal/model"
+)
+
+var liveDate = model.Date{Year: 2027, Month: time.May, Day: 1}
+
+// liveServer returns server with the live channel of the app which streams
+// events published to the broker
+func liveServer(t *testing.T, tr app.TaskRepo, br app.BoardRepo, broker app.EventBroker) *httptest.Server {
+	a := app.New(app.Deps{Tasks: tr, Board: br, Broker: broker}, app.Config{})
+	srv := httptest.NewServer(New("", a).Handler)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func dialLive(t *testing.T, srv *httptest.Server, user string) *websocket.Conn {
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/todo-list/api/live?user=" + user
+	ws, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = ws.Close()
+	})
+	return ws
+}
+
+func readLive(t *testing.T, ws *websocket.Conn) liveMessage {
+	require.NoError(t, ws.SetReadDeadline(time.Now().Add(5*time.Second)))
+	var m liveMessage
+	require.NoError(t, ws.ReadJSON(&m))
+	return m
+}
+
+func TestLiveChannel(t *testing.T) {
+	tr, br := new(mocks.TaskRepo), new(mocks.BoardRepo)
+	broker := events.NewBroker(10)
+	srv := liveServer(t, tr, br, broker)
+	room := "date:2027-05-01"
+
+	alice := dialLive(t, srv, "alice")
+	require.NoError(t, alice.WriteJSON(liveRequest{Id: "1", Type: liveSubscribe, Date: "2027-05-01"}))
+	assert.Equal(t, liveMessage{Type: liveResult, Id: "1"}, readLive(t, alice))
+	assert.Equal(t, liveMessage{Type: livePresence, Room: room, Users: []string{"alice"}}, readLive(t, alice))
+
+	bob := dialLive(t, srv, "bob")
+	require.NoError(t, bob.WriteJSON(liveRequest{Id: "1", Type: liveSubscribe, Date: "2027-05-01"}))
+	assert.Equal(t, liveMessage{Type: liveResult, Id: "1"}, readLive(t, bob))
+	assert.Equal(t, liveMessage{Type: livePresence, Room: room, Users: []string{"alice", "bob"}}, readLive(t, bob))
+	assert.Equal(t, liveMessage{Type: livePresence, Room: room, Users: []string{"alice", "bob"}}, readLive(t, alice))
+
+	added := model.TodoTask{Id: 1061, Title: "Live", PlanningDate: liveDate, State: "todo"}
+	br.On("GetLastRank", mock.Anything, "todo").Return("", nil).Once()
+	tr.On("AddTask", mock.Anything, mock.MatchedBy(func(task model.TodoTask) bool {
+		return task.Title == "Live" && task.PlanningDate == liveDate
+	})).Return(added, nil).Once()
+
+	require.NoError(t, bob.WriteJSON(liveRequest{Id: "2", Type: liveAddTask, Task: []byte(`{"title":"Live","planning_date":{"year":2027,"month":5,"day":1}}`)}))
+	reply := readLive(t, bob)
+	assert.Equal(t, "2", reply.Id)
+	assert.Nil(t, reply.Err)
+	require.NotNil(t, reply.Data)
+	assert.Equal(t, 1061, reply.Data.Id)
+
+	// relay publishes the event written by the repo
+	e, err := events.New(model.EventTaskCreated, added, nil)
+	require.NoError(t, err)
+	e.Id = 1
+	require.NoError(t, broker.Publish(context.Background(), e))
+	for _, ws := range []*websocket.Conn{alice, bob} {
+		m := readLive(t, ws)
+		assert.Equal(t, liveEvent, m.Type)
+		assert.Equal(t, room, m.Room)
+		assert.Equal(t, 1, m.EventId)
+		assert.Equal(t, model.EventTaskCreated, m.Event)
+		assert.JSONEq(t, string(e.Payload), string(m.Payload))
+	}
+
+	// events of other dates are not passed to the room
+	other, err := events.New(model.EventTaskCreated, model.TodoTask{Id: 1062, PlanningDate: model.Date{Year: 2027, Month: time.May, Day: 2}}, nil)
+	require.NoError(t, err)
+	other.Id = 2
+	require.NoError(t, broker.Publish(context.Background(), other))
+
+	// but the task moved from the date of the room is passed
+	moved := added
+	moved.PlanningDate = model.Date{Year: 2027, Month: time.May, Day: 3}
+	e, err = events.New(model.EventTaskUpdated, moved, &added)
+	require.NoError(t, err)
+	e.Id = 3
+	require.NoError(t, broker.Publish(context.Background(), e))
+	for _, ws := range []*websocket.Conn{alice, bob} {
+		m := readLive(t, ws)
+		assert.Equal(t, 3, m.EventId)
+		assert.Equal(t, model.EventTaskUpdated, m.Event)
+	}
+
+	tr.On("UnassignTask", mock.Anything, 1063, "bob").Return(model.TodoTask{}, model.ErrTaskNotFound).Once()
+	require.NoError(t, alice.WriteJSON(liveRequest{Id: "2", Type: liveUnassignTask, TaskId: 1063, Assignee: "bob"}))
+	reply = readLive(t, alice)
+	assert.Equal(t, "2", reply.Id)
+	require.NotNil(t, reply.Err)
+	assert.Equal(t, model.ErrTaskNotFound.Error(), *reply.Err)
+
+	require.NoError(t, alice.WriteJSON(liveRequest{Id: "3", Type: "unknown"}))
+	reply = readLive(t, alice)
+	require.NotNil(t, reply.Err)
+	assert.Equal(t, model.ErrInvalidInput.Error(), *reply.Err)
+
+	require.NoError(t, alice.Close())
+	assert.Equal(t, liveMessage{Type: livePresence, Room: room, Users: []string{"bob"}}, readLive(t, bob))
+
+	tr.AssertExpectations(t)
+	br.AssertExpectations(t)
+}
+
+func TestLiveChannelUnknownUser(t *testing.T) {
+	srv := liveServer(t, new(mocks.TaskRepo), new(mocks.BoardRepo), events.NewBroker(10))
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/todo-list/api/live"
+	_, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	require.Error(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestLiveHubPresence(t *testing.T) {
+	hub := newLiveHub()
+	first, second, third := newLiveConn("bob"), newLiveConn("alice"), newLiveConn("bob")
+
+	hub.join(liveBoardRoom, first)
+	hub.join(liveBoardRoom, second)
+	hub.join(liveBoardRoom, third)
+	hub.join(roomOf(model.EventScope{Date: liveDate}), newLiveConn("carol"))
+	hub.join(roomOf(model.EventScope{Project: "backend", Date: liveDate}), newLiveConn("dave"))
+	assert.Equal(t, []string{"alice", "bob"}, hub.viewers(liveBoardRoom))
+	assert.Equal(t, []string{"carol"}, hub.viewers("date:2027-05-01"))
+	assert.Equal(t, []string{"dave"}, hub.viewers("project:backend/date:2027-05-01"))
+
+	// user with another connection stays in the room
+	hub.leave(liveBoardRoom, first)
+	assert.Equal(t, []string{"alice", "bob"}, hub.viewers(liveBoardRoom))
+
+	hub.leave(liveBoardRoom, second)
+	hub.leave(liveBoardRoom, third)
+	assert.Empty(t, hub.viewers(liveBoardRoom))
+	assert.NotContains(t, hub.rooms, liveBoardRoom)
+}
@@ -0,0 +1,48 @@
+package httpserver
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+// @Summary		Изменение порядка задач на день
+// @Description	Задаёт порядок всех задач, запланированных на дату, и возвращает их в новом порядке. Список id должен содержать все задачи этой даты без повторов, порядок сохраняется атомарно
+// @Produce		json
+// @Param		input body reorderDayRequest true "Дата и упорядоченный список id задач"
+// @Success		200	{object} tasksResponse "Успешное изменение порядка"
+// @Failure		500	{object} taskResponse  "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse  "Неверный формат входных данных"
+// @Failure 	409 {object} taskResponse  "Список id не совпадает с задачами на эту дату"
+// @Router		/task/by_date/order [put]
+func reorderDay(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req reorderDayRequest
+		if err := c.BindJSON(&req); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		tasks, err := a.ReorderDay(c, model.Date{
+			Year:  req.PlanningDate.Year,
+			Month: time.Month(req.PlanningDate.Month),
+			Day:   req.PlanningDate.Day,
+		}, req.Ids)
+
+		switch {
+		case errors.Is(err, model.ErrInvalidInput):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+		case errors.Is(err, model.ErrDayOrder):
+			c.AbortWithStatusJSON(http.StatusConflict, errorResponse(model.ErrDayOrder))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, tasksSuccessResponse(tasks))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
@@ -1,5 +1,10 @@
 package httpserver
 
+import (
+	"encoding/json"
+	"time"
+)
+
 type addTaskRequest struct {
 	Title        string `json:"title"`
 	Description  string `json:"description"`
@@ -8,7 +13,9 @@ type addTaskRequest struct {
 		Month int `json:"month"`
 		Day   int `json:"day"`
 	} `json:"planning_date"`
-	Status bool `json:"status"`
+	Status  bool   `json:"status"`
+	Project string `json:"project"`
+	State   string `json:"state"`
 }
 
 type getTaskByTextRequest struct {
@@ -23,7 +30,8 @@ type updateTaskRequest struct {
 		Month int `json:"month"`
 		Day   int `json:"day"`
 	} `json:"planning_date"`
-	Status bool `json:"status"`
+	Status bool   `json:"status"`
+	State  string `json:"state"`
 }
 
 type getTasksByStatusRequest struct {
@@ -32,6 +40,12 @@ type getTasksByStatusRequest struct {
 	Limit  int  `json:"limit"`
 }
 
+type getTasksByStateRequest struct {
+	State  string `json:"state"`
+	Offset int    `json:"offset"`
+	Limit  int    `json:"limit"`
+}
+
 type getTasksByDateAndStatusRequest struct {
 	PlanningDate struct {
 		Year  int `json:"year"`
@@ -40,3 +54,164 @@ type getTasksByDateAndStatusRequest struct {
 	} `json:"planning_date"`
 	Status bool `json:"status"`
 }
+
+type assignTaskRequest struct {
+	Assignee string `json:"assignee"`
+}
+
+type getTasksByAssigneeRequest struct {
+	Assignee string `json:"assignee"`
+	Offset   int    `json:"offset"`
+	Limit    int    `json:"limit"`
+}
+
+type getTasksAssignedToMeRequest struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+type setTaskProjectRequest struct {
+	Project string `json:"project"`
+}
+
+type addProjectRequest struct {
+	Name    string   `json:"name"`
+	Members []string `json:"members"`
+}
+
+type addProjectMemberRequest struct {
+	Member string `json:"member"`
+}
+
+type blockTaskRequest struct {
+	BlockerId int `json:"blocker_id"`
+}
+
+type addCommentRequest struct {
+	Text string `json:"text"`
+}
+
+type updateCommentRequest struct {
+	Text string `json:"text"`
+}
+
+type getCommentsByTaskRequest struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+type moveTaskRequest struct {
+	State    string `json:"state"`
+	BeforeId int    `json:"before_id"`
+	AfterId  int    `json:"after_id"`
+}
+
+type getBoardRequest struct {
+	Limit int `json:"limit"`
+}
+
+type reorderDayRequest struct {
+	PlanningDate struct {
+		Year  int `json:"year"`
+		Month int `json:"month"`
+		Day   int `json:"day"`
+	} `json:"planning_date"`
+	Ids []int `json:"ids"`
+}
+
+type getOverdueTasksRequest struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+type getHistoryByTaskRequest struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+type snoozeTaskRequest struct {
+	Days int `json:"days"`
+}
+
+type rescheduleToWeekdayRequest struct {
+	Weekday string `json:"weekday"`
+}
+
+type rescheduleTaskRequest struct {
+	PlanningDate struct {
+		Year  int `json:"year"`
+		Month int `json:"month"`
+		Day   int `json:"day"`
+	} `json:"planning_date"`
+}
+
+type rescheduleDayRequest struct {
+	From struct {
+		Year  int `json:"year"`
+		Month int `json:"month"`
+		Day   int `json:"day"`
+	} `json:"from"`
+	To struct {
+		Year  int `json:"year"`
+		Month int `json:"month"`
+		Day   int `json:"day"`
+	} `json:"to"`
+}
+
+type addReminderRequest struct {
+	At            *time.Time `json:"at"`
+	OffsetMinutes int        `json:"offset_minutes"`
+	Channel       string     `json:"channel"`
+	Recipient     string     `json:"recipient"`
+}
+
+type getDeliveriesByReminderRequest struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+type addSubscriptionRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	Secret string   `json:"secret"`
+}
+
+type getDeliveriesBySubscriptionRequest struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+// liveRequest is a message of the client of the live channel, Id is returned
+// in the result of the request
+type liveRequest struct {
+	Id          string          `json:"id"`
+	Type        string          `json:"type"`
+	Date        string          `json:"date"`
+	Project     string          `json:"project"`
+	LastEventId int             `json:"last_event_id"`
+	TaskId      int             `json:"task_id"`
+	Task        json.RawMessage `json:"task"`
+	Force       bool            `json:"force"`
+	Move        moveTaskRequest `json:"move"`
+	Assignee    string          `json:"assignee"`
+}
+
+type syncChangeRequest struct {
+	Ref     string            `json:"ref"`
+	Op      string            `json:"op"`
+	TaskId  int               `json:"task_id"`
+	Version int               `json:"version"`
+	Task    updateTaskRequest `json:"task"`
+}
+
+type syncRequest struct {
+	Client  string              `json:"client"`
+	Token   string              `json:"token"`
+	Changes []syncChangeRequest `json:"changes"`
+}
+
+type graphqlRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
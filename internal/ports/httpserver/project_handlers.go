@@ -0,0 +1,212 @@
+package httpserver
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+// @Summary		Перенос задачи в проект
+// @Description	Возвращает задачу с обновлённым проектом, пустой проект оставляет задачу только в рабочем пространстве
+// @Produce		json
+// @Param		input body setTaskProjectRequest true "Название проекта"
+// @Param 		id path int true "id задачи"
+// @Success		200	{object} taskResponse "Успешный перенос"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных или ответственный задачи не состоит в проекте"
+// @Failure 	404 {object} taskResponse "Задача с заданным id или проект не найдены"
+// @Router		/task/{id}/project [put]
+func setTaskProject(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		var req setTaskProjectRequest
+		if err = c.BindJSON(&req); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		t, err := a.SetTaskProject(c, id, req.Project)
+
+		switch {
+		case errors.Is(err, model.ErrTaskNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(model.ErrTaskNotFound))
+		case errors.Is(err, model.ErrProjectNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(model.ErrProjectNotFound))
+		case errors.Is(err, model.ErrInvalidInput):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+		case errors.Is(err, model.ErrNotMember):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrNotMember))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, taskSuccessResponse(t))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
+
+// @Summary		Добавление проекта
+// @Description	Возвращает добавленный проект с его участниками
+// @Produce		json
+// @Param		input body addProjectRequest true "Название и участники проекта"
+// @Success		200	{object} projectResponse "Успешное добавление"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных или участник не состоит в рабочем пространстве"
+// @Failure 	409 {object} taskResponse "Проект с таким названием уже существует"
+// @Router		/project [post]
+func addProject(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req addProjectRequest
+		if err := c.BindJSON(&req); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		p, err := a.AddProject(c, model.Project{
+			Name:    req.Name,
+			Members: req.Members,
+		})
+
+		switch {
+		case errors.Is(err, model.ErrInvalidInput):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+		case errors.Is(err, model.ErrNotMember):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrNotMember))
+		case errors.Is(err, model.ErrProjectExists):
+			c.AbortWithStatusJSON(http.StatusConflict, errorResponse(model.ErrProjectExists))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, projectSuccessResponse(p))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
+
+// @Summary		Получение проекта
+// @Description	Возвращает проект с заданным названием и его участников
+// @Produce		json
+// @Param 		name path string true "Название проекта"
+// @Success		200	{object} projectResponse "Успешное получение"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных"
+// @Failure 	404 {object} taskResponse "Проект не найден"
+// @Router		/project/{name} [get]
+func getProject(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		p, err := a.GetProject(c, c.Param("name"))
+
+		switch {
+		case errors.Is(err, model.ErrProjectNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(model.ErrProjectNotFound))
+		case errors.Is(err, model.ErrInvalidInput):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, projectSuccessResponse(p))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
+
+// @Summary		Получение списка проектов
+// @Description	Возвращает все проекты, упорядоченные по названию
+// @Produce		json
+// @Success		200	{object} projectsResponse "Успешное получение"
+// @Failure		500	{object} taskResponse  "Проблемы на стороне сервера"
+// @Router		/project [get]
+func getProjects(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		projects, err := a.GetProjects(c)
+
+		switch {
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, projectsSuccessResponse(projects))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
+
+// @Summary		Добавление участника проекта
+// @Description	Возвращает проект с обновлённым списком участников
+// @Produce		json
+// @Param		input body addProjectMemberRequest true "Имя пользователя"
+// @Param 		name path string true "Название проекта"
+// @Success		200	{object} projectResponse "Успешное добавление"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных или пользователь не состоит в рабочем пространстве"
+// @Failure 	404 {object} taskResponse "Проект не найден"
+// @Router		/project/{name}/members [post]
+func addProjectMember(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req addProjectMemberRequest
+		if err := c.BindJSON(&req); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		p, err := a.AddProjectMember(c, c.Param("name"), req.Member)
+
+		switch {
+		case errors.Is(err, model.ErrProjectNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(model.ErrProjectNotFound))
+		case errors.Is(err, model.ErrInvalidInput):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+		case errors.Is(err, model.ErrNotMember):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrNotMember))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, projectSuccessResponse(p))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
+
+// @Summary		Удаление участника проекта
+// @Description	Возвращает проект с обновлённым списком участников, участника нельзя удалить, пока он назначен на задачи проекта
+// @Produce		json
+// @Param 		name path string true "Название проекта"
+// @Param 		member path string true "Имя пользователя"
+// @Success		200	{object} projectResponse "Успешное удаление"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных"
+// @Failure 	404 {object} taskResponse "Проект не найден"
+// @Failure 	409 {object} taskResponse "Участник назначен на задачи проекта"
+// @Router		/project/{name}/members/{member} [delete]
+func removeProjectMember(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		p, err := a.RemoveProjectMember(c, c.Param("name"), c.Param("member"))
+
+		switch {
+		case errors.Is(err, model.ErrProjectNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(model.ErrProjectNotFound))
+		case errors.Is(err, model.ErrInvalidInput):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+		case errors.Is(err, model.ErrMemberAssigned):
+			c.AbortWithStatusJSON(http.StatusConflict, errorResponse(model.ErrMemberAssigned))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, projectSuccessResponse(p))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
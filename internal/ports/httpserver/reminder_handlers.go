@@ -0,0 +1,180 @@
+package httpserver
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"time"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+// @Summary		Добавление напоминания к задаче
+// @Description	Напоминание срабатывает в момент at или, если он не указан, со смещением offset_minutes от начала запланированной даты задачи в UTC
+// @Produce		json
+// @Param		input body addReminderRequest true "Время, канал (email или webhook) и получатель напоминания"
+// @Param 		id path int true "id задачи"
+// @Success		200	{object} reminderResponse "Успешное добавление"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных"
+// @Failure 	404 {object} taskResponse "Задача с заданным id не найдена"
+// @Router		/task/{id}/reminders [post]
+func addReminder(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		taskId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		var req addReminderRequest
+		if err = c.BindJSON(&req); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		r := model.Reminder{
+			Offset:    time.Duration(req.OffsetMinutes) * time.Minute,
+			Channel:   req.Channel,
+			Recipient: req.Recipient,
+		}
+		if req.At != nil {
+			r.At = *req.At
+		}
+
+		r, err = a.AddReminder(c, taskId, r)
+
+		switch {
+		case errors.Is(err, model.ErrTaskNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(model.ErrTaskNotFound))
+		case errors.Is(err, model.ErrInvalidReminder):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidReminder))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, reminderSuccessResponse(r))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
+
+// @Summary		Получение напоминаний задачи
+// @Description	Возвращает напоминания задачи с их статусом и временем следующей попытки отправки
+// @Produce		json
+// @Param 		id path int true "id задачи"
+// @Success		200	{object} remindersResponse "Успешное получение"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных"
+// @Failure 	404 {object} taskResponse "Задача с заданным id не найдена"
+// @Router		/task/{id}/reminders [get]
+func getRemindersByTask(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		taskId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		reminders, err := a.GetRemindersByTask(c, taskId)
+
+		switch {
+		case errors.Is(err, model.ErrTaskNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(model.ErrTaskNotFound))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, remindersSuccessResponse(reminders))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
+
+// @Summary		Удаление напоминания
+// @Description	Удаляет напоминание задачи вместе с журналом его отправки
+// @Produce		json
+// @Param 		id path int true "id задачи"
+// @Param 		reminder_id path int true "id напоминания"
+// @Success		200	{object} taskResponse "Успешное удаление"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных"
+// @Failure 	404 {object} taskResponse "Напоминание с заданным id не найдено"
+// @Router		/task/{id}/reminders/{reminder_id} [delete]
+func deleteReminder(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		taskId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		id, err := strconv.Atoi(c.Param("reminder_id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		err = a.DeleteReminder(c, taskId, id)
+
+		switch {
+		case errors.Is(err, model.ErrReminderNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(model.ErrReminderNotFound))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, deleteSuccessResponse())
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
+
+// @Summary		Получение журнала отправки напоминания с пагинацией
+// @Description	Возвращает попытки отправки напоминания от старых к новым, у успешных попыток ошибка пустая
+// @Produce		json
+// @Param		input body getDeliveriesByReminderRequest true "Пагинация"
+// @Param 		id path int true "id задачи"
+// @Param 		reminder_id path int true "id напоминания"
+// @Success		200	{object} deliveriesResponse "Успешное получение"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных"
+// @Failure 	404 {object} taskResponse "Напоминание с заданным id не найдено"
+// @Router		/task/{id}/reminders/{reminder_id}/deliveries [get]
+func getDeliveriesByReminder(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		taskId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		id, err := strconv.Atoi(c.Param("reminder_id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		var req getDeliveriesByReminderRequest
+		if err = c.BindJSON(&req); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		deliveries, err := a.GetDeliveriesByReminder(c, taskId, id, req.Offset, req.Limit)
+
+		switch {
+		case errors.Is(err, model.ErrReminderNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(model.ErrReminderNotFound))
+		case errors.Is(err, model.ErrInvalidInput):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, deliveriesSuccessResponse(deliveries))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
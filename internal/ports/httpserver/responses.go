@@ -1,6 +1,12 @@
 package httpserver
 
 import (
+	"encoding/json"
+	"errors"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"net/url"
+	"strings"
+	"time"
 	"todo-list/internal/model"
 )
 
@@ -13,7 +19,16 @@ type taskData struct {
 		Month int `json:"month"`
 		Day   int `json:"day"`
 	} `json:"planning_date"`
-	Status bool `json:"status"`
+	Status    bool     `json:"status"`
+	State     string   `json:"state"`
+	Rank      string   `json:"rank"`
+	Position  int      `json:"position"`
+	Overdue   bool     `json:"overdue"`
+	Postponed int      `json:"postponed"`
+	Assignees []string `json:"assignees"`
+	Project   string   `json:"project"`
+	Blocked   bool     `json:"blocked"`
+	Version   int      `json:"version"`
 }
 
 type taskResponse struct {
@@ -26,6 +41,279 @@ type tasksResponse struct {
 	Err  *string    `json:"error"`
 }
 
+type stateData struct {
+	Name string `json:"name"`
+	Done bool   `json:"done"`
+}
+
+type workflowData struct {
+	States      []stateData         `json:"states"`
+	Transitions map[string][]string `json:"transitions"`
+}
+
+type workflowResponse struct {
+	Data *workflowData `json:"data"`
+	Err  *string       `json:"error"`
+}
+
+type columnData struct {
+	State string     `json:"state"`
+	Done  bool       `json:"done"`
+	Tasks []taskData `json:"tasks"`
+}
+
+type boardData struct {
+	Columns []columnData `json:"columns"`
+}
+
+type boardResponse struct {
+	Data *boardData `json:"data"`
+	Err  *string    `json:"error"`
+}
+
+type dateData struct {
+	Year  int `json:"year"`
+	Month int `json:"month"`
+	Day   int `json:"day"`
+}
+
+type historyEntryData struct {
+	Id        int       `json:"id"`
+	TaskId    int       `json:"task_id"`
+	Action    string    `json:"action"`
+	FromDate  dateData  `json:"from_date"`
+	ToDate    dateData  `json:"to_date"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type historyResponse struct {
+	Data []historyEntryData `json:"data"`
+	Err  *string            `json:"error"`
+}
+
+type dependencyData struct {
+	BlockerId int `json:"blocker_id"`
+	BlockedId int `json:"blocked_id"`
+}
+
+type dependencyGraphData struct {
+	Tasks        []taskData       `json:"tasks"`
+	Dependencies []dependencyData `json:"dependencies"`
+}
+
+type dependencyGraphResponse struct {
+	Data *dependencyGraphData `json:"data"`
+	Err  *string              `json:"error"`
+}
+
+type commentData struct {
+	Id        int       `json:"id"`
+	TaskId    int       `json:"task_id"`
+	Author    string    `json:"author"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type commentResponse struct {
+	Data *commentData `json:"data"`
+	Err  *string      `json:"error"`
+}
+
+type commentsResponse struct {
+	Data []commentData `json:"data"`
+	Err  *string       `json:"error"`
+}
+
+type attachmentData struct {
+	Id          int       `json:"id"`
+	TaskId      int       `json:"task_id"`
+	Name        string    `json:"name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type attachmentResponse struct {
+	Data *attachmentData `json:"data"`
+	Err  *string         `json:"error"`
+}
+
+type attachmentsResponse struct {
+	Data []attachmentData `json:"data"`
+	Err  *string          `json:"error"`
+}
+
+type reminderData struct {
+	Id            int        `json:"id"`
+	TaskId        int        `json:"task_id"`
+	At            *time.Time `json:"at"`
+	OffsetMinutes int        `json:"offset_minutes"`
+	Channel       string     `json:"channel"`
+	Recipient     string     `json:"recipient"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	FireAt        time.Time  `json:"fire_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+type reminderResponse struct {
+	Data *reminderData `json:"data"`
+	Err  *string       `json:"error"`
+}
+
+type remindersResponse struct {
+	Data []reminderData `json:"data"`
+	Err  *string        `json:"error"`
+}
+
+type deliveryData struct {
+	Id         int       `json:"id"`
+	ReminderId int       `json:"reminder_id"`
+	Attempt    int       `json:"attempt"`
+	Error      string    `json:"error"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type deliveriesResponse struct {
+	Data []deliveryData `json:"data"`
+	Err  *string        `json:"error"`
+}
+
+type subscriptionData struct {
+	Id        int       `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type subscriptionResponse struct {
+	Data *subscriptionData `json:"data"`
+	Err  *string           `json:"error"`
+}
+
+type subscriptionsResponse struct {
+	Data []subscriptionData `json:"data"`
+	Err  *string            `json:"error"`
+}
+
+type webhookDeliveryData struct {
+	Id             int             `json:"id"`
+	SubscriptionId int             `json:"subscription_id"`
+	Event          string          `json:"event"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	ResponseCode   int             `json:"response_code"`
+	Error          string          `json:"error"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+type webhookDeliveriesResponse struct {
+	Data []webhookDeliveryData `json:"data"`
+	Err  *string               `json:"error"`
+}
+
+type tombstoneData struct {
+	TaskId    int       `json:"task_id"`
+	Version   int       `json:"version"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// syncResultData is an outcome of the change of the offline client, task is
+// null if the change is rejected, the task is deleted or the change deletes it
+type syncResultData struct {
+	Ref     string    `json:"ref"`
+	Status  string    `json:"status"`
+	TaskId  int       `json:"task_id"`
+	Task    *taskData `json:"task"`
+	Deleted bool      `json:"deleted"`
+	Err     *string   `json:"error"`
+}
+
+type syncData struct {
+	Results    []syncResultData `json:"results"`
+	Tasks      []taskData       `json:"tasks"`
+	Tombstones []tombstoneData  `json:"tombstones"`
+	Reset      bool             `json:"reset"`
+	Token      string           `json:"token"`
+}
+
+type syncResponse struct {
+	Data *syncData `json:"data"`
+	Err  *string   `json:"error"`
+}
+
+// importResultData is an outcome of the row of the imported file, warnings
+// tell which parts of the row are kept in the title of the task and errors
+// explain why the row is skipped
+type importResultData struct {
+	Line     int      `json:"line"`
+	Status   string   `json:"status"`
+	Task     taskData `json:"task"`
+	Warnings []string `json:"warnings"`
+	Errors   []string `json:"errors"`
+}
+
+type importReportData struct {
+	Mode    string             `json:"mode"`
+	DryRun  bool               `json:"dry_run"`
+	Created int                `json:"created"`
+	Skipped int                `json:"skipped"`
+	Results []importResultData `json:"results"`
+}
+
+type importReportResponse struct {
+	Data *importReportData `json:"data"`
+	Err  *string           `json:"error"`
+}
+
+// graphqlResponse is a response in the format of GraphQL, not in the format
+// of the other responses
+type graphqlResponse struct {
+	Data   any                        `json:"data"`
+	Errors []gqlerrors.FormattedError `json:"errors,omitempty" swaggertype:"array,object"`
+}
+
+type calendarFeedData struct {
+	User  string `json:"user"`
+	Token string `json:"token"`
+	Path  string `json:"path"`
+}
+
+type calendarFeedResponse struct {
+	Data *calendarFeedData `json:"data"`
+	Err  *string           `json:"error"`
+}
+
+type caldavPasswordData struct {
+	User     string `json:"user"`
+	Password string `json:"password"`
+	Path     string `json:"path"`
+}
+
+type caldavPasswordResponse struct {
+	Data *caldavPasswordData `json:"data"`
+	Err  *string             `json:"error"`
+}
+
+// liveMessage is a message of the server in the live channel. Result of the
+// request has its Id and Data or Err, event has EventId, Event and Payload,
+// presence has Room and its Users
+type liveMessage struct {
+	Type    string          `json:"type"`
+	Id      string          `json:"id,omitempty"`
+	Room    string          `json:"room,omitempty"`
+	EventId int             `json:"event_id,omitempty"`
+	Event   string          `json:"event,omitempty"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+	Users   []string        `json:"users,omitempty"`
+	Data    *taskData       `json:"data,omitempty"`
+	Err     *string         `json:"error,omitempty"`
+}
+
 func taskSuccessResponse(t model.TodoTask) taskResponse {
 	return taskResponse{
 		Data: &taskData{
@@ -41,7 +329,16 @@ func taskSuccessResponse(t model.TodoTask) taskResponse {
 				Month: int(t.PlanningDate.Month),
 				Day:   t.PlanningDate.Day,
 			},
-			Status: t.Status,
+			Status:    t.Status,
+			State:     t.State,
+			Rank:      t.Rank,
+			Position:  t.Position,
+			Overdue:   t.Overdue,
+			Postponed: t.Postponed,
+			Assignees: assigneesData(t.Assignees),
+			Project:   t.Project,
+			Blocked:   t.Blocked,
+			Version:   t.Version,
 		},
 		Err: nil,
 	}
@@ -63,7 +360,16 @@ func tasksSuccessResponse(tasks []model.TodoTask) tasksResponse {
 				Month: int(t.PlanningDate.Month),
 				Day:   t.PlanningDate.Day,
 			},
-			Status: t.Status,
+			Status:    t.Status,
+			State:     t.State,
+			Rank:      t.Rank,
+			Position:  t.Position,
+			Overdue:   t.Overdue,
+			Postponed: t.Postponed,
+			Assignees: assigneesData(t.Assignees),
+			Project:   t.Project,
+			Blocked:   t.Blocked,
+			Version:   t.Version,
 		})
 	}
 	return tasksResponse{
@@ -72,6 +378,307 @@ func tasksSuccessResponse(tasks []model.TodoTask) tasksResponse {
 	}
 }
 
+func workflowSuccessResponse(w model.Workflow) workflowResponse {
+	states := make([]stateData, 0, len(w.States))
+	for _, s := range w.States {
+		states = append(states, stateData{
+			Name: s.Name,
+			Done: s.Done,
+		})
+	}
+	return workflowResponse{
+		Data: &workflowData{
+			States:      states,
+			Transitions: w.Transitions,
+		},
+		Err: nil,
+	}
+}
+
+func boardSuccessResponse(b model.Board) boardResponse {
+	columns := make([]columnData, 0, len(b.Columns))
+	for _, c := range b.Columns {
+		columns = append(columns, columnData{
+			State: c.State.Name,
+			Done:  c.State.Done,
+			Tasks: tasksSuccessResponse(c.Tasks).Data,
+		})
+	}
+	return boardResponse{
+		Data: &boardData{
+			Columns: columns,
+		},
+		Err: nil,
+	}
+}
+
+func historySuccessResponse(history []model.HistoryEntry) historyResponse {
+	resp := make([]historyEntryData, 0, len(history))
+	for _, e := range history {
+		resp = append(resp, historyEntryData{
+			Id:     e.Id,
+			TaskId: e.TaskId,
+			Action: e.Action,
+			FromDate: dateData{
+				Year:  e.FromDate.Year,
+				Month: int(e.FromDate.Month),
+				Day:   e.FromDate.Day,
+			},
+			ToDate: dateData{
+				Year:  e.ToDate.Year,
+				Month: int(e.ToDate.Month),
+				Day:   e.ToDate.Day,
+			},
+			CreatedAt: e.CreatedAt,
+		})
+	}
+	return historyResponse{
+		Data: resp,
+		Err:  nil,
+	}
+}
+
+func dependencyGraphSuccessResponse(graph model.DependencyGraph) dependencyGraphResponse {
+	deps := make([]dependencyData, 0, len(graph.Dependencies))
+	for _, d := range graph.Dependencies {
+		deps = append(deps, dependencyData{
+			BlockerId: d.BlockerId,
+			BlockedId: d.BlockedId,
+		})
+	}
+	return dependencyGraphResponse{
+		Data: &dependencyGraphData{
+			Tasks:        tasksSuccessResponse(graph.Tasks).Data,
+			Dependencies: deps,
+		},
+		Err: nil,
+	}
+}
+
+func commentSuccessResponse(c model.Comment) commentResponse {
+	return commentResponse{
+		Data: &commentData{
+			Id:        c.Id,
+			TaskId:    c.TaskId,
+			Author:    c.Author,
+			Text:      c.Text,
+			CreatedAt: c.CreatedAt,
+			UpdatedAt: c.UpdatedAt,
+		},
+		Err: nil,
+	}
+}
+
+func commentsSuccessResponse(comments []model.Comment) commentsResponse {
+	resp := make([]commentData, 0, len(comments))
+	for _, c := range comments {
+		resp = append(resp, commentData{
+			Id:        c.Id,
+			TaskId:    c.TaskId,
+			Author:    c.Author,
+			Text:      c.Text,
+			CreatedAt: c.CreatedAt,
+			UpdatedAt: c.UpdatedAt,
+		})
+	}
+	return commentsResponse{
+		Data: resp,
+		Err:  nil,
+	}
+}
+
+func attachmentSuccessResponse(at model.Attachment) attachmentResponse {
+	return attachmentResponse{
+		Data: &attachmentData{
+			Id:          at.Id,
+			TaskId:      at.TaskId,
+			Name:        at.Name,
+			ContentType: at.ContentType,
+			Size:        at.Size,
+			CreatedAt:   at.CreatedAt,
+		},
+		Err: nil,
+	}
+}
+
+func attachmentsSuccessResponse(attachments []model.Attachment) attachmentsResponse {
+	resp := make([]attachmentData, 0, len(attachments))
+	for _, at := range attachments {
+		resp = append(resp, attachmentData{
+			Id:          at.Id,
+			TaskId:      at.TaskId,
+			Name:        at.Name,
+			ContentType: at.ContentType,
+			Size:        at.Size,
+			CreatedAt:   at.CreatedAt,
+		})
+	}
+	return attachmentsResponse{
+		Data: resp,
+		Err:  nil,
+	}
+}
+
+// syncSuccessResponse returns changes of the tasks since the token of the
+// client and outcomes of its changes
+func syncSuccessResponse(changes model.SyncChanges, results []model.SyncResult) syncResponse {
+	data := &syncData{
+		Results:    make([]syncResultData, 0, len(results)),
+		Tasks:      tasksSuccessResponse(changes.Tasks).Data,
+		Tombstones: make([]tombstoneData, 0, len(changes.Tombstones)),
+		Reset:      changes.Reset,
+		Token:      changes.Token,
+	}
+	for _, r := range results {
+		result := syncResultData{
+			Ref:     r.Ref,
+			Status:  r.Status,
+			TaskId:  r.Task.Id,
+			Deleted: r.Deleted,
+		}
+		// tasks read from the repository have versions from the first one
+		if r.Task.Version > 0 {
+			result.Task = taskSuccessResponse(r.Task).Data
+		}
+		if r.Err != nil {
+			errStr := reportedError(r.Err).Error()
+			result.Err = &errStr
+		}
+		data.Results = append(data.Results, result)
+	}
+	for _, ts := range changes.Tombstones {
+		data.Tombstones = append(data.Tombstones, tombstoneData{
+			TaskId:    ts.TaskId,
+			Version:   ts.Version,
+			DeletedAt: ts.DeletedAt,
+		})
+	}
+
+	return syncResponse{
+		Data: data,
+		Err:  nil,
+	}
+}
+
+// importReportSuccessResponse returns outcomes of the rows of the imported file
+func importReportSuccessResponse(report model.ImportReport) importReportResponse {
+	data := &importReportData{
+		Mode:    report.Mode,
+		DryRun:  report.DryRun,
+		Created: report.Created,
+		Skipped: report.Skipped,
+		Results: make([]importResultData, 0, len(report.Results)),
+	}
+	for _, r := range report.Results {
+		data.Results = append(data.Results, importResultData{
+			Line:     r.Line,
+			Status:   r.Status,
+			Task:     *taskSuccessResponse(r.Task).Data,
+			Warnings: importWarnings(r.Warnings),
+			Errors:   importErrors(r.Err),
+		})
+	}
+	return importReportResponse{
+		Data: data,
+		Err:  nil,
+	}
+}
+
+// importWarnings makes empty list of warnings to be encoded as [] instead of null
+func importWarnings(warnings []string) []string {
+	if warnings == nil {
+		return []string{}
+	}
+	return warnings
+}
+
+// importErrors returns messages of the error of the row, the reasons of the
+// invalid rows are returned in full, so they can be fixed in the file
+func importErrors(err error) []string {
+	if err == nil {
+		return []string{}
+	} else if errors.Is(err, model.ErrInvalidInput) || errors.Is(err, model.ErrInvalidTask) {
+		return strings.Split(err.Error(), "\n")
+	}
+	return []string{reportedError(err).Error()}
+}
+
+// assigneesData makes empty list of assignees to be encoded as [] instead of null
+func assigneesData(assignees []string) []string {
+	if assignees == nil {
+		return []string{}
+	}
+	return assignees
+}
+
+type projectData struct {
+	Name    string   `json:"name"`
+	Members []string `json:"members"`
+}
+
+type projectResponse struct {
+	Data *projectData `json:"data"`
+	Err  *string      `json:"error"`
+}
+
+type projectsResponse struct {
+	Data []projectData `json:"data"`
+	Err  *string       `json:"error"`
+}
+
+func projectSuccessResponse(p model.Project) projectResponse {
+	return projectResponse{
+		Data: &projectData{
+			Name:    p.Name,
+			Members: assigneesData(p.Members),
+		},
+		Err: nil,
+	}
+}
+
+func projectsSuccessResponse(projects []model.Project) projectsResponse {
+	resp := make([]projectData, 0, len(projects))
+	for _, p := range projects {
+		resp = append(resp, projectData{
+			Name:    p.Name,
+			Members: assigneesData(p.Members),
+		})
+	}
+	return projectsResponse{
+		Data: resp,
+		Err:  nil,
+	}
+}
+
+// reportedErrors are the errors of the single items of the batch returned to
+// the client as they are, other errors are reported as unknown
+var reportedErrors = []error{
+	model.ErrInvalidInput,
+	model.ErrInvalidTask,
+	model.ErrUnknownState,
+	model.ErrTaskNotFound,
+	model.ErrTaskBlocked,
+	model.ErrTransition,
+	model.ErrVersionConflict,
+	model.ErrNotMember,
+	model.ErrProjectNotFound,
+	model.ErrProjectExists,
+	model.ErrMemberAssigned,
+	model.ErrDuplicateTask,
+	model.ErrImportAborted,
+	model.ErrTaskRepo,
+}
+
+// reportedError returns the error from reportedErrors which err matches
+func reportedError(err error) error {
+	for _, e := range reportedErrors {
+		if errors.Is(err, e) {
+			return e
+		}
+	}
+	return model.ErrUnknown
+}
+
 func errorResponse(err error) taskResponse {
 	errStr := err.Error()
 	return taskResponse{
@@ -80,9 +687,149 @@ func errorResponse(err error) taskResponse {
 	}
 }
 
+// graphqlErrors returns GraphQL response of the request which was not executed
+func graphqlErrors(err error) graphqlResponse {
+	return graphqlResponse{Errors: gqlerrors.FormatErrors(err)}
+}
+
 func deleteSuccessResponse() taskResponse {
 	return taskResponse{
 		Data: nil,
 		Err:  nil,
 	}
 }
+
+// reminderDataOf converts reminder to its response, at is null for reminders
+// relative to the planning date of the task
+func reminderDataOf(r model.Reminder) reminderData {
+	d := reminderData{
+		Id:            r.Id,
+		TaskId:        r.TaskId,
+		OffsetMinutes: int(r.Offset / time.Minute),
+		Channel:       r.Channel,
+		Recipient:     r.Recipient,
+		Status:        r.Status,
+		Attempts:      r.Attempts,
+		FireAt:        r.FireAt,
+		CreatedAt:     r.CreatedAt,
+	}
+	if !r.At.IsZero() {
+		at := r.At
+		d.At = &at
+	}
+	return d
+}
+
+func reminderSuccessResponse(r model.Reminder) reminderResponse {
+	d := reminderDataOf(r)
+	return reminderResponse{
+		Data: &d,
+		Err:  nil,
+	}
+}
+
+func remindersSuccessResponse(reminders []model.Reminder) remindersResponse {
+	resp := make([]reminderData, 0, len(reminders))
+	for _, r := range reminders {
+		resp = append(resp, reminderDataOf(r))
+	}
+	return remindersResponse{
+		Data: resp,
+		Err:  nil,
+	}
+}
+
+func deliveriesSuccessResponse(deliveries []model.Delivery) deliveriesResponse {
+	resp := make([]deliveryData, 0, len(deliveries))
+	for _, d := range deliveries {
+		resp = append(resp, deliveryData{
+			Id:         d.Id,
+			ReminderId: d.ReminderId,
+			Attempt:    d.Attempt,
+			Error:      d.Error,
+			CreatedAt:  d.CreatedAt,
+		})
+	}
+	return deliveriesResponse{
+		Data: resp,
+		Err:  nil,
+	}
+}
+
+func subscriptionSuccessResponse(sub model.Subscription) subscriptionResponse {
+	return subscriptionResponse{
+		Data: &subscriptionData{
+			Id:        sub.Id,
+			URL:       sub.URL,
+			Events:    sub.Events,
+			Secret:    sub.Secret,
+			CreatedAt: sub.CreatedAt,
+		},
+		Err: nil,
+	}
+}
+
+func subscriptionsSuccessResponse(subs []model.Subscription) subscriptionsResponse {
+	resp := make([]subscriptionData, 0, len(subs))
+	for _, sub := range subs {
+		resp = append(resp, subscriptionData{
+			Id:        sub.Id,
+			URL:       sub.URL,
+			Events:    sub.Events,
+			Secret:    sub.Secret,
+			CreatedAt: sub.CreatedAt,
+		})
+	}
+	return subscriptionsResponse{
+		Data: resp,
+		Err:  nil,
+	}
+}
+
+func webhookDeliveriesSuccessResponse(deliveries []model.WebhookDelivery) webhookDeliveriesResponse {
+	resp := make([]webhookDeliveryData, 0, len(deliveries))
+	for _, d := range deliveries {
+		resp = append(resp, webhookDeliveryData{
+			Id:             d.Id,
+			SubscriptionId: d.SubscriptionId,
+			Event:          d.Event,
+			Payload:        d.Payload,
+			Status:         d.Status,
+			Attempts:       d.Attempts,
+			ResponseCode:   d.ResponseCode,
+			Error:          d.Error,
+			NextAttemptAt:  d.NextAttemptAt,
+			CreatedAt:      d.CreatedAt,
+		})
+	}
+	return webhookDeliveriesResponse{
+		Data: resp,
+		Err:  nil,
+	}
+}
+
+// calendarFeedSuccessResponse returns token of the feed of the user with its
+// path relative to the prefix of the API
+func calendarFeedSuccessResponse(user string, token string) calendarFeedResponse {
+	return calendarFeedResponse{
+		Data: &calendarFeedData{
+			User:  user,
+			Token: token,
+			Path:  "/calendar/" + url.PathEscape(user) + "/" + token + ".ics",
+		},
+		Err: nil,
+	}
+}
+
+// caldavPasswordSuccessResponse returns CalDAV password of the user with the
+// path to their calendar on the server
+func caldavPasswordSuccessResponse(user string, password string) caldavPasswordResponse {
+	return caldavPasswordResponse{
+		Data: &caldavPasswordData{
+			User:     user,
+			Password: password,
+			Path:     caldavPrefix + "/" + url.PathEscape(user) + "/" + caldavCalendar + "/",
+		},
+		Err: nil,
+	}
+}
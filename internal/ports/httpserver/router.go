@@ -2,6 +2,7 @@ package httpserver
 
 import (
 	"github.com/gin-gonic/gin"
+	"net/http"
 	"todo-list/internal/app"
 
 	swaggerFiles "github.com/swaggo/files"
@@ -19,4 +20,98 @@ func appRouter(r *gin.RouterGroup, a app.App) {
 	r.DELETE("/task/:id", deleteTask(a))
 	r.GET("/task/by_status", getTasksByStatus(a))
 	r.GET("/task/by_date", getTasksByDateAndStatus(a))
+	r.PUT("/task/by_date/order", reorderDay(a))
+	r.GET("/task/overdue", getOverdueTasks(a))
+	r.GET("/task/:id/history", getHistoryByTask(a))
+	r.POST("/task/:id/snooze", snoozeTask(a))
+	r.POST("/task/:id/next_weekday", rescheduleToWeekday(a))
+	r.POST("/task/:id/reschedule", rescheduleTask(a))
+	r.POST("/task/by_date/reschedule", rescheduleDay(a))
+	r.GET("/task/by_state", getTasksByState(a))
+	r.POST("/task/:id/assignees", assignTask(a))
+	r.DELETE("/task/:id/assignees/:assignee", unassignTask(a))
+	r.GET("/task/by_assignee", getTasksByAssignee(a))
+	r.GET("/task/assigned_to_me", getTasksAssignedToMe(a))
+	r.PUT("/task/:id/project", setTaskProject(a))
+
+	r.POST("/project", addProject(a))
+	r.GET("/project", getProjects(a))
+	r.GET("/project/:name", getProject(a))
+	r.POST("/project/:name/members", addProjectMember(a))
+	r.DELETE("/project/:name/members/:member", removeProjectMember(a))
+
+	r.POST("/task/:id/blockers", blockTask(a))
+	r.DELETE("/task/:id/blockers/:blocker_id", unblockTask(a))
+	r.GET("/task/:id/dependencies", getDependencyGraph(a))
+	r.POST("/task/import", importFile(a))
+	r.POST("/task/import/todotxt", importTodoTxt(a))
+	r.GET("/task/export/todotxt", exportTodoTxt(a))
+
+	r.GET("/workflow", getWorkflow(a))
+	r.GET("/board", getBoard(a))
+	r.POST("/task/:id/move", moveTask(a))
+
+	r.POST("/task/:id/comments", addComment(a))
+	r.GET("/task/:id/comments", getCommentsByTask(a))
+	r.PUT("/task/:id/comments/:comment_id", updateComment(a))
+	r.DELETE("/task/:id/comments/:comment_id", deleteComment(a))
+
+	r.POST("/task/:id/attachments", addAttachment(a))
+	r.GET("/task/:id/attachments", getAttachmentsByTask(a))
+	r.GET("/task/:id/attachments/:attachment_id", getAttachment(a))
+	r.DELETE("/task/:id/attachments/:attachment_id", deleteAttachment(a))
+
+	r.POST("/task/:id/reminders", addReminder(a))
+	r.GET("/task/:id/reminders", getRemindersByTask(a))
+	r.DELETE("/task/:id/reminders/:reminder_id", deleteReminder(a))
+	r.GET("/task/:id/reminders/:reminder_id/deliveries", getDeliveriesByReminder(a))
+
+	r.POST("/webhooks", addSubscription(a))
+	r.GET("/webhooks", getSubscriptions(a))
+	r.DELETE("/webhooks/:id", deleteSubscription(a))
+	r.GET("/webhooks/:id/deliveries", getDeliveriesBySubscription(a))
+
+	r.GET("/events", streamEvents(a))
+	r.GET("/live", liveChannel(a, newLiveHub()))
+
+	r.GET("/sync", getChanges(a))
+	r.POST("/sync", syncChanges(a))
+
+	r.POST("/graphql", graphqlQuery(a))
+
+	r.POST("/calendar/token", issueCalendarToken(a))
+	r.DELETE("/calendar/token", revokeCalendarToken(a))
+	r.POST("/calendar/caldav", issueCalDAVPassword(a))
+	r.DELETE("/calendar/caldav", revokeCalDAVPassword(a))
+	r.GET("/calendar/export", exportCalendar(a))
+	r.GET("/calendar/:user/:token", getCalendarFeed(a))
+}
+
+func webRouter(r *gin.RouterGroup, a app.App) {
+	r.Use(csrfProtection())
+
+	r.GET("/", webIndex)
+	r.GET("/today", webToday(a))
+	r.GET("/tasks", webTasksByStatus(a))
+	r.GET("/search", webSearch(a))
+	r.GET("/tasks/new", webNewTask)
+	r.POST("/tasks", webAddTask(a))
+	r.GET("/tasks/:id/edit", webEditTask(a))
+	r.POST("/tasks/:id", webUpdateTask(a))
+	r.POST("/tasks/:id/toggle", webToggleTask(a))
+}
+
+func caldavRouter(r *gin.RouterGroup, a app.App) {
+	r.Use(caldavAuth(a))
+
+	r.OPTIONS("/*path", caldavOptions)
+	r.Handle("PROPFIND", "/*path", caldavPropfind(a))
+	r.Handle("REPORT", "/*path", caldavReport(a))
+	r.GET("/*path", caldavGet(a))
+	r.HEAD("/*path", caldavGet(a))
+	r.PUT("/*path", caldavPut(a))
+	r.DELETE("/*path", caldavDelete(a))
+	for _, method := range []string{http.MethodPost, "PROPPATCH", "MKCOL", "MKCALENDAR", "COPY", "MOVE"} {
+		r.Handle(method, "/*path", davMethodNotAllowed)
+	}
 }
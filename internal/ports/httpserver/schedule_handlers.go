@@ -0,0 +1,247 @@
+package httpserver
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+// weekdays maps names of the days of the week to their numbers
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// rescheduleResponse writes response with the rescheduled task or its error
+func rescheduleResponse(c *gin.Context, t model.TodoTask, err error) {
+	switch {
+	case errors.Is(err, model.ErrTaskNotFound):
+		c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(model.ErrTaskNotFound))
+	case errors.Is(err, model.ErrInvalidInput):
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+	case errors.Is(err, model.ErrTaskRepo):
+		c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+	case err == nil:
+		c.JSON(http.StatusOK, taskSuccessResponse(t))
+	default:
+		c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+	}
+}
+
+// @Summary		Получение списка просроченных задач с пагинацией
+// @Description	Возвращает невыполненные задачи, которые не были выполнены в запланированный день и были перенесены или отмечены просроченными, в порядке запланированной даты
+// @Produce		json
+// @Param		input body getOverdueTasksRequest true "Пагинация"
+// @Success		200	{object} tasksResponse "Успешное получение задач"
+// @Failure		500	{object} taskResponse  "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse  "Неверный формат входных данных"
+// @Router		/task/overdue [get]
+func getOverdueTasks(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req getOverdueTasksRequest
+		if err := c.BindJSON(&req); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		tasks, err := a.GetOverdueTasks(c, req.Offset, req.Limit)
+
+		switch {
+		case errors.Is(err, model.ErrInvalidInput):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, tasksSuccessResponse(tasks))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
+
+// @Summary		Получение истории переносов задачи с пагинацией
+// @Description	Возвращает список переносов задачи с одной даты на другую от старых к новым
+// @Produce		json
+// @Param		input body getHistoryByTaskRequest true "Пагинация"
+// @Param 		id path int true "id задачи"
+// @Success		200	{object} historyResponse "Успешное получение истории"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных"
+// @Failure 	404 {object} taskResponse "Задача с заданным id не найдена"
+// @Router		/task/{id}/history [get]
+func getHistoryByTask(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		taskId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		var req getHistoryByTaskRequest
+		if err = c.BindJSON(&req); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		history, err := a.GetHistoryByTask(c, taskId, req.Offset, req.Limit)
+
+		switch {
+		case errors.Is(err, model.ErrTaskNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(model.ErrTaskNotFound))
+		case errors.Is(err, model.ErrInvalidInput):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, historySuccessResponse(history))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
+
+// @Summary		Откладывание задачи на несколько дней
+// @Description	Переносит задачу на заданное количество дней (от 1 до 366) после запланированной даты, просроченная задача откладывается от текущей даты
+// @Produce		json
+// @Param 		id path int true "id задачи"
+// @Param		input body snoozeTaskRequest true "Количество дней"
+// @Success		200	{object} taskResponse "Успешный перенос"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных"
+// @Failure 	404 {object} taskResponse "Задача с заданным id не найдена"
+// @Router		/task/{id}/snooze [post]
+func snoozeTask(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		var req snoozeTaskRequest
+		if err = c.BindJSON(&req); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		t, err := a.SnoozeTask(c, id, req.Days)
+		rescheduleResponse(c, t, err)
+	}
+}
+
+// @Summary		Перенос задачи на ближайший день недели
+// @Description	Переносит задачу на ближайший заданный день недели (sunday, monday, ..., saturday) после запланированной даты, просроченная задача переносится относительно текущей даты
+// @Produce		json
+// @Param 		id path int true "id задачи"
+// @Param		input body rescheduleToWeekdayRequest true "День недели"
+// @Success		200	{object} taskResponse "Успешный перенос"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных"
+// @Failure 	404 {object} taskResponse "Задача с заданным id не найдена"
+// @Router		/task/{id}/next_weekday [post]
+func rescheduleToWeekday(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		var req rescheduleToWeekdayRequest
+		if err = c.BindJSON(&req); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		weekday, ok := weekdays[strings.ToLower(req.Weekday)]
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		t, err := a.RescheduleToWeekday(c, id, weekday)
+		rescheduleResponse(c, t, err)
+	}
+}
+
+// @Summary		Перенос задачи на заданную дату
+// @Description	Переносит задачу в конец заданного дня, дата не может быть раньше текущей
+// @Produce		json
+// @Param 		id path int true "id задачи"
+// @Param		input body rescheduleTaskRequest true "Новая дата"
+// @Success		200	{object} taskResponse "Успешный перенос"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных"
+// @Failure 	404 {object} taskResponse "Задача с заданным id не найдена"
+// @Router		/task/{id}/reschedule [post]
+func rescheduleTask(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		var req rescheduleTaskRequest
+		if err = c.BindJSON(&req); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		t, err := a.RescheduleTask(c, id, model.Date{
+			Year:  req.PlanningDate.Year,
+			Month: time.Month(req.PlanningDate.Month),
+			Day:   req.PlanningDate.Day,
+		})
+		rescheduleResponse(c, t, err)
+	}
+}
+
+// @Summary		Перенос всех невыполненных задач дня
+// @Description	Переносит все невыполненные задачи даты from в конец даты to с сохранением их порядка и возвращает все задачи даты to
+// @Produce		json
+// @Param		input body rescheduleDayRequest true "Исходная и новая даты"
+// @Success		200	{object} tasksResponse "Успешный перенос"
+// @Failure		500	{object} taskResponse  "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse  "Неверный формат входных данных"
+// @Router		/task/by_date/reschedule [post]
+func rescheduleDay(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req rescheduleDayRequest
+		if err := c.BindJSON(&req); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		tasks, err := a.RescheduleDay(c, model.Date{
+			Year:  req.From.Year,
+			Month: time.Month(req.From.Month),
+			Day:   req.From.Day,
+		}, model.Date{
+			Year:  req.To.Year,
+			Month: time.Month(req.To.Month),
+			Day:   req.To.Day,
+		})
+
+		switch {
+		case errors.Is(err, model.ErrInvalidInput):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, tasksSuccessResponse(tasks))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package httpserver
 
 import (
+	"context"
 	"github.com/gin-gonic/gin"
+	"net"
 	"net/http"
 	"todo-list/internal/app"
 )
@@ -12,8 +14,24 @@ func New(addr string, a app.App) *http.Server {
 	router := gin.Default()
 	api := router.Group("todo-list/api")
 	appRouter(api, a)
-	return &http.Server{
+	web := router.Group(webPrefix)
+	webRouter(web, a)
+	caldav := router.Group(caldavPrefix)
+	caldavRouter(caldav, a)
+	for _, method := range []string{http.MethodGet, http.MethodHead, "PROPFIND"} {
+		router.Handle(method, "/.well-known/caldav", caldavWellKnown)
+	}
+
+	// streams of the events don't end by themselves, so their requests are
+	// cancelled when the server is shut down
+	ctx, cancel := context.WithCancel(context.Background())
+	srv := &http.Server{
 		Addr:    addr,
 		Handler: router,
+		BaseContext: func(net.Listener) context.Context {
+			return ctx
+		},
 	}
+	srv.RegisterOnShutdown(cancel)
+	return srv
 }
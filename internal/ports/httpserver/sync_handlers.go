@@ -0,0 +1,102 @@
+package httpserver
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+// @Summary		Получение изменений задач для офлайн клиента
+// @Description	Возвращает задачи, изменённые после выдачи токена синхронизации, и удалённые задачи (tombstones). Без токена или с устаревшим токеном возвращаются все задачи и reset = true, тогда клиент заменяет ими свою копию. Полученный token передаётся в следующем запросе
+// @Produce		json
+// @Param		token query string false "Токен синхронизации из предыдущего ответа"
+// @Success		200	{object} syncResponse "Успешное получение"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный токен синхронизации"
+// @Router		/sync [get]
+func getChanges(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		changes, err := a.GetChanges(c, c.Query("token"))
+
+		switch {
+		case errors.Is(err, model.ErrInvalidInput):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, syncSuccessResponse(changes, nil))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
+
+// @Summary		Синхронизация офлайн клиента
+// @Description	Возвращает изменения задач после токена синхронизации так же, как GET /sync, и затем применяет изменения клиента (не более 100) по порядку. Операция create добавляет задачу, повторная операция create с тем же ref от того же клиента client возвращает уже добавленную задачу, поэтому изменения можно отправить повторно, если ответ потерян. Операции update и delete применяются, только если версия задачи на сервере равна версии version, известной клиенту, иначе изменение не применяется и возвращается конфликт с текущей задачей (deleted = true, если задача удалена). Для каждого изменения возвращается результат applied, conflict или rejected с ref изменения. Идентификатор клиента и ref не длиннее 100 символов. Изменения клиента придут повторно при следующей синхронизации
+// @Accept		json
+// @Produce		json
+// @Param		input body syncRequest true "Токен синхронизации и изменения клиента"
+// @Success		200	{object} syncResponse "Успешная синхронизация"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных или токен синхронизации"
+// @Router		/sync [post]
+func syncChanges(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req syncRequest
+		if err := c.BindJSON(&req); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		// changes are read before the push, so the invalid token doesn't
+		// leave the pushed changes without results
+		changes, err := a.GetChanges(c, req.Token)
+
+		switch {
+		case errors.Is(err, model.ErrInvalidInput):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+			return
+		case err != nil:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+			return
+		}
+
+		pushed := make([]model.SyncChange, 0, len(req.Changes))
+		for _, ch := range req.Changes {
+			pushed = append(pushed, model.SyncChange{
+				Ref:     ch.Ref,
+				Op:      ch.Op,
+				TaskId:  ch.TaskId,
+				Version: ch.Version,
+				Task: model.TodoTask{
+					Title:       ch.Task.Title,
+					Description: ch.Task.Description,
+					PlanningDate: model.Date{
+						Year:  ch.Task.PlanningDate.Year,
+						Month: time.Month(ch.Task.PlanningDate.Month),
+						Day:   ch.Task.PlanningDate.Day,
+					},
+					Status: ch.Task.Status,
+					State:  ch.Task.State,
+				},
+			})
+		}
+
+		results, err := a.PushChanges(c, req.Client, pushed)
+
+		switch {
+		case errors.Is(err, model.ErrInvalidInput):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+		case err == nil:
+			c.JSON(http.StatusOK, syncSuccessResponse(changes, results))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
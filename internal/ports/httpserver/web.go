@@ -0,0 +1,196 @@
+package httpserver
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"html/template"
+	"net/http"
+	"strings"
+	"time"
+	"todo-list/internal/model"
+)
+
+const (
+	// webPrefix is a path of the web interface
+	webPrefix = "/todo-list/web"
+
+	// webPageSize is a number of tasks on the page of the list
+	webPageSize = 20
+
+	// csrfCookie is a cookie with the CSRF token of the browser, csrfField is
+	// a field of the form with the same token
+	csrfCookie = "csrf_token"
+	csrfField  = "csrf_token"
+)
+
+var errInvalidCSRFToken = errors.New("form is expired, reload the page and submit it again")
+
+//go:embed templates/*.html
+var templates embed.FS
+
+var webTemplates = map[string]*template.Template{
+	"list":  parsePage("list.html"),
+	"form":  parsePage("form.html"),
+	"error": parsePage("error.html"),
+}
+
+// parsePage parses the page with the common layout
+func parsePage(name string) *template.Template {
+	return template.Must(template.New(name).Funcs(template.FuncMap{
+		"date": formatDate,
+	}).ParseFS(templates, "templates/layout.html", "templates/"+name))
+}
+
+// today returns current date, planning dates are validated in UTC
+var today = func() model.Date {
+	year, month, day := time.Now().UTC().Date()
+	return model.Date{Year: year, Month: month, Day: day}
+}
+
+// formatDate formats the date as YYYY-MM-DD
+func formatDate(d model.Date) string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// parseDate parses date in format YYYY-MM-DD, invalid date is returned as
+// zero date, so it is reported by validation of the task
+func parseDate(s string) model.Date {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return model.Date{}
+	}
+	return model.Date{Year: t.Year(), Month: t.Month(), Day: t.Day()}
+}
+
+// taskForm is a form of adding and editing the task
+type taskForm struct {
+	Title        string
+	Description  string
+	PlanningDate string
+	Status       bool
+}
+
+// webPage is data of the page of the web interface
+type webPage struct {
+	Base      string
+	CSRFToken string
+	Title     string
+	Query     string
+
+	// list of the tasks, Back is the page returned to after changing the task
+	Tasks []model.TodoTask
+	Back  string
+	Prev  string
+	Next  string
+
+	// form of the task
+	Action string
+	Edit   bool
+	Form   taskForm
+	Errors []string
+
+	// error page
+	Message string
+}
+
+// renderPage writes the page with given status, the page is rendered to
+// the buffer first, so failed template doesn't leave half of the page
+func renderPage(c *gin.Context, status int, name string, page webPage) {
+	page.Base = webPrefix
+	page.CSRFToken = c.GetString(csrfCookie)
+
+	var b bytes.Buffer
+	if err := webTemplates[name].ExecuteTemplate(&b, "layout", page); err != nil {
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	c.Data(status, "text/html; charset=utf-8", b.Bytes())
+}
+
+// renderError writes the error page and aborts the request
+func renderError(c *gin.Context, status int, err error) {
+	renderPage(c, status, "error", webPage{Title: http.StatusText(status), Message: err.Error()})
+	c.Abort()
+}
+
+// validationMessages returns messages of the errors of validation of the
+// task joined into err by the app
+func validationMessages(err error) []string {
+	var messages []string
+	var walk func(err error)
+	walk = func(err error) {
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, e := range joined.Unwrap() {
+				walk(e)
+			}
+		} else if err != model.ErrInvalidTask {
+			messages = append(messages, err.Error())
+		}
+	}
+	walk(err)
+	return messages
+}
+
+// backPath returns the page of the web interface to return to, other
+// values are replaced with the today page, so they can't redirect outside
+func backPath(back string) string {
+	if !strings.HasPrefix(back, webPrefix+"/") || strings.ContainsAny(back, "\\\r\n") || strings.Contains(back, "//") {
+		return webPrefix + "/today"
+	}
+	return back
+}
+
+// newCSRFToken returns random token
+func newCSRFToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// csrfProtection issues the CSRF token to the browser in the cookie and
+// rejects forms which don't contain the same token. Other sites can't read
+// the cookie, so they can't submit the form on behalf of the user
+func csrfProtection() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("X-Frame-Options", "DENY")
+		c.Header("Content-Security-Policy", "default-src 'self'; style-src 'unsafe-inline'; form-action 'self'; frame-ancestors 'none'")
+
+		token, err := c.Cookie(csrfCookie)
+		if err != nil || len(token) != 64 {
+			if token, err = newCSRFToken(); err != nil {
+				c.AbortWithStatus(http.StatusInternalServerError)
+				return
+			}
+			http.SetCookie(c.Writer, &http.Cookie{
+				Name:     csrfCookie,
+				Value:    token,
+				Path:     webPrefix,
+				HttpOnly: true,
+				Secure:   c.Request.TLS != nil,
+				SameSite: http.SameSiteStrictMode,
+			})
+			if c.Request.Method == http.MethodPost { // new token can't be in the form
+				c.Set(csrfCookie, token)
+				renderError(c, http.StatusForbidden, errInvalidCSRFToken)
+				return
+			}
+		}
+		c.Set(csrfCookie, token)
+
+		if c.Request.Method == http.MethodPost {
+			if subtle.ConstantTimeCompare([]byte(c.PostForm(csrfField)), []byte(token)) != 1 {
+				renderError(c, http.StatusForbidden, errInvalidCSRFToken)
+				return
+			}
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,254 @@
+package httpserver
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+// webAppError writes the error page for the error of the app
+func webAppError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, model.ErrTaskNotFound):
+		renderError(c, http.StatusNotFound, model.ErrTaskNotFound)
+	case errors.Is(err, model.ErrInvalidTask):
+		renderError(c, http.StatusUnprocessableEntity, errors.New(strings.Join(validationMessages(err), "; ")))
+	case errors.Is(err, model.ErrTaskBlocked):
+		renderError(c, http.StatusConflict, model.ErrTaskBlocked)
+	case errors.Is(err, model.ErrTransition):
+		renderError(c, http.StatusConflict, model.ErrTransition)
+	case errors.Is(err, model.ErrTaskRepo):
+		renderError(c, http.StatusInternalServerError, model.ErrTaskRepo)
+	default:
+		renderError(c, http.StatusInternalServerError, model.ErrUnknown)
+	}
+}
+
+// taskOfForm returns the task filled from the form
+func taskOfForm(c *gin.Context) (model.TodoTask, taskForm) {
+	form := taskForm{
+		Title:        c.PostForm("title"),
+		Description:  c.PostForm("description"),
+		PlanningDate: c.PostForm("planning_date"),
+		Status:       c.PostForm("status") == "true",
+	}
+	return model.TodoTask{
+		Title:        form.Title,
+		Description:  form.Description,
+		PlanningDate: parseDate(form.PlanningDate),
+		Status:       form.Status,
+	}, form
+}
+
+// webTaskId returns id of the task from the path or writes 404 page
+func webTaskId(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		renderError(c, http.StatusNotFound, model.ErrTaskNotFound)
+		return 0, false
+	}
+	return id, true
+}
+
+func webIndex(c *gin.Context) {
+	c.Redirect(http.StatusSeeOther, webPrefix+"/today")
+}
+
+func webToday(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		date := today()
+		undone, err := a.GetTasksByDateAndStatus(c, date, false)
+		if err != nil {
+			webAppError(c, err)
+			return
+		}
+		done, err := a.GetTasksByDateAndStatus(c, date, true)
+		if err != nil {
+			webAppError(c, err)
+			return
+		}
+
+		renderPage(c, http.StatusOK, "list", webPage{
+			Title: "Today, " + formatDate(date),
+			Tasks: append(undone, done...),
+			Back:  webPrefix + "/today",
+		})
+	}
+}
+
+func webTasksByStatus(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		status := c.DefaultQuery("status", "undone")
+		if status != "done" && status != "undone" {
+			renderError(c, http.StatusBadRequest, model.ErrInvalidInput)
+			return
+		}
+		page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+		if err != nil || page < 1 {
+			renderError(c, http.StatusBadRequest, model.ErrInvalidInput)
+			return
+		}
+
+		// one more task shows if there is the next page
+		tasks, err := a.GetTasksByStatus(c, status == "done", (page-1)*webPageSize, webPageSize+1)
+		if err != nil {
+			webAppError(c, err)
+			return
+		}
+
+		link := func(page int) string {
+			return webPrefix + "/tasks?" + url.Values{"status": {status}, "page": {strconv.Itoa(page)}}.Encode()
+		}
+		p := webPage{Back: link(page)}
+		if status == "done" {
+			p.Title = "Done tasks"
+		} else {
+			p.Title = "Undone tasks"
+		}
+		if page > 1 {
+			p.Prev = link(page - 1)
+		}
+		if len(tasks) > webPageSize {
+			tasks, p.Next = tasks[:webPageSize], link(page+1)
+		}
+		p.Tasks = tasks
+		renderPage(c, http.StatusOK, "list", p)
+	}
+}
+
+func webSearch(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := c.Query("q")
+		p := webPage{
+			Title: "Search",
+			Query: query,
+			Back:  webPrefix + "/search?" + url.Values{"q": {query}}.Encode(),
+		}
+		if query != "" {
+			tasks, err := a.GetTaskByText(c, query)
+			if err != nil {
+				webAppError(c, err)
+				return
+			}
+			p.Title, p.Tasks = "Search: "+query, tasks
+		}
+		renderPage(c, http.StatusOK, "list", p)
+	}
+}
+
+func webNewTask(c *gin.Context) {
+	renderPage(c, http.StatusOK, "form", webPage{
+		Title:  "New task",
+		Action: webPrefix + "/tasks",
+		Form:   taskForm{PlanningDate: formatDate(today())},
+	})
+}
+
+func webAddTask(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		t, form := taskOfForm(c)
+		t.Status = false
+
+		_, err := a.AddTask(c, t)
+		switch {
+		case errors.Is(err, model.ErrInvalidTask):
+			renderPage(c, http.StatusUnprocessableEntity, "form", webPage{
+				Title:  "New task",
+				Action: webPrefix + "/tasks",
+				Form:   form,
+				Errors: validationMessages(err),
+			})
+		case err == nil:
+			c.Redirect(http.StatusSeeOther, webPrefix+"/today")
+		default:
+			webAppError(c, err)
+		}
+	}
+}
+
+func webEditTask(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := webTaskId(c)
+		if !ok {
+			return
+		}
+		t, err := a.GetTaskById(c, id)
+		if err != nil {
+			webAppError(c, err)
+			return
+		}
+
+		renderPage(c, http.StatusOK, "form", webPage{
+			Title:  "Edit task",
+			Action: webPrefix + "/tasks/" + strconv.Itoa(id),
+			Edit:   true,
+			Form: taskForm{
+				Title:        t.Title,
+				Description:  t.Description,
+				PlanningDate: formatDate(t.PlanningDate),
+				Status:       t.Status,
+			},
+		})
+	}
+}
+
+func webUpdateTask(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := webTaskId(c)
+		if !ok {
+			return
+		}
+		t, form := taskOfForm(c)
+
+		// state of the task is kept or chosen by the status
+		_, err := a.UpdateTask(c, id, t)
+
+		page := webPage{
+			Title:  "Edit task",
+			Action: webPrefix + "/tasks/" + strconv.Itoa(id),
+			Edit:   true,
+			Form:   form,
+		}
+		switch {
+		case errors.Is(err, model.ErrInvalidTask):
+			page.Errors = validationMessages(err)
+			renderPage(c, http.StatusUnprocessableEntity, "form", page)
+		case errors.Is(err, model.ErrTaskBlocked):
+			page.Errors = []string{model.ErrTaskBlocked.Error()}
+			renderPage(c, http.StatusConflict, "form", page)
+		case errors.Is(err, model.ErrTransition):
+			page.Errors = []string{model.ErrTransition.Error()}
+			renderPage(c, http.StatusConflict, "form", page)
+		case err == nil:
+			c.Redirect(http.StatusSeeOther, webPrefix+"/today")
+		default:
+			webAppError(c, err)
+		}
+	}
+}
+
+func webToggleTask(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := webTaskId(c)
+		if !ok {
+			return
+		}
+		t, err := a.GetTaskById(c, id)
+		if err != nil {
+			webAppError(c, err)
+			return
+		}
+
+		t.Status, t.State = !t.Status, ""
+		if _, err = a.UpdateTask(c, id, t); err != nil {
+			webAppError(c, err)
+			return
+		}
+		c.Redirect(http.StatusSeeOther, backPath(c.PostForm("back")))
+	}
+}
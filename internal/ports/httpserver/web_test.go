@@ -0,0 +1,173 @@
+package httpserver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+	"todo-list/internal/app"
+	"todo-list/internal/app/mocks"
+	"todo-list/internal/model"
+)
+
+// webClient is a browser with the CSRF cookie
+type webClient struct {
+	h     http.Handler
+	token string
+}
+
+func (b *webClient) get(path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, webPrefix+path, nil)
+	if b.token != "" {
+		req.AddCookie(&http.Cookie{Name: csrfCookie, Value: b.token})
+	}
+	w := httptest.NewRecorder()
+	b.h.ServeHTTP(w, req)
+	for _, c := range w.Result().Cookies() {
+		if c.Name == csrfCookie {
+			b.token = c.Value
+		}
+	}
+	return w
+}
+
+func (b *webClient) post(path string, form url.Values) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, webPrefix+path, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.AddCookie(&http.Cookie{Name: csrfCookie, Value: b.token})
+	w := httptest.NewRecorder()
+	b.h.ServeHTTP(w, req)
+	return w
+}
+
+func TestWeb(t *testing.T) {
+	tr, br := new(mocks.TaskRepo), new(mocks.BoardRepo)
+	a := app.New(app.Deps{Tasks: tr, Board: br}, app.Config{})
+	b := &webClient{h: New("", a).Handler}
+
+	date := model.Date{Year: 2027, Month: time.June, Day: 1}
+	today = func() model.Date { return date }
+	defer func() {
+		today = func() model.Date {
+			year, month, day := time.Now().UTC().Date()
+			return model.Date{Year: year, Month: month, Day: day}
+		}
+	}()
+
+	t.Run("today", func(t *testing.T) {
+		tr.On("GetTasksByDateAndStatus", mock.Anything, date, false).Return([]model.TodoTask{
+			{Id: 1131, Title: "<script>alert(1)</script>", PlanningDate: date, State: "todo"},
+		}, nil).Once()
+		tr.On("GetTasksByDateAndStatus", mock.Anything, date, true).Return([]model.TodoTask{
+			{Id: 1132, Title: "Finished", PlanningDate: date, Status: true, State: "done"},
+		}, nil).Once()
+
+		w := b.get("/today")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Len(t, b.token, 64)
+		body := w.Body.String()
+		assert.Contains(t, body, "Today, 2027-06-01")
+		assert.Contains(t, body, "&lt;script&gt;alert(1)&lt;/script&gt;")
+		assert.NotContains(t, body, "<script>")
+		assert.Contains(t, body, `<tr class="done">`)
+		assert.Contains(t, body, `name="csrf_token" value="`+b.token+`"`)
+		assert.Equal(t, "DENY", w.Header().Get("X-Frame-Options"))
+	})
+
+	t.Run("form without CSRF token", func(t *testing.T) {
+		w := b.post("/tasks", url.Values{"title": {"Forged"}, "planning_date": {"2027-06-01"}})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), errInvalidCSRFToken.Error())
+	})
+
+	t.Run("validation messages", func(t *testing.T) {
+		w := b.post("/tasks", url.Values{csrfField: {b.token}, "title": {""}, "planning_date": {"2027-02-30"}})
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		body := w.Body.String()
+		assert.Contains(t, body, "<li>no title of the task</li>")
+		assert.Contains(t, body, "<li>date is invalid</li>")
+		assert.Contains(t, body, `value="2027-02-30"`)
+	})
+
+	t.Run("add", func(t *testing.T) {
+		br.On("GetLastRank", mock.Anything, "todo").Return("", nil).Once()
+		tr.On("AddTask", mock.Anything, mock.MatchedBy(func(t model.TodoTask) bool {
+			return t.Title == "Deploy" && t.Description == "Release 2" && t.PlanningDate == date
+		})).Return(model.TodoTask{Id: 1133, Title: "Deploy", PlanningDate: date, State: "todo"}, nil).Once()
+
+		w := b.post("/tasks", url.Values{csrfField: {b.token}, "title": {"Deploy"}, "description": {"Release 2"}, "planning_date": {"2027-06-01"}})
+		assert.Equal(t, http.StatusSeeOther, w.Code)
+		assert.Equal(t, webPrefix+"/today", w.Header().Get("Location"))
+	})
+
+	t.Run("toggle returns to the list", func(t *testing.T) {
+		task := model.TodoTask{Id: 1134, Title: "Check", PlanningDate: date, State: "todo"}
+		tr.On("GetTaskById", mock.Anything, 1134).Return(task, nil).Times(4)
+		br.On("GetLastRank", mock.Anything, "done").Return("", nil).Twice()
+		tr.On("UpdateTask", mock.Anything, 1134, mock.MatchedBy(func(t model.TodoTask) bool {
+			return t.Status && t.State == "done"
+		})).Return(model.TodoTask{Id: 1134, Title: "Check", PlanningDate: date, Status: true, State: "done"}, nil).Twice()
+
+		w := b.post("/tasks/1134/toggle", url.Values{csrfField: {b.token}, "back": {webPrefix + "/tasks?status=undone&page=2"}})
+		assert.Equal(t, http.StatusSeeOther, w.Code)
+		assert.Equal(t, webPrefix+"/tasks?status=undone&page=2", w.Header().Get("Location"))
+
+		w = b.post("/tasks/1134/toggle", url.Values{csrfField: {b.token}, "back": {"https://example.com/"}})
+		assert.Equal(t, webPrefix+"/today", w.Header().Get("Location"))
+	})
+
+	t.Run("edit blocked task", func(t *testing.T) {
+		task := model.TodoTask{Id: 1135, Title: "Blocked", PlanningDate: date, State: "todo", Blocked: true}
+		tr.On("GetTaskById", mock.Anything, 1135).Return(task, nil).Twice()
+		br.On("GetLastRank", mock.Anything, "done").Return("", nil).Once()
+		tr.On("UpdateTask", mock.Anything, 1135, mock.Anything).Return(model.TodoTask{}, model.ErrTaskBlocked).Once()
+
+		w := b.get("/tasks/1135/edit")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `value="Blocked"`)
+
+		w = b.post("/tasks/1135", url.Values{csrfField: {b.token}, "title": {"Blocked"}, "planning_date": {"2027-06-01"}, "status": {"true"}})
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), model.ErrTaskBlocked.Error())
+	})
+
+	t.Run("pages of the list", func(t *testing.T) {
+		tasks := make([]model.TodoTask, webPageSize+1)
+		for i := range tasks {
+			tasks[i] = model.TodoTask{Id: 1136, Title: "Done", PlanningDate: date, Status: true}
+		}
+		tr.On("GetTasksByStatus", mock.Anything, true, webPageSize, webPageSize+1).Return(tasks, nil).Once()
+
+		w := b.get("/tasks?status=done&page=2")
+		assert.Equal(t, http.StatusOK, w.Code)
+		body := w.Body.String()
+		assert.Contains(t, body, `href="/todo-list/web/tasks?page=1&amp;status=done"`)
+		assert.Contains(t, body, `href="/todo-list/web/tasks?page=3&amp;status=done"`)
+		assert.Equal(t, webPageSize, strings.Count(body, "/tasks/1136/edit"))
+	})
+
+	t.Run("search", func(t *testing.T) {
+		tr.On("GetTaskByText", mock.Anything, "deploy").Return([]model.TodoTask{{Id: 1133, Title: "Deploy", PlanningDate: date}}, nil).Once()
+
+		w := b.get("/search?q=deploy")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "Search: deploy")
+		assert.Contains(t, w.Body.String(), `value="deploy"`)
+	})
+
+	t.Run("task not found", func(t *testing.T) {
+		tr.On("GetTaskById", mock.Anything, 1137).Return(model.TodoTask{}, model.ErrTaskNotFound).Once()
+
+		w := b.get("/tasks/1137/edit")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), model.ErrTaskNotFound.Error())
+	})
+
+	tr.AssertExpectations(t)
+	br.AssertExpectations(t)
+}
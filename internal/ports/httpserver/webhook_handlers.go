@@ -0,0 +1,139 @@
+package httpserver
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+// @Summary		Подписка webhook на события задач
+// @Description	Возвращает подписку вместе с секретом, которым подписываются события, секрет генерируется, если не указан
+// @Produce		json
+// @Param		input body addSubscriptionRequest true "URL, типы событий (task.created, task.updated, task.completed, task.deleted) и секрет"
+// @Success		200	{object} subscriptionResponse "Успешная подписка"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных"
+// @Router		/webhooks [post]
+func addSubscription(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req addSubscriptionRequest
+		if err := c.BindJSON(&req); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		sub, err := a.AddSubscription(c, model.Subscription{
+			URL:    req.URL,
+			Events: req.Events,
+			Secret: req.Secret,
+		})
+
+		switch {
+		case errors.Is(err, model.ErrInvalidSubscription):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidSubscription))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, subscriptionSuccessResponse(sub))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
+
+// @Summary		Получение подписок webhook
+// @Description	Возвращает все подписки без их секретов
+// @Produce		json
+// @Success		200	{object} subscriptionsResponse "Успешное получение"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Router		/webhooks [get]
+func getSubscriptions(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		subs, err := a.GetSubscriptions(c)
+
+		switch {
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, subscriptionsSuccessResponse(subs))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
+
+// @Summary		Удаление подписки webhook
+// @Description	Удаляет подписку вместе с историей доставки событий
+// @Produce		json
+// @Param 		id path int true "id подписки"
+// @Success		200	{object} taskResponse "Успешное удаление"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных"
+// @Failure 	404 {object} taskResponse "Подписка с заданным id не найдена"
+// @Router		/webhooks/{id} [delete]
+func deleteSubscription(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		err = a.DeleteSubscription(c, id)
+
+		switch {
+		case errors.Is(err, model.ErrSubscriptionNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(model.ErrSubscriptionNotFound))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, deleteSuccessResponse())
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
+
+// @Summary		Получение истории доставки событий webhook с пагинацией
+// @Description	Возвращает доставки событий от новых к старым с результатом последней попытки
+// @Produce		json
+// @Param		input body getDeliveriesBySubscriptionRequest true "Пагинация"
+// @Param 		id path int true "id подписки"
+// @Success		200	{object} webhookDeliveriesResponse "Успешное получение"
+// @Failure		500	{object} taskResponse "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse "Неверный формат входных данных"
+// @Failure 	404 {object} taskResponse "Подписка с заданным id не найдена"
+// @Router		/webhooks/{id}/deliveries [get]
+func getDeliveriesBySubscription(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		var req getDeliveriesBySubscriptionRequest
+		if err = c.BindJSON(&req); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		deliveries, err := a.GetDeliveriesBySubscription(c, id, req.Offset, req.Limit)
+
+		switch {
+		case errors.Is(err, model.ErrSubscriptionNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(model.ErrSubscriptionNotFound))
+		case errors.Is(err, model.ErrInvalidInput):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, webhookDeliveriesSuccessResponse(deliveries))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
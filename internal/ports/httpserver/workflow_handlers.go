@@ -0,0 +1,53 @@
+package httpserver
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+// @Summary		Получение состояний задач и переходов между ними
+// @Description	Возвращает состояния задач и разрешённые переходы между ними, если переходы не указаны, разрешены любые
+// @Produce		json
+// @Success		200	{object} workflowResponse "Успешное получение"
+// @Router		/workflow [get]
+func getWorkflow(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, workflowSuccessResponse(a.Workflow()))
+	}
+}
+
+// @Summary		Получение списка задач в заданном состоянии с пагинацией
+// @Description	Возвращает список задач в заданном состоянии
+// @Produce		json
+// @Param		input body getTasksByStateRequest true "Состояние и пагинация"
+// @Success		200	{object} tasksResponse "Успешное получение задач"
+// @Failure		500	{object} taskResponse  "Проблемы на стороне сервера"
+// @Failure 	400 {object} taskResponse  "Неверный формат входных данных или неизвестное состояние"
+// @Router		/task/by_state [get]
+func getTasksByState(a app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req getTasksByStateRequest
+		if err := c.BindJSON(&req); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+			return
+		}
+
+		tasks, err := a.GetTasksByState(c, req.State, req.Offset, req.Limit)
+
+		switch {
+		case errors.Is(err, model.ErrInvalidInput):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrInvalidInput))
+		case errors.Is(err, model.ErrUnknownState):
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(model.ErrUnknownState))
+		case errors.Is(err, model.ErrTaskRepo):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrTaskRepo))
+		case err == nil:
+			c.JSON(http.StatusOK, tasksSuccessResponse(tasks))
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(model.ErrUnknown))
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+const (
+	addAttachmentQuery = `
+		INSERT INTO attachments (task_id, name, content_type, size, blob_key)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at;`
+
+	getAttachmentByIdQuery = `
+		SELECT id, task_id, name, content_type, size, blob_key, created_at FROM attachments
+		WHERE id = $1;`
+
+	getAttachmentsByTaskQuery = `
+		SELECT id, task_id, name, content_type, size, blob_key, created_at FROM attachments
+		WHERE task_id = $1
+		ORDER BY id;`
+
+	deleteAttachmentQuery = `
+		DELETE FROM attachments
+		WHERE id = $1;`
+)
+
+type attachmentRepo struct {
+	*pgxpool.Pool
+}
+
+// scanAttachment reads all columns of the attachments table from the row into the attachment
+func scanAttachment(row pgx.Row) (model.Attachment, error) {
+	var at model.Attachment
+	if err := row.Scan(&at.Id, &at.TaskId, &at.Name, &at.ContentType, &at.Size, &at.Key, &at.CreatedAt); err != nil {
+		return model.Attachment{}, err
+	}
+	at.CreatedAt = at.CreatedAt.UTC()
+	return at, nil
+}
+
+func (r *attachmentRepo) AddAttachment(ctx context.Context, at model.Attachment) (model.Attachment, error) {
+	err := r.QueryRow(ctx, addAttachmentQuery, at.TaskId, at.Name, at.ContentType, at.Size, at.Key).Scan(&at.Id, &at.CreatedAt)
+	if err != nil {
+		return model.Attachment{}, errors.Join(model.ErrTaskRepo, err)
+	}
+	at.CreatedAt = at.CreatedAt.UTC()
+	return at, nil
+}
+
+func (r *attachmentRepo) GetAttachmentById(ctx context.Context, id int) (model.Attachment, error) {
+	at, err := scanAttachment(r.QueryRow(ctx, getAttachmentByIdQuery, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Attachment{}, model.ErrAttachmentNotFound
+	} else if err != nil {
+		return model.Attachment{}, errors.Join(model.ErrTaskRepo, err)
+	} else {
+		return at, nil
+	}
+}
+
+func (r *attachmentRepo) GetAttachmentsByTask(ctx context.Context, taskId int) ([]model.Attachment, error) {
+	rows, err := r.Query(ctx, getAttachmentsByTaskQuery, taskId)
+	if err != nil {
+		return nil, errors.Join(model.ErrTaskRepo, err)
+	}
+	defer rows.Close()
+
+	attachments := make([]model.Attachment, 0)
+	for rows.Next() {
+		at, err := scanAttachment(rows)
+		if err != nil {
+			return nil, errors.Join(model.ErrTaskRepo, err)
+		}
+		attachments = append(attachments, at)
+	}
+	return attachments, nil
+}
+
+func (r *attachmentRepo) DeleteAttachment(ctx context.Context, id int) error {
+	e, err := r.Exec(ctx, deleteAttachmentQuery, id)
+	if err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	} else if e.RowsAffected() == 0 {
+		return model.ErrAttachmentNotFound
+	} else {
+		return nil
+	}
+}
+
+// NewAttachmentRepo creates repository of attachments which works with given pool of connections
+func NewAttachmentRepo(pool *pgxpool.Pool) app.AttachmentRepo {
+	return &attachmentRepo{
+		Pool: pool,
+	}
+}
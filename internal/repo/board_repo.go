@@ -0,0 +1,128 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+const (
+	getLastRankQuery = `
+		SELECT COALESCE(MAX(rank), '')
+		FROM tasks
+		WHERE state = $1;`
+
+	getRankNeighboursQuery = `
+		SELECT prev, rank, next FROM (
+		    SELECT id, rank,
+		           LAG(rank, 1, '') OVER w AS prev,
+		           LEAD(rank, 1, '') OVER w AS next
+		    FROM tasks
+		    WHERE state = $3 AND id <> $2
+		    WINDOW w AS (ORDER BY rank, id)
+		) column_tasks
+		WHERE id = $1;`
+
+	moveTaskQuery = `
+		UPDATE tasks
+		SET state = $2,
+		    status = $3,
+		    rank = $4
+		WHERE id = $1;`
+
+	setRankQuery = `
+		UPDATE tasks
+		SET rank = $2
+		WHERE id = $1;`
+)
+
+type boardRepo struct {
+	*pgxpool.Pool
+}
+
+func (r *boardRepo) GetLastRank(ctx context.Context, state string) (string, error) {
+	var rank string
+	if err := r.QueryRow(ctx, getLastRankQuery, state).Scan(&rank); err != nil {
+		return "", errors.Join(model.ErrTaskRepo, err)
+	}
+	return rank, nil
+}
+
+func (r *boardRepo) GetRankNeighbours(ctx context.Context, id int, exceptId int, state string) (string, string, string, error) {
+	var prev, rank, next string
+	err := r.QueryRow(ctx, getRankNeighboursQuery, id, exceptId, state).Scan(&prev, &rank, &next)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", "", "", model.ErrTaskNotFound
+	} else if err != nil {
+		return "", "", "", errors.Join(model.ErrTaskRepo, err)
+	}
+	return prev, rank, next, nil
+}
+
+func (r *boardRepo) MoveTask(ctx context.Context, id int, state string, status bool, rank string) (model.TodoTask, error) {
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return model.TodoTask{}, errors.Join(model.ErrTaskRepo, err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	// blockers are checked after the task is locked, so they can't be added
+	// before the task is moved
+	current, err := lockTask(ctx, tx, id)
+	if err != nil {
+		return model.TodoTask{}, err
+	} else if status && !current.Status && current.Blocked {
+		return model.TodoTask{}, model.ErrTaskBlocked
+	}
+
+	if _, err = tx.Exec(ctx, moveTaskQuery, id, state, status, rank); err != nil {
+		return model.TodoTask{}, errors.Join(model.ErrTaskRepo, err)
+	}
+	if err = writeUpdates(ctx, tx, []int{id}, func(model.TodoTask) model.TodoTask { return current }); err != nil {
+		return model.TodoTask{}, errors.Join(model.ErrTaskRepo, err)
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return model.TodoTask{}, errors.Join(model.ErrTaskRepo, err)
+	}
+
+	t, err := scanTask(r.QueryRow(ctx, getTaskByIdQuery, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.TodoTask{}, model.ErrTaskNotFound
+	} else if err != nil {
+		return model.TodoTask{}, errors.Join(model.ErrTaskRepo, err)
+	}
+	return t, nil
+}
+
+func (r *boardRepo) SetRanks(ctx context.Context, ranks map[int]string) error {
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	for id, rank := range ranks {
+		if _, err = tx.Exec(ctx, setRankQuery, id, rank); err != nil {
+			return errors.Join(model.ErrTaskRepo, err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	}
+	return nil
+}
+
+// NewBoardRepo creates repository of positions of the tasks on the board which works with given pool of connections
+func NewBoardRepo(pool *pgxpool.Pool) app.BoardRepo {
+	return &boardRepo{
+		Pool: pool,
+	}
+}
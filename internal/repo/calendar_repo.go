@@ -0,0 +1,100 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+const (
+	addObjectQuery = `
+		INSERT INTO calendar_objects (owner, name, uid, task_id)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (owner, name) DO NOTHING;`
+
+	getObjectByNameQuery = `
+		SELECT owner, name, uid, task_id FROM calendar_objects
+		WHERE owner = $1 AND name = $2;`
+
+	getObjectsByUserQuery = `
+		SELECT owner, name, uid, task_id FROM calendar_objects
+		WHERE owner = $1
+		ORDER BY task_id;`
+
+	deleteObjectQuery = `
+		DELETE FROM calendar_objects
+		WHERE owner = $1 AND name = $2;`
+)
+
+type calendarRepo struct {
+	*pgxpool.Pool
+}
+
+// scanObject reads all columns of the calendar_objects table from the row into the object
+func scanObject(row pgx.Row) (model.CalendarObject, error) {
+	var o model.CalendarObject
+	if err := row.Scan(&o.User, &o.Name, &o.UID, &o.Task.Id); err != nil {
+		return model.CalendarObject{}, err
+	}
+	return o, nil
+}
+
+func (r *calendarRepo) AddObject(ctx context.Context, o model.CalendarObject) error {
+	tag, err := r.Exec(ctx, addObjectQuery, o.User, o.Name, o.UID, o.Task.Id)
+	if err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	} else if tag.RowsAffected() == 0 {
+		return model.ErrObjectExists
+	}
+	return nil
+}
+
+func (r *calendarRepo) GetObjectByName(ctx context.Context, user string, name string) (model.CalendarObject, error) {
+	o, err := scanObject(r.QueryRow(ctx, getObjectByNameQuery, user, name))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.CalendarObject{}, model.ErrObjectNotFound
+	} else if err != nil {
+		return model.CalendarObject{}, errors.Join(model.ErrTaskRepo, err)
+	} else {
+		return o, nil
+	}
+}
+
+func (r *calendarRepo) GetObjectsByUser(ctx context.Context, user string) ([]model.CalendarObject, error) {
+	rows, err := r.Query(ctx, getObjectsByUserQuery, user)
+	if err != nil {
+		return nil, errors.Join(model.ErrTaskRepo, err)
+	}
+	defer rows.Close()
+
+	objects := make([]model.CalendarObject, 0)
+	for rows.Next() {
+		o, err := scanObject(rows)
+		if err != nil {
+			return nil, errors.Join(model.ErrTaskRepo, err)
+		}
+		objects = append(objects, o)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Join(model.ErrTaskRepo, err)
+	}
+	return objects, nil
+}
+
+func (r *calendarRepo) DeleteObject(ctx context.Context, user string, name string) error {
+	if _, err := r.Exec(ctx, deleteObjectQuery, user, name); err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	}
+	return nil
+}
+
+// NewCalendarRepo creates repository of the objects of the CalDAV calendars
+// which works with given pool of connections
+func NewCalendarRepo(pool *pgxpool.Pool) app.CalendarRepo {
+	return &calendarRepo{
+		Pool: pool,
+	}
+}
@@ -0,0 +1,119 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+const (
+	addCommentQuery = `
+		INSERT INTO comments (task_id, author, text)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at, updated_at;`
+
+	getCommentByIdQuery = `
+		SELECT id, task_id, author, text, created_at, updated_at FROM comments
+		WHERE id = $1;`
+
+	updateCommentQuery = `
+		UPDATE comments
+		SET text = $2,
+		    updated_at = now()
+		WHERE id = $1
+		RETURNING id, task_id, author, text, created_at, updated_at;`
+
+	deleteCommentQuery = `
+		DELETE FROM comments
+		WHERE id = $1;`
+
+	getCommentsByTaskQuery = `
+		SELECT id, task_id, author, text, created_at, updated_at FROM comments
+		WHERE task_id = $1
+		ORDER BY id
+		OFFSET $2 LIMIT $3;`
+)
+
+type commentRepo struct {
+	*pgxpool.Pool
+}
+
+// scanComment reads all columns of the comments table from the row into the comment
+func scanComment(row pgx.Row) (model.Comment, error) {
+	var c model.Comment
+	if err := row.Scan(&c.Id, &c.TaskId, &c.Author, &c.Text, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		return model.Comment{}, err
+	}
+	c.CreatedAt, c.UpdatedAt = c.CreatedAt.UTC(), c.UpdatedAt.UTC()
+	return c, nil
+}
+
+func (r *commentRepo) AddComment(ctx context.Context, c model.Comment) (model.Comment, error) {
+	err := r.QueryRow(ctx, addCommentQuery, c.TaskId, c.Author, c.Text).Scan(&c.Id, &c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		return model.Comment{}, errors.Join(model.ErrTaskRepo, err)
+	}
+	c.CreatedAt, c.UpdatedAt = c.CreatedAt.UTC(), c.UpdatedAt.UTC()
+	return c, nil
+}
+
+func (r *commentRepo) GetCommentById(ctx context.Context, id int) (model.Comment, error) {
+	c, err := scanComment(r.QueryRow(ctx, getCommentByIdQuery, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Comment{}, model.ErrCommentNotFound
+	} else if err != nil {
+		return model.Comment{}, errors.Join(model.ErrTaskRepo, err)
+	} else {
+		return c, nil
+	}
+}
+
+func (r *commentRepo) UpdateComment(ctx context.Context, id int, text string) (model.Comment, error) {
+	c, err := scanComment(r.QueryRow(ctx, updateCommentQuery, id, text))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Comment{}, model.ErrCommentNotFound
+	} else if err != nil {
+		return model.Comment{}, errors.Join(model.ErrTaskRepo, err)
+	} else {
+		return c, nil
+	}
+}
+
+func (r *commentRepo) DeleteComment(ctx context.Context, id int) error {
+	e, err := r.Exec(ctx, deleteCommentQuery, id)
+	if err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	} else if e.RowsAffected() == 0 {
+		return model.ErrCommentNotFound
+	} else {
+		return nil
+	}
+}
+
+func (r *commentRepo) GetCommentsByTask(ctx context.Context, taskId int, offset int, limit int) ([]model.Comment, error) {
+	rows, err := r.Query(ctx, getCommentsByTaskQuery, taskId, offset, limit)
+	if err != nil {
+		return nil, errors.Join(model.ErrTaskRepo, err)
+	}
+	defer rows.Close()
+
+	comments := make([]model.Comment, 0)
+	for rows.Next() {
+		c, err := scanComment(rows)
+		if err != nil {
+			return nil, errors.Join(model.ErrTaskRepo, err)
+		}
+		comments = append(comments, c)
+	}
+	return comments, nil
+}
+
+// NewCommentRepo creates repository of comments which works with given pool of connections
+func NewCommentRepo(pool *pgxpool.Pool) app.CommentRepo {
+	return &commentRepo{
+		Pool: pool,
+	}
+}
@@ -0,0 +1,69 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+const (
+	// setCredentialQuery replaces the hash of the credential of the same
+	// kind, so the previous secret stops working
+	setCredentialQuery = `
+		INSERT INTO calendar_credentials (user_name, kind, hash)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_name, kind) DO UPDATE
+		SET hash = EXCLUDED.hash, created_at = now();`
+
+	getCredentialQuery = `
+		SELECT hash FROM calendar_credentials
+		WHERE user_name = $1 AND kind = $2;`
+
+	deleteCredentialQuery = `
+		DELETE FROM calendar_credentials
+		WHERE user_name = $1 AND kind = $2;`
+)
+
+type credentialRepo struct {
+	*pgxpool.Pool
+}
+
+func (r *credentialRepo) SetCredential(ctx context.Context, user string, kind string, hash []byte) error {
+	if _, err := r.Exec(ctx, setCredentialQuery, user, kind, hash); err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	}
+	return nil
+}
+
+func (r *credentialRepo) GetCredential(ctx context.Context, user string, kind string) ([]byte, error) {
+	var hash []byte
+	err := r.QueryRow(ctx, getCredentialQuery, user, kind).Scan(&hash)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, model.ErrCredentialNotFound
+	} else if err != nil {
+		return nil, errors.Join(model.ErrTaskRepo, err)
+	} else {
+		return hash, nil
+	}
+}
+
+func (r *credentialRepo) DeleteCredential(ctx context.Context, user string, kind string) error {
+	e, err := r.Exec(ctx, deleteCredentialQuery, user, kind)
+	if err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	} else if e.RowsAffected() == 0 {
+		return model.ErrCredentialNotFound
+	} else {
+		return nil
+	}
+}
+
+// NewCredentialRepo creates repository of hashed calendar credentials of the users which works with given pool of connections
+func NewCredentialRepo(pool *pgxpool.Pool) app.CredentialRepo {
+	return &credentialRepo{
+		Pool: pool,
+	}
+}
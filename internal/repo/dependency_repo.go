@@ -0,0 +1,111 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+// dependencyLockKey is a key of the transaction level advisory lock which
+// serializes adding of links between tasks, so concurrent links can't make a cycle
+const dependencyLockKey = 29
+
+const (
+	lockDependenciesQuery = `SELECT pg_advisory_xact_lock($1);`
+
+	// blocksQuery returns true if the first task transitively blocks the second
+	blocksQuery = `
+		WITH RECURSIVE down AS (
+		    SELECT blocked_id FROM task_dependencies
+		    WHERE blocker_id = $1
+		    UNION
+		    SELECT d.blocked_id FROM task_dependencies d
+		    JOIN down ON d.blocker_id = down.blocked_id
+		)
+		SELECT EXISTS (SELECT 1 FROM down WHERE blocked_id = $2);`
+
+	addDependencyQuery = `
+		INSERT INTO task_dependencies (blocker_id, blocked_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING;`
+
+	deleteDependencyQuery = `
+		DELETE FROM task_dependencies
+		WHERE blocker_id = $1 AND blocked_id = $2;`
+)
+
+type dependencyRepo struct {
+	*pgxpool.Pool
+}
+
+// AddDependency checks new link for a cycle under the lock in the same
+// transaction as the link is added, update of the blocked task is written to
+// the outbox if the link is new
+func (r *dependencyRepo) AddDependency(ctx context.Context, blockerId int, blockedId int) error {
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if _, err = tx.Exec(ctx, lockDependenciesQuery, dependencyLockKey); err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	}
+	var cycle bool
+	if err = tx.QueryRow(ctx, blocksQuery, blockedId, blockerId).Scan(&cycle); err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	} else if cycle {
+		return model.ErrDependencyCycle
+	}
+
+	e, err := tx.Exec(ctx, addDependencyQuery, blockerId, blockedId)
+	if err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	}
+	if e.RowsAffected() > 0 {
+		if err = writeUpdates(ctx, tx, []int{blockedId}, nil); err != nil {
+			return errors.Join(model.ErrTaskRepo, err)
+		}
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	}
+	return nil
+}
+
+// DeleteDependency removes the link and writes update of the blocked task to
+// the outbox in the same transaction if the link existed
+func (r *dependencyRepo) DeleteDependency(ctx context.Context, blockerId int, blockedId int) error {
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	e, err := tx.Exec(ctx, deleteDependencyQuery, blockerId, blockedId)
+	if err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	}
+	if e.RowsAffected() > 0 {
+		if err = writeUpdates(ctx, tx, []int{blockedId}, nil); err != nil {
+			return errors.Join(model.ErrTaskRepo, err)
+		}
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	}
+	return nil
+}
+
+// NewDependencyRepo creates repository of links between tasks which works with given pool of connections
+func NewDependencyRepo(pool *pgxpool.Pool) app.DependencyRepo {
+	return &dependencyRepo{
+		Pool: pool,
+	}
+}
@@ -0,0 +1,152 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
+	"todo-list/internal/app"
+	"todo-list/internal/events"
+	"todo-list/internal/model"
+)
+
+const (
+	addEventQuery = `
+		INSERT INTO outbox (key, event, task_id, payload)
+		VALUES ($1, $2, $3, $4);`
+
+	// claimEventsQuery skips events locked by another server, so every
+	// event is published by one of them
+	claimEventsQuery = `
+		WITH due AS (
+		    SELECT id FROM outbox
+		    WHERE published_at IS NULL AND next_attempt_at <= $1
+		    ORDER BY id
+		    LIMIT $3
+		    FOR UPDATE SKIP LOCKED
+		), claimed AS (
+		    UPDATE outbox o
+		    SET attempts = o.attempts + 1, next_attempt_at = $2
+		    FROM due
+		    WHERE o.id = due.id
+		    RETURNING o.id, o.key, o.event, o.task_id, o.payload, o.attempts, o.created_at
+		)
+		SELECT * FROM claimed
+		ORDER BY id;`
+
+	markPublishedQuery = `
+		UPDATE outbox
+		SET published_at = $2, error = ''
+		WHERE id = $1;`
+
+	retryEventQuery = `
+		UPDATE outbox
+		SET error = $2, next_attempt_at = $3
+		WHERE id = $1;`
+
+	purgeEventsQuery = `
+		DELETE FROM outbox
+		WHERE published_at < $1;`
+)
+
+// writeEvent adds event about the task to the outbox in the transaction
+// which has changed the task, so the event is published only if the change
+// is committed. prev is the task before the change or nil if it is created
+// or deleted
+func writeEvent(ctx context.Context, tx pgx.Tx, event string, t model.TodoTask, prev *model.TodoTask) error {
+	e, err := events.New(event, t, prev)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, addEventQuery, e.Key, e.Type, e.TaskId, e.Payload)
+	return err
+}
+
+// writeUpdates reads the tasks with given ids changed in the transaction and
+// adds events about their update to the outbox. prev returns the task before
+// the change by the changed task, it is nil if the change keeps the task in
+// the same day, assignees and project. Completion is added for the tasks
+// which were not done before the change and are done now
+func writeUpdates(ctx context.Context, tx pgx.Tx, ids []int, prev func(model.TodoTask) model.TodoTask) error {
+	rows, err := tx.Query(ctx, getTasksByIdsQuery, ids)
+	if err != nil {
+		return err
+	}
+	tasks, err := scanTasks(rows)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range tasks {
+		var before *model.TodoTask
+		if prev != nil {
+			p := prev(t)
+			before = &p
+		}
+		if err = writeEvent(ctx, tx, model.EventTaskUpdated, t, before); err != nil {
+			return err
+		}
+		if before != nil && !before.Status && t.Status {
+			if err = writeEvent(ctx, tx, model.EventTaskCompleted, t, before); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type outboxRepo struct {
+	*pgxpool.Pool
+}
+
+func (r *outboxRepo) ClaimEvents(ctx context.Context, now time.Time, until time.Time, limit int) ([]model.Event, error) {
+	rows, err := r.Query(ctx, claimEventsQuery, now, until, limit)
+	if err != nil {
+		return nil, errors.Join(model.ErrTaskRepo, err)
+	}
+	defer rows.Close()
+
+	claimed := make([]model.Event, 0)
+	for rows.Next() {
+		var e model.Event
+		if err = rows.Scan(&e.Id, &e.Key, &e.Type, &e.TaskId, &e.Payload, &e.Attempts, &e.CreatedAt); err != nil {
+			return nil, errors.Join(model.ErrTaskRepo, err)
+		}
+		e.CreatedAt = e.CreatedAt.UTC()
+		claimed = append(claimed, e)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Join(model.ErrTaskRepo, err)
+	}
+	return claimed, nil
+}
+
+func (r *outboxRepo) MarkPublished(ctx context.Context, id int, at time.Time) error {
+	if _, err := r.Exec(ctx, markPublishedQuery, id, at); err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	}
+	return nil
+}
+
+func (r *outboxRepo) RetryEvent(ctx context.Context, id int, errText string, nextAttemptAt time.Time) error {
+	if _, err := r.Exec(ctx, retryEventQuery, id, errText, nextAttemptAt); err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	}
+	return nil
+}
+
+func (r *outboxRepo) PurgeEvents(ctx context.Context, before time.Time) (int, error) {
+	e, err := r.Exec(ctx, purgeEventsQuery, before)
+	if err != nil {
+		return 0, errors.Join(model.ErrTaskRepo, err)
+	}
+	return int(e.RowsAffected()), nil
+}
+
+// NewOutboxRepo creates repository of the events about changes of the tasks which works with given pool of connections
+func NewOutboxRepo(pool *pgxpool.Pool) app.OutboxRepo {
+	return &outboxRepo{
+		Pool: pool,
+	}
+}
@@ -0,0 +1,100 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+const (
+	getTasksByDateQuery = selectTasks + `
+		WHERE planning_date = $1
+		ORDER BY position, id;`
+
+	lockDayQuery = `
+		SELECT id FROM tasks
+		WHERE planning_date = $1
+		FOR UPDATE;`
+
+	reorderDayQuery = `
+		UPDATE tasks
+		SET position = o.position
+		FROM unnest($2::INTEGER[]) WITH ORDINALITY AS o(id, position)
+		WHERE tasks.id = o.id AND tasks.planning_date = $1;`
+)
+
+type planRepo struct {
+	*pgxpool.Pool
+}
+
+func (r *planRepo) GetTasksByDate(ctx context.Context, date model.Date) ([]model.TodoTask, error) {
+	rows, err := r.Query(ctx, getTasksByDateQuery,
+		fmt.Sprintf("%d-%d-%d", date.Year, date.Month, date.Day))
+	if err != nil {
+		return nil, errors.Join(model.ErrTaskRepo, err)
+	}
+	return scanTasks(rows)
+}
+
+func (r *planRepo) ReorderDay(ctx context.Context, date model.Date, ids []int) error {
+	d := fmt.Sprintf("%d-%d-%d", date.Year, date.Month, date.Day)
+
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	// tasks of the day are locked, so they can't be moved to another day or
+	// deleted until the new order is saved. Row locks don't block inserts, a
+	// task planned on the day concurrently keeps its position after the others
+	rows, err := tx.Query(ctx, lockDayQuery, d)
+	if err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	}
+	planned := make(map[int]struct{})
+	for rows.Next() {
+		var id int
+		if err = rows.Scan(&id); err != nil {
+			rows.Close()
+			return errors.Join(model.ErrTaskRepo, err)
+		}
+		planned[id] = struct{}{}
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	}
+
+	if len(planned) != len(ids) {
+		return model.ErrDayOrder
+	}
+	for _, id := range ids {
+		if _, ok := planned[id]; !ok {
+			return model.ErrDayOrder
+		}
+	}
+
+	if _, err = tx.Exec(ctx, reorderDayQuery, d, ids); err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	}
+	if err = writeUpdates(ctx, tx, ids, nil); err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	}
+	return nil
+}
+
+// NewPlanRepo creates repository of the order of the tasks planned on the same day which works with given pool of connections
+func NewPlanRepo(pool *pgxpool.Pool) app.PlanRepo {
+	return &planRepo{
+		Pool: pool,
+	}
+}
@@ -0,0 +1,221 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+const (
+	// fireAt is a time of the next attempt to deliver the reminder r of the
+	// task t, relative reminders fire at the offset from midnight of the
+	// planning date in UTC
+	fireAt = `COALESCE(r.retry_at, r.remind_at, t.planning_date::TIMESTAMP AT TIME ZONE 'UTC' + r.offset_seconds * INTERVAL '1 second')`
+
+	// reminderColumns are columns read by scanReminder from the reminder r
+	// joined with its task t
+	reminderColumns = `
+		r.id, r.task_id, r.remind_at, r.offset_seconds, r.channel, r.recipient, r.status, r.attempts, ` + fireAt + `, r.created_at`
+
+	selectReminders = `
+		SELECT` + reminderColumns + `
+		FROM reminders r JOIN tasks t ON t.id = r.task_id`
+
+	addReminderQuery = `
+		WITH r AS (
+			INSERT INTO reminders (task_id, remind_at, offset_seconds, channel, recipient)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING *
+		)
+		SELECT` + reminderColumns + `
+		FROM r JOIN tasks t ON t.id = r.task_id;`
+
+	getReminderByIdQuery = selectReminders + `
+		WHERE r.id = $1;`
+
+	getRemindersByTaskQuery = selectReminders + `
+		WHERE r.task_id = $1
+		ORDER BY r.id;`
+
+	deleteReminderQuery = `
+		DELETE FROM reminders
+		WHERE id = $1;`
+
+	// claimDueRemindersQuery skips reminders locked by another server, so
+	// every reminder is delivered by one of them
+	claimDueRemindersQuery = `
+		WITH due AS (
+			SELECT r.id FROM reminders r JOIN tasks t ON t.id = r.task_id
+			WHERE r.status = 'pending' AND NOT t.status AND ` + fireAt + ` <= $1
+			ORDER BY ` + fireAt + `, r.id
+			LIMIT $3
+			FOR UPDATE OF r SKIP LOCKED
+		)
+		UPDATE reminders r
+		SET attempts = r.attempts + 1, retry_at = $2
+		FROM due, tasks t
+		WHERE r.id = due.id AND t.id = r.task_id
+		RETURNING` + reminderColumns + `;`
+
+	addDeliveryQuery = `
+		INSERT INTO reminder_deliveries (reminder_id, attempt, error)
+		VALUES ($1, $2, $3);`
+
+	setReminderStatusQuery = `
+		UPDATE reminders
+		SET status = $2, retry_at = $3
+		WHERE id = $1;`
+
+	getDeliveriesByReminderQuery = `
+		SELECT id, reminder_id, attempt, error, created_at FROM reminder_deliveries
+		WHERE reminder_id = $1
+		ORDER BY id
+		OFFSET $2
+		LIMIT $3;`
+)
+
+type reminderRepo struct {
+	*pgxpool.Pool
+}
+
+// scanReminder reads reminderColumns from the row into the reminder
+func scanReminder(row pgx.Row) (model.Reminder, error) {
+	var r model.Reminder
+	var at *time.Time
+	var offset int64
+	if err := row.Scan(&r.Id, &r.TaskId, &at, &offset, &r.Channel, &r.Recipient, &r.Status, &r.Attempts, &r.FireAt, &r.CreatedAt); err != nil {
+		return model.Reminder{}, err
+	}
+	if at != nil {
+		r.At = at.UTC()
+	}
+	r.Offset = time.Duration(offset) * time.Second
+	r.FireAt = r.FireAt.UTC()
+	r.CreatedAt = r.CreatedAt.UTC()
+	return r, nil
+}
+
+// scanReminders reads all reminders from the rows and closes them
+func scanReminders(rows pgx.Rows) ([]model.Reminder, error) {
+	defer rows.Close()
+
+	reminders := make([]model.Reminder, 0)
+	for rows.Next() {
+		r, err := scanReminder(rows)
+		if err != nil {
+			return nil, errors.Join(model.ErrTaskRepo, err)
+		}
+		reminders = append(reminders, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(model.ErrTaskRepo, err)
+	}
+	return reminders, nil
+}
+
+func (r *reminderRepo) AddReminder(ctx context.Context, rem model.Reminder) (model.Reminder, error) {
+	var at *time.Time
+	if !rem.At.IsZero() {
+		at = &rem.At
+	}
+
+	rem, err := scanReminder(r.QueryRow(ctx, addReminderQuery,
+		rem.TaskId, at, int64(rem.Offset/time.Second), rem.Channel, rem.Recipient))
+	if err != nil {
+		return model.Reminder{}, errors.Join(model.ErrTaskRepo, err)
+	}
+	return rem, nil
+}
+
+func (r *reminderRepo) GetReminderById(ctx context.Context, id int) (model.Reminder, error) {
+	rem, err := scanReminder(r.QueryRow(ctx, getReminderByIdQuery, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Reminder{}, model.ErrReminderNotFound
+	} else if err != nil {
+		return model.Reminder{}, errors.Join(model.ErrTaskRepo, err)
+	} else {
+		return rem, nil
+	}
+}
+
+func (r *reminderRepo) GetRemindersByTask(ctx context.Context, taskId int) ([]model.Reminder, error) {
+	rows, err := r.Query(ctx, getRemindersByTaskQuery, taskId)
+	if err != nil {
+		return nil, errors.Join(model.ErrTaskRepo, err)
+	}
+	return scanReminders(rows)
+}
+
+func (r *reminderRepo) DeleteReminder(ctx context.Context, id int) error {
+	e, err := r.Exec(ctx, deleteReminderQuery, id)
+	if err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	} else if e.RowsAffected() == 0 {
+		return model.ErrReminderNotFound
+	} else {
+		return nil
+	}
+}
+
+func (r *reminderRepo) ClaimDueReminders(ctx context.Context, now time.Time, until time.Time, limit int) ([]model.Reminder, error) {
+	rows, err := r.Query(ctx, claimDueRemindersQuery, now, until, limit)
+	if err != nil {
+		return nil, errors.Join(model.ErrTaskRepo, err)
+	}
+	return scanReminders(rows)
+}
+
+func (r *reminderRepo) RecordDelivery(ctx context.Context, d model.Delivery, status string, retryAt time.Time) error {
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if _, err = tx.Exec(ctx, addDeliveryQuery, d.ReminderId, d.Attempt, d.Error); err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	}
+
+	if e, err := tx.Exec(ctx, setReminderStatusQuery, d.ReminderId, status, retryAt); err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	} else if e.RowsAffected() == 0 {
+		return model.ErrReminderNotFound
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	}
+	return nil
+}
+
+func (r *reminderRepo) GetDeliveriesByReminder(ctx context.Context, reminderId int, offset int, limit int) ([]model.Delivery, error) {
+	rows, err := r.Query(ctx, getDeliveriesByReminderQuery, reminderId, offset, limit)
+	if err != nil {
+		return nil, errors.Join(model.ErrTaskRepo, err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]model.Delivery, 0)
+	for rows.Next() {
+		var d model.Delivery
+		if err = rows.Scan(&d.Id, &d.ReminderId, &d.Attempt, &d.Error, &d.CreatedAt); err != nil {
+			return nil, errors.Join(model.ErrTaskRepo, err)
+		}
+		d.CreatedAt = d.CreatedAt.UTC()
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, nil
+}
+
+// NewReminderRepo creates repository of reminders and their deliveries which works with given pool of connections
+func NewReminderRepo(pool *pgxpool.Pool) app.ReminderRepo {
+	return &reminderRepo{
+		Pool: pool,
+	}
+}
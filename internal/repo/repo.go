@@ -5,75 +5,315 @@ import (
 	"errors"
 	"fmt"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"slices"
 	"time"
 	"todo-list/internal/app"
 	"todo-list/internal/model"
 )
 
 const (
+	// taskColumns are columns of the tasks table in order of scanTask
+	taskColumns = `
+		id, title, description, planning_date, status, assignees, COALESCE(project, ''), state, rank, position, overdue, postponed, version`
+
+	// blockedColumn calculates if task has blockers which are not done
+	blockedColumn = `
+		EXISTS (
+		    SELECT 1 FROM task_dependencies d
+		    JOIN tasks b ON b.id = d.blocker_id
+		    WHERE d.blocked_id = tasks.id AND NOT b.status
+		) AS blocked`
+
+	selectTasks = `
+		SELECT` + taskColumns + `,` + blockedColumn + `
+		FROM tasks`
+
 	addTaskQuery = `
-		INSERT INTO tasks (title, description, planning_date, status)
-		VALUES ($1, $2, $3, $4)
-		RETURNING id;`
+		INSERT INTO tasks (title, description, planning_date, status, project, state, rank, assignees, position)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, COALESCE($8::VARCHAR(50)[], '{}'), (
+		    SELECT COALESCE(MAX(position), 0) + 1 FROM tasks WHERE planning_date = $3
+		))
+		RETURNING id, assignees, position, version;`
 
-	getTaskByIdQuery = `
-		SELECT * FROM tasks
+	getTaskByIdQuery = selectTasks + `
 		WHERE id = $1;`
 
-	getTaskByTextQuery = `
-		SELECT * FROM tasks
+	getTaskByTextQuery = selectTasks + `
 		WHERE (title ILIKE $1 OR description ILIKE $1);`
 
+	// lockTaskQuery locks the task until the end of the transaction
+	lockTaskQuery = selectTasks + `
+		WHERE id = $1
+		FOR UPDATE;`
+
 	updateTaskQuery = `
 		UPDATE tasks
 		SET title = $2,
 		    description = $3,
 		    planning_date = $4,
-		    status = $5
-		WHERE id = $1;`
+		    status = $5,
+		    state = $6,
+		    rank = $7,
+		    position = CASE
+		        WHEN planning_date = $4 THEN position
+		        ELSE (SELECT COALESCE(MAX(position), 0) + 1 FROM tasks WHERE planning_date = $4)
+		    END,
+		    overdue = overdue AND planning_date = $4,
+		    postponed = postponed + CASE WHEN planning_date < $4 THEN 1 ELSE 0 END
+		WHERE id = $1 AND ($8 = 0 OR version = $8)
+		RETURNING assignees, COALESCE(project, ''), position, overdue, postponed, version,` + blockedColumn + `;`
 
+	// deleteTaskQuery returns the deleted task in order of scanTask, it can't
+	// be blocked since its links are deleted with it
 	deleteTaskQuery = `
 		DELETE FROM tasks
-		WHERE id = $1;`
+		WHERE id = $1
+		RETURNING` + taskColumns + `, false AS blocked;`
 
-	getTasksByStatusQuery = `
-		SELECT * FROM tasks
+	getTasksByStatusQuery = selectTasks + `
 		WHERE status = $1
+		ORDER BY id
+		OFFSET $2 LIMIT $3;`
+
+	getTasksByStateQuery = selectTasks + `
+		WHERE state = $1
+		ORDER BY rank, id
+		OFFSET $2 LIMIT $3;`
+
+	getTasksByDateAndStatusQuery = selectTasks + `
+		WHERE planning_date = $1 AND status = $2
+		ORDER BY position, id;`
+
+	assignTaskQuery = `
+		UPDATE tasks
+		SET assignees = array_append(assignees, $2)
+		WHERE id = $1 AND NOT ($2 = ANY(assignees));`
+
+	unassignTaskQuery = `
+		UPDATE tasks
+		SET assignees = array_remove(assignees, $2)
+		WHERE id = $1 AND $2 = ANY(assignees);`
+
+	getTasksByAssigneeQuery = selectTasks + `
+		WHERE $1 = ANY(assignees)
+		ORDER BY id
 		OFFSET $2 LIMIT $3;`
 
-	getTasksByDateAndStatusQuery = `
-		SELECT * FROM tasks
-		WHERE planning_date = $1 AND status = $2;`
+	setTaskProjectQuery = `
+		UPDATE tasks
+		SET project = NULLIF($2, '')
+		WHERE id = $1;`
+
+	addProjectQuery = `
+		INSERT INTO projects (name, members)
+		VALUES ($1, $2)
+		ON CONFLICT (name) DO NOTHING
+		RETURNING name, members;`
+
+	getProjectQuery = `
+		SELECT name, members FROM projects
+		WHERE name = $1;`
+
+	getProjectsQuery = `
+		SELECT name, members FROM projects
+		ORDER BY name;`
+
+	// lockProjectQuery keeps members of the project from being removed until
+	// the end of the transaction
+	lockProjectQuery = `
+		SELECT members FROM projects
+		WHERE name = $1
+		FOR SHARE;`
+
+	addProjectMemberQuery = `
+		UPDATE projects
+		SET members = array_append(members, $2)
+		WHERE name = $1 AND NOT ($2 = ANY(members))
+		RETURNING name, members;`
+
+	// removeProjectMemberQuery must run after the project is locked, so the
+	// member is not assigned to its tasks in the meantime
+	removeProjectMemberQuery = `
+		UPDATE projects
+		SET members = array_remove(members, $2)
+		WHERE name = $1 AND NOT EXISTS (
+		    SELECT 1 FROM tasks
+		    WHERE project = $1 AND $2 = ANY(assignees)
+		)
+		RETURNING name, members;`
+
+	lockProjectForUpdateQuery = `
+		SELECT members FROM projects
+		WHERE name = $1
+		FOR UPDATE;`
+	// getDependenciesQuery returns links between tasks which transitively
+	// block the task (up) or are blocked by it (down)
+	getDependenciesQuery = `
+		WITH RECURSIVE up AS (
+		    SELECT blocker_id, blocked_id FROM task_dependencies
+		    WHERE blocked_id = $1
+		    UNION
+		    SELECT d.blocker_id, d.blocked_id FROM task_dependencies d
+		    JOIN up ON d.blocked_id = up.blocker_id
+		), down AS (
+		    SELECT blocker_id, blocked_id FROM task_dependencies
+		    WHERE blocker_id = $1
+		    UNION
+		    SELECT d.blocker_id, d.blocked_id FROM task_dependencies d
+		    JOIN down ON d.blocker_id = down.blocked_id
+		)
+		SELECT blocker_id, blocked_id FROM up
+		UNION
+		SELECT blocker_id, blocked_id FROM down
+		ORDER BY blocker_id, blocked_id;`
+
+	getOverdueTasksQuery = selectTasks + `
+		WHERE overdue AND NOT status
+		ORDER BY planning_date, position, id
+		OFFSET $1 LIMIT $2;`
+
+	getTasksByIdsQuery = selectTasks + `
+		WHERE id = ANY($1)
+		ORDER BY id;`
 )
 
 type repo struct {
-	pgx.Conn
+	*pgxpool.Pool
 }
 
-func (r *repo) AddTask(ctx context.Context, t model.TodoTask) (model.TodoTask, error) {
-	var id int
-	err := r.QueryRow(ctx, addTaskQuery,
+// scanTask reads all columns of the tasks table from the row into the task
+func scanTask(row pgx.Row) (model.TodoTask, error) {
+	var t model.TodoTask
+	var d time.Time
+	if err := row.Scan(&t.Id, &t.Title, &t.Description, &d, &t.Status, &t.Assignees, &t.Project, &t.State, &t.Rank, &t.Position, &t.Overdue, &t.Postponed, &t.Version, &t.Blocked); err != nil {
+		return model.TodoTask{}, err
+	}
+	t.PlanningDate.Year, t.PlanningDate.Month, t.PlanningDate.Day = d.UTC().Date()
+	return t, nil
+}
+
+// scanTasks reads all rows of the tasks table and closes them
+func scanTasks(rows pgx.Rows) ([]model.TodoTask, error) {
+	defer rows.Close()
+
+	tasks := make([]model.TodoTask, 0)
+	for rows.Next() {
+		t, err := scanTask(rows)
+		if err != nil {
+			return nil, errors.Join(model.ErrTaskRepo, err)
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks, nil
+}
+
+// lockTask locks the task with given id until the end of the transaction
+// and returns it
+func lockTask(ctx context.Context, tx pgx.Tx, id int) (model.TodoTask, error) {
+	t, err := scanTask(tx.QueryRow(ctx, lockTaskQuery, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.TodoTask{}, model.ErrTaskNotFound
+	} else if err != nil {
+		return model.TodoTask{}, errors.Join(model.ErrTaskRepo, err)
+	}
+	return t, nil
+}
+
+// addTask inserts the task in the transaction and writes its creation to the outbox
+func addTask(ctx context.Context, tx pgx.Tx, t model.TodoTask) (model.TodoTask, error) {
+	if t.Project != "" {
+		if err := checkProject(ctx, tx, t.Project, t.Assignees); err != nil {
+			return model.TodoTask{}, err
+		}
+	}
+
+	err := tx.QueryRow(ctx, addTaskQuery,
 		t.Title,
 		t.Description,
 		fmt.Sprintf("%d-%d-%d", t.PlanningDate.Year, t.PlanningDate.Month, t.PlanningDate.Day),
-		t.Status).Scan(&id)
+		t.Status,
+		t.Project,
+		t.State,
+		t.Rank,
+		t.Assignees).Scan(&t.Id, &t.Assignees, &t.Position, &t.Version)
 	if err != nil {
 		return model.TodoTask{}, errors.Join(model.ErrTaskRepo, err)
 	}
-	t.Id = id
+	if err = writeEvent(ctx, tx, model.EventTaskCreated, t, nil); err != nil {
+		return model.TodoTask{}, errors.Join(model.ErrTaskRepo, err)
+	}
 	return t, nil
 }
 
+// checkProject checks that the project with given name exists and all
+// assignees are its members. The project is locked until the end of the
+// transaction, so its members can't be removed before the task is changed
+func checkProject(ctx context.Context, tx pgx.Tx, project string, assignees []string) error {
+	var members []string
+	err := tx.QueryRow(ctx, lockProjectQuery, project).Scan(&members)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.ErrProjectNotFound
+	} else if err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	}
+
+	for _, assignee := range assignees {
+		if !slices.Contains(members, assignee) {
+			return model.ErrNotMember
+		}
+	}
+	return nil
+}
+
+func (r *repo) AddTask(ctx context.Context, t model.TodoTask) (model.TodoTask, error) {
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return model.TodoTask{}, errors.Join(model.ErrTaskRepo, err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if t, err = addTask(ctx, tx, t); err != nil {
+		return model.TodoTask{}, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return model.TodoTask{}, errors.Join(model.ErrTaskRepo, err)
+	}
+	return t, nil
+}
+
+func (r *repo) AddTasks(ctx context.Context, tasks []model.TodoTask) ([]model.TodoTask, error) {
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return nil, errors.Join(model.ErrTaskRepo, err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	added := make([]model.TodoTask, 0, len(tasks))
+	for _, t := range tasks {
+		if t, err = addTask(ctx, tx, t); err != nil {
+			return nil, err
+		}
+		added = append(added, t)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Join(model.ErrTaskRepo, err)
+	}
+	return added, nil
+}
+
 func (r *repo) GetTaskById(ctx context.Context, id int) (model.TodoTask, error) {
-	row := r.QueryRow(ctx, getTaskByIdQuery, id)
-	var t model.TodoTask
-	var d time.Time
-	if err := row.Scan(&t.Id, &t.Title, &t.Description, &d, &t.Status); errors.Is(err, pgx.ErrNoRows) {
+	t, err := scanTask(r.QueryRow(ctx, getTaskByIdQuery, id))
+	if errors.Is(err, pgx.ErrNoRows) {
 		return model.TodoTask{}, model.ErrTaskNotFound
 	} else if err != nil {
 		return model.TodoTask{}, errors.Join(model.ErrTaskRepo, err)
 	} else {
-		t.PlanningDate.Year, t.PlanningDate.Month, t.PlanningDate.Day = d.UTC().Date()
 		return t, nil
 	}
 }
@@ -83,50 +323,99 @@ func (r *repo) GetTaskByText(ctx context.Context, text string) ([]model.TodoTask
 	if err != nil {
 		return nil, errors.Join(model.ErrTaskRepo, err)
 	}
-	defer rows.Close()
+	return scanTasks(rows)
+}
 
-	tasks := make([]model.TodoTask, 0)
-	for rows.Next() {
-		var t model.TodoTask
-		var d time.Time
-		_ = rows.Scan(&t.Id, &t.Title, &t.Description, &d, &t.Status)
-		t.PlanningDate.Year, t.PlanningDate.Month, t.PlanningDate.Day = d.UTC().Date()
-		tasks = append(tasks, t)
+// updateTask updates fields of the task after it is locked, so the check of
+// its blockers can't race with concurrent changes of the task. Task can't be
+// marked as done until all its blockers are done unless force is set, task
+// which is already done may be edited anyway
+func (r *repo) updateTask(ctx context.Context, id int, t model.TodoTask, force bool) (model.TodoTask, error) {
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return model.TodoTask{}, errors.Join(model.ErrTaskRepo, err)
 	}
-	return tasks, nil
-}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
 
-func (r *repo) UpdateTask(ctx context.Context, id int, t model.TodoTask) (model.TodoTask, error) {
-	e, err := r.Exec(ctx, updateTaskQuery,
+	current, err := lockTask(ctx, tx, id)
+	if err != nil {
+		return model.TodoTask{}, err
+	} else if !force && t.Status && !current.Status && current.Blocked {
+		return model.TodoTask{}, model.ErrTaskBlocked
+	}
+
+	updated := model.TodoTask{
+		Id:           id,
+		Title:        t.Title,
+		Description:  t.Description,
+		PlanningDate: t.PlanningDate,
+		Status:       t.Status,
+		State:        t.State,
+		Rank:         t.Rank,
+	}
+	err = tx.QueryRow(ctx, updateTaskQuery,
 		id,
 		t.Title,
 		t.Description,
 		fmt.Sprintf("%d-%d-%d", t.PlanningDate.Year, t.PlanningDate.Month, t.PlanningDate.Day),
-		t.Status)
-	if err != nil {
+		t.Status,
+		t.State,
+		t.Rank,
+		t.Version).Scan(&updated.Assignees, &updated.Project, &updated.Position, &updated.Overdue, &updated.Postponed, &updated.Version, &updated.Blocked)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// task is locked, so it exists but has another version
+		return model.TodoTask{}, model.ErrVersionConflict
+	} else if err != nil {
 		return model.TodoTask{}, errors.Join(model.ErrTaskRepo, err)
-	} else if e.RowsAffected() == 0 {
-		return model.TodoTask{}, model.ErrTaskNotFound
-	} else {
-		return model.TodoTask{
-			Id:           id,
-			Title:        t.Title,
-			Description:  t.Description,
-			PlanningDate: t.PlanningDate,
-			Status:       t.Status,
-		}, nil
 	}
+
+	if err = writeEvent(ctx, tx, model.EventTaskUpdated, updated, &current); err != nil {
+		return model.TodoTask{}, errors.Join(model.ErrTaskRepo, err)
+	}
+	if updated.Status && !current.Status {
+		if err = writeEvent(ctx, tx, model.EventTaskCompleted, updated, &current); err != nil {
+			return model.TodoTask{}, errors.Join(model.ErrTaskRepo, err)
+		}
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return model.TodoTask{}, errors.Join(model.ErrTaskRepo, err)
+	}
+	return updated, nil
+}
+
+func (r *repo) UpdateTask(ctx context.Context, id int, t model.TodoTask) (model.TodoTask, error) {
+	return r.updateTask(ctx, id, t, false)
+}
+
+func (r *repo) ForceUpdateTask(ctx context.Context, id int, t model.TodoTask) (model.TodoTask, error) {
+	return r.updateTask(ctx, id, t, true)
 }
 
 func (r *repo) DeleteTask(ctx context.Context, id int) error {
-	e, err := r.Exec(ctx, deleteTaskQuery, id)
+	tx, err := r.Begin(ctx)
 	if err != nil {
 		return errors.Join(model.ErrTaskRepo, err)
-	} else if e.RowsAffected() == 0 {
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	t, err := scanTask(tx.QueryRow(ctx, deleteTaskQuery, id))
+	if errors.Is(err, pgx.ErrNoRows) {
 		return model.ErrTaskNotFound
-	} else {
-		return nil
+	} else if err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	}
+
+	if err = writeEvent(ctx, tx, model.EventTaskDeleted, t, nil); err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
 	}
+	return nil
 }
 
 func (r *repo) GetTasksByStatus(ctx context.Context, status bool, offset int, limit int) ([]model.TodoTask, error) {
@@ -134,17 +423,15 @@ func (r *repo) GetTasksByStatus(ctx context.Context, status bool, offset int, li
 	if err != nil {
 		return nil, errors.Join(model.ErrTaskRepo, err)
 	}
-	defer rows.Close()
+	return scanTasks(rows)
+}
 
-	tasks := make([]model.TodoTask, 0)
-	for rows.Next() {
-		var t model.TodoTask
-		var d time.Time
-		_ = rows.Scan(&t.Id, &t.Title, &t.Description, &d, &t.Status)
-		t.PlanningDate.Year, t.PlanningDate.Month, t.PlanningDate.Day = d.UTC().Date()
-		tasks = append(tasks, t)
+func (r *repo) GetTasksByState(ctx context.Context, state string, offset int, limit int) ([]model.TodoTask, error) {
+	rows, err := r.Query(ctx, getTasksByStateQuery, state, offset, limit)
+	if err != nil {
+		return nil, errors.Join(model.ErrTaskRepo, err)
 	}
-	return tasks, nil
+	return scanTasks(rows)
 }
 
 func (r *repo) GetTasksByDateAndStatus(ctx context.Context, date model.Date, status bool) ([]model.TodoTask, error) {
@@ -154,21 +441,254 @@ func (r *repo) GetTasksByDateAndStatus(ctx context.Context, date model.Date, sta
 	if err != nil {
 		return nil, errors.Join(model.ErrTaskRepo, err)
 	}
+	return scanTasks(rows)
+}
+
+// AssignTask checks the assignee against members of the project of the task
+// after the task is locked and writes update of the task to the outbox if
+// the assignee is new
+func (r *repo) AssignTask(ctx context.Context, id int, assignee string) (model.TodoTask, error) {
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return model.TodoTask{}, errors.Join(model.ErrTaskRepo, err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	t, err := lockTask(ctx, tx, id)
+	if err != nil {
+		return model.TodoTask{}, err
+	}
+	if t.Project != "" {
+		if err = checkProject(ctx, tx, t.Project, []string{assignee}); err != nil {
+			return model.TodoTask{}, err
+		}
+	}
+
+	e, err := tx.Exec(ctx, assignTaskQuery, id, assignee)
+	if err != nil {
+		return model.TodoTask{}, errors.Join(model.ErrTaskRepo, err)
+	}
+	if e.RowsAffected() > 0 {
+		if err = writeUpdates(ctx, tx, []int{id}, func(model.TodoTask) model.TodoTask { return t }); err != nil {
+			return model.TodoTask{}, errors.Join(model.ErrTaskRepo, err)
+		}
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return model.TodoTask{}, errors.Join(model.ErrTaskRepo, err)
+	}
+	return r.GetTaskById(ctx, id)
+}
+
+// UnassignTask writes update of the task to the outbox in the same
+// transaction if the assignee is removed
+func (r *repo) UnassignTask(ctx context.Context, id int, assignee string) (model.TodoTask, error) {
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return model.TodoTask{}, errors.Join(model.ErrTaskRepo, err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	t, err := lockTask(ctx, tx, id)
+	if err != nil {
+		return model.TodoTask{}, err
+	}
+
+	e, err := tx.Exec(ctx, unassignTaskQuery, id, assignee)
+	if err != nil {
+		return model.TodoTask{}, errors.Join(model.ErrTaskRepo, err)
+	}
+	if e.RowsAffected() > 0 {
+		if err = writeUpdates(ctx, tx, []int{id}, func(model.TodoTask) model.TodoTask { return t }); err != nil {
+			return model.TodoTask{}, errors.Join(model.ErrTaskRepo, err)
+		}
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return model.TodoTask{}, errors.Join(model.ErrTaskRepo, err)
+	}
+	return r.GetTaskById(ctx, id)
+}
+
+func (r *repo) GetTasksByAssignee(ctx context.Context, assignee string, offset int, limit int) ([]model.TodoTask, error) {
+	rows, err := r.Query(ctx, getTasksByAssigneeQuery, assignee, offset, limit)
+	if err != nil {
+		return nil, errors.Join(model.ErrTaskRepo, err)
+	}
+	return scanTasks(rows)
+}
+
+func (r *repo) SetTaskProject(ctx context.Context, id int, project string) (model.TodoTask, error) {
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return model.TodoTask{}, errors.Join(model.ErrTaskRepo, err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	t, err := lockTask(ctx, tx, id)
+	if err != nil {
+		return model.TodoTask{}, err
+	}
+	if project != "" {
+		if err = checkProject(ctx, tx, project, t.Assignees); err != nil {
+			return model.TodoTask{}, err
+		}
+	}
+
+	if _, err = tx.Exec(ctx, setTaskProjectQuery, id, project); err != nil {
+		return model.TodoTask{}, errors.Join(model.ErrTaskRepo, err)
+	}
+	if t.Project != project {
+		if err = writeUpdates(ctx, tx, []int{id}, func(model.TodoTask) model.TodoTask { return t }); err != nil {
+			return model.TodoTask{}, errors.Join(model.ErrTaskRepo, err)
+		}
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return model.TodoTask{}, errors.Join(model.ErrTaskRepo, err)
+	}
+	t.Project = project
+	return t, nil
+}
+
+// scanProject reads name and members of the project from the row
+func scanProject(row pgx.Row) (model.Project, error) {
+	var p model.Project
+	if err := row.Scan(&p.Name, &p.Members); err != nil {
+		return model.Project{}, err
+	}
+	return p, nil
+}
+
+func (r *repo) AddProject(ctx context.Context, p model.Project) (model.Project, error) {
+	p, err := scanProject(r.QueryRow(ctx, addProjectQuery, p.Name, p.Members))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Project{}, model.ErrProjectExists
+	} else if err != nil {
+		return model.Project{}, errors.Join(model.ErrTaskRepo, err)
+	} else {
+		return p, nil
+	}
+}
+
+func (r *repo) GetProject(ctx context.Context, name string) (model.Project, error) {
+	p, err := scanProject(r.QueryRow(ctx, getProjectQuery, name))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Project{}, model.ErrProjectNotFound
+	} else if err != nil {
+		return model.Project{}, errors.Join(model.ErrTaskRepo, err)
+	} else {
+		return p, nil
+	}
+}
+
+func (r *repo) GetProjects(ctx context.Context) ([]model.Project, error) {
+	rows, err := r.Query(ctx, getProjectsQuery)
+	if err != nil {
+		return nil, errors.Join(model.ErrTaskRepo, err)
+	}
 	defer rows.Close()
 
-	tasks := make([]model.TodoTask, 0)
+	projects := make([]model.Project, 0)
 	for rows.Next() {
-		var t model.TodoTask
-		var d time.Time
-		_ = rows.Scan(&t.Id, &t.Title, &t.Description, &d, &t.Status)
-		t.PlanningDate.Year, t.PlanningDate.Month, t.PlanningDate.Day = d.UTC().Date()
-		tasks = append(tasks, t)
+		p, err := scanProject(rows)
+		if err != nil {
+			return nil, errors.Join(model.ErrTaskRepo, err)
+		}
+		projects = append(projects, p)
 	}
-	return tasks, nil
+	if err = rows.Err(); err != nil {
+		return nil, errors.Join(model.ErrTaskRepo, err)
+	}
+	return projects, nil
+}
+
+func (r *repo) AddProjectMember(ctx context.Context, name string, member string) (model.Project, error) {
+	p, err := scanProject(r.QueryRow(ctx, addProjectMemberQuery, name, member))
+	if errors.Is(err, pgx.ErrNoRows) { // project doesn't exist or member is already added
+		return r.GetProject(ctx, name)
+	} else if err != nil {
+		return model.Project{}, errors.Join(model.ErrTaskRepo, err)
+	} else {
+		return p, nil
+	}
+}
+
+func (r *repo) RemoveProjectMember(ctx context.Context, name string, member string) (model.Project, error) {
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return model.Project{}, errors.Join(model.ErrTaskRepo, err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	var members []string
+	err = tx.QueryRow(ctx, lockProjectForUpdateQuery, name).Scan(&members)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Project{}, model.ErrProjectNotFound
+	} else if err != nil {
+		return model.Project{}, errors.Join(model.ErrTaskRepo, err)
+	} else if !slices.Contains(members, member) {
+		return model.Project{Name: name, Members: members}, nil
+	}
+
+	p, err := scanProject(tx.QueryRow(ctx, removeProjectMemberQuery, name, member))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Project{}, model.ErrMemberAssigned
+	} else if err != nil {
+		return model.Project{}, errors.Join(model.ErrTaskRepo, err)
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return model.Project{}, errors.Join(model.ErrTaskRepo, err)
+	}
+	return p, nil
+}
+
+func (r *repo) GetOverdueTasks(ctx context.Context, offset int, limit int) ([]model.TodoTask, error) {
+	rows, err := r.Query(ctx, getOverdueTasksQuery, offset, limit)
+	if err != nil {
+		return nil, errors.Join(model.ErrTaskRepo, err)
+	}
+	return scanTasks(rows)
+}
+
+func (r *repo) GetDependencyGraph(ctx context.Context, id int) (model.DependencyGraph, error) {
+	rows, err := r.Query(ctx, getDependenciesQuery, id)
+	if err != nil {
+		return model.DependencyGraph{}, errors.Join(model.ErrTaskRepo, err)
+	}
+
+	graph := model.DependencyGraph{
+		Dependencies: make([]model.Dependency, 0),
+	}
+	ids := []int{id}
+	for rows.Next() {
+		var d model.Dependency
+		if err = rows.Scan(&d.BlockerId, &d.BlockedId); err != nil {
+			rows.Close()
+			return model.DependencyGraph{}, errors.Join(model.ErrTaskRepo, err)
+		}
+		graph.Dependencies = append(graph.Dependencies, d)
+		ids = append(ids, d.BlockerId, d.BlockedId)
+	}
+	rows.Close()
+
+	rows, err = r.Query(ctx, getTasksByIdsQuery, ids)
+	if err != nil {
+		return model.DependencyGraph{}, errors.Join(model.ErrTaskRepo, err)
+	}
+	if graph.Tasks, err = scanTasks(rows); err != nil {
+		return model.DependencyGraph{}, err
+	}
+	return graph, nil
 }
 
-func New(conn *pgx.Conn) app.TaskRepo {
+func New(pool *pgxpool.Pool) app.TaskRepo {
 	return &repo{
-		Conn: *conn,
+		Pool: pool,
 	}
 }
@@ -0,0 +1,248 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+const (
+	// moveOverdueQuery moves undone tasks planned before $1 to the end of the
+	// day $1 keeping their order and writes each move to the history
+	moveOverdueQuery = `
+		WITH late AS (
+		    SELECT id, planning_date,
+		           ROW_NUMBER() OVER (ORDER BY planning_date, position, id) AS n
+		    FROM tasks
+		    WHERE planning_date < $1 AND NOT status
+		), moved AS (
+		    UPDATE tasks
+		    SET planning_date = $1,
+		        overdue = true,
+		        postponed = postponed + 1,
+		        position = late.n + (
+		            SELECT COALESCE(MAX(position), 0) FROM tasks WHERE planning_date = $1
+		        )
+		    FROM late
+		    WHERE tasks.id = late.id
+		    RETURNING tasks.id, late.planning_date AS from_date
+		)
+		INSERT INTO task_history (task_id, action, from_date, to_date)
+		SELECT id, $2, from_date, $1 FROM moved
+		RETURNING id, task_id, action, from_date, to_date, created_at;`
+
+	// rescheduleTaskQuery moves the task $1 to the end of the day $2 and
+	// writes the move to the history
+	rescheduleTaskQuery = `
+		WITH old AS (
+		    SELECT id, planning_date
+		    FROM tasks
+		    WHERE id = $1 AND planning_date <> $2
+		    FOR UPDATE
+		), moved AS (
+		    UPDATE tasks
+		    SET planning_date = $2,
+		        overdue = false,
+		        postponed = postponed + CASE WHEN old.planning_date < $2 THEN 1 ELSE 0 END,
+		        position = (
+		            SELECT COALESCE(MAX(position), 0) + 1 FROM tasks WHERE planning_date = $2
+		        )
+		    FROM old
+		    WHERE tasks.id = old.id
+		    RETURNING tasks.id, old.planning_date AS from_date
+		)
+		INSERT INTO task_history (task_id, action, from_date, to_date)
+		SELECT id, $3, from_date, $2 FROM moved
+		RETURNING id, task_id, action, from_date, to_date, created_at;`
+
+	// rescheduleDayQuery moves undone tasks of the day $1 to the end of the
+	// day $2 keeping their order and writes each move to the history
+	rescheduleDayQuery = `
+		WITH old AS (
+		    SELECT id, planning_date,
+		           ROW_NUMBER() OVER (ORDER BY position, id) AS n
+		    FROM tasks
+		    WHERE planning_date = $1 AND NOT status
+		), moved AS (
+		    UPDATE tasks
+		    SET planning_date = $2,
+		        overdue = false,
+		        postponed = postponed + CASE WHEN old.planning_date < $2 THEN 1 ELSE 0 END,
+		        position = old.n + (
+		            SELECT COALESCE(MAX(position), 0) FROM tasks WHERE planning_date = $2
+		        )
+		    FROM old
+		    WHERE tasks.id = old.id
+		    RETURNING tasks.id, old.planning_date AS from_date
+		)
+		INSERT INTO task_history (task_id, action, from_date, to_date)
+		SELECT id, $3, from_date, $2 FROM moved
+		RETURNING id, task_id, action, from_date, to_date, created_at;`
+
+	flagOverdueQuery = `
+		UPDATE tasks
+		SET overdue = true
+		WHERE planning_date < $1 AND NOT status AND NOT overdue
+		RETURNING id;`
+
+	getHistoryByTaskQuery = `
+		SELECT id, task_id, action, from_date, to_date, created_at FROM task_history
+		WHERE task_id = $1
+		ORDER BY id
+		OFFSET $2 LIMIT $3;`
+)
+
+type scheduleRepo struct {
+	*pgxpool.Pool
+}
+
+// scanHistoryEntry reads all columns of the task_history table from the row into the entry
+func scanHistoryEntry(row pgx.Row) (model.HistoryEntry, error) {
+	var e model.HistoryEntry
+	var from, to time.Time
+	if err := row.Scan(&e.Id, &e.TaskId, &e.Action, &from, &to, &e.CreatedAt); err != nil {
+		return model.HistoryEntry{}, err
+	}
+	e.FromDate.Year, e.FromDate.Month, e.FromDate.Day = from.UTC().Date()
+	e.ToDate.Year, e.ToDate.Month, e.ToDate.Day = to.UTC().Date()
+	e.CreatedAt = e.CreatedAt.UTC()
+	return e, nil
+}
+
+// scanHistory reads all rows of the task_history table and closes them
+func scanHistory(rows pgx.Rows) ([]model.HistoryEntry, error) {
+	defer rows.Close()
+
+	history := make([]model.HistoryEntry, 0)
+	for rows.Next() {
+		e, err := scanHistoryEntry(rows)
+		if err != nil {
+			return nil, errors.Join(model.ErrTaskRepo, err)
+		}
+		history = append(history, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(model.ErrTaskRepo, err)
+	}
+	return history, nil
+}
+
+// moveTasks runs the query moving tasks between days which returns written
+// history entries and writes updates of the moved tasks to the outbox
+func (r *scheduleRepo) moveTasks(ctx context.Context, query string, args ...any) ([]model.HistoryEntry, error) {
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return nil, errors.Join(model.ErrTaskRepo, err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Join(model.ErrTaskRepo, err)
+	}
+	history, err := scanHistory(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(history) > 0 {
+		ids := make([]int, 0, len(history))
+		from := make(map[int]model.Date, len(history))
+		for _, e := range history {
+			ids = append(ids, e.TaskId)
+			from[e.TaskId] = e.FromDate
+		}
+		// tasks are only moved between days, so they differ from the tasks
+		// before the move by their planning date
+		prev := func(t model.TodoTask) model.TodoTask {
+			t.PlanningDate = from[t.Id]
+			return t
+		}
+		if err = writeUpdates(ctx, tx, ids, prev); err != nil {
+			return nil, errors.Join(model.ErrTaskRepo, err)
+		}
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Join(model.ErrTaskRepo, err)
+	}
+	return history, nil
+}
+
+func (r *scheduleRepo) MoveOverdue(ctx context.Context, today model.Date) ([]model.HistoryEntry, error) {
+	return r.moveTasks(ctx, moveOverdueQuery,
+		fmt.Sprintf("%d-%d-%d", today.Year, today.Month, today.Day),
+		model.ActionRollover)
+}
+
+func (r *scheduleRepo) RescheduleTask(ctx context.Context, id int, date model.Date, action string) (model.HistoryEntry, error) {
+	history, err := r.moveTasks(ctx, rescheduleTaskQuery,
+		id,
+		fmt.Sprintf("%d-%d-%d", date.Year, date.Month, date.Day),
+		action)
+	if err != nil {
+		return model.HistoryEntry{}, err
+	} else if len(history) == 0 {
+		return model.HistoryEntry{}, model.ErrTaskNotFound
+	}
+	return history[0], nil
+}
+
+func (r *scheduleRepo) RescheduleDay(ctx context.Context, from model.Date, to model.Date, action string) ([]model.HistoryEntry, error) {
+	return r.moveTasks(ctx, rescheduleDayQuery,
+		fmt.Sprintf("%d-%d-%d", from.Year, from.Month, from.Day),
+		fmt.Sprintf("%d-%d-%d", to.Year, to.Month, to.Day),
+		action)
+}
+
+// FlagOverdue flags the tasks and writes their updates to the outbox in the
+// same transaction, like the moves of the tasks between days
+func (r *scheduleRepo) FlagOverdue(ctx context.Context, today model.Date) (int, error) {
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return 0, errors.Join(model.ErrTaskRepo, err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	rows, err := tx.Query(ctx, flagOverdueQuery, fmt.Sprintf("%d-%d-%d", today.Year, today.Month, today.Day))
+	if err != nil {
+		return 0, errors.Join(model.ErrTaskRepo, err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		return 0, errors.Join(model.ErrTaskRepo, err)
+	}
+
+	if len(ids) > 0 {
+		if err = writeUpdates(ctx, tx, ids, nil); err != nil {
+			return 0, errors.Join(model.ErrTaskRepo, err)
+		}
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return 0, errors.Join(model.ErrTaskRepo, err)
+	}
+	return len(ids), nil
+}
+
+func (r *scheduleRepo) GetHistoryByTask(ctx context.Context, taskId int, offset int, limit int) ([]model.HistoryEntry, error) {
+	rows, err := r.Query(ctx, getHistoryByTaskQuery, taskId, offset, limit)
+	if err != nil {
+		return nil, errors.Join(model.ErrTaskRepo, err)
+	}
+	return scanHistory(rows)
+}
+
+// NewScheduleRepo creates repository of moves of the tasks between days which works with given pool of connections
+func NewScheduleRepo(pool *pgxpool.Pool) app.ScheduleRepo {
+	return &scheduleRepo{
+		Pool: pool,
+	}
+}
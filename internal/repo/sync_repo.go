@@ -0,0 +1,186 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"strconv"
+	"time"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+const (
+	// snapshotQuery returns the oldest transaction in progress, changes of
+	// all transactions before it are visible in the snapshot
+	snapshotQuery = `
+		SELECT pg_snapshot_xmin(pg_current_snapshot())::TEXT;`
+
+	getAllTasksQuery = selectTasks + `
+		ORDER BY id;`
+
+	getChangedTasksQuery = selectTasks + `
+		WHERE id IN (
+		    SELECT task_id FROM task_changes
+		    WHERE xid >= $1::TEXT::XID8 AND deleted_at IS NULL
+		)
+		ORDER BY id;`
+
+	getTombstonesQuery = `
+		SELECT task_id, version, deleted_at FROM task_changes
+		WHERE xid >= $1::TEXT::XID8 AND deleted_at IS NOT NULL
+		ORDER BY task_id;`
+
+	purgeTombstonesQuery = `
+		DELETE FROM task_changes
+		WHERE deleted_at < $1;`
+
+	getCreatedTaskQuery = `
+		SELECT task_id FROM sync_refs
+		WHERE client = $1 AND ref = $2;`
+
+	// addSyncRefQuery waits for the transaction adding the same ref
+	// concurrently and adds nothing if it is committed
+	addSyncRefQuery = `
+		INSERT INTO sync_refs (client, ref)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING;`
+
+	setSyncRefTaskQuery = `
+		UPDATE sync_refs
+		SET task_id = $3
+		WHERE client = $1 AND ref = $2;`
+
+	purgeSyncRefsQuery = `
+		DELETE FROM sync_refs
+		WHERE created_at < $1;`
+)
+
+type syncRepo struct {
+	*pgxpool.Pool
+}
+
+func (r *syncRepo) GetChanges(ctx context.Context, since uint64) (model.SyncChanges, uint64, error) {
+	// all queries read the same snapshot, so changes committed while they
+	// run are returned by the next call
+	tx, err := r.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return model.SyncChanges{}, 0, errors.Join(model.ErrTaskRepo, err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	var snapshot string
+	if err = tx.QueryRow(ctx, snapshotQuery).Scan(&snapshot); err != nil {
+		return model.SyncChanges{}, 0, errors.Join(model.ErrTaskRepo, err)
+	}
+	xmin, err := strconv.ParseUint(snapshot, 10, 64)
+	if err != nil {
+		return model.SyncChanges{}, 0, errors.Join(model.ErrTaskRepo, err)
+	}
+
+	// client without a copy of the tasks gets all of them and needs no
+	// tombstones
+	sinceXid := strconv.FormatUint(since, 10)
+	query, args := getChangedTasksQuery, []any{sinceXid}
+	if since == 0 {
+		query, args = getAllTasksQuery, nil
+	}
+
+	var changes model.SyncChanges
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return model.SyncChanges{}, 0, errors.Join(model.ErrTaskRepo, err)
+	}
+	if changes.Tasks, err = scanTasks(rows); err != nil {
+		return model.SyncChanges{}, 0, err
+	}
+	changes.Tombstones = make([]model.Tombstone, 0)
+	if since == 0 {
+		return changes, xmin, nil
+	}
+
+	rows, err = tx.Query(ctx, getTombstonesQuery, sinceXid)
+	if err != nil {
+		return model.SyncChanges{}, 0, errors.Join(model.ErrTaskRepo, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ts model.Tombstone
+		if err = rows.Scan(&ts.TaskId, &ts.Version, &ts.DeletedAt); err != nil {
+			return model.SyncChanges{}, 0, errors.Join(model.ErrTaskRepo, err)
+		}
+		ts.DeletedAt = ts.DeletedAt.UTC()
+		changes.Tombstones = append(changes.Tombstones, ts)
+	}
+	if err = rows.Err(); err != nil {
+		return model.SyncChanges{}, 0, errors.Join(model.ErrTaskRepo, err)
+	}
+	return changes, xmin, nil
+}
+
+func (r *syncRepo) GetCreatedTask(ctx context.Context, client string, ref string) (int, error) {
+	var id int
+	err := r.QueryRow(ctx, getCreatedTaskQuery, client, ref).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	} else if err != nil {
+		return 0, errors.Join(model.ErrTaskRepo, err)
+	}
+	return id, nil
+}
+
+func (r *syncRepo) AddCreatedTask(ctx context.Context, client string, ref string, t model.TodoTask) (int, error) {
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return 0, errors.Join(model.ErrTaskRepo, err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	tag, err := tx.Exec(ctx, addSyncRefQuery, client, ref)
+	if err != nil {
+		return 0, errors.Join(model.ErrTaskRepo, err)
+	} else if tag.RowsAffected() == 0 {
+		// the same change is applied by the concurrent push
+		var id int
+		if err = tx.QueryRow(ctx, getCreatedTaskQuery, client, ref).Scan(&id); err != nil {
+			return 0, errors.Join(model.ErrTaskRepo, err)
+		}
+		return id, nil
+	}
+
+	if t, err = addTask(ctx, tx, t); err != nil {
+		return 0, err
+	}
+	if _, err = tx.Exec(ctx, setSyncRefTaskQuery, client, ref, t.Id); err != nil {
+		return 0, errors.Join(model.ErrTaskRepo, err)
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return 0, errors.Join(model.ErrTaskRepo, err)
+	}
+	return t.Id, nil
+}
+
+func (r *syncRepo) PurgeTombstones(ctx context.Context, before time.Time) (int, error) {
+	tag, err := r.Exec(ctx, purgeTombstonesQuery, before)
+	if err != nil {
+		return 0, errors.Join(model.ErrTaskRepo, err)
+	}
+	if _, err = r.Exec(ctx, purgeSyncRefsQuery, before); err != nil {
+		return 0, errors.Join(model.ErrTaskRepo, err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// NewSyncRepo creates repository of the changes of the tasks for the offline
+// clients which works with given pool of connections
+func NewSyncRepo(pool *pgxpool.Pool) app.SyncRepo {
+	return &syncRepo{
+		Pool: pool,
+	}
+}
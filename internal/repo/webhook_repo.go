@@ -0,0 +1,200 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
+	"todo-list/internal/app"
+	"todo-list/internal/model"
+)
+
+const (
+	addSubscriptionQuery = `
+		INSERT INTO webhook_subscriptions (url, events, secret)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at;`
+
+	getSubscriptionByIdQuery = `
+		SELECT id, url, events, secret, created_at FROM webhook_subscriptions
+		WHERE id = $1;`
+
+	getSubscriptionsQuery = `
+		SELECT id, url, events, secret, created_at FROM webhook_subscriptions
+		ORDER BY id;`
+
+	deleteSubscriptionQuery = `
+		DELETE FROM webhook_subscriptions
+		WHERE id = $1;`
+
+	// addDeliveriesQuery skips subscriptions which already have delivery of
+	// the event, so the event published again is not delivered twice
+	addDeliveriesQuery = `
+		INSERT INTO webhook_deliveries (subscription_id, event_key, event, payload)
+		SELECT id, $1, $2, $3 FROM webhook_subscriptions
+		WHERE $2 = ANY (events)
+		ON CONFLICT (subscription_id, event_key) DO NOTHING;`
+
+	deliveryColumns = `
+		id, subscription_id, event, payload, status, attempts, response_code, error, next_attempt_at, created_at`
+
+	// claimDueDeliveriesQuery skips deliveries locked by another server, so
+	// every delivery is sent by one of them
+	claimDueDeliveriesQuery = `
+		WITH due AS (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at, id
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE webhook_deliveries d
+		SET attempts = d.attempts + 1, next_attempt_at = $2
+		FROM due
+		WHERE d.id = due.id
+		RETURNING` + deliveryColumns + `;`
+
+	recordAttemptQuery = `
+		UPDATE webhook_deliveries
+		SET status = $2, response_code = $3, error = $4, next_attempt_at = $5
+		WHERE id = $1;`
+
+	getDeliveriesBySubscriptionQuery = `
+		SELECT` + deliveryColumns + ` FROM webhook_deliveries
+		WHERE subscription_id = $1
+		ORDER BY id DESC
+		OFFSET $2
+		LIMIT $3;`
+)
+
+type webhookRepo struct {
+	*pgxpool.Pool
+}
+
+// scanSubscription reads all columns of the webhook_subscriptions table from the row into the subscription
+func scanSubscription(row pgx.Row) (model.Subscription, error) {
+	var sub model.Subscription
+	if err := row.Scan(&sub.Id, &sub.URL, &sub.Events, &sub.Secret, &sub.CreatedAt); err != nil {
+		return model.Subscription{}, err
+	}
+	sub.CreatedAt = sub.CreatedAt.UTC()
+	return sub, nil
+}
+
+// scanWebhookDelivery reads deliveryColumns from the row into the delivery
+func scanWebhookDelivery(row pgx.Row) (model.WebhookDelivery, error) {
+	var d model.WebhookDelivery
+	if err := row.Scan(&d.Id, &d.SubscriptionId, &d.Event, &d.Payload, &d.Status, &d.Attempts,
+		&d.ResponseCode, &d.Error, &d.NextAttemptAt, &d.CreatedAt); err != nil {
+		return model.WebhookDelivery{}, err
+	}
+	d.NextAttemptAt = d.NextAttemptAt.UTC()
+	d.CreatedAt = d.CreatedAt.UTC()
+	return d, nil
+}
+
+// scanWebhookDeliveries reads all deliveries from the rows and closes them
+func scanWebhookDeliveries(rows pgx.Rows) ([]model.WebhookDelivery, error) {
+	defer rows.Close()
+
+	deliveries := make([]model.WebhookDelivery, 0)
+	for rows.Next() {
+		d, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, errors.Join(model.ErrTaskRepo, err)
+		}
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(model.ErrTaskRepo, err)
+	}
+	return deliveries, nil
+}
+
+func (r *webhookRepo) AddSubscription(ctx context.Context, sub model.Subscription) (model.Subscription, error) {
+	err := r.QueryRow(ctx, addSubscriptionQuery, sub.URL, sub.Events, sub.Secret).Scan(&sub.Id, &sub.CreatedAt)
+	if err != nil {
+		return model.Subscription{}, errors.Join(model.ErrTaskRepo, err)
+	}
+	sub.CreatedAt = sub.CreatedAt.UTC()
+	return sub, nil
+}
+
+func (r *webhookRepo) GetSubscriptionById(ctx context.Context, id int) (model.Subscription, error) {
+	sub, err := scanSubscription(r.QueryRow(ctx, getSubscriptionByIdQuery, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Subscription{}, model.ErrSubscriptionNotFound
+	} else if err != nil {
+		return model.Subscription{}, errors.Join(model.ErrTaskRepo, err)
+	} else {
+		return sub, nil
+	}
+}
+
+func (r *webhookRepo) GetSubscriptions(ctx context.Context) ([]model.Subscription, error) {
+	rows, err := r.Query(ctx, getSubscriptionsQuery)
+	if err != nil {
+		return nil, errors.Join(model.ErrTaskRepo, err)
+	}
+	defer rows.Close()
+
+	subs := make([]model.Subscription, 0)
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			return nil, errors.Join(model.ErrTaskRepo, err)
+		}
+		subs = append(subs, sub)
+	}
+	return subs, nil
+}
+
+func (r *webhookRepo) DeleteSubscription(ctx context.Context, id int) error {
+	e, err := r.Exec(ctx, deleteSubscriptionQuery, id)
+	if err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	} else if e.RowsAffected() == 0 {
+		return model.ErrSubscriptionNotFound
+	} else {
+		return nil
+	}
+}
+
+func (r *webhookRepo) AddDeliveries(ctx context.Context, e model.Event) error {
+	if _, err := r.Exec(ctx, addDeliveriesQuery, e.Key, e.Type, e.Payload); err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	}
+	return nil
+}
+
+func (r *webhookRepo) ClaimDueDeliveries(ctx context.Context, now time.Time, until time.Time, limit int) ([]model.WebhookDelivery, error) {
+	rows, err := r.Query(ctx, claimDueDeliveriesQuery, now, until, limit)
+	if err != nil {
+		return nil, errors.Join(model.ErrTaskRepo, err)
+	}
+	return scanWebhookDeliveries(rows)
+}
+
+func (r *webhookRepo) RecordAttempt(ctx context.Context, id int, status string, code int, errText string, nextAttemptAt time.Time) error {
+	// delivery could be deleted with its subscription while being sent
+	if _, err := r.Exec(ctx, recordAttemptQuery, id, status, code, errText, nextAttemptAt); err != nil {
+		return errors.Join(model.ErrTaskRepo, err)
+	}
+	return nil
+}
+
+func (r *webhookRepo) GetDeliveriesBySubscription(ctx context.Context, subscriptionId int, offset int, limit int) ([]model.WebhookDelivery, error) {
+	rows, err := r.Query(ctx, getDeliveriesBySubscriptionQuery, subscriptionId, offset, limit)
+	if err != nil {
+		return nil, errors.Join(model.ErrTaskRepo, err)
+	}
+	return scanWebhookDeliveries(rows)
+}
+
+// NewWebhookRepo creates repository of webhook subscriptions and deliveries which works with given pool of connections
+func NewWebhookRepo(pool *pgxpool.Pool) app.WebhookRepo {
+	return &webhookRepo{
+		Pool: pool,
+	}
+}
@@ -0,0 +1,207 @@
+// Package todotxt reads and writes tasks in the todo.txt format
+// (https://github.com/todotxt/todo.txt).
+//
+// Completion mark x is the status of the task, due:YYYY-MM-DD tag is its
+// planning date, +project token is its project and @context tokens are the
+// assignees of the task, so the contexts must be the members of the project
+// or of the workspace. State of the workflow other than the default one is
+// kept as state:name tag. Tasks have no priority, no creation and completion
+// dates and only one project, so the priority and the dates are kept in the
+// title as pri:A, created:YYYY-MM-DD and completed:YYYY-MM-DD tags and are
+// written back to their places in the line, and other projects stay in the
+// title. Each such fallback is reported as the warning of the line. Other
+// tags are the part of the title, so the tasks can be found by them.
+// Descriptions are not kept.
+package todotxt
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+	"todo-list/internal/model"
+)
+
+const (
+	doneMark      = "x"
+	contextPrefix = "@"
+	projectPrefix = "+"
+	dueTag        = "due:"
+	priTag        = "pri:"
+	createdTag    = "created:"
+	completedTag  = "completed:"
+	stateTag      = "state:"
+)
+
+// isDate returns true if word is a date of the todo.txt line
+func isDate(word string) bool {
+	_, err := time.Parse(time.DateOnly, word)
+	return err == nil
+}
+
+// isPriority returns true if word is a priority of the todo.txt line, (A) to (Z)
+func isPriority(word string) bool {
+	return len(word) == 3 && word[0] == '(' && word[1] >= 'A' && word[1] <= 'Z' && word[2] == ')'
+}
+
+// ParseLine returns task of the todo.txt line and warnings about the parts of
+// the line which are kept in the title instead of the fields of the task
+func ParseLine(line string) (model.TodoTask, []string, error) {
+	var t model.TodoTask
+	var warnings []string
+	var priority, created, completed string
+	words := strings.Fields(line)
+	if len(words) > 0 && words[0] == doneMark {
+		t.Status = true
+		words = words[1:]
+		// completion date is followed by creation date
+		if len(words) > 0 && isDate(words[0]) {
+			completed, words = words[0], words[1:]
+			if len(words) > 0 && isDate(words[0]) {
+				created, words = words[0], words[1:]
+			}
+		}
+	} else {
+		if len(words) > 0 && isPriority(words[0]) {
+			priority = words[0][1:2]
+			words = words[1:]
+		}
+		if len(words) > 0 && isDate(words[0]) {
+			created, words = words[0], words[1:]
+		}
+	}
+
+	title := make([]string, 0, len(words)+3)
+	for _, w := range words {
+		switch {
+		case strings.HasPrefix(w, dueTag):
+			due, err := time.Parse(time.DateOnly, strings.TrimPrefix(w, dueTag))
+			if err != nil {
+				return model.TodoTask{}, nil, fmt.Errorf("due date %q is not in the format YYYY-MM-DD", strings.TrimPrefix(w, dueTag))
+			}
+			t.PlanningDate = model.Date{Year: due.Year(), Month: due.Month(), Day: due.Day()}
+		case strings.HasPrefix(w, stateTag) && len(w) > len(stateTag):
+			t.State = strings.TrimPrefix(w, stateTag)
+		case strings.HasPrefix(w, projectPrefix) && len(w) > len(projectPrefix):
+			if t.Project == "" {
+				t.Project = strings.TrimPrefix(w, projectPrefix)
+				continue
+			}
+			warnings = append(warnings, fmt.Sprintf("task has only one project, %s is kept in the title", w))
+			title = append(title, w)
+		case strings.HasPrefix(w, contextPrefix) && len(w) > len(contextPrefix):
+			if assignee := strings.TrimPrefix(w, contextPrefix); !slices.Contains(t.Assignees, assignee) {
+				t.Assignees = append(t.Assignees, assignee)
+			}
+		default:
+			title = append(title, w)
+		}
+	}
+	for _, tag := range []struct{ name, prefix, value string }{
+		{"priority", priTag, priority},
+		{"creation date", createdTag, created},
+		{"completion date", completedTag, completed},
+	} {
+		if tag.value != "" {
+			title = append(title, tag.prefix+tag.value)
+			warnings = append(warnings, fmt.Sprintf("task has no %s, it is kept in the title as %s", tag.name, tag.prefix+tag.value))
+		}
+	}
+	t.Title = strings.Join(title, " ")
+	return t, warnings, nil
+}
+
+// Read reads tasks from the lines of todo.txt, empty lines are skipped. Line
+// which can't be parsed is returned as the row with the error, warnings of the
+// line are returned with the row
+func Read(r io.Reader) ([]model.ImportRow, error) {
+	var rows []model.ImportRow
+	s := bufio.NewScanner(r)
+	for line := 1; s.Scan(); line++ {
+		if strings.TrimSpace(s.Text()) == "" {
+			continue
+		}
+		t, warnings, err := ParseLine(s.Text())
+		if err != nil {
+			err = errors.Join(model.ErrInvalidInput, err)
+		}
+		rows = append(rows, model.ImportRow{Line: line, Task: t, Warnings: warnings, Err: err})
+	}
+	return rows, s.Err()
+}
+
+// defaultState returns name of the first state of the workflow which is done
+// or not done as required, the app chooses it for the tasks without state
+func defaultState(w model.Workflow, done bool) string {
+	for _, s := range w.States {
+		if s.Done == done {
+			return s.Name
+		}
+	}
+	return ""
+}
+
+// takeTag removes the first tag with the prefix which value is accepted by
+// the check from the words and returns its value
+func takeTag(words []string, prefix string, check func(string) bool) ([]string, string) {
+	for i, word := range words {
+		if value, ok := strings.CutPrefix(word, prefix); ok && check(value) {
+			return append(words[:i:i], words[i+1:]...), value
+		}
+	}
+	return words, ""
+}
+
+// FormatTask returns todo.txt line of the task
+func FormatTask(t model.TodoTask, w model.Workflow) string {
+	var b strings.Builder
+	words := strings.Fields(t.Title)
+	var priority, created, completed string
+	if t.Status {
+		// creation date can't be written without completion date
+		if words, completed = takeTag(words, completedTag, isDate); completed != "" {
+			words, created = takeTag(words, createdTag, isDate)
+		}
+		b.WriteString(doneMark + " ")
+	} else {
+		words, priority = takeTag(words, priTag, func(p string) bool { return isPriority("(" + p + ")") })
+		words, created = takeTag(words, createdTag, isDate)
+	}
+	if priority != "" {
+		b.WriteString("(" + priority + ") ")
+	}
+	for _, date := range []string{completed, created} {
+		if date != "" {
+			b.WriteString(date + " ")
+		}
+	}
+
+	b.WriteString(strings.Join(words, " "))
+	if t.Project != "" {
+		b.WriteString(" " + projectPrefix + t.Project)
+	}
+	for _, assignee := range t.Assignees {
+		b.WriteString(" " + contextPrefix + assignee)
+	}
+	if t.PlanningDate != (model.Date{}) {
+		fmt.Fprintf(&b, " %s%04d-%02d-%02d", dueTag, t.PlanningDate.Year, t.PlanningDate.Month, t.PlanningDate.Day)
+	}
+	if t.State != "" && t.State != defaultState(w, t.Status) {
+		b.WriteString(" " + stateTag + t.State)
+	}
+	return b.String()
+}
+
+// Write writes tasks to todo.txt, one line each
+func Write(wr io.Writer, tasks []model.TodoTask, w model.Workflow) error {
+	bw := bufio.NewWriter(wr)
+	for _, t := range tasks {
+		if _, err := bw.WriteString(FormatTask(t, w) + "\n"); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,114 @@
+package todotxt
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+	"todo-list/internal/model"
+)
+
+var workflow = model.Workflow{States: []model.State{
+	{Name: "todo"},
+	{Name: "in_progress"},
+	{Name: "done", Done: true},
+}}
+
+func TestParseLine(t *testing.T) {
+	due := model.Date{Year: 2027, Month: time.June, Day: 1}
+	tests := []struct {
+		description string
+		line        string
+		expected    model.TodoTask
+		warnings    []string
+	}{
+		{
+			description: "priority, creation date, projects and contexts",
+			line:        "(A) 2026-10-01 Call mom +family +home @alice @bob @alice due:2027-06-01",
+			expected:    model.TodoTask{Title: "Call mom +home pri:A created:2026-10-01", PlanningDate: due, Project: "family", Assignees: []string{"alice", "bob"}},
+			warnings: []string{
+				"task has only one project, +home is kept in the title",
+				"task has no priority, it is kept in the title as pri:A",
+				"task has no creation date, it is kept in the title as created:2026-10-01",
+			},
+		},
+		{
+			description: "completion and creation dates",
+			line:        "x 2026-10-02 2026-10-01 Pay rent pri:B due:2027-06-01",
+			expected:    model.TodoTask{Title: "Pay rent pri:B created:2026-10-01 completed:2026-10-02", PlanningDate: due, Status: true},
+			warnings: []string{
+				"task has no creation date, it is kept in the title as created:2026-10-01",
+				"task has no completion date, it is kept in the title as completed:2026-10-02",
+			},
+		},
+		{
+			description: "priority of the completed task is the part of the title",
+			line:        "x (A) Done",
+			expected:    model.TodoTask{Title: "(A) Done", Status: true},
+		},
+		{
+			description: "state and other tags",
+			line:        "Review  PR url:https://example.com state:in_progress @ +",
+			expected:    model.TodoTask{Title: "Review PR url:https://example.com @ +", State: "in_progress"},
+		},
+		{
+			description: "lowercase priority and x in the middle",
+			line:        "(a) fix x",
+			expected:    model.TodoTask{Title: "(a) fix x"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			task, warnings, err := ParseLine(test.line)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, task)
+			assert.Equal(t, test.warnings, warnings)
+		})
+	}
+
+	_, _, err := ParseLine("Pay rent due:tomorrow")
+	assert.EqualError(t, err, `due date "tomorrow" is not in the format YYYY-MM-DD`)
+}
+
+func TestRead(t *testing.T) {
+	rows, err := Read(strings.NewReader("First\n\n  \nSecond due:2027-13-01\r\nx Third\n(B) Fourth\n"))
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+	assert.Equal(t, model.ImportRow{Line: 1, Task: model.TodoTask{Title: "First"}}, rows[0])
+	assert.Equal(t, 4, rows[1].Line)
+	assert.ErrorIs(t, rows[1].Err, model.ErrInvalidInput)
+	assert.Equal(t, model.ImportRow{Line: 5, Task: model.TodoTask{Title: "Third", Status: true}}, rows[2])
+	assert.Equal(t, []string{"task has no priority, it is kept in the title as pri:B"}, rows[3].Warnings)
+}
+
+func TestWrite(t *testing.T) {
+	due := model.Date{Year: 2027, Month: time.June, Day: 1}
+	tasks := []model.TodoTask{
+		{Title: "Call mom pri:A created:2026-10-01", PlanningDate: due, State: "todo", Project: "family", Assignees: []string{"alice"}},
+		{Title: "Review PR", PlanningDate: due, State: "in_progress"},
+		{Title: "Pay rent pri:B created:2026-10-01 completed:2026-10-02", PlanningDate: due, Status: true, State: "done"},
+		{Title: "Archive created:2026-10-01", Status: true, State: "done"},
+	}
+	var b bytes.Buffer
+	require.NoError(t, Write(&b, tasks, workflow))
+	assert.Equal(t, "(A) 2026-10-01 Call mom +family @alice due:2027-06-01\n"+
+		"Review PR due:2027-06-01 state:in_progress\n"+
+		"x 2026-10-02 2026-10-01 Pay rent pri:B due:2027-06-01\n"+
+		"x Archive created:2026-10-01\n", b.String())
+
+	// written tasks are read back the same
+	rows, err := Read(&b)
+	require.NoError(t, err)
+	require.Len(t, rows, len(tasks))
+	for i, row := range rows {
+		assert.Equal(t, tasks[i].Title, row.Task.Title)
+		assert.Equal(t, tasks[i].PlanningDate, row.Task.PlanningDate)
+		assert.Equal(t, tasks[i].Status, row.Task.Status)
+		assert.Equal(t, tasks[i].Project, row.Task.Project)
+		assert.Equal(t, tasks[i].Assignees, row.Task.Assignees)
+	}
+	assert.Equal(t, "in_progress", rows[1].Task.State)
+}
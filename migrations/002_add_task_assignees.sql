@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS projects (
+    name VARCHAR(50) PRIMARY KEY,
+    members VARCHAR(50)[] NOT NULL DEFAULT '{}'
+);
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS assignees VARCHAR(50)[] NOT NULL DEFAULT '{}';
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS project VARCHAR(50) REFERENCES projects (name);
+
+CREATE INDEX IF NOT EXISTS tasks_assignees_idx ON tasks USING GIN (assignees);
+CREATE INDEX IF NOT EXISTS tasks_project_idx ON tasks (project);
@@ -0,0 +1,11 @@
+-- state has no default, so every insert has to choose it explicitly. Existing
+-- tasks get the default state of their status
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS state VARCHAR(50);
+
+UPDATE tasks
+SET state = CASE WHEN status THEN 'done' ELSE 'todo' END
+WHERE state IS NULL;
+
+ALTER TABLE tasks ALTER COLUMN state SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS tasks_state_idx ON tasks (state);
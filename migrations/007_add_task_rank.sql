@@ -0,0 +1,4 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS rank VARCHAR(255) COLLATE "C" NOT NULL DEFAULT '';
+
+DROP INDEX IF EXISTS tasks_state_idx;
+CREATE INDEX IF NOT EXISTS tasks_state_rank_idx ON tasks (state, rank, id);
@@ -0,0 +1 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS postponed INTEGER NOT NULL DEFAULT 0;
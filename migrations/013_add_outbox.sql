@@ -0,0 +1,23 @@
+-- deliveries written before the outbox have no event key, their ids keep
+-- them unique
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS event_key VARCHAR(36);
+UPDATE webhook_deliveries SET event_key = id::text WHERE event_key IS NULL;
+ALTER TABLE webhook_deliveries ALTER COLUMN event_key SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS webhook_deliveries_event_key_idx ON webhook_deliveries (subscription_id, event_key);
+
+CREATE TABLE IF NOT EXISTS outbox (
+    id BIGSERIAL PRIMARY KEY,
+    key VARCHAR(36) NOT NULL UNIQUE,
+    event VARCHAR(50) NOT NULL,
+    task_id INTEGER NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    published_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS outbox_pending_idx ON outbox (id) WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS outbox_published_at_idx ON outbox (published_at) WHERE published_at IS NOT NULL;
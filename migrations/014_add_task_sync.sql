@@ -0,0 +1,90 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+
+-- task_changes keeps the last change of every task for the sync clients:
+-- transaction which made it and deletion time for the deleted tasks
+CREATE TABLE IF NOT EXISTS task_changes (
+    task_id INTEGER PRIMARY KEY,
+    version INTEGER NOT NULL,
+    xid XID8 NOT NULL,
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS task_changes_xid_idx ON task_changes (xid);
+CREATE INDEX IF NOT EXISTS task_changes_deleted_at_idx ON task_changes (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- sync_refs keeps refs of the changes of the sync clients which created
+-- tasks, so the batch pushed again after a lost response doesn't create them
+-- twice. task_id is set in the transaction adding the ref
+CREATE TABLE IF NOT EXISTS sync_refs (
+    client TEXT NOT NULL,
+    ref TEXT NOT NULL,
+    task_id INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (client, ref)
+);
+
+CREATE INDEX IF NOT EXISTS sync_refs_created_at_idx ON sync_refs (created_at);
+
+CREATE OR REPLACE FUNCTION bump_task_version() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.version := OLD.version + 1;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS tasks_bump_version ON tasks;
+CREATE TRIGGER tasks_bump_version BEFORE UPDATE ON tasks
+    FOR EACH ROW EXECUTE FUNCTION bump_task_version();
+
+CREATE OR REPLACE FUNCTION record_task_change() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        INSERT INTO task_changes (task_id, version, xid, deleted_at)
+        VALUES (OLD.id, OLD.version + 1, pg_current_xact_id(), clock_timestamp())
+        ON CONFLICT (task_id) DO UPDATE
+        SET version = EXCLUDED.version, xid = EXCLUDED.xid, deleted_at = EXCLUDED.deleted_at;
+        RETURN OLD;
+    END IF;
+
+    INSERT INTO task_changes (task_id, version, xid)
+    VALUES (NEW.id, NEW.version, pg_current_xact_id())
+    ON CONFLICT (task_id) DO UPDATE
+    SET version = EXCLUDED.version, xid = EXCLUDED.xid, deleted_at = NULL;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS tasks_record_change ON tasks;
+CREATE TRIGGER tasks_record_change AFTER INSERT OR UPDATE OR DELETE ON tasks
+    FOR EACH ROW EXECUTE FUNCTION record_task_change();
+
+-- blocked flag of the task is calculated, so the tasks it changes for are
+-- passed to the sync clients again without a new version
+CREATE OR REPLACE FUNCTION resend_blocked_tasks() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_TABLE_NAME = 'tasks' THEN
+        UPDATE task_changes SET xid = pg_current_xact_id()
+        WHERE deleted_at IS NULL AND task_id IN (
+            SELECT blocked_id FROM task_dependencies WHERE blocker_id = NEW.id
+        );
+        RETURN NEW;
+    ELSIF TG_OP = 'DELETE' THEN
+        UPDATE task_changes SET xid = pg_current_xact_id()
+        WHERE deleted_at IS NULL AND task_id = OLD.blocked_id;
+        RETURN OLD;
+    END IF;
+
+    UPDATE task_changes SET xid = pg_current_xact_id()
+    WHERE deleted_at IS NULL AND task_id = NEW.blocked_id;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS tasks_resend_blocked ON tasks;
+CREATE TRIGGER tasks_resend_blocked AFTER UPDATE OF status ON tasks
+    FOR EACH ROW WHEN (OLD.status IS DISTINCT FROM NEW.status)
+    EXECUTE FUNCTION resend_blocked_tasks();
+
+DROP TRIGGER IF EXISTS task_dependencies_resend_blocked ON task_dependencies;
+CREATE TRIGGER task_dependencies_resend_blocked AFTER INSERT OR DELETE ON task_dependencies
+    FOR EACH ROW EXECUTE FUNCTION resend_blocked_tasks();
@@ -0,0 +1,9 @@
+-- random secrets of the calendar links of the users, only their hashes are
+-- kept, so the secret is shown once when it is issued and a new one revokes it
+CREATE TABLE IF NOT EXISTS calendar_credentials (
+    user_name VARCHAR(50) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    hash BYTEA NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_name, kind)
+);
@@ -0,0 +1,11 @@
+-- calendar_objects keeps names and UIDs chosen by CalDAV clients for the
+-- tasks they created, other tasks get default names
+CREATE TABLE IF NOT EXISTS calendar_objects (
+    owner VARCHAR(50) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    uid VARCHAR(255) NOT NULL,
+    task_id INTEGER NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    PRIMARY KEY (owner, name)
+);
+
+CREATE INDEX IF NOT EXISTS calendar_objects_task_id_idx ON calendar_objects (task_id);
@@ -0,0 +1,98 @@
+// Package migrations keeps the schema of task_repo as the numbered SQL files
+// which are applied in order of their names when the server starts
+package migrations
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"io/fs"
+	"slices"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+const (
+	// lockKey is a key of the advisory lock which keeps the servers started
+	// at the same time from applying the same migration twice
+	lockKey = 20260101
+
+	lockQuery = `
+		SELECT pg_advisory_xact_lock($1);`
+
+	createMigrationsQuery = `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+		    name VARCHAR(255) PRIMARY KEY,
+		    applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		);`
+
+	isAppliedQuery = `
+		SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE name = $1);`
+
+	markAppliedQuery = `
+		INSERT INTO schema_migrations (name)
+		VALUES ($1);`
+)
+
+// DB begins transactions in which the migrations are applied
+type DB interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// Apply applies the migrations which haven't been applied to the database
+// yet, each of them in its own transaction
+func Apply(ctx context.Context, db DB) error {
+	names, err := fs.Glob(files, "*.sql")
+	if err != nil {
+		return err
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		if err = apply(ctx, db, name); err != nil {
+			return fmt.Errorf("migration %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// apply applies the migration with given name if it hasn't been applied
+func apply(ctx context.Context, db DB, name string) error {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if _, err = tx.Exec(ctx, lockQuery, lockKey); err != nil {
+		return err
+	}
+	if _, err = tx.Exec(ctx, createMigrationsQuery); err != nil {
+		return err
+	}
+
+	var applied bool
+	if err = tx.QueryRow(ctx, isAppliedQuery, name).Scan(&applied); err != nil {
+		return err
+	} else if applied {
+		return nil
+	}
+
+	script, err := files.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	// script without arguments is sent with the simple protocol, so it may
+	// contain several statements
+	if _, err = tx.Exec(ctx, string(script)); err != nil {
+		return err
+	}
+	if _, err = tx.Exec(ctx, markAppliedQuery, name); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
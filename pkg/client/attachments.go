@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+)
+
+// AddAttachment uploads file with given name read from r and attaches it to
+// the task, the request is not retried because r can't be read twice
+func (c *Client) AddAttachment(ctx context.Context, taskId int, name string, r io.Reader) (Attachment, error) {
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		part, err := mw.CreateFormFile("file", name)
+		if err == nil {
+			_, err = io.Copy(part, r)
+		}
+		if err == nil {
+			err = mw.Close()
+		}
+		_ = pw.CloseWithError(err)
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+fmt.Sprintf("/task/%d/attachments", taskId), pr)
+	if err != nil {
+		_ = pr.Close()
+		return Attachment{}, err
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	if c.user != "" {
+		req.Header.Set(userHeader, c.user)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return Attachment{}, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	var d attachmentData
+	if err = decode(resp, &d); err != nil {
+		return Attachment{}, err
+	}
+	return d.attachment(), nil
+}
+
+// GetAttachmentsByTask returns attachments of the task
+func (c *Client) GetAttachmentsByTask(ctx context.Context, taskId int) ([]Attachment, error) {
+	var d []attachmentData
+	if err := c.do(ctx, request{method: http.MethodGet, path: fmt.Sprintf("/task/%d/attachments", taskId)}, &d); err != nil {
+		return nil, err
+	}
+	attachments := make([]Attachment, 0, len(d))
+	for _, ad := range d {
+		attachments = append(attachments, ad.attachment())
+	}
+	return attachments, nil
+}
+
+// GetAttachment returns attachment of the task with its content, name, type
+// and size of the attachment are taken from the headers of the response.
+// Content must be closed by caller
+func (c *Client) GetAttachment(ctx context.Context, taskId int, id int) (Attachment, io.ReadCloser, error) {
+	resp, err := c.send(ctx, request{method: http.MethodGet, path: fmt.Sprintf("/task/%d/attachments/%d", taskId, id)})
+	if err != nil {
+		return Attachment{}, nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		return Attachment{}, nil, decode(resp, nil)
+	}
+
+	at := Attachment{
+		Id:          id,
+		TaskId:      taskId,
+		ContentType: resp.Header.Get("Content-Type"),
+		Size:        resp.ContentLength,
+	}
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil {
+		at.Name = params["filename"]
+	}
+	return at, resp.Body, nil
+}
+
+// DeleteAttachment deletes attachment of the task with its content
+func (c *Client) DeleteAttachment(ctx context.Context, taskId int, id int) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: fmt.Sprintf("/task/%d/attachments/%d", taskId, id)}, nil)
+}
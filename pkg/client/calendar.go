@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// CalendarFeed is a secret link to the ICS feed of the user, Path is
+// relative to BaseURL. The server keeps only the hash of the token, so it
+// can't be read again
+type CalendarFeed struct {
+	User  string `json:"user"`
+	Token string `json:"token"`
+	Path  string `json:"path"`
+}
+
+// IssueCalendarToken returns new calendar feed of the user of the client,
+// the previous link of the user stops working
+func (c *Client) IssueCalendarToken(ctx context.Context) (CalendarFeed, error) {
+	var feed CalendarFeed
+	if err := c.do(ctx, request{method: http.MethodPost, path: "/calendar/token"}, &feed); err != nil {
+		return CalendarFeed{}, err
+	}
+	return feed, nil
+}
+
+// RevokeCalendarToken revokes the link to the calendar feed of the user of the client
+func (c *Client) RevokeCalendarToken(ctx context.Context) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: "/calendar/token"}, nil)
+}
+
+// CalDAVPassword is a secret password of the user in CalDAV clients, Path
+// is the path to their calendar on the server. The server keeps only the
+// hash of the password, so it can't be read again
+type CalDAVPassword struct {
+	User     string `json:"user"`
+	Password string `json:"password"`
+	Path     string `json:"path"`
+}
+
+// IssueCalDAVPassword returns new CalDAV password of the user of the client,
+// the previous password of the user stops working
+func (c *Client) IssueCalDAVPassword(ctx context.Context) (CalDAVPassword, error) {
+	var password CalDAVPassword
+	if err := c.do(ctx, request{method: http.MethodPost, path: "/calendar/caldav"}, &password); err != nil {
+		return CalDAVPassword{}, err
+	}
+	return password, nil
+}
+
+// RevokeCalDAVPassword revokes the CalDAV password of the user of the client
+func (c *Client) RevokeCalDAVPassword(ctx context.Context) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: "/calendar/caldav"}, nil)
+}
+
+// GetCalendarFeed returns ICS feed of the tasks assigned to the user,
+// components are "vevent" and "vtodo", both of them are returned if they are empty
+func (c *Client) GetCalendarFeed(ctx context.Context, user string, token string, components ...string) ([]byte, error) {
+	return c.calendar(ctx, request{
+		method: http.MethodGet,
+		path:   "/calendar/" + url.PathEscape(user) + "/" + url.PathEscape(token) + ".ics",
+		query:  componentsQuery(nil, components),
+	})
+}
+
+// ExportCalendar returns ICS file with tasks of every day from the date from
+// to the date to inclusive
+func (c *Client) ExportCalendar(ctx context.Context, from Date, to Date, components ...string) ([]byte, error) {
+	return c.calendar(ctx, request{
+		method: http.MethodGet,
+		path:   "/calendar/export",
+		query: componentsQuery(url.Values{
+			"from": {fmt.Sprintf("%04d-%02d-%02d", from.Year, from.Month, from.Day)},
+			"to":   {fmt.Sprintf("%04d-%02d-%02d", to.Year, to.Month, to.Day)},
+		}, components),
+	})
+}
+
+func componentsQuery(query url.Values, components []string) url.Values {
+	if len(components) == 0 {
+		return query
+	}
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("components", strings.Join(components, ","))
+	return query
+}
+
+// calendar sends the request which returns the calendar instead of JSON
+func (c *Client) calendar(ctx context.Context, r request) ([]byte, error) {
+	resp, err := c.send(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, decode(resp, nil)
+	}
+	return io.ReadAll(resp.Body)
+}
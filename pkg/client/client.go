@@ -0,0 +1,187 @@
+// Package client is a Go client of the HTTP API of todo-list. Methods of the
+// client mirror the routes of the API and return the same entities as the app,
+// errors of the API are returned as *Error wrapping the errors of this
+// package, so they can be checked with errors.Is
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	// defaultRetries is a number of retries of the request if it is not configured
+	defaultRetries = 2
+
+	// defaultRetryDelay is a delay before the first retry if it is not
+	// configured, it is doubled before every next retry
+	defaultRetryDelay = 200 * time.Millisecond
+
+	// userHeader is a header with name of the current user
+	userHeader = "X-User"
+)
+
+// Config is a configuration of the client. BaseURL is the address of the API
+// with its prefix, for example http://localhost:8080/todo-list/api. User is
+// the name of the current user passed to the routes which need it. Requests
+// which fail with 5xx status or can't be sent are retried Retries times with
+// growing delay starting from RetryDelay, negative Retries disables retries.
+// Only GET, PUT and DELETE requests are retried, so tasks are not duplicated
+type Config struct {
+	BaseURL    string
+	User       string
+	HTTPClient *http.Client
+	Retries    int
+	RetryDelay time.Duration
+}
+
+// Client sends requests to the API, it is safe for concurrent use
+type Client struct {
+	baseURL    string
+	user       string
+	http       *http.Client
+	retries    int
+	retryDelay time.Duration
+}
+
+// New creates client of the API with given config
+func New(cfg Config) *Client {
+	c := &Client{
+		baseURL:    cfg.BaseURL,
+		user:       cfg.User,
+		http:       cfg.HTTPClient,
+		retries:    cfg.Retries,
+		retryDelay: cfg.RetryDelay,
+	}
+	if c.http == nil {
+		c.http = http.DefaultClient
+	}
+	if c.retries == 0 {
+		c.retries = defaultRetries
+	} else if c.retries < 0 {
+		c.retries = 0
+	}
+	if c.retryDelay <= 0 {
+		c.retryDelay = defaultRetryDelay
+	}
+	return c
+}
+
+// As returns copy of the client which makes requests on behalf of the user
+func (c *Client) As(user string) *Client {
+	cp := *c
+	cp.user = user
+	return &cp
+}
+
+// envelope is the common format of the responses of the API
+type envelope struct {
+	Data json.RawMessage `json:"data"`
+	Err  *string         `json:"error"`
+}
+
+// request describes the request to the API, body is encoded as JSON. Some
+// routes of the API read JSON body of GET requests, so it is sent with any method
+type request struct {
+	method string
+	path   string
+	query  url.Values
+	body   any
+}
+
+// retryable returns true if the request can be repeated without changing the
+// result of the first one
+func (r request) retryable() bool {
+	return r.method == http.MethodGet || r.method == http.MethodPut || r.method == http.MethodDelete
+}
+
+// do sends the request and decodes data of the response into out if it is not nil
+func (c *Client) do(ctx context.Context, r request, out any) error {
+	resp, err := c.send(ctx, r)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	return decode(resp, out)
+}
+
+// send sends the request with retries and returns the response with status
+// below 500 or the last response or error
+func (c *Client) send(ctx context.Context, r request) (*http.Response, error) {
+	var body []byte
+	if r.body != nil {
+		var err error
+		if body, err = json.Marshal(r.body); err != nil {
+			return nil, err
+		}
+	}
+
+	u := c.baseURL + r.path
+	if len(r.query) > 0 {
+		u += "?" + r.query.Encode()
+	}
+
+	delay := c.retryDelay
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, r.method, u, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		if c.user != "" {
+			req.Header.Set(userHeader, c.user)
+		}
+
+		resp, err := c.http.Do(req)
+		if err == nil && resp.StatusCode < http.StatusInternalServerError {
+			return resp, nil
+		}
+		if attempt >= c.retries || !r.retryable() || ctx.Err() != nil {
+			return resp, err
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		select {
+		case <-time.After(delay):
+			delay *= 2
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// decode decodes the envelope of the response, error of the API is returned
+// as *Error
+func decode(resp *http.Response, out any) error {
+	var env envelope
+	if err := json.NewDecoder(resp.Body).Decode(&env); err != nil {
+		if resp.StatusCode >= http.StatusBadRequest {
+			return &Error{StatusCode: resp.StatusCode, Err: fmt.Errorf("unexpected response: %s", resp.Status)}
+		}
+		return err
+	}
+	if env.Err != nil || resp.StatusCode >= http.StatusBadRequest {
+		msg := resp.Status
+		if env.Err != nil {
+			msg = *env.Err
+		}
+		return &Error{StatusCode: resp.StatusCode, Err: errorOf(msg)}
+	}
+	if out == nil || len(env.Data) == 0 || string(env.Data) == "null" {
+		return nil
+	}
+	return json.Unmarshal(env.Data, out)
+}
@@ -0,0 +1,264 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+	"todo-list/internal/app"
+	"todo-list/internal/app/mocks"
+	"todo-list/internal/model"
+	"todo-list/internal/ports/httpserver"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const apiPrefix = "/todo-list/api"
+
+func TestClient(t *testing.T) {
+	tr, br := new(mocks.TaskRepo), new(mocks.BoardRepo)
+	cr, ar := new(mocks.CommentRepo), new(mocks.AttachmentRepo)
+	a := app.New(app.Deps{Tasks: tr, Board: br, Comments: cr, Attachments: ar}, app.Config{})
+	srv := httptest.NewServer(httpserver.New("", a).Handler)
+	defer srv.Close()
+
+	c := New(Config{BaseURL: srv.URL + apiPrefix, RetryDelay: time.Millisecond})
+	ctx := context.Background()
+	date := model.Date{Year: 2027, Month: time.June, Day: 1}
+
+	t.Run("add task", func(t *testing.T) {
+		br.On("GetLastRank", mock.Anything, "todo").Return("", nil).Once()
+		tr.On("AddTask", mock.Anything, mock.MatchedBy(func(task model.TodoTask) bool {
+			return task.Title == "Client" && task.PlanningDate == date
+		})).Return(model.TodoTask{Id: 1101, Title: "Client", PlanningDate: date, State: "todo", Version: 1}, nil).Once()
+
+		task, err := c.AddTask(ctx, Task{Title: "Client", PlanningDate: date})
+		require.NoError(t, err)
+		assert.Equal(t, Task{Id: 1101, Title: "Client", PlanningDate: date, State: "todo", Assignees: []string{}, Version: 1}, task)
+	})
+
+	t.Run("error of the API", func(t *testing.T) {
+		tr.On("GetTaskById", mock.Anything, 1102).Return(model.TodoTask{}, model.ErrTaskNotFound).Once()
+
+		_, err := c.GetTaskById(ctx, 1102)
+		assert.ErrorIs(t, err, ErrTaskNotFound)
+		var apiErr *Error
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	})
+
+	t.Run("body of GET request", func(t *testing.T) {
+		tr.On("GetTasksByStatus", mock.Anything, true, 5, 10).Return([]model.TodoTask{
+			{Id: 1103, Title: "Done", Status: true},
+		}, nil).Once()
+
+		tasks, err := c.GetTasksByStatus(ctx, true, 5, 10)
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		assert.Equal(t, 1103, tasks[0].Id)
+	})
+
+	t.Run("user of the client", func(t *testing.T) {
+		tr.On("GetTaskById", mock.Anything, 1104).Return(model.TodoTask{Id: 1104}, nil).Once()
+		cr.On("AddComment", mock.Anything, model.Comment{TaskId: 1104, Author: "alice", Text: "Hello"}).
+			Return(model.Comment{Id: 1, TaskId: 1104, Author: "alice", Text: "Hello"}, nil).Once()
+
+		comment, err := c.As("alice").AddComment(ctx, 1104, "Hello")
+		require.NoError(t, err)
+		assert.Equal(t, "alice", comment.Author)
+
+		_, err = c.AddComment(ctx, 1104, "Hello")
+		assert.ErrorIs(t, err, ErrUnknownUser)
+	})
+
+	t.Run("project", func(t *testing.T) {
+		tr.On("AddProject", mock.Anything, model.Project{Name: "backend", Members: []string{}}).
+			Return(model.Project{Name: "backend", Members: []string{}}, nil).Once()
+		tr.On("GetProject", mock.Anything, "missing").Return(model.Project{}, model.ErrProjectNotFound).Once()
+
+		p, err := c.AddProject(ctx, Project{Name: "backend"})
+		require.NoError(t, err)
+		assert.Equal(t, Project{Name: "backend", Members: []string{}}, p)
+
+		_, err = c.GetProject(ctx, "missing")
+		assert.ErrorIs(t, err, ErrProjectNotFound)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		ar.On("GetAttachmentsByTask", mock.Anything, 1105).Return(nil, nil).Once()
+		tr.On("DeleteTask", mock.Anything, 1105).Return(nil).Once()
+
+		assert.NoError(t, c.DeleteTask(ctx, 1105))
+	})
+
+	t.Run("calendar export", func(t *testing.T) {
+		tr.On("GetTasksByDateAndStatus", mock.Anything, date, false).Return([]model.TodoTask{
+			{Id: 1161, Title: "Calendar", PlanningDate: date, State: "todo", Version: 1},
+		}, nil).Once()
+		tr.On("GetTasksByDateAndStatus", mock.Anything, date, true).Return(nil, nil).Once()
+
+		ics, err := c.ExportCalendar(ctx, date, date, "vtodo")
+		require.NoError(t, err)
+		assert.Contains(t, string(ics), "UID:task-1161-vtodo@todo-list\r\n")
+		assert.NotContains(t, string(ics), "VEVENT")
+	})
+
+	t.Run("calendar disabled", func(t *testing.T) {
+		_, err := c.As("alice").IssueCalendarToken(ctx)
+		assert.ErrorIs(t, err, ErrCalendarDisabled)
+		_, err = c.As("alice").IssueCalDAVPassword(ctx)
+		assert.ErrorIs(t, err, ErrCalendarDisabled)
+	})
+
+	t.Run("import and export", func(t *testing.T) {
+		tr.On("GetTasksByDateAndStatus", mock.Anything, date, false).Return([]model.TodoTask{{Id: 1171, Title: "Client"}}, nil).Once()
+		tr.On("GetTasksByDateAndStatus", mock.Anything, date, true).Return([]model.TodoTask{}, nil).Once()
+
+		report, err := c.ImportTodoTxt(ctx, strings.NewReader("(A) Imported due:2027-06-01\nClient due:2027-06-01\n"), "", true)
+		require.NoError(t, err)
+		assert.Equal(t, ImportBestEffort, report.Mode)
+		assert.True(t, report.DryRun)
+		require.Len(t, report.Results, 2)
+		assert.Equal(t, "Imported pri:A", report.Results[0].Task.Title)
+		assert.Equal(t, []string{"task has no priority, it is kept in the title as pri:A"}, report.Results[0].Warnings)
+		assert.NoError(t, report.Results[0].Err)
+		assert.ErrorIs(t, report.Results[1].Err, ErrDuplicateTask)
+
+		tr.On("GetTasksByDateAndStatus", mock.Anything, date, false).Return([]model.TodoTask{{Id: 1171, Title: "Client"}}, nil).Once()
+		tr.On("GetTasksByDateAndStatus", mock.Anything, date, true).Return([]model.TodoTask{}, nil).Once()
+		report, err = c.ImportTasks(ctx, ImportCSV, strings.NewReader("title,planning_date\nImported,2027-06-01\nClient,2027-06-01\n"), ImportTransaction, true)
+		require.NoError(t, err)
+		assert.Equal(t, ImportTransaction, report.Mode)
+		require.Len(t, report.Results, 2)
+		assert.ErrorIs(t, report.Results[0].Err, ErrImportAborted)
+		assert.Equal(t, "Imported", report.Results[0].Task.Title)
+		assert.ErrorIs(t, report.Results[1].Err, ErrDuplicateTask)
+
+		_, err = c.ImportTodoTxt(ctx, strings.NewReader("Imported due:2027-06-01\n"), "all", false)
+		assert.ErrorIs(t, err, ErrInvalidInput)
+
+		tr.On("GetTasksByStatus", mock.Anything, false, 0, 100).Return([]model.TodoTask{{Id: 1172, Title: "Exported", PlanningDate: date, State: "todo"}}, nil).Once()
+		tr.On("GetTasksByStatus", mock.Anything, true, 0, 100).Return([]model.TodoTask{}, nil).Once()
+		b, err := c.ExportTodoTxt(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "Exported due:2027-06-01\n", string(b))
+	})
+
+	tr.AssertExpectations(t)
+	br.AssertExpectations(t)
+	cr.AssertExpectations(t)
+	ar.AssertExpectations(t)
+}
+
+// flaky fails first failures requests with status 503
+func flaky(h http.Handler, failures int32, calls *atomic.Int32) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+func TestRetries(t *testing.T) {
+	tr := new(mocks.TaskRepo)
+	a := app.New(app.Deps{Tasks: tr}, app.Config{})
+	ctx := context.Background()
+
+	t.Run("GET is retried", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := httptest.NewServer(flaky(httpserver.New("", a).Handler, 2, &calls))
+		defer srv.Close()
+		tr.On("GetTaskById", mock.Anything, 1106).Return(model.TodoTask{Id: 1106}, nil).Once()
+
+		task, err := New(Config{BaseURL: srv.URL + apiPrefix, RetryDelay: time.Millisecond}).GetTaskById(ctx, 1106)
+		require.NoError(t, err)
+		assert.Equal(t, 1106, task.Id)
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("retries are exhausted", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := httptest.NewServer(flaky(httpserver.New("", a).Handler, 5, &calls))
+		defer srv.Close()
+
+		_, err := New(Config{BaseURL: srv.URL + apiPrefix, Retries: 1, RetryDelay: time.Millisecond}).GetTaskById(ctx, 1107)
+		var apiErr *Error
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("POST is not retried", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := httptest.NewServer(flaky(httpserver.New("", a).Handler, 1, &calls))
+		defer srv.Close()
+
+		_, err := New(Config{BaseURL: srv.URL + apiPrefix, RetryDelay: time.Millisecond}).SnoozeTask(ctx, 1108, 1)
+		var apiErr *Error
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("context is canceled", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := httptest.NewServer(flaky(httpserver.New("", a).Handler, 5, &calls))
+		defer srv.Close()
+		ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+
+		_, err := New(Config{BaseURL: srv.URL + apiPrefix, Retries: 5, RetryDelay: time.Second}).GetTaskById(ctx, 1109)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	tr.AssertExpectations(t)
+}
+
+func TestEventStream(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.URL.Query().Get("assigned_to_me"))
+		assert.Equal(t, "2027-06-01", r.URL.Query().Get("date"))
+		assert.Equal(t, "7", r.URL.Query().Get("last_event_id"))
+		assert.Equal(t, "alice", r.Header.Get(userHeader))
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, strings.Join([]string{
+			"retry: 3000\n",
+			"event: reset\ndata: {}\n",
+			": heartbeat\n",
+			fmt.Sprintf("id: 8\nevent: %s\ndata: {\"id\":1110}\n\n", model.EventTaskUpdated),
+		}, "\n"))
+	}))
+	defer srv.Close()
+
+	c := New(Config{BaseURL: srv.URL, User: "alice"})
+	stream, err := c.SubscribeEvents(context.Background(), EventFilter{
+		AssignedToMe: true,
+		Date:         model.Date{Year: 2027, Month: time.June, Day: 1},
+	}, 7)
+	require.NoError(t, err)
+	defer func() {
+		_ = stream.Close()
+	}()
+
+	e, err := stream.Next()
+	require.NoError(t, err)
+	assert.Equal(t, EventReset, e.Type)
+
+	e, err = stream.Next()
+	require.NoError(t, err)
+	assert.Equal(t, Event{Id: 8, Type: model.EventTaskUpdated, Payload: []byte(`{"id":1110}`)}, e)
+	assert.Equal(t, 8, stream.LastEventId())
+
+	_, err = stream.Next()
+	assert.True(t, errors.Is(err, io.EOF))
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// AddComment adds comment of the user of the client to the task with given id
+func (c *Client) AddComment(ctx context.Context, taskId int, text string) (Comment, error) {
+	var d commentData
+	if err := c.do(ctx, request{method: http.MethodPost, path: fmt.Sprintf("/task/%d/comments", taskId), body: struct {
+		Text string `json:"text"`
+	}{text}}, &d); err != nil {
+		return Comment{}, err
+	}
+	return d.comment(), nil
+}
+
+// UpdateComment changes text of the comment, only its author can do it
+func (c *Client) UpdateComment(ctx context.Context, taskId int, id int, text string) (Comment, error) {
+	var d commentData
+	if err := c.do(ctx, request{method: http.MethodPut, path: fmt.Sprintf("/task/%d/comments/%d", taskId, id), body: struct {
+		Text string `json:"text"`
+	}{text}}, &d); err != nil {
+		return Comment{}, err
+	}
+	return d.comment(), nil
+}
+
+// DeleteComment deletes comment, only its author can do it
+func (c *Client) DeleteComment(ctx context.Context, taskId int, id int) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: fmt.Sprintf("/task/%d/comments/%d", taskId, id)}, nil)
+}
+
+// GetCommentsByTask returns comments of the task from oldest to newest with pagination
+func (c *Client) GetCommentsByTask(ctx context.Context, taskId int, offset int, limit int) ([]Comment, error) {
+	var d []commentData
+	if err := c.do(ctx, request{method: http.MethodGet, path: fmt.Sprintf("/task/%d/comments", taskId), body: page{offset, limit}}, &d); err != nil {
+		return nil, err
+	}
+	comments := make([]Comment, 0, len(d))
+	for _, cd := range d {
+		comments = append(comments, cd.comment())
+	}
+	return comments, nil
+}
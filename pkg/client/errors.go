@@ -0,0 +1,110 @@
+package client
+
+import (
+	"errors"
+	"todo-list/internal/model"
+)
+
+// errors returned by the API
+var (
+	ErrTaskRepo        = model.ErrTaskRepo
+	ErrTaskNotFound    = model.ErrTaskNotFound
+	ErrInvalidTask     = model.ErrInvalidTask
+	ErrInvalidInput    = model.ErrInvalidInput
+	ErrTaskBlocked     = model.ErrTaskBlocked
+	ErrDependencyCycle = model.ErrDependencyCycle
+	ErrUnknownState    = model.ErrUnknownState
+	ErrTransition      = model.ErrTransition
+	ErrVersionConflict = model.ErrVersionConflict
+	ErrDayOrder        = model.ErrDayOrder
+	ErrNotMember       = model.ErrNotMember
+	ErrUnknownUser     = model.ErrUnknownUser
+	ErrForbidden       = model.ErrForbidden
+	ErrUnknown         = model.ErrUnknown
+
+	ErrCommentNotFound = model.ErrCommentNotFound
+	ErrInvalidComment  = model.ErrInvalidComment
+
+	ErrProjectNotFound = model.ErrProjectNotFound
+	ErrProjectExists   = model.ErrProjectExists
+	ErrMemberAssigned  = model.ErrMemberAssigned
+
+	ErrBlobStore          = model.ErrBlobStore
+	ErrAttachmentNotFound = model.ErrAttachmentNotFound
+	ErrInvalidAttachment  = model.ErrInvalidAttachment
+	ErrAttachmentTooLarge = model.ErrAttachmentTooLarge
+
+	ErrReminderNotFound = model.ErrReminderNotFound
+	ErrInvalidReminder  = model.ErrInvalidReminder
+
+	ErrSubscriptionNotFound = model.ErrSubscriptionNotFound
+	ErrInvalidSubscription  = model.ErrInvalidSubscription
+
+	ErrCalendarDisabled = model.ErrCalendarDisabled
+	ErrFeedNotFound     = model.ErrFeedNotFound
+	ErrWrongPassword    = model.ErrWrongPassword
+
+	ErrDuplicateTask = model.ErrDuplicateTask
+	ErrImportAborted = model.ErrImportAborted
+)
+
+// apiErrors are the errors the API reports by their text
+var apiErrors = []error{
+	ErrTaskRepo,
+	ErrTaskNotFound,
+	ErrInvalidTask,
+	ErrInvalidInput,
+	ErrTaskBlocked,
+	ErrDependencyCycle,
+	ErrUnknownState,
+	ErrTransition,
+	ErrVersionConflict,
+	ErrDayOrder,
+	ErrNotMember,
+	ErrUnknownUser,
+	ErrForbidden,
+	ErrUnknown,
+	ErrCommentNotFound,
+	ErrInvalidComment,
+	ErrProjectNotFound,
+	ErrProjectExists,
+	ErrMemberAssigned,
+	ErrBlobStore,
+	ErrAttachmentNotFound,
+	ErrInvalidAttachment,
+	ErrAttachmentTooLarge,
+	ErrReminderNotFound,
+	ErrInvalidReminder,
+	ErrSubscriptionNotFound,
+	ErrInvalidSubscription,
+	ErrCalendarDisabled,
+	ErrFeedNotFound,
+	ErrWrongPassword,
+	ErrDuplicateTask,
+	ErrImportAborted,
+}
+
+// Error is an error response of the API with its HTTP status
+type Error struct {
+	StatusCode int
+	Err        error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// errorOf returns the error of the API with given text, unknown text is
+// returned as a new error
+func errorOf(msg string) error {
+	for _, err := range apiErrors {
+		if err.Error() == msg {
+			return err
+		}
+	}
+	return errors.New(msg)
+}
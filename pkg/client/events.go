@@ -0,0 +1,122 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"todo-list/internal/model"
+)
+
+// EventReset is a type of the event sent instead of the events lost by the
+// server, the client should reload the tasks after it
+const EventReset = "reset"
+
+// Event is a change of the task received from the stream, its Payload is JSON
+type Event = model.Event
+
+// EventFilter limits the events of the stream to the tasks assigned to the
+// user of the client, planned on Date and belonging to Project before or
+// after the change, zero fields don't limit them
+type EventFilter struct {
+	AssignedToMe bool
+	Date         Date
+	Project      string
+}
+
+// EventStream reads events sent by the server, it must be closed by caller
+type EventStream struct {
+	body   io.ReadCloser
+	r      *bufio.Reader
+	lastId int
+}
+
+// SubscribeEvents opens the stream of events about creation, update and
+// deletion of the tasks matched by the filter which follow the event with
+// lastEventId, zero lastEventId starts from the new events
+func (c *Client) SubscribeEvents(ctx context.Context, filter EventFilter, lastEventId int) (*EventStream, error) {
+	query := url.Values{}
+	if filter.AssignedToMe {
+		query.Set("assigned_to_me", "true")
+	}
+	if filter.Date != (Date{}) {
+		query.Set("date", fmt.Sprintf("%04d-%02d-%02d", filter.Date.Year, filter.Date.Month, filter.Date.Day))
+	}
+	if filter.Project != "" {
+		query.Set("project", filter.Project)
+	}
+	if lastEventId > 0 {
+		query.Set("last_event_id", strconv.Itoa(lastEventId))
+	}
+
+	resp, err := c.send(ctx, request{method: http.MethodGet, path: "/events", query: query})
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		return nil, decode(resp, nil)
+	}
+	return &EventStream{body: resp.Body, r: bufio.NewReader(resp.Body), lastId: lastEventId}, nil
+}
+
+// Next blocks until the next event and returns it, event of type EventReset
+// has no task. io.EOF is returned when the server closes the stream, then
+// the client subscribes again with LastEventId
+func (s *EventStream) Next() (Event, error) {
+	var (
+		e       Event
+		data    []string
+		hasData bool
+	)
+	for {
+		line, err := s.r.ReadString('\n')
+		if err != nil {
+			return Event{}, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		if line == "" {
+			if e.Type == "" && !hasData {
+				continue
+			}
+			e.Payload = []byte(strings.Join(data, "\n"))
+			if e.Id > 0 {
+				s.lastId = e.Id
+			}
+			return e, nil
+		}
+		if strings.HasPrefix(line, ":") { // heartbeat
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			if e.Id, err = strconv.Atoi(value); err != nil {
+				return Event{}, fmt.Errorf("invalid id of the event: %q", value)
+			}
+		case "event":
+			e.Type = value
+		case "data":
+			data, hasData = append(data, value), true
+		}
+	}
+}
+
+// LastEventId returns id of the last received event
+func (s *EventStream) LastEventId() int {
+	return s.lastId
+}
+
+// Close closes the stream
+func (s *EventStream) Close() error {
+	return s.body.Close()
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// GraphQLError is an error of the GraphQL query, it is returned together
+// with data of the fields resolved without errors
+type GraphQLError struct {
+	Messages []string
+}
+
+func (e *GraphQLError) Error() string {
+	return "graphql: " + strings.Join(e.Messages, "; ")
+}
+
+type graphqlResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// GraphQL executes the query with variables and decodes its data into out if
+// it is not nil. Errors of the query are returned as *GraphQLError, for
+// rejected queries it is wrapped into *Error with the status of the response
+func (c *Client) GraphQL(ctx context.Context, query string, variables map[string]any, out any) error {
+	resp, err := c.send(ctx, request{method: http.MethodPost, path: "/graphql", body: struct {
+		Query     string         `json:"query"`
+		Variables map[string]any `json:"variables,omitempty"`
+	}{query, variables}})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	var gr graphqlResponse
+	if err = json.NewDecoder(resp.Body).Decode(&gr); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return &Error{StatusCode: resp.StatusCode, Err: fmt.Errorf("unexpected response: %s", resp.Status)}
+		}
+		return err
+	}
+	if out != nil && len(gr.Data) > 0 && string(gr.Data) != "null" {
+		if err = json.Unmarshal(gr.Data, out); err != nil {
+			return err
+		}
+	}
+
+	if len(gr.Errors) > 0 {
+		gerr := &GraphQLError{Messages: make([]string, 0, len(gr.Errors))}
+		for _, e := range gr.Errors {
+			gerr.Messages = append(gerr.Messages, e.Message)
+		}
+		err = gerr
+	}
+	if resp.StatusCode != http.StatusOK {
+		if err == nil {
+			err = errors.New(resp.Status)
+		}
+		return &Error{StatusCode: resp.StatusCode, Err: err}
+	}
+	return err
+}
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"todo-list/internal/model"
+)
+
+// modes of the import, rows are added in a single transaction only if all of
+// them are valid in the transaction mode
+const (
+	ImportBestEffort  = model.ImportBestEffort
+	ImportTransaction = model.ImportTransaction
+)
+
+// media types of the files of the bulk import
+const (
+	ImportCSV       = "text/csv"
+	ImportJSONLines = "application/x-ndjson"
+)
+
+// ImportTasks adds tasks from CSV or JSON lines file of given media type read
+// from r and returns outcome of every row, empty mode is the best effort mode.
+// Nothing is added in the dry run. The request is not retried because r can't
+// be read twice
+func (c *Client) ImportTasks(ctx context.Context, mediaType string, r io.Reader, mode string, dryRun bool) (ImportReport, error) {
+	return c.importFile(ctx, "/task/import", mediaType, r, mode, dryRun)
+}
+
+// ImportTodoTxt adds tasks from todo.txt file read from r like ImportTasks
+func (c *Client) ImportTodoTxt(ctx context.Context, r io.Reader, mode string, dryRun bool) (ImportReport, error) {
+	return c.importFile(ctx, "/task/import/todotxt", "text/plain", r, mode, dryRun)
+}
+
+// ExportTodoTxt returns todo.txt file with all tasks
+func (c *Client) ExportTodoTxt(ctx context.Context) ([]byte, error) {
+	resp, err := c.send(ctx, request{method: http.MethodGet, path: "/task/export/todotxt"})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, decode(resp, nil)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// importFile sends the file to the route of the import
+func (c *Client) importFile(ctx context.Context, path string, mediaType string, r io.Reader, mode string, dryRun bool) (ImportReport, error) {
+	query := url.Values{}
+	if mode != "" {
+		query.Set("mode", mode)
+	}
+	if dryRun {
+		query.Set("dry_run", strconv.FormatBool(dryRun))
+	}
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, r)
+	if err != nil {
+		return ImportReport{}, err
+	}
+	req.Header.Set("Content-Type", mediaType)
+	if c.user != "" {
+		req.Header.Set(userHeader, c.user)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return ImportReport{}, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	var d importReportData
+	if err = decode(resp, &d); err != nil {
+		return ImportReport{}, err
+	}
+	return d.report(), nil
+}
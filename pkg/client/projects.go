@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// projectCall sends the request which returns the project
+func (c *Client) projectCall(ctx context.Context, r request) (Project, error) {
+	var d projectData
+	if err := c.do(ctx, r, &d); err != nil {
+		return Project{}, err
+	}
+	return d.project(), nil
+}
+
+// SetTaskProject moves the task to the project, empty project leaves the task
+// only in the workspace
+func (c *Client) SetTaskProject(ctx context.Context, id int, project string) (Task, error) {
+	return c.taskCall(ctx, request{method: http.MethodPut, path: fmt.Sprintf("/task/%d/project", id), body: struct {
+		Project string `json:"project"`
+	}{project}})
+}
+
+// AddProject adds the project with its members
+func (c *Client) AddProject(ctx context.Context, p Project) (Project, error) {
+	return c.projectCall(ctx, request{method: http.MethodPost, path: "/project", body: projectData(p)})
+}
+
+// GetProject returns the project with given name
+func (c *Client) GetProject(ctx context.Context, name string) (Project, error) {
+	return c.projectCall(ctx, request{method: http.MethodGet, path: "/project/" + url.PathEscape(name)})
+}
+
+// GetProjects returns all projects ordered by name
+func (c *Client) GetProjects(ctx context.Context) ([]Project, error) {
+	var d []projectData
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/project"}, &d); err != nil {
+		return nil, err
+	}
+	projects := make([]Project, 0, len(d))
+	for _, pd := range d {
+		projects = append(projects, pd.project())
+	}
+	return projects, nil
+}
+
+// AddProjectMember adds the user to the members of the project
+func (c *Client) AddProjectMember(ctx context.Context, name string, member string) (Project, error) {
+	return c.projectCall(ctx, request{method: http.MethodPost, path: fmt.Sprintf("/project/%s/members", url.PathEscape(name)), body: struct {
+		Member string `json:"member"`
+	}{member}})
+}
+
+// RemoveProjectMember removes the user from the members of the project, the
+// member can't be removed while assigned to the tasks of the project
+func (c *Client) RemoveProjectMember(ctx context.Context, name string, member string) (Project, error) {
+	return c.projectCall(ctx, request{
+		method: http.MethodDelete,
+		path:   fmt.Sprintf("/project/%s/members/%s", url.PathEscape(name), url.PathEscape(member)),
+	})
+}
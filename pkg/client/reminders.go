@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// AddReminder adds reminder to the task with given id, it fires at r.At if
+// it is set and r.Offset before the planning date of the task otherwise
+func (c *Client) AddReminder(ctx context.Context, taskId int, r Reminder) (Reminder, error) {
+	body := reminderRequest{
+		OffsetMinutes: int(r.Offset.Minutes()),
+		Channel:       r.Channel,
+		Recipient:     r.Recipient,
+	}
+	if !r.At.IsZero() {
+		body.At = &r.At
+	}
+	var d reminderData
+	if err := c.do(ctx, request{method: http.MethodPost, path: fmt.Sprintf("/task/%d/reminders", taskId), body: body}, &d); err != nil {
+		return Reminder{}, err
+	}
+	return d.reminder(), nil
+}
+
+// GetRemindersByTask returns reminders of the task
+func (c *Client) GetRemindersByTask(ctx context.Context, taskId int) ([]Reminder, error) {
+	var d []reminderData
+	if err := c.do(ctx, request{method: http.MethodGet, path: fmt.Sprintf("/task/%d/reminders", taskId)}, &d); err != nil {
+		return nil, err
+	}
+	reminders := make([]Reminder, 0, len(d))
+	for _, rd := range d {
+		reminders = append(reminders, rd.reminder())
+	}
+	return reminders, nil
+}
+
+// DeleteReminder deletes reminder of the task
+func (c *Client) DeleteReminder(ctx context.Context, taskId int, id int) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: fmt.Sprintf("/task/%d/reminders/%d", taskId, id)}, nil)
+}
+
+// GetDeliveriesByReminder returns attempts to deliver the reminder with pagination
+func (c *Client) GetDeliveriesByReminder(ctx context.Context, taskId int, id int, offset int, limit int) ([]Delivery, error) {
+	var d []deliveryData
+	if err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   fmt.Sprintf("/task/%d/reminders/%d/deliveries", taskId, id),
+		body:   page{offset, limit},
+	}, &d); err != nil {
+		return nil, err
+	}
+	deliveries := make([]Delivery, 0, len(d))
+	for _, dd := range d {
+		deliveries = append(deliveries, Delivery(dd))
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// GetChanges returns tasks changed and deleted since the sync token, empty
+// token returns all tasks
+func (c *Client) GetChanges(ctx context.Context, token string) (SyncChanges, error) {
+	var d syncData
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/sync", query: url.Values{"token": {token}}}, &d); err != nil {
+		return SyncChanges{}, err
+	}
+	return d.changes(), nil
+}
+
+// Sync applies changes made offline in their order and returns result of
+// every change with tasks changed on the server since the sync token.
+// Creations are applied once for the client id and their refs, so the
+// changes can be pushed again if the response is lost
+func (c *Client) Sync(ctx context.Context, clientId string, token string, changes []SyncChange) ([]SyncResult, SyncChanges, error) {
+	body := syncRequest{Client: clientId, Token: token, Changes: make([]syncChangeRequest, 0, len(changes))}
+	for _, ch := range changes {
+		body.Changes = append(body.Changes, syncChangeRequest{
+			Ref:     ch.Ref,
+			Op:      ch.Op,
+			TaskId:  ch.TaskId,
+			Version: ch.Version,
+			Task:    taskRequestOf(ch.Task),
+		})
+	}
+	var d syncData
+	if err := c.do(ctx, request{method: http.MethodPost, path: "/sync", body: body}, &d); err != nil {
+		return nil, SyncChanges{}, err
+	}
+	return d.results(), d.changes(), nil
+}
@@ -0,0 +1,263 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// page is a body of the requests with pagination
+type page struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+// taskCall sends the request which returns the task
+func (c *Client) taskCall(ctx context.Context, r request) (Task, error) {
+	var d taskData
+	if err := c.do(ctx, r, &d); err != nil {
+		return Task{}, err
+	}
+	return d.task(), nil
+}
+
+// tasksCall sends the request which returns the list of tasks
+func (c *Client) tasksCall(ctx context.Context, r request) ([]Task, error) {
+	var d []taskData
+	if err := c.do(ctx, r, &d); err != nil {
+		return nil, err
+	}
+	return tasksOf(d), nil
+}
+
+// AddTask adds the task, only its title, description, planning date, status,
+// state and project are sent
+func (c *Client) AddTask(ctx context.Context, t Task) (Task, error) {
+	return c.taskCall(ctx, request{method: http.MethodPost, path: "/task", body: taskRequestOf(t)})
+}
+
+// GetTaskById returns the task with given id
+func (c *Client) GetTaskById(ctx context.Context, id int) (Task, error) {
+	return c.taskCall(ctx, request{method: http.MethodGet, path: fmt.Sprintf("/task/%d", id)})
+}
+
+// GetTaskByText returns tasks with given text in title or description
+func (c *Client) GetTaskByText(ctx context.Context, text string) ([]Task, error) {
+	return c.tasksCall(ctx, request{method: http.MethodGet, path: "/task", body: struct {
+		Text string `json:"text"`
+	}{text}})
+}
+
+// UpdateTask updates fields of the task with given id
+func (c *Client) UpdateTask(ctx context.Context, id int, t Task) (Task, error) {
+	return c.taskCall(ctx, request{method: http.MethodPut, path: fmt.Sprintf("/task/%d", id), body: taskRequestOf(t)})
+}
+
+// ForceUpdateTask updates fields of the task with given id even if it is
+// marked as done while its blockers are not done
+func (c *Client) ForceUpdateTask(ctx context.Context, id int, t Task) (Task, error) {
+	return c.taskCall(ctx, request{
+		method: http.MethodPut,
+		path:   fmt.Sprintf("/task/%d", id),
+		query:  url.Values{"force": {"true"}},
+		body:   taskRequestOf(t),
+	})
+}
+
+// DeleteTask deletes the task with given id
+func (c *Client) DeleteTask(ctx context.Context, id int) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: fmt.Sprintf("/task/%d", id)}, nil)
+}
+
+// GetTasksByStatus returns tasks filtered by status with pagination
+func (c *Client) GetTasksByStatus(ctx context.Context, status bool, offset int, limit int) ([]Task, error) {
+	return c.tasksCall(ctx, request{method: http.MethodGet, path: "/task/by_status", body: struct {
+		Status bool `json:"status"`
+		page
+	}{status, page{offset, limit}}})
+}
+
+// GetTasksByState returns tasks in given state of the workflow ordered by rank with pagination
+func (c *Client) GetTasksByState(ctx context.Context, state string, offset int, limit int) ([]Task, error) {
+	return c.tasksCall(ctx, request{method: http.MethodGet, path: "/task/by_state", body: struct {
+		State string `json:"state"`
+		page
+	}{state, page{offset, limit}}})
+}
+
+// GetTasksByDateAndStatus returns tasks filtered by planning date and status in order of the day
+func (c *Client) GetTasksByDateAndStatus(ctx context.Context, date Date, status bool) ([]Task, error) {
+	return c.tasksCall(ctx, request{method: http.MethodGet, path: "/task/by_date", body: struct {
+		PlanningDate dateData `json:"planning_date"`
+		Status       bool     `json:"status"`
+	}{dateDataOf(date), status}})
+}
+
+// GetOverdueTasks returns undone tasks flagged as overdue with pagination
+func (c *Client) GetOverdueTasks(ctx context.Context, offset int, limit int) ([]Task, error) {
+	return c.tasksCall(ctx, request{method: http.MethodGet, path: "/task/overdue", body: page{offset, limit}})
+}
+
+// AssignTask adds the user to the assignees of the task
+func (c *Client) AssignTask(ctx context.Context, id int, assignee string) (Task, error) {
+	return c.taskCall(ctx, request{method: http.MethodPost, path: fmt.Sprintf("/task/%d/assignees", id), body: struct {
+		Assignee string `json:"assignee"`
+	}{assignee}})
+}
+
+// UnassignTask removes the user from the assignees of the task
+func (c *Client) UnassignTask(ctx context.Context, id int, assignee string) (Task, error) {
+	return c.taskCall(ctx, request{
+		method: http.MethodDelete,
+		path:   fmt.Sprintf("/task/%d/assignees/%s", id, url.PathEscape(assignee)),
+	})
+}
+
+// GetTasksByAssignee returns tasks assigned to the user with pagination
+func (c *Client) GetTasksByAssignee(ctx context.Context, assignee string, offset int, limit int) ([]Task, error) {
+	return c.tasksCall(ctx, request{method: http.MethodGet, path: "/task/by_assignee", body: struct {
+		Assignee string `json:"assignee"`
+		page
+	}{assignee, page{offset, limit}}})
+}
+
+// GetTasksAssignedToMe returns tasks assigned to the user of the client with pagination
+func (c *Client) GetTasksAssignedToMe(ctx context.Context, offset int, limit int) ([]Task, error) {
+	return c.tasksCall(ctx, request{method: http.MethodGet, path: "/task/assigned_to_me", body: page{offset, limit}})
+}
+
+// BlockTask makes task with blockerId a blocker of task with blockedId and
+// returns updated blocked task
+func (c *Client) BlockTask(ctx context.Context, blockerId int, blockedId int) (Task, error) {
+	return c.taskCall(ctx, request{method: http.MethodPost, path: fmt.Sprintf("/task/%d/blockers", blockedId), body: struct {
+		BlockerId int `json:"blocker_id"`
+	}{blockerId}})
+}
+
+// UnblockTask removes task with blockerId from blockers of task with
+// blockedId and returns updated blocked task
+func (c *Client) UnblockTask(ctx context.Context, blockerId int, blockedId int) (Task, error) {
+	return c.taskCall(ctx, request{method: http.MethodDelete, path: fmt.Sprintf("/task/%d/blockers/%d", blockedId, blockerId)})
+}
+
+// GetDependencyGraph returns the task with all tasks which transitively
+// block it or are blocked by it
+func (c *Client) GetDependencyGraph(ctx context.Context, id int) (DependencyGraph, error) {
+	var d dependencyGraphData
+	if err := c.do(ctx, request{method: http.MethodGet, path: fmt.Sprintf("/task/%d/dependencies", id)}, &d); err != nil {
+		return DependencyGraph{}, err
+	}
+	graph := DependencyGraph{
+		Tasks:        tasksOf(d.Tasks),
+		Dependencies: make([]Dependency, 0, len(d.Dependencies)),
+	}
+	for _, dep := range d.Dependencies {
+		graph.Dependencies = append(graph.Dependencies, Dependency(dep))
+	}
+	return graph, nil
+}
+
+// GetWorkflow returns states of the tasks and allowed transitions between them
+func (c *Client) GetWorkflow(ctx context.Context) (Workflow, error) {
+	var d workflowData
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/workflow"}, &d); err != nil {
+		return Workflow{}, err
+	}
+	w := Workflow{
+		States:      make([]State, 0, len(d.States)),
+		Transitions: d.Transitions,
+	}
+	for _, s := range d.States {
+		w.States = append(w.States, State(s))
+	}
+	return w, nil
+}
+
+// MoveTask moves the task to the column of the board and place in it described by m
+func (c *Client) MoveTask(ctx context.Context, id int, m Move) (Task, error) {
+	return c.taskCall(ctx, request{method: http.MethodPost, path: fmt.Sprintf("/task/%d/move", id), body: struct {
+		State    string `json:"state"`
+		BeforeId int    `json:"before_id"`
+		AfterId  int    `json:"after_id"`
+	}{m.State, m.Before, m.After}})
+}
+
+// GetBoard returns columns for all states of the workflow with at most limit
+// tasks in each of them
+func (c *Client) GetBoard(ctx context.Context, limit int) (Board, error) {
+	var d boardData
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/board", body: struct {
+		Limit int `json:"limit"`
+	}{limit}}, &d); err != nil {
+		return Board{}, err
+	}
+	b := Board{Columns: make([]Column, 0, len(d.Columns))}
+	for _, col := range d.Columns {
+		b.Columns = append(b.Columns, Column{
+			State: State{Name: col.State, Done: col.Done},
+			Tasks: tasksOf(col.Tasks),
+		})
+	}
+	return b, nil
+}
+
+// ReorderDay sets order of the tasks planned on the date and returns them in the new order
+func (c *Client) ReorderDay(ctx context.Context, date Date, ids []int) ([]Task, error) {
+	return c.tasksCall(ctx, request{method: http.MethodPut, path: "/task/by_date/order", body: struct {
+		PlanningDate dateData `json:"planning_date"`
+		Ids          []int    `json:"ids"`
+	}{dateDataOf(date), ids}})
+}
+
+// GetHistoryByTask returns moves of the task between days from oldest to newest with pagination
+func (c *Client) GetHistoryByTask(ctx context.Context, taskId int, offset int, limit int) ([]HistoryEntry, error) {
+	var d []historyEntryData
+	if err := c.do(ctx, request{method: http.MethodGet, path: fmt.Sprintf("/task/%d/history", taskId), body: page{offset, limit}}, &d); err != nil {
+		return nil, err
+	}
+	history := make([]HistoryEntry, 0, len(d))
+	for _, e := range d {
+		history = append(history, HistoryEntry{
+			Id:        e.Id,
+			TaskId:    e.TaskId,
+			Action:    e.Action,
+			FromDate:  e.FromDate.date(),
+			ToDate:    e.ToDate.date(),
+			CreatedAt: e.CreatedAt,
+		})
+	}
+	return history, nil
+}
+
+// SnoozeTask moves the task days later
+func (c *Client) SnoozeTask(ctx context.Context, id int, days int) (Task, error) {
+	return c.taskCall(ctx, request{method: http.MethodPost, path: fmt.Sprintf("/task/%d/snooze", id), body: struct {
+		Days int `json:"days"`
+	}{days}})
+}
+
+// RescheduleToWeekday moves the task to the nearest given day of the week
+func (c *Client) RescheduleToWeekday(ctx context.Context, id int, weekday time.Weekday) (Task, error) {
+	return c.taskCall(ctx, request{method: http.MethodPost, path: fmt.Sprintf("/task/%d/next_weekday", id), body: struct {
+		Weekday string `json:"weekday"`
+	}{strings.ToLower(weekday.String())}})
+}
+
+// RescheduleTask moves the task to the date
+func (c *Client) RescheduleTask(ctx context.Context, id int, date Date) (Task, error) {
+	return c.taskCall(ctx, request{method: http.MethodPost, path: fmt.Sprintf("/task/%d/reschedule", id), body: struct {
+		PlanningDate dateData `json:"planning_date"`
+	}{dateDataOf(date)}})
+}
+
+// RescheduleDay moves all undone tasks of the date from to the end of the
+// date to and returns all tasks of the date to in order of the day
+func (c *Client) RescheduleDay(ctx context.Context, from Date, to Date) ([]Task, error) {
+	return c.tasksCall(ctx, request{method: http.MethodPost, path: "/task/by_date/reschedule", body: struct {
+		From dateData `json:"from"`
+		To   dateData `json:"to"`
+	}{dateDataOf(from), dateDataOf(to)}})
+}
@@ -0,0 +1,370 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+	"todo-list/internal/model"
+)
+
+// entities returned by the client, they are the entities of the app
+type (
+	Task            = model.TodoTask
+	Date            = model.Date
+	State           = model.State
+	Workflow        = model.Workflow
+	Move            = model.Move
+	Column          = model.Column
+	Board           = model.Board
+	Dependency      = model.Dependency
+	DependencyGraph = model.DependencyGraph
+	HistoryEntry    = model.HistoryEntry
+	Comment         = model.Comment
+	Project         = model.Project
+	Attachment      = model.Attachment
+	Reminder        = model.Reminder
+	Delivery        = model.Delivery
+	Subscription    = model.Subscription
+	WebhookDelivery = model.WebhookDelivery
+	Tombstone       = model.Tombstone
+	SyncChanges     = model.SyncChanges
+	SyncChange      = model.SyncChange
+	SyncResult      = model.SyncResult
+	ImportResult    = model.ImportResult
+	ImportReport    = model.ImportReport
+)
+
+type dateData struct {
+	Year  int `json:"year"`
+	Month int `json:"month"`
+	Day   int `json:"day"`
+}
+
+func dateDataOf(d Date) dateData {
+	return dateData{Year: d.Year, Month: int(d.Month), Day: d.Day}
+}
+
+func (d dateData) date() Date {
+	return Date{Year: d.Year, Month: time.Month(d.Month), Day: d.Day}
+}
+
+// taskRequest is a body of the requests which add and update the task
+type taskRequest struct {
+	Title        string   `json:"title"`
+	Description  string   `json:"description"`
+	PlanningDate dateData `json:"planning_date"`
+	Status       bool     `json:"status"`
+	Project      string   `json:"project,omitempty"`
+	State        string   `json:"state"`
+}
+
+// taskRequestOf returns the request with the fields of the task, the project
+// is used only when the task is added
+func taskRequestOf(t Task) taskRequest {
+	return taskRequest{
+		Title:        t.Title,
+		Description:  t.Description,
+		PlanningDate: dateDataOf(t.PlanningDate),
+		Status:       t.Status,
+		Project:      t.Project,
+		State:        t.State,
+	}
+}
+
+type taskData struct {
+	Id           int      `json:"id"`
+	Title        string   `json:"title"`
+	Description  string   `json:"description"`
+	PlanningDate dateData `json:"planning_date"`
+	Status       bool     `json:"status"`
+	State        string   `json:"state"`
+	Rank         string   `json:"rank"`
+	Position     int      `json:"position"`
+	Overdue      bool     `json:"overdue"`
+	Postponed    int      `json:"postponed"`
+	Assignees    []string `json:"assignees"`
+	Project      string   `json:"project"`
+	Blocked      bool     `json:"blocked"`
+	Version      int      `json:"version"`
+}
+
+func (d taskData) task() Task {
+	return Task{
+		Id:           d.Id,
+		Title:        d.Title,
+		Description:  d.Description,
+		PlanningDate: d.PlanningDate.date(),
+		Status:       d.Status,
+		State:        d.State,
+		Rank:         d.Rank,
+		Position:     d.Position,
+		Overdue:      d.Overdue,
+		Postponed:    d.Postponed,
+		Assignees:    d.Assignees,
+		Project:      d.Project,
+		Blocked:      d.Blocked,
+		Version:      d.Version,
+	}
+}
+
+func tasksOf(data []taskData) []Task {
+	tasks := make([]Task, 0, len(data))
+	for _, d := range data {
+		tasks = append(tasks, d.task())
+	}
+	return tasks
+}
+
+type stateData struct {
+	Name string `json:"name"`
+	Done bool   `json:"done"`
+}
+
+type workflowData struct {
+	States      []stateData         `json:"states"`
+	Transitions map[string][]string `json:"transitions"`
+}
+
+type columnData struct {
+	State string     `json:"state"`
+	Done  bool       `json:"done"`
+	Tasks []taskData `json:"tasks"`
+}
+
+type boardData struct {
+	Columns []columnData `json:"columns"`
+}
+
+type dependencyData struct {
+	BlockerId int `json:"blocker_id"`
+	BlockedId int `json:"blocked_id"`
+}
+
+type dependencyGraphData struct {
+	Tasks        []taskData       `json:"tasks"`
+	Dependencies []dependencyData `json:"dependencies"`
+}
+
+type historyEntryData struct {
+	Id        int       `json:"id"`
+	TaskId    int       `json:"task_id"`
+	Action    string    `json:"action"`
+	FromDate  dateData  `json:"from_date"`
+	ToDate    dateData  `json:"to_date"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type commentData struct {
+	Id        int       `json:"id"`
+	TaskId    int       `json:"task_id"`
+	Author    string    `json:"author"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (d commentData) comment() Comment {
+	return Comment(d)
+}
+
+type projectData struct {
+	Name    string   `json:"name"`
+	Members []string `json:"members"`
+}
+
+func (d projectData) project() Project {
+	return Project(d)
+}
+
+type attachmentData struct {
+	Id          int       `json:"id"`
+	TaskId      int       `json:"task_id"`
+	Name        string    `json:"name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (d attachmentData) attachment() Attachment {
+	return Attachment{
+		Id:          d.Id,
+		TaskId:      d.TaskId,
+		Name:        d.Name,
+		ContentType: d.ContentType,
+		Size:        d.Size,
+		CreatedAt:   d.CreatedAt,
+	}
+}
+
+type reminderRequest struct {
+	At            *time.Time `json:"at"`
+	OffsetMinutes int        `json:"offset_minutes"`
+	Channel       string     `json:"channel"`
+	Recipient     string     `json:"recipient"`
+}
+
+type reminderData struct {
+	Id            int        `json:"id"`
+	TaskId        int        `json:"task_id"`
+	At            *time.Time `json:"at"`
+	OffsetMinutes int        `json:"offset_minutes"`
+	Channel       string     `json:"channel"`
+	Recipient     string     `json:"recipient"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	FireAt        time.Time  `json:"fire_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+func (d reminderData) reminder() Reminder {
+	r := Reminder{
+		Id:        d.Id,
+		TaskId:    d.TaskId,
+		Offset:    time.Duration(d.OffsetMinutes) * time.Minute,
+		Channel:   d.Channel,
+		Recipient: d.Recipient,
+		Status:    d.Status,
+		Attempts:  d.Attempts,
+		FireAt:    d.FireAt,
+		CreatedAt: d.CreatedAt,
+	}
+	if d.At != nil {
+		r.At = *d.At
+	}
+	return r
+}
+
+type deliveryData struct {
+	Id         int       `json:"id"`
+	ReminderId int       `json:"reminder_id"`
+	Attempt    int       `json:"attempt"`
+	Error      string    `json:"error"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type subscriptionData struct {
+	Id        int       `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type webhookDeliveryData struct {
+	Id             int             `json:"id"`
+	SubscriptionId int             `json:"subscription_id"`
+	Event          string          `json:"event"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	ResponseCode   int             `json:"response_code"`
+	Error          string          `json:"error"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+type syncChangeRequest struct {
+	Ref     string      `json:"ref"`
+	Op      string      `json:"op"`
+	TaskId  int         `json:"task_id"`
+	Version int         `json:"version"`
+	Task    taskRequest `json:"task"`
+}
+
+type syncRequest struct {
+	Client  string              `json:"client"`
+	Token   string              `json:"token"`
+	Changes []syncChangeRequest `json:"changes"`
+}
+
+type tombstoneData struct {
+	TaskId    int       `json:"task_id"`
+	Version   int       `json:"version"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+type syncResultData struct {
+	Ref     string    `json:"ref"`
+	Status  string    `json:"status"`
+	TaskId  int       `json:"task_id"`
+	Task    *taskData `json:"task"`
+	Deleted bool      `json:"deleted"`
+	Err     *string   `json:"error"`
+}
+
+type syncData struct {
+	Results    []syncResultData `json:"results"`
+	Tasks      []taskData       `json:"tasks"`
+	Tombstones []tombstoneData  `json:"tombstones"`
+	Reset      bool             `json:"reset"`
+	Token      string           `json:"token"`
+}
+
+func (d syncData) changes() SyncChanges {
+	changes := SyncChanges{
+		Tasks:      tasksOf(d.Tasks),
+		Tombstones: make([]Tombstone, 0, len(d.Tombstones)),
+		Reset:      d.Reset,
+		Token:      d.Token,
+	}
+	for _, ts := range d.Tombstones {
+		changes.Tombstones = append(changes.Tombstones, Tombstone(ts))
+	}
+	return changes
+}
+
+type importResultData struct {
+	Line     int      `json:"line"`
+	Status   string   `json:"status"`
+	Task     taskData `json:"task"`
+	Warnings []string `json:"warnings"`
+	Errors   []string `json:"errors"`
+}
+
+type importReportData struct {
+	Mode    string             `json:"mode"`
+	DryRun  bool               `json:"dry_run"`
+	Created int                `json:"created"`
+	Skipped int                `json:"skipped"`
+	Results []importResultData `json:"results"`
+}
+
+func (d importReportData) report() ImportReport {
+	report := ImportReport{
+		Mode:    d.Mode,
+		DryRun:  d.DryRun,
+		Created: d.Created,
+		Skipped: d.Skipped,
+		Results: make([]ImportResult, 0, len(d.Results)),
+	}
+	for _, r := range d.Results {
+		res := ImportResult{Line: r.Line, Status: r.Status, Task: r.Task.task(), Warnings: r.Warnings}
+		errs := make([]error, 0, len(r.Errors))
+		for _, msg := range r.Errors {
+			errs = append(errs, errorOf(msg))
+		}
+		res.Err = errors.Join(errs...)
+		report.Results = append(report.Results, res)
+	}
+	return report
+}
+
+func (d syncData) results() []SyncResult {
+	results := make([]SyncResult, 0, len(d.Results))
+	for _, r := range d.Results {
+		res := SyncResult{
+			Ref:     r.Ref,
+			Status:  r.Status,
+			Task:    Task{Id: r.TaskId},
+			Deleted: r.Deleted,
+		}
+		if r.Task != nil {
+			res.Task = r.Task.task()
+		}
+		if r.Err != nil {
+			res.Err = errorOf(*r.Err)
+		}
+		results = append(results, res)
+	}
+	return results
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// AddSubscription subscribes the webhook to events of given types, the
+// secret is returned only by this method
+func (c *Client) AddSubscription(ctx context.Context, sub Subscription) (Subscription, error) {
+	var d subscriptionData
+	if err := c.do(ctx, request{method: http.MethodPost, path: "/webhooks", body: struct {
+		URL    string   `json:"url"`
+		Events []string `json:"events"`
+		Secret string   `json:"secret"`
+	}{sub.URL, sub.Events, sub.Secret}}, &d); err != nil {
+		return Subscription{}, err
+	}
+	return Subscription(d), nil
+}
+
+// GetSubscriptions returns all webhook subscriptions without secrets
+func (c *Client) GetSubscriptions(ctx context.Context) ([]Subscription, error) {
+	var d []subscriptionData
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/webhooks"}, &d); err != nil {
+		return nil, err
+	}
+	subs := make([]Subscription, 0, len(d))
+	for _, sd := range d {
+		subs = append(subs, Subscription(sd))
+	}
+	return subs, nil
+}
+
+// DeleteSubscription deletes webhook subscription with its deliveries
+func (c *Client) DeleteSubscription(ctx context.Context, id int) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: fmt.Sprintf("/webhooks/%d", id)}, nil)
+}
+
+// GetDeliveriesBySubscription returns deliveries of the events to the webhook with pagination
+func (c *Client) GetDeliveriesBySubscription(ctx context.Context, id int, offset int, limit int) ([]WebhookDelivery, error) {
+	var d []webhookDeliveryData
+	if err := c.do(ctx, request{method: http.MethodGet, path: fmt.Sprintf("/webhooks/%d/deliveries", id), body: page{offset, limit}}, &d); err != nil {
+		return nil, err
+	}
+	deliveries := make([]WebhookDelivery, 0, len(d))
+	for _, dd := range d {
+		deliveries = append(deliveries, WebhookDelivery{
+			Id:             dd.Id,
+			SubscriptionId: dd.SubscriptionId,
+			Event:          dd.Event,
+			Payload:        dd.Payload,
+			Status:         dd.Status,
+			Attempts:       dd.Attempts,
+			ResponseCode:   dd.ResponseCode,
+			Error:          dd.Error,
+			NextAttemptAt:  dd.NextAttemptAt,
+			CreatedAt:      dd.CreatedAt,
+		})
+	}
+	return deliveries, nil
+}